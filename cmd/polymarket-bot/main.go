@@ -4,6 +4,9 @@ import (
 	"os"
 
 	"limitorderbot/internal/cli"
+
+	// Strategies register themselves for ORDER_MODE; add yours here.
+	_ "limitorderbot/internal/strategy/examples"
 )
 
 func main() {
@@ -0,0 +1,1189 @@
+// Code generated by go run ./gen from the dashboard OpenAPI spec. DO NOT EDIT.
+
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Status struct {
+	IsRunning            bool      `json:"is_running,omitempty"`
+	LastCheck            time.Time `json:"last_check"`
+	NextCheck            time.Time `json:"next_check"`
+	CheckIntervalSeconds int       `json:"check_interval_seconds,omitempty"`
+	USDCBalance          float64   `json:"usdc_balance,omitempty"`
+	TotalPNL             float64   `json:"total_pnl,omitempty"`
+	ErrorCount           int       `json:"error_count,omitempty"`
+	LastError            *string   `json:"last_error,omitempty"`
+	// auth, rate_limited, insufficient_balance, validation, network or other
+	LastErrorKind string         `json:"last_error_kind,omitempty"`
+	ErrorsByKind  map[string]int `json:"errors_by_kind,omitempty"`
+	// Set while a rate limit holds the loop back
+	BackoffUntil       *time.Time `json:"backoff_until,omitempty"`
+	ActiveMarketsCount int        `json:"active_markets_count,omitempty"`
+	PendingOrdersCount int        `json:"pending_orders_count,omitempty"`
+	WalletAddress      string     `json:"wallet_address,omitempty"`
+	RunID              string     `json:"run_id,omitempty"`
+	Paused             *PauseInfo `json:"paused,omitempty"`
+	BalanceWarning     bool       `json:"balance_warning,omitempty"`
+	BalanceErrorCount  int        `json:"balance_error_count,omitempty"`
+	MinBalanceNeeded   float64    `json:"min_balance_needed,omitempty"`
+	CLOB               ClobHealth `json:"clob"`
+	NativeUSDCBalance  float64    `json:"native_usdc_balance,omitempty"`
+	ProxyUSDCBalance   *float64   `json:"proxy_usdc_balance,omitempty"`
+	// Collateral parked in the YIELD_POOL venue
+	YieldUSDCBalance   *float64             `json:"yield_usdc_balance,omitempty"`
+	FundingAlert       *string              `json:"funding_alert,omitempty"`
+	MaticBalance       float64              `json:"matic_balance,omitempty"`
+	MinMatic           float64              `json:"min_matic,omitempty"`
+	GasAlert           *string              `json:"gas_alert,omitempty"`
+	GasTodayMatic      float64              `json:"gas_today_matic,omitempty"`
+	GasTodayUSD        float64              `json:"gas_today_usd,omitempty"`
+	GasDailyBudgetUSD  float64              `json:"gas_daily_budget_usd,omitempty"`
+	Reconciliation     *ReconcileReport     `json:"reconciliation,omitempty"`
+	NetDelta           map[string]float64   `json:"net_delta,omitempty"`
+	MaxNetDeltaUSD     float64              `json:"max_net_delta_usd,omitempty"`
+	StrategyCooldowns  map[string]time.Time `json:"strategy_cooldowns,omitempty"`
+	NonScoringOrders   int                  `json:"non_scoring_orders,omitempty"`
+	PendingResolutions []PendingResolution  `json:"pending_resolutions,omitempty"`
+}
+
+// PendingResolution: An ended market we traded that has not settled
+type PendingResolution struct {
+	ConditionID string    `json:"condition_id,omitempty"`
+	MarketSlug  string    `json:"market_slug,omitempty"`
+	MarketURL   string    `json:"market_url,omitempty"`
+	EndedAt     time.Time `json:"ended_at"`
+	// UMA oracle status, e.g. proposed or disputed
+	UMAStatus string     `json:"uma_status,omitempty"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+}
+
+type PauseInfo struct {
+	Reason string    `json:"reason,omitempty"`
+	Since  time.Time `json:"since"`
+}
+
+type ClobHealth struct {
+	Available   bool       `json:"available,omitempty"`
+	LastCheck   *time.Time `json:"last_check,omitempty"`
+	LastOK      *time.Time `json:"last_ok,omitempty"`
+	LatencyMS   float64    `json:"latency_ms,omitempty"`
+	ServerTime  int64      `json:"server_time,omitempty"`
+	ClockSkewMS int64      `json:"clock_skew_ms,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+}
+
+type ReconcileReport struct {
+	At     time.Time        `json:"at"`
+	Issues []ReconcileIssue `json:"issues,omitempty"`
+	Errors []string         `json:"errors,omitempty"`
+}
+
+type ReconcileIssue struct {
+	// One of ghost_order, untracked_order, untracked_position,
+	// stale_positions_sold.
+	Kind        string  `json:"kind,omitempty"`
+	ConditionID string  `json:"condition_id,omitempty"`
+	MarketSlug  string  `json:"market_slug,omitempty"`
+	OrderID     string  `json:"order_id,omitempty"`
+	Size        float64 `json:"size,omitempty"`
+	Detail      string  `json:"detail,omitempty"`
+	Fixed       bool    `json:"fixed,omitempty"`
+}
+
+type MarketsResponse struct {
+	Markets []Market `json:"markets,omitempty"`
+}
+
+type Market struct {
+	MarketSlug string `json:"market_slug,omitempty"`
+	// The market on Polymarket
+	MarketURL      string    `json:"market_url,omitempty"`
+	Question       string    `json:"question,omitempty"`
+	StartTimestamp int64     `json:"start_timestamp,omitempty"`
+	StartDatetime  time.Time `json:"start_datetime"`
+	EndDatetime    time.Time `json:"end_datetime"`
+	// Seconds; negative once started
+	TimeUntilStart          int64          `json:"time_until_start,omitempty"`
+	TimeUntilStartFormatted string         `json:"time_until_start_formatted,omitempty"`
+	IsActive                bool           `json:"is_active,omitempty"`
+	IsResolved              bool           `json:"is_resolved,omitempty"`
+	Outcomes                []OutcomeQuote `json:"outcomes,omitempty"`
+	OrdersPlaced            bool           `json:"orders_placed,omitempty"`
+	Volume                  float64        `json:"volume,omitempty"`
+	Liquidity               float64        `json:"liquidity,omitempty"`
+	Tags                    []string       `json:"tags,omitempty"`
+	ResolutionSource        string         `json:"resolution_source,omitempty"`
+	UMAResolutionStatus     string         `json:"uma_resolution_status,omitempty"`
+}
+
+type OutcomeQuote struct {
+	Outcome string   `json:"outcome,omitempty"`
+	Price   *float64 `json:"price,omitempty"`
+	BestBid *float64 `json:"best_bid,omitempty"`
+	BestAsk *float64 `json:"best_ask,omitempty"`
+	// When the prices were read; older than the price interval after a restart
+	PriceAt *time.Time `json:"price_at,omitempty"`
+}
+
+type MarketDetail struct {
+	ConditionID string `json:"condition_id,omitempty"`
+	MarketSlug  string `json:"market_slug,omitempty"`
+	// The market on Polymarket
+	MarketURL      string            `json:"market_url,omitempty"`
+	Question       string            `json:"question,omitempty"`
+	StartDatetime  *time.Time        `json:"start_datetime,omitempty"`
+	EndDatetime    *time.Time        `json:"end_datetime,omitempty"`
+	IsActive       bool              `json:"is_active,omitempty"`
+	WinningOutcome *string           `json:"winning_outcome,omitempty"`
+	Volume         float64           `json:"volume,omitempty"`
+	Liquidity      float64           `json:"liquidity,omitempty"`
+	Tags           []string          `json:"tags,omitempty"`
+	Orders         []Order           `json:"orders,omitempty"`
+	Fills          []Fill            `json:"fills,omitempty"`
+	Positions      []OutcomePosition `json:"positions,omitempty"`
+	CashFlowUSD    float64           `json:"cash_flow_usd,omitempty"`
+	BoughtShares   float64           `json:"bought_shares,omitempty"`
+	SoldShares     float64           `json:"sold_shares,omitempty"`
+	MarkPNLUSD     float64           `json:"mark_pnl_usd,omitempty"`
+	// Cash flow if each outcome wins, by outcome
+	ProjectedPNL        map[string]float64 `json:"projected_pnl,omitempty"`
+	ResolutionSource    string             `json:"resolution_source,omitempty"`
+	UMAResolutionStatus string             `json:"uma_resolution_status,omitempty"`
+}
+
+// Order: An order as listed by the dashboard. Listing endpoints shorten
+// order_id; fields a listing doesn't report are left out.
+type Order struct {
+	OrderID    string `json:"order_id,omitempty"`
+	MarketSlug string `json:"market_slug,omitempty"`
+	// The market on Polymarket
+	MarketURL string `json:"market_url,omitempty"`
+	Outcome   string `json:"outcome,omitempty"`
+	// One of BUY, SELL.
+	Side            string  `json:"side,omitempty"`
+	TransactionType string  `json:"transaction_type,omitempty"`
+	Price           float64 `json:"price,omitempty"`
+	Size            float64 `json:"size,omitempty"`
+	SizeUSD         float64 `json:"size_usd,omitempty"`
+	// One of PENDING, PLACED, FILLED, PARTIALLY_FILLED, CANCELLED, FAILED.
+	Status       string     `json:"status,omitempty"`
+	Strategy     *string    `json:"strategy,omitempty"`
+	Phase        string     `json:"phase,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	FilledAt     *time.Time `json:"filled_at,omitempty"`
+	ErrorMessage *string    `json:"error_message,omitempty"`
+	// The transaction on the block explorer; empty without one
+	TxURL string `json:"tx_url,omitempty"`
+	// Whether the resting order earns liquidity rewards; null until checked
+	Scoring *bool `json:"scoring,omitempty"`
+}
+
+type Fill struct {
+	OrderID string `json:"order_id,omitempty"`
+	Outcome string `json:"outcome,omitempty"`
+	// One of BUY, SELL.
+	Side            string     `json:"side,omitempty"`
+	TransactionType string     `json:"transaction_type,omitempty"`
+	Price           float64    `json:"price,omitempty"`
+	SizeMatched     float64    `json:"size_matched,omitempty"`
+	NotionalUSD     float64    `json:"notional_usd,omitempty"`
+	FilledAt        *time.Time `json:"filled_at,omitempty"`
+	// The transaction on the block explorer; empty without one
+	TxURL string `json:"tx_url,omitempty"`
+}
+
+type OutcomePosition struct {
+	Outcome string   `json:"outcome,omitempty"`
+	TokenID string   `json:"token_id,omitempty"`
+	Balance float64  `json:"balance,omitempty"`
+	BestBid *float64 `json:"best_bid,omitempty"`
+	BestAsk *float64 `json:"best_ask,omitempty"`
+	Mark    *float64 `json:"mark,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type OrdersResponse struct {
+	PendingOrders []Order `json:"pending_orders,omitempty"`
+	RecentOrders  []Order `json:"recent_orders,omitempty"`
+	PendingTotal  int     `json:"pending_total,omitempty"`
+	RecentTotal   int     `json:"recent_total,omitempty"`
+	Limit         int     `json:"limit,omitempty"`
+	Offset        int     `json:"offset,omitempty"`
+}
+
+type MarketHistoryResponse struct {
+	Markets []MarketHistory `json:"markets,omitempty"`
+	Total   int             `json:"total,omitempty"`
+	Limit   int             `json:"limit,omitempty"`
+	Offset  int             `json:"offset,omitempty"`
+	// Gas paid by transactions not tied to a market (swaps, rebalances)
+	UnattributedGasUSD float64 `json:"unattributed_gas_usd,omitempty"`
+}
+
+type MarketHistory struct {
+	MarketSlug string `json:"market_slug,omitempty"`
+	// The market on Polymarket
+	MarketURL    string  `json:"market_url,omitempty"`
+	ConditionID  string  `json:"condition_id,omitempty"`
+	Strategy     string  `json:"strategy,omitempty"`
+	Status       string  `json:"status,omitempty"`
+	Result       string  `json:"result,omitempty"`
+	TotalCost    float64 `json:"total_cost,omitempty"`
+	TotalRevenue float64 `json:"total_revenue,omitempty"`
+	GasCost      float64 `json:"gas_cost,omitempty"`
+	// total_revenue - total_cost - gas_cost
+	PNL            float64      `json:"pnl,omitempty"`
+	FilledCount    int          `json:"filled_count,omitempty"`
+	TotalCount     int          `json:"total_count,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+	WinningOutcome string       `json:"winning_outcome,omitempty"`
+	HeldWinner     *bool        `json:"held_winner,omitempty"`
+	Notes          []Annotation `json:"notes,omitempty"`
+}
+
+type MarketPrices struct {
+	ConditionID   string          `json:"condition_id,omitempty"`
+	MarketSlug    string          `json:"market_slug,omitempty"`
+	StartDatetime *time.Time      `json:"start_datetime,omitempty"`
+	EndDatetime   *time.Time      `json:"end_datetime,omitempty"`
+	From          time.Time       `json:"from"`
+	To            time.Time       `json:"to"`
+	Outcomes      []OutcomePrices `json:"outcomes,omitempty"`
+}
+
+type OutcomePrices struct {
+	Outcome string       `json:"outcome,omitempty"`
+	TokenID string       `json:"token_id,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Prices  []PricePoint `json:"prices,omitempty"`
+}
+
+type PricePoint struct {
+	T time.Time `json:"t"`
+	P float64   `json:"p,omitempty"`
+}
+
+type Statistics struct {
+	TotalMarkets       int           `json:"total_markets,omitempty"`
+	SuccessfulTrades   int           `json:"successful_trades,omitempty"`
+	UnsuccessfulTrades int           `json:"unsuccessful_trades,omitempty"`
+	ResolvedMarkets    int           `json:"resolved_markets,omitempty"`
+	HeldWinnerMarkets  int           `json:"held_winner_markets,omitempty"`
+	TotalPNL           float64       `json:"total_pnl,omitempty"`
+	RewardsUSD         float64       `json:"rewards_usd,omitempty"`
+	PNLWithRewards     float64       `json:"pnl_with_rewards,omitempty"`
+	Capital            *CapitalFlows `json:"capital,omitempty"`
+}
+
+// CapitalFlows: Equity change between the first and last complete balance
+// samples, split into trading PnL, rewards and operator transfers
+type CapitalFlows struct {
+	Since           time.Time `json:"since"`
+	StartEquityUSD  float64   `json:"start_equity_usd,omitempty"`
+	EquityUSD       float64   `json:"equity_usd,omitempty"`
+	EquityChangeUSD float64   `json:"equity_change_usd,omitempty"`
+	TradingPNLUSD   float64   `json:"trading_pnl_usd,omitempty"`
+	RewardsUSD      float64   `json:"rewards_usd,omitempty"`
+	// Equity change not explained by trading PnL or rewards
+	NetTransfersUSD float64 `json:"net_transfers_usd,omitempty"`
+	// Recorded deposits over the same period
+	DepositsUSD float64 `json:"deposits_usd,omitempty"`
+	// Recorded withdrawals over the same period
+	WithdrawalsUSD float64 `json:"withdrawals_usd,omitempty"`
+	// net_transfers_usd less recorded deposits and withdrawals
+	UnexplainedUSD float64 `json:"unexplained_usd,omitempty"`
+}
+
+type CashFlowsResponse struct {
+	Flows          []CashFlow `json:"flows,omitempty"`
+	DepositsUSD    float64    `json:"deposits_usd,omitempty"`
+	WithdrawalsUSD float64    `json:"withdrawals_usd,omitempty"`
+	// Last block the wallet's transfers were scanned through
+	LastBlock int `json:"last_block,omitempty"`
+}
+
+type CashFlow struct {
+	Time time.Time `json:"time"`
+	// One of deposit, withdrawal.
+	Kind         string  `json:"kind,omitempty"`
+	AmountUSD    float64 `json:"amount_usd,omitempty"`
+	Wallet       string  `json:"wallet,omitempty"`
+	Counterparty string  `json:"counterparty,omitempty"`
+	TxHash       string  `json:"tx_hash,omitempty"`
+	LogIndex     int     `json:"log_index,omitempty"`
+	Block        int     `json:"block,omitempty"`
+}
+
+type StrategyStatisticsResponse struct {
+	Strategies []StrategyStatistics `json:"strategies,omitempty"`
+}
+
+type StrategyStatistics struct {
+	StrategyName       string  `json:"strategy_name,omitempty"`
+	TotalMarkets       int     `json:"total_markets,omitempty"`
+	SuccessfulTrades   int     `json:"successful_trades,omitempty"`
+	UnsuccessfulTrades int     `json:"unsuccessful_trades,omitempty"`
+	TotalPNL           float64 `json:"total_pnl,omitempty"`
+}
+
+type FillStatisticsResponse struct {
+	Strategies []FillStatistics `json:"strategies,omitempty"`
+}
+
+type FillStatistics struct {
+	StrategyName   string       `json:"strategy_name,omitempty"`
+	Orders         int          `json:"orders,omitempty"`
+	Filled         int          `json:"filled,omitempty"`
+	FillRatio      float64      `json:"fill_ratio,omitempty"`
+	AvgFillSeconds float64      `json:"avg_fill_seconds,omitempty"`
+	P50FillSeconds float64      `json:"p50_fill_seconds,omitempty"`
+	P90FillSeconds float64      `json:"p90_fill_seconds,omitempty"`
+	ByDistance     []FillBucket `json:"by_distance,omitempty"`
+}
+
+type FillBucket struct {
+	// Distance from mid at placement, e.g. 1-2c, or unknown
+	Distance       string  `json:"distance,omitempty"`
+	Orders         int     `json:"orders,omitempty"`
+	Filled         int     `json:"filled,omitempty"`
+	FillRatio      float64 `json:"fill_ratio,omitempty"`
+	AvgFillSeconds float64 `json:"avg_fill_seconds,omitempty"`
+	P50FillSeconds float64 `json:"p50_fill_seconds,omitempty"`
+	P90FillSeconds float64 `json:"p90_fill_seconds,omitempty"`
+}
+
+type PhaseStatisticsResponse struct {
+	Phases []PhaseStatistics `json:"phases,omitempty"`
+}
+
+type PhaseStatistics struct {
+	Phase      string  `json:"phase,omitempty"`
+	Orders     int     `json:"orders,omitempty"`
+	Filled     int     `json:"filled,omitempty"`
+	CostUSD    float64 `json:"cost_usd,omitempty"`
+	RevenueUSD float64 `json:"revenue_usd,omitempty"`
+	NetUSD     float64 `json:"net_usd,omitempty"`
+}
+
+type PNLSeries struct {
+	// One of hour, day.
+	Interval string     `json:"interval,omitempty"`
+	Points   []PNLPoint `json:"points,omitempty"`
+}
+
+type PNLPoint struct {
+	Time             time.Time `json:"time"`
+	PNL              float64   `json:"pnl,omitempty"`
+	CumulativePNL    float64   `json:"cumulative_pnl,omitempty"`
+	USDCBalance      *float64  `json:"usdc_balance,omitempty"`
+	MaticBalance     *float64  `json:"matic_balance,omitempty"`
+	PositionValueUSD *float64  `json:"position_value_usd,omitempty"`
+	// USDC.e (wallet, proxy, yield pool), positions at mark and MATIC at
+	// MATIC_USD_PRICE
+	EquityUSD *float64 `json:"equity_usd,omitempty"`
+	Orders    int      `json:"orders,omitempty"`
+}
+
+type RewardsResponse struct {
+	Days     []RewardDay `json:"days,omitempty"`
+	TotalUSD float64     `json:"total_usd,omitempty"`
+}
+
+type RewardDay struct {
+	Date         string        `json:"date,omitempty"`
+	SizeHours    float64       `json:"size_hours,omitempty"`
+	EstimatedUSD float64       `json:"estimated_usd,omitempty"`
+	ActualUSD    *float64      `json:"actual_usd,omitempty"`
+	RewardUSD    float64       `json:"reward_usd,omitempty"`
+	Levels       []RewardLevel `json:"levels,omitempty"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+type RewardLevel struct {
+	Price        float64 `json:"price,omitempty"`
+	OrderSeconds float64 `json:"order_seconds,omitempty"`
+	SizeSeconds  float64 `json:"size_seconds,omitempty"`
+}
+
+type DecisionsResponse struct {
+	Decisions []Decision `json:"decisions,omitempty"`
+	Total     int        `json:"total,omitempty"`
+	Limit     int        `json:"limit,omitempty"`
+	Offset    int        `json:"offset,omitempty"`
+}
+
+type Decision struct {
+	Time        time.Time `json:"time"`
+	ConditionID string    `json:"condition_id,omitempty"`
+	MarketSlug  string    `json:"market_slug,omitempty"`
+	Strategy    string    `json:"strategy,omitempty"`
+	Action      string    `json:"action,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	Detail      string    `json:"detail,omitempty"`
+	Orders      int       `json:"orders,omitempty"`
+}
+
+type LogsResponse struct {
+	Logs []string `json:"logs,omitempty"`
+}
+
+type AnnotationsResponse struct {
+	Annotations []Annotation `json:"annotations,omitempty"`
+}
+
+type Annotation struct {
+	ConditionID string    `json:"condition_id,omitempty"`
+	OrderID     string    `json:"order_id,omitempty"`
+	Note        string    `json:"note,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AnnotationInput: Needs a condition_id or order_id, and a note or at least
+// one tag.
+type AnnotationInput struct {
+	ConditionID string   `json:"condition_id,omitempty"`
+	OrderID     string   `json:"order_id,omitempty"`
+	Note        string   `json:"note,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+type RPCHealthResponse struct {
+	Endpoints []EndpointHealth `json:"endpoints,omitempty"`
+}
+
+type EndpointHealth struct {
+	URL               string     `json:"url,omitempty"`
+	Healthy           bool       `json:"healthy,omitempty"`
+	LatencyMS         float64    `json:"latency_ms,omitempty"`
+	Calls             int64      `json:"calls,omitempty"`
+	Errors            int64      `json:"errors,omitempty"`
+	ConsecutiveErrors int        `json:"consecutive_errors,omitempty"`
+	LastError         string     `json:"last_error,omitempty"`
+	OpenUntil         *time.Time `json:"open_until,omitempty"`
+}
+
+type TransactionsResponse struct {
+	Transactions []TxJob `json:"transactions,omitempty"`
+}
+
+type TxJob struct {
+	ID          string  `json:"id,omitempty"`
+	Kind        string  `json:"kind,omitempty"`
+	ConditionID string  `json:"condition_id,omitempty"`
+	MarketSlug  string  `json:"market_slug,omitempty"`
+	Amount      float64 `json:"amount,omitempty"`
+	// One of QUEUED, SENT, CONFIRMED, FAILED.
+	Status    string    `json:"status,omitempty"`
+	TxHash    string    `json:"tx_hash,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	QueuedAt  time.Time `json:"queued_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	RunID     string    `json:"run_id,omitempty"`
+	GasMatic  float64   `json:"gas_matic,omitempty"`
+	// The transaction on the block explorer; empty without one
+	TxURL string `json:"tx_url,omitempty"`
+}
+
+type ReconciliationResponse struct {
+	Reconciliation *ReconcileReport `json:"reconciliation,omitempty"`
+}
+
+type ArbitrageResponse struct {
+	Signals []ArbSignal `json:"signals,omitempty"`
+	Fee     float64     `json:"fee,omitempty"`
+}
+
+type ArbSignal struct {
+	ConditionID string `json:"condition_id,omitempty"`
+	MarketSlug  string `json:"market_slug,omitempty"`
+	// One of buy_both, sell_both.
+	Kind      string  `json:"kind,omitempty"`
+	UpPrice   float64 `json:"up_price,omitempty"`
+	DownPrice float64 `json:"down_price,omitempty"`
+	Sum       float64 `json:"sum,omitempty"`
+	// Per share pair, after fees
+	Edge       float64   `json:"edge,omitempty"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+type MetricsResponse struct {
+	Loop   LoopMetrics `json:"loop"`
+	Memory MemoryStats `json:"memory"`
+}
+
+type LoopMetrics struct {
+	Loops        int64                 `json:"loops,omitempty"`
+	OverBudget   int64                 `json:"over_budget,omitempty"`
+	BudgetMS     float64               `json:"budget_ms,omitempty"`
+	LastStarted  *time.Time            `json:"last_started,omitempty"`
+	LastTotalMS  float64               `json:"last_total_ms,omitempty"`
+	LastPhases   []PhaseTiming         `json:"last_phases,omitempty"`
+	PhaseSummary map[string]PhaseStats `json:"phase_summary,omitempty"`
+}
+
+type PhaseTiming struct {
+	Name       string  `json:"name,omitempty"`
+	DurationMS float64 `json:"duration_ms,omitempty"`
+	Skipped    bool    `json:"skipped,omitempty"`
+}
+
+type PhaseStats struct {
+	Count   int64   `json:"count,omitempty"`
+	Skipped int64   `json:"skipped,omitempty"`
+	TotalMS float64 `json:"total_ms,omitempty"`
+	MaxMS   float64 `json:"max_ms,omitempty"`
+	LastMS  float64 `json:"last_ms,omitempty"`
+}
+
+type MemoryStats struct {
+	Caches         map[string]CacheStats `json:"caches,omitempty"`
+	Maps           map[string]int        `json:"maps,omitempty"`
+	ArchivedOrders int                   `json:"archived_orders,omitempty"`
+	LastCompaction *time.Time            `json:"last_compaction,omitempty"`
+}
+
+type CacheStats struct {
+	Size      int     `json:"size,omitempty"`
+	Capacity  int     `json:"capacity,omitempty"`
+	Hits      int64   `json:"hits,omitempty"`
+	Misses    int64   `json:"misses,omitempty"`
+	Evictions int64   `json:"evictions,omitempty"`
+	HitRate   float64 `json:"hit_rate,omitempty"`
+}
+
+type ConfigResponse struct {
+	RunID     string    `json:"run_id,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	Current   bool      `json:"current,omitempty"`
+	// The config snapshot; secrets are redacted
+	Config map[string]any `json:"config,omitempty"`
+	Runs   []RunRef       `json:"runs,omitempty"`
+}
+
+type RunRef struct {
+	ID        string    `json:"id,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+type SessionsResponse struct {
+	Sessions []Session `json:"sessions,omitempty"`
+}
+
+type Session struct {
+	RunID           string     `json:"run_id,omitempty"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	Current         bool       `json:"current,omitempty"`
+	DurationSeconds int        `json:"duration_seconds,omitempty"`
+	ErrorCount      int        `json:"error_count,omitempty"`
+	Orders          int        `json:"orders,omitempty"`
+	FilledOrders    int        `json:"filled_orders,omitempty"`
+	TotalPNL        float64    `json:"total_pnl,omitempty"`
+}
+
+type PanicReport struct {
+	Paused          bool     `json:"paused,omitempty"`
+	OrdersCancelled bool     `json:"orders_cancelled,omitempty"`
+	SellsPlaced     int      `json:"sells_placed,omitempty"`
+	Errors          []string `json:"errors,omitempty"`
+}
+
+type ResumeResponse struct {
+	// Still paused, e.g. while the kill switch is engaged
+	Paused bool `json:"paused,omitempty"`
+}
+
+type StrategyParams struct {
+	OrderSizeUSD         float64                   `json:"order_size_usd,omitempty"`
+	SpreadOffset         float64                   `json:"spread_offset,omitempty"`
+	LiquidityQueueShares float64                   `json:"liquidity_queue_shares,omitempty"`
+	SpreadCaptureMinEdge float64                   `json:"spread_capture_min_edge,omitempty"`
+	LastMinuteEdge       float64                   `json:"last_minute_edge,omitempty"`
+	MaxNetDeltaUSD       float64                   `json:"max_net_delta_usd,omitempty"`
+	MinBookDepthUSD      float64                   `json:"min_book_depth_usd,omitempty"`
+	StaleQuoteTicks      int                       `json:"stale_quote_ticks,omitempty"`
+	MinSellPrice         float64                   `json:"min_sell_price,omitempty"`
+	MarketSellDiscount   float64                   `json:"market_sell_discount,omitempty"`
+	MaxSellSlippage      float64                   `json:"max_sell_slippage,omitempty"`
+	Strategies           map[string]StrategyConfig `json:"strategies,omitempty"`
+}
+
+type StrategyConfig struct {
+	ExitTimeoutSeconds int     `json:"exit_timeout_seconds,omitempty"`
+	CancelUnfilled     bool    `json:"cancel_unfilled,omitempty"`
+	MarketSellFilled   bool    `json:"market_sell_filled,omitempty"`
+	Enabled            bool    `json:"enabled,omitempty"`
+	HoldToResolution   bool    `json:"hold_to_resolution,omitempty"`
+	HoldMinMid         float64 `json:"hold_min_mid,omitempty"`
+	CapitalBudgetUSD   float64 `json:"capital_budget_usd,omitempty"`
+	ExitWhenMerged     bool    `json:"exit_when_merged,omitempty"`
+	ExtendFillRatio    float64 `json:"extend_fill_ratio,omitempty"`
+	ExtendSeconds      int     `json:"extend_seconds,omitempty"`
+}
+
+// StrategyParamsUpdate: StrategyParams with every field optional; null or
+// left out keeps the current value.
+type StrategyParamsUpdate struct {
+	OrderSizeUSD         *float64                        `json:"order_size_usd,omitempty"`
+	SpreadOffset         *float64                        `json:"spread_offset,omitempty"`
+	LiquidityQueueShares *float64                        `json:"liquidity_queue_shares,omitempty"`
+	SpreadCaptureMinEdge *float64                        `json:"spread_capture_min_edge,omitempty"`
+	LastMinuteEdge       *float64                        `json:"last_minute_edge,omitempty"`
+	MaxNetDeltaUSD       *float64                        `json:"max_net_delta_usd,omitempty"`
+	MinBookDepthUSD      *float64                        `json:"min_book_depth_usd,omitempty"`
+	StaleQuoteTicks      *int                            `json:"stale_quote_ticks,omitempty"`
+	MinSellPrice         *float64                        `json:"min_sell_price,omitempty"`
+	MarketSellDiscount   *float64                        `json:"market_sell_discount,omitempty"`
+	MaxSellSlippage      *float64                        `json:"max_sell_slippage,omitempty"`
+	Strategies           map[string]StrategyConfigUpdate `json:"strategies,omitempty"`
+}
+
+type StrategyConfigUpdate struct {
+	ExitTimeoutSeconds *int     `json:"exit_timeout_seconds,omitempty"`
+	CancelUnfilled     *bool    `json:"cancel_unfilled,omitempty"`
+	MarketSellFilled   *bool    `json:"market_sell_filled,omitempty"`
+	Enabled            *bool    `json:"enabled,omitempty"`
+	HoldToResolution   *bool    `json:"hold_to_resolution,omitempty"`
+	HoldMinMid         *float64 `json:"hold_min_mid,omitempty"`
+	CapitalBudgetUSD   *float64 `json:"capital_budget_usd,omitempty"`
+	ExitWhenMerged     *bool    `json:"exit_when_merged,omitempty"`
+	ExtendFillRatio    *float64 `json:"extend_fill_ratio,omitempty"`
+	ExtendSeconds      *int     `json:"extend_seconds,omitempty"`
+}
+
+type StrategyParamsResult struct {
+	Params  StrategyParams `json:"params"`
+	Changes []ParamChange  `json:"changes,omitempty"`
+}
+
+type ParamChange struct {
+	// JSON name; strategy fields are strategies.<name>.<field>
+	Param string `json:"param,omitempty"`
+	Old   any    `json:"old,omitempty"`
+	New   any    `json:"new,omitempty"`
+}
+
+type ProfileDump struct {
+	Files []string `json:"files,omitempty"`
+}
+
+// GetStatus calls GET /api/status: bot status, balances and health.
+func (c *Client) GetStatus(ctx context.Context) (*Status, error) {
+	var out Status
+	if err := c.do(ctx, http.MethodGet, "/api/status", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMarkets calls GET /api/markets: next active markets by start time (at
+// most 10).
+func (c *Client) GetMarkets(ctx context.Context) (*MarketsResponse, error) {
+	var out MarketsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/markets", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMarketDetail calls GET /api/markets/{cid}/detail: orders, fills, live
+// positions and PnL of one market.
+func (c *Client) GetMarketDetail(ctx context.Context, cid string) (*MarketDetail, error) {
+	var out MarketDetail
+	if err := c.do(ctx, http.MethodGet, "/api/markets/"+url.PathEscape(cid)+"/detail", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOrdersParams are the query parameters of GetOrders; zero values are left out.
+type GetOrdersParams struct {
+	// Order status, case-insensitive
+	Status   string
+	Strategy string
+	// Substring of the market slug or condition id
+	Market string
+	// RFC3339 time or YYYY-MM-DD (local day start)
+	From string
+	// RFC3339 time or YYYY-MM-DD (local day end)
+	To     string
+	Limit  *int
+	Offset *int
+}
+
+func (p *GetOrdersParams) values() url.Values {
+	q := url.Values{}
+	if p.Status != "" {
+		q.Set("status", p.Status)
+	}
+	if p.Strategy != "" {
+		q.Set("strategy", p.Strategy)
+	}
+	if p.Market != "" {
+		q.Set("market", p.Market)
+	}
+	if p.From != "" {
+		q.Set("from", p.From)
+	}
+	if p.To != "" {
+		q.Set("to", p.To)
+	}
+	if p.Limit != nil {
+		q.Set("limit", strconv.Itoa(*p.Limit))
+	}
+	if p.Offset != nil {
+		q.Set("offset", strconv.Itoa(*p.Offset))
+	}
+	return q
+}
+
+// GetOrders calls GET /api/orders: pending (live) and recent (history)
+// orders.
+func (c *Client) GetOrders(ctx context.Context, params *GetOrdersParams) (*OrdersResponse, error) {
+	var q url.Values
+	if params != nil {
+		q = params.values()
+	}
+	var out OrdersResponse
+	if err := c.do(ctx, http.MethodGet, "/api/orders", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMarketHistoryParams are the query parameters of GetMarketHistory; zero values are left out.
+type GetMarketHistoryParams struct {
+	// Order status, case-insensitive
+	Status   string
+	Strategy string
+	// Substring of the market slug or condition id
+	Market string
+	// RFC3339 time or YYYY-MM-DD (local day start)
+	From string
+	// RFC3339 time or YYYY-MM-DD (local day end)
+	To     string
+	Limit  *int
+	Offset *int
+}
+
+func (p *GetMarketHistoryParams) values() url.Values {
+	q := url.Values{}
+	if p.Status != "" {
+		q.Set("status", p.Status)
+	}
+	if p.Strategy != "" {
+		q.Set("strategy", p.Strategy)
+	}
+	if p.Market != "" {
+		q.Set("market", p.Market)
+	}
+	if p.From != "" {
+		q.Set("from", p.From)
+	}
+	if p.To != "" {
+		q.Set("to", p.To)
+	}
+	if p.Limit != nil {
+		q.Set("limit", strconv.Itoa(*p.Limit))
+	}
+	if p.Offset != nil {
+		q.Set("offset", strconv.Itoa(*p.Offset))
+	}
+	return q
+}
+
+// GetMarketHistory calls GET /api/market-history: per-market cost, revenue,
+// gas and PnL from the order history.
+func (c *Client) GetMarketHistory(ctx context.Context, params *GetMarketHistoryParams) (*MarketHistoryResponse, error) {
+	var q url.Values
+	if params != nil {
+		q = params.values()
+	}
+	var out MarketHistoryResponse
+	if err := c.do(ctx, http.MethodGet, "/api/market-history", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMarketPrices calls GET /api/market-history/{cid}/prices: outcome price
+// history around a market's orders.
+func (c *Client) GetMarketPrices(ctx context.Context, cid string) (*MarketPrices, error) {
+	var out MarketPrices
+	if err := c.do(ctx, http.MethodGet, "/api/market-history/"+url.PathEscape(cid)+"/prices", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetStatistics calls GET /api/statistics: market success counts and total
+// PnL.
+func (c *Client) GetStatistics(ctx context.Context) (*Statistics, error) {
+	var out Statistics
+	if err := c.do(ctx, http.MethodGet, "/api/statistics", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetStrategyStatistics calls GET /api/strategy-statistics: market success
+// counts and PnL per strategy.
+func (c *Client) GetStrategyStatistics(ctx context.Context) (*StrategyStatisticsResponse, error) {
+	var out StrategyStatisticsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/strategy-statistics", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFillStatistics calls GET /api/statistics/fills: fill ratio and time to
+// first fill per strategy and distance from mid.
+func (c *Client) GetFillStatistics(ctx context.Context) (*FillStatisticsResponse, error) {
+	var out FillStatisticsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/statistics/fills", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPhaseStatistics calls GET /api/statistics/phases: fills, cost and
+// revenue per order phase.
+func (c *Client) GetPhaseStatistics(ctx context.Context) (*PhaseStatisticsResponse, error) {
+	var out PhaseStatisticsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/statistics/phases", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPNLSeriesParams are the query parameters of GetPNLSeries; zero values are left out.
+type GetPNLSeriesParams struct {
+	// hour (default) or day
+	Interval string
+	// RFC3339 time or YYYY-MM-DD (local day start)
+	From string
+	// RFC3339 time or YYYY-MM-DD (local day end)
+	To string
+}
+
+func (p *GetPNLSeriesParams) values() url.Values {
+	q := url.Values{}
+	if p.Interval != "" {
+		q.Set("interval", p.Interval)
+	}
+	if p.From != "" {
+		q.Set("from", p.From)
+	}
+	if p.To != "" {
+		q.Set("to", p.To)
+	}
+	return q
+}
+
+// GetPNLSeries calls GET /api/pnl-series: pnL, balances and equity bucketed
+// by hour or day.
+func (c *Client) GetPNLSeries(ctx context.Context, params *GetPNLSeriesParams) (*PNLSeries, error) {
+	var q url.Values
+	if params != nil {
+		q = params.values()
+	}
+	var out PNLSeries
+	if err := c.do(ctx, http.MethodGet, "/api/pnl-series", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCashFlows calls GET /api/cash-flows: deposits and withdrawals found on
+// the wallet, newest first.
+func (c *Client) GetCashFlows(ctx context.Context) (*CashFlowsResponse, error) {
+	var out CashFlowsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/cash-flows", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRewards calls GET /api/rewards: liquidity rewards per UTC day, newest
+// first.
+func (c *Client) GetRewards(ctx context.Context) (*RewardsResponse, error) {
+	var out RewardsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/rewards", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDecisionsParams are the query parameters of GetDecisions; zero values are left out.
+type GetDecisionsParams struct {
+	Action   string
+	Reason   string
+	Strategy string
+	// Substring of the market slug or condition id
+	Market string
+	// RFC3339 time or YYYY-MM-DD (local day start)
+	From string
+	// RFC3339 time or YYYY-MM-DD (local day end)
+	To     string
+	Limit  *int
+	Offset *int
+}
+
+func (p *GetDecisionsParams) values() url.Values {
+	q := url.Values{}
+	if p.Action != "" {
+		q.Set("action", p.Action)
+	}
+	if p.Reason != "" {
+		q.Set("reason", p.Reason)
+	}
+	if p.Strategy != "" {
+		q.Set("strategy", p.Strategy)
+	}
+	if p.Market != "" {
+		q.Set("market", p.Market)
+	}
+	if p.From != "" {
+		q.Set("from", p.From)
+	}
+	if p.To != "" {
+		q.Set("to", p.To)
+	}
+	if p.Limit != nil {
+		q.Set("limit", strconv.Itoa(*p.Limit))
+	}
+	if p.Offset != nil {
+		q.Set("offset", strconv.Itoa(*p.Offset))
+	}
+	return q
+}
+
+// GetDecisions calls GET /api/decisions: why markets were or weren't traded,
+// newest first.
+func (c *Client) GetDecisions(ctx context.Context, params *GetDecisionsParams) (*DecisionsResponse, error) {
+	var q url.Values
+	if params != nil {
+		q = params.values()
+	}
+	var out DecisionsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/decisions", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetLogs calls GET /api/logs: last 50 lines of the log file.
+func (c *Client) GetLogs(ctx context.Context) (*LogsResponse, error) {
+	var out LogsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/logs", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAnnotationsParams are the query parameters of GetAnnotations; zero values are left out.
+type GetAnnotationsParams struct {
+	ConditionID string
+	OrderID     string
+}
+
+func (p *GetAnnotationsParams) values() url.Values {
+	q := url.Values{}
+	if p.ConditionID != "" {
+		q.Set("condition_id", p.ConditionID)
+	}
+	if p.OrderID != "" {
+		q.Set("order_id", p.OrderID)
+	}
+	return q
+}
+
+// GetAnnotations calls GET /api/annotations: trade journal notes.
+func (c *Client) GetAnnotations(ctx context.Context, params *GetAnnotationsParams) (*AnnotationsResponse, error) {
+	var q url.Values
+	if params != nil {
+		q = params.values()
+	}
+	var out AnnotationsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/annotations", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AddAnnotation calls POST /api/annotations: append a trade journal note. It
+// needs the client's token.
+func (c *Client) AddAnnotation(ctx context.Context, body AnnotationInput) (*Annotation, error) {
+	var out Annotation
+	if err := c.do(ctx, http.MethodPost, "/api/annotations", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRPCHealth calls GET /api/rpc-health: health of the Polygon RPC
+// endpoints.
+func (c *Client) GetRPCHealth(ctx context.Context) (*RPCHealthResponse, error) {
+	var out RPCHealthResponse
+	if err := c.do(ctx, http.MethodGet, "/api/rpc-health", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTransactions calls GET /api/transactions: recent on-chain jobs (merges,
+// redeems, swaps).
+func (c *Client) GetTransactions(ctx context.Context) (*TransactionsResponse, error) {
+	var out TransactionsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/transactions", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetReconciliation calls GET /api/reconciliation: latest wallet-vs-bot
+// reconciliation.
+func (c *Client) GetReconciliation(ctx context.Context) (*ReconciliationResponse, error) {
+	var out ReconciliationResponse
+	if err := c.do(ctx, http.MethodGet, "/api/reconciliation", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetArbitrage calls GET /api/arbitrage: mispriced complements found by the
+// latest scan.
+func (c *Client) GetArbitrage(ctx context.Context) (*ArbitrageResponse, error) {
+	var out ArbitrageResponse
+	if err := c.do(ctx, http.MethodGet, "/api/arbitrage", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMetrics calls GET /api/metrics: loop timing and memory instrumentation.
+func (c *Client) GetMetrics(ctx context.Context) (*MetricsResponse, error) {
+	var out MetricsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/metrics", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetConfigParams are the query parameters of GetConfig; zero values are left out.
+type GetConfigParams struct {
+	// Run id; defaults to the current run
+	Run string
+	// RFC3339 time; selects the run in effect then
+	At string
+}
+
+func (p *GetConfigParams) values() url.Values {
+	q := url.Values{}
+	if p.Run != "" {
+		q.Set("run", p.Run)
+	}
+	if p.At != "" {
+		q.Set("at", p.At)
+	}
+	return q
+}
+
+// GetConfig calls GET /api/config: effective configuration (secrets removed)
+// of a run.
+func (c *Client) GetConfig(ctx context.Context, params *GetConfigParams) (*ConfigResponse, error) {
+	var q url.Values
+	if params != nil {
+		q = params.values()
+	}
+	var out ConfigResponse
+	if err := c.do(ctx, http.MethodGet, "/api/config", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSessions calls GET /api/sessions: bot runs with their order counts and
+// PnL, newest first.
+func (c *Client) GetSessions(ctx context.Context) (*SessionsResponse, error) {
+	var out SessionsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/sessions", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Panic calls POST /api/control/panic: pause, cancel every open order and
+// market-sell every position. It needs the client's token.
+func (c *Client) Panic(ctx context.Context) (*PanicReport, error) {
+	var out PanicReport
+	if err := c.do(ctx, http.MethodPost, "/api/control/panic", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Resume calls POST /api/control/resume: lift a pause set by the panic
+// button. It needs the client's token.
+func (c *Client) Resume(ctx context.Context) (*ResumeResponse, error) {
+	var out ResumeResponse
+	if err := c.do(ctx, http.MethodPost, "/api/control/resume", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetStrategyParams calls GET /api/strategies: live strategy parameters. It
+// needs the client's token.
+func (c *Client) GetStrategyParams(ctx context.Context) (*StrategyParams, error) {
+	var out StrategyParams
+	if err := c.do(ctx, http.MethodGet, "/api/strategies", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateStrategyParams calls PUT /api/strategies: change live strategy
+// parameters; fields left out keep their value. It needs the client's token.
+func (c *Client) UpdateStrategyParams(ctx context.Context, body StrategyParamsUpdate) (*StrategyParamsResult, error) {
+	var out StrategyParamsResult
+	if err := c.do(ctx, http.MethodPut, "/api/strategies", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DumpProfilesParams are the query parameters of DumpProfiles; zero values are left out.
+type DumpProfilesParams struct {
+	// CPU profile length, 0-120 (default 30); 0 skips it
+	Seconds *int
+}
+
+func (p *DumpProfilesParams) values() url.Values {
+	q := url.Values{}
+	if p.Seconds != nil {
+		q.Set("seconds", strconv.Itoa(*p.Seconds))
+	}
+	return q
+}
+
+// DumpProfiles calls POST /debug/profile/dump: write CPU, heap and goroutine
+// profiles to PROFILE_DIR. It needs the client's token.
+func (c *Client) DumpProfiles(ctx context.Context, params *DumpProfilesParams) (*ProfileDump, error) {
+	var q url.Values
+	if params != nil {
+		q = params.values()
+	}
+	var out ProfileDump
+	if err := c.do(ctx, http.MethodPost, "/debug/profile/dump", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,399 @@
+// Command gen writes the apiclient types and endpoint methods from the
+// dashboard's OpenAPI spec:
+//
+//	go run ./gen <openapi.json> <out.go>
+//
+// It handles the subset of OpenAPI 3.0 the spec uses: component schemas
+// (objects, arrays, maps, $ref, nullable), path and query parameters, and
+// JSON request and response bodies.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ordered is a JSON object that remembers its key order, so the generated
+// code follows the spec.
+type ordered[T any] struct {
+	keys []string
+	vals map[string]T
+}
+
+func (o *ordered[T]) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	o.vals = map[string]T{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		o.keys = append(o.keys, key)
+		o.vals[key] = v
+	}
+	return nil
+}
+
+type schema struct {
+	Ref                  string           `json:"$ref"`
+	Type                 string           `json:"type"`
+	Format               string           `json:"format"`
+	Description          string           `json:"description"`
+	Nullable             bool             `json:"nullable"`
+	Enum                 []string         `json:"enum"`
+	Required             []string         `json:"required"`
+	Items                *schema          `json:"items"`
+	Properties           ordered[*schema] `json:"properties"`
+	AdditionalProperties *schema          `json:"additionalProperties"`
+	AllOf                []*schema        `json:"allOf"`
+}
+
+type parameter struct {
+	Ref         string  `json:"$ref"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *schema `json:"schema"`
+}
+
+type mediaTypes struct {
+	JSON *struct {
+		Schema *schema `json:"schema"`
+	} `json:"application/json"`
+}
+
+type operation struct {
+	OperationID string      `json:"operationId"`
+	Summary     string      `json:"summary"`
+	Parameters  []parameter `json:"parameters"`
+	Security    []any       `json:"security"`
+	RequestBody *struct {
+		Content mediaTypes `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content mediaTypes `json:"content"`
+	} `json:"responses"`
+}
+
+type spec struct {
+	Paths      ordered[ordered[*operation]] `json:"paths"`
+	Components struct {
+		Parameters map[string]parameter `json:"parameters"`
+		Schemas    ordered[*schema]     `json:"schemas"`
+	} `json:"components"`
+}
+
+// initialisms are written in capitals in Go names.
+var initialisms = map[string]bool{
+	"api": true, "clob": true, "id": true, "json": true, "ms": true, "ok": true, "pnl": true,
+	"rpc": true, "uma": true, "url": true, "usd": true, "usdc": true,
+}
+
+func goName(s string) string {
+	var b strings.Builder
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		if initialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+type generator struct {
+	spec    spec
+	buf     bytes.Buffer
+	imports map[string]bool
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// goType maps a schema to a Go type; nullable scalars and objects become
+// pointers.
+func (g *generator) goType(s *schema) string {
+	if s == nil {
+		return "any"
+	}
+	ptr := ""
+	if s.Nullable {
+		ptr = "*"
+	}
+	if len(s.AllOf) == 1 {
+		s = &schema{Ref: s.AllOf[0].Ref}
+	}
+	switch {
+	case s.Ref != "":
+		return ptr + refName(s.Ref)
+	case s.Type == "string" && s.Format == "date-time":
+		g.imports["time"] = true
+		return ptr + "time.Time"
+	case s.Type == "string":
+		return ptr + "string"
+	case s.Type == "number":
+		return ptr + "float64"
+	case s.Type == "integer" && s.Format == "int64":
+		return ptr + "int64"
+	case s.Type == "integer":
+		return ptr + "int"
+	case s.Type == "boolean":
+		return ptr + "bool"
+	case s.Type == "array":
+		return "[]" + g.goType(s.Items)
+	case s.Type == "object" && s.AdditionalProperties != nil:
+		return "map[string]" + g.goType(s.AdditionalProperties)
+	case s.Type == "object" && len(s.Properties.keys) == 0:
+		return "map[string]any"
+	case s.Type == "":
+		return "any"
+	}
+	log.Fatalf("unsupported schema %+v", *s)
+	return ""
+}
+
+func (g *generator) comment(indent, text string) {
+	for _, line := range wrap(text, 74-len(indent)) {
+		g.printf("%s// %s\n", indent, line)
+	}
+}
+
+func wrap(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, w := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(w) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += w
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (g *generator) schemas() {
+	for _, name := range g.spec.Components.Schemas.keys {
+		s := g.spec.Components.Schemas.vals[name]
+		if s.Description != "" {
+			g.comment("", name+": "+s.Description)
+		}
+		required := map[string]bool{}
+		for _, r := range s.Required {
+			required[r] = true
+		}
+		g.printf("type %s struct {\n", name)
+		for _, prop := range s.Properties.keys {
+			p := s.Properties.vals[prop]
+			doc := p.Description
+			if len(p.Enum) > 0 {
+				doc = strings.TrimSpace(doc + " One of " + strings.Join(p.Enum, ", ") + ".")
+			}
+			if doc != "" {
+				g.comment("\t", doc)
+			}
+			typ := g.goType(p)
+			tag := prop
+			// omitempty has no effect on structs.
+			if !required[prop] && typ != "time.Time" && (p.Ref == "" || p.Nullable) {
+				tag += ",omitempty"
+			}
+			g.printf("\t%s %s `json:%q`\n", goName(prop), typ, tag)
+		}
+		g.printf("}\n\n")
+	}
+}
+
+func (g *generator) param(p parameter) parameter {
+	if p.Ref != "" {
+		return g.spec.Components.Parameters[refName(p.Ref)]
+	}
+	return p
+}
+
+func responseSchema(op *operation) *schema {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		r := op.Responses[code]
+		if strings.HasPrefix(code, "2") && r.Content.JSON != nil {
+			return r.Content.JSON.Schema
+		}
+	}
+	return nil
+}
+
+func (g *generator) operations() {
+	for _, path := range g.spec.Paths.keys {
+		ops := g.spec.Paths.vals[path]
+		for _, method := range ops.keys {
+			g.operation(path, strings.ToUpper(method), ops.vals[method])
+		}
+	}
+}
+
+func (g *generator) operation(path, method string, op *operation) {
+	name := goName(op.OperationID)
+	var pathArgs, query []parameter
+	for _, p := range op.Parameters {
+		switch p = g.param(p); p.In {
+		case "path":
+			pathArgs = append(pathArgs, p)
+		case "query":
+			query = append(query, p)
+		default:
+			log.Fatalf("%s: unsupported parameter location %q", op.OperationID, p.In)
+		}
+	}
+
+	if len(query) > 0 {
+		g.printf("// %sParams are the query parameters of %s; zero values are left out.\n", name, name)
+		g.printf("type %sParams struct {\n", name)
+		for _, p := range query {
+			if p.Description != "" {
+				g.comment("\t", p.Description)
+			}
+			g.printf("\t%s %s\n", goName(p.Name), g.queryType(p))
+		}
+		g.printf("}\n\n")
+		g.printf("func (p *%sParams) values() url.Values {\n\tq := url.Values{}\n", name)
+		for _, p := range query {
+			field := "p." + goName(p.Name)
+			if g.queryType(p) == "*int" {
+				g.imports["strconv"] = true
+				g.printf("\tif %s != nil {\n\t\tq.Set(%q, strconv.Itoa(*%s))\n\t}\n", field, p.Name, field)
+			} else {
+				g.printf("\tif %s != \"\" {\n\t\tq.Set(%q, %s)\n\t}\n", field, p.Name, field)
+			}
+		}
+		g.printf("\treturn q\n}\n\n")
+	}
+
+	args := []string{"ctx context.Context"}
+	urlExpr := strings.Builder{}
+	rest := path
+	for _, p := range pathArgs {
+		args = append(args, p.Name+" string")
+		before, after, ok := strings.Cut(rest, "{"+p.Name+"}")
+		if !ok {
+			log.Fatalf("%s: path parameter %s not in %s", op.OperationID, p.Name, path)
+		}
+		fmt.Fprintf(&urlExpr, "%q + url.PathEscape(%s) + ", before, p.Name)
+		rest = after
+	}
+	fmt.Fprintf(&urlExpr, "%q", rest)
+	if len(query) > 0 {
+		args = append(args, "params *"+name+"Params")
+	}
+	bodyArg := "nil"
+	if op.RequestBody != nil && op.RequestBody.Content.JSON != nil {
+		args = append(args, "body "+g.goType(op.RequestBody.Content.JSON.Schema))
+		bodyArg = "body"
+	}
+	out := responseSchema(op)
+	if out == nil {
+		log.Fatalf("%s: no JSON response", op.OperationID)
+	}
+	outType := g.goType(out)
+
+	doc := fmt.Sprintf("%s calls %s %s: %s.", name, method, path, strings.ToLower(op.Summary[:1])+op.Summary[1:])
+	if len(op.Security) > 0 {
+		doc += " It needs the client's token."
+	}
+	g.comment("", doc)
+	g.printf("func (c *Client) %s(%s) (*%s, error) {\n", name, strings.Join(args, ", "), outType)
+	queryArg := "nil"
+	if len(query) > 0 {
+		g.printf("\tvar q url.Values\n\tif params != nil {\n\t\tq = params.values()\n\t}\n")
+		queryArg = "q"
+	}
+	g.printf("\tvar out %s\n", outType)
+	g.printf("\tif err := c.do(ctx, http.Method%s, %s, %s, %s, &out); err != nil {\n\t\treturn nil, err\n\t}\n",
+		strings.ToUpper(method[:1])+strings.ToLower(method[1:]), urlExpr.String(), queryArg, bodyArg)
+	g.printf("\treturn &out, nil\n}\n\n")
+	g.imports["context"] = true
+	g.imports["net/http"] = true
+	if len(query) > 0 || len(pathArgs) > 0 {
+		g.imports["net/url"] = true
+	}
+}
+
+// queryType keeps integers as pointers so 0 can be sent.
+func (g *generator) queryType(p parameter) string {
+	switch p.Schema.Type {
+	case "string":
+		return "string"
+	case "integer":
+		return "*int"
+	}
+	log.Fatalf("query parameter %s: unsupported type %q", p.Name, p.Schema.Type)
+	return ""
+}
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) != 3 {
+		log.Fatal("usage: gen <openapi.json> <out.go>")
+	}
+	raw, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+	g := &generator{imports: map[string]bool{}}
+	if err := json.Unmarshal(raw, &g.spec); err != nil {
+		log.Fatalf("%s: %v", os.Args[1], err)
+	}
+
+	g.schemas()
+	g.operations()
+	body := g.buf.Bytes()
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by go run ./gen from the dashboard OpenAPI spec. DO NOT EDIT.\n\npackage apiclient\n\nimport (\n")
+	imports := make([]string, 0, len(g.imports))
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	for _, imp := range imports {
+		fmt.Fprintf(&out, "\t%q\n", imp)
+	}
+	out.WriteString(")\n\n")
+	out.Write(body)
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+	if err := os.WriteFile(os.Args[2], src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
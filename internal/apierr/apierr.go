@@ -0,0 +1,142 @@
+// Package apierr sorts errors from the CLOB, Gamma and the chain into the
+// few categories the bot reacts to differently: it retries network errors,
+// backs off when rate limited, pauses on auth failures and alerts when the
+// wallet runs short.
+package apierr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind is an error category.
+type Kind string
+
+const (
+	Unknown             Kind = ""
+	Auth                Kind = "auth"
+	RateLimited         Kind = "rate_limited"
+	InsufficientBalance Kind = "insufficient_balance"
+	Validation          Kind = "validation"
+	// Network covers transport failures and 5xx answers: the request may
+	// well succeed if simply tried again.
+	Network Kind = "network"
+)
+
+// Error tags an underlying error with its Kind. Its message is the wrapped
+// error's, so tagging changes nothing in logs.
+type Error struct {
+	Kind Kind
+	// Status is the HTTP status that produced the error, 0 when none.
+	Status int
+	// RetryAfter is the server's Retry-After hint for RateLimited, if any.
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+func (e *Error) Unwrap() error { return e.Err }
+
+// Wrap tags err with kind. nil and cancellations are returned as they are:
+// a cancelled request says nothing about the API.
+func Wrap(kind Kind, err error) error {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return err
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// New returns a new error of the given kind.
+func New(kind Kind, msg string) error {
+	return &Error{Kind: kind, Err: errors.New(msg)}
+}
+
+// FromStatus tags err, built from a non-2xx answer, by its status. body is
+// the response text, used to tell a refused order for lack of funds from
+// other bad requests.
+func FromStatus(status int, header http.Header, body string, err error) error {
+	e := &Error{Kind: Validation, Status: status, Err: err}
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		e.Kind = Auth
+	case status == http.StatusTooManyRequests:
+		e.Kind = RateLimited
+		e.RetryAfter = retryAfter(header)
+	case status >= 500:
+		e.Kind = Network
+	case isBalanceMessage(body):
+		e.Kind = InsufficientBalance
+	}
+	return e
+}
+
+// KindOf returns the category of err: the Kind it was tagged with, else
+// Network for transport failures and timeouts, InsufficientBalance for the
+// wording nodes and the CLOB use for it, and Unknown otherwise.
+func KindOf(err error) Kind {
+	if err == nil {
+		return Unknown
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	var ne net.Error
+	switch {
+	case errors.As(err, &ne), errors.Is(err, context.DeadlineExceeded), errors.Is(err, io.ErrUnexpectedEOF):
+		return Network
+	case isBalanceMessage(err.Error()):
+		return InsufficientBalance
+	}
+	return Unknown
+}
+
+// Is reports whether err is of the given kind.
+func Is(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
+
+// RetryAfterOf returns the Retry-After hint carried by err, or 0.
+func RetryAfterOf(err error) time.Duration {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.RetryAfter
+	}
+	return 0
+}
+
+// StatusOf returns the HTTP status carried by err, or 0.
+func StatusOf(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Status
+	}
+	return 0
+}
+
+func isBalanceMessage(s string) bool {
+	s = strings.ToLower(s)
+	return strings.Contains(s, "not enough balance") ||
+		strings.Contains(s, "insufficient funds") ||
+		strings.Contains(s, "insufficient balance")
+}
+
+func retryAfter(h http.Header) time.Duration {
+	v := strings.TrimSpace(h.Get("Retry-After"))
+	if v == "" {
+		return 0
+	}
+	if n, err := strconv.Atoi(v); err == nil && n > 0 {
+		return time.Duration(n) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"limitorderbot/internal/apierr"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// sellRefreshLimit bounds the GetOrder calls one reconciliation pass spends
+// on historical SELL records.
+const sellRefreshLimit = 50
+
+// bookFill sets a SELL record's revenue and PnL from what has actually
+// filled (size_matched at the volume-weighted fill price, or the limit price
+// before trade prices are known). A SELL books nothing at placement: an
+// order that never fills earns nothing. BUY records are left as they are.
+func bookFill(o *models.OrderRecord) {
+	if o.TransactionType != string(models.OrderSideSell) {
+		return
+	}
+	price := o.Price
+	if o.FillPrice != nil {
+		price = *o.FillPrice
+	}
+	rev := filledShares(*o) * price
+	o.RevenueUSD = &rev
+	o.CostUSD = floatPtr(0)
+	o.PNLUSD = floatPtr(rev)
+}
+
+// sellRetryBase and sellRetryMax bound the backoff between GetOrder retries
+// for a history SELL whose lookup failed.
+const (
+	sellRetryBase = time.Minute
+	sellRetryMax  = time.Hour
+)
+
+// sellRetry is a history SELL whose last lookups failed.
+type sellRetry struct {
+	failures int
+	next     time.Time
+}
+
+// rebookSells recomputes SELL revenue in the order history from fills,
+// correcting records written when revenue was booked in full at placement.
+// Records the loop isn't tracking that still look open are refreshed from
+// the CLOB first; one whose state can't be fetched keeps its old figures
+// rather than being zeroed without evidence. Only an order the CLOB says it
+// doesn't know is given up on; other failures are retried with backoff.
+func (b *Bot) rebookSells(ctx context.Context) {
+	tracked := map[string]bool{}
+	for _, orders := range b.activeOrders {
+		for _, o := range orders {
+			tracked[o.OrderID] = true
+		}
+	}
+	now := b.clock.Now()
+	fixed, fetched := 0, 0
+	for id, o := range b.orderHistory {
+		if o.TransactionType != string(models.OrderSideSell) {
+			continue
+		}
+		open := o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled
+		if open && !tracked[id] {
+			if b.clob == nil || b.sellUnknown[id] || fetched >= sellRefreshLimit || ctx.Err() != nil {
+				continue
+			}
+			if r, ok := b.sellRetries[id]; ok && now.Before(r.next) {
+				continue
+			}
+			fetched++
+			details, err := b.clob.GetOrder(ctx, id)
+			switch {
+			case apierr.StatusOf(err) == http.StatusNotFound,
+				err == nil && (details == nil || (asString(details["id"]) == "" && asString(details["status"]) == "")):
+				b.sellUnknown[id] = true
+				delete(b.sellRetries, id)
+				continue
+			case err != nil:
+				if ctx.Err() == nil {
+					r := b.sellRetries[id]
+					r.failures++
+					r.next = now.Add(min(sellRetryBase<<min(r.failures-1, 6), sellRetryMax))
+					b.sellRetries[id] = r
+				}
+				continue
+			}
+			delete(b.sellRetries, id)
+			b.applyOrderDetails(&o, details)
+		}
+		before := o.RevenueUSD
+		bookFill(&o)
+		if before == nil || *before != *o.RevenueUSD {
+			fixed++
+		}
+		b.orderHistory[id] = o
+	}
+	if fixed > 0 {
+		logging.Logger().Printf("Re-booked revenue of %d SELL records from their fills\n", fixed)
+		_ = b.saveOrderHistory()
+	}
+}
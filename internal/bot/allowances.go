@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/clob"
+	"limitorderbot/internal/logging"
+)
+
+// allowanceCheckEvery spaces checkAllowances' on-chain reads.
+const allowanceCheckEvery = 10 * time.Minute
+
+// checkAllowances tops the exchanges' collateral allowances back up to
+// ALLOWANCE_EXACT_USD once fills have used up half of it. Only an EOA
+// signer's approvals are the bot's to refresh: proxy and Safe wallets
+// approve through Polymarket.
+func (b *Bot) checkAllowances(ctx context.Context, now time.Time) {
+	if b.cfg.AllowanceExactUSD <= 0 || b.cfg.SignatureType != "EOA" || b.gasLow {
+		return
+	}
+	if !b.lastAllowanceCheck.IsZero() && now.Sub(b.lastAllowanceCheck) < allowanceCheckEvery {
+		return
+	}
+	b.lastAllowanceCheck = now
+	if b.txWorker.pending("approve", "") {
+		return
+	}
+	collateral := b.chain.Addresses().Collateral
+	for _, s := range clob.Spenders(b.cfg.ChainID) {
+		allow, err := b.chain.ERC20Allowance(ctx, collateral, common.HexToAddress(s.Address))
+		if err != nil {
+			return
+		}
+		if left := toFloat6(allow); left < b.cfg.AllowanceExactUSD/2 {
+			b.queueApprove(s, left)
+		}
+	}
+}
+
+func (b *Bot) queueApprove(s clob.Spender, left float64) {
+	spender := common.HexToAddress(s.Address)
+	amount6 := big.NewInt(int64(b.cfg.AllowanceExactUSD * 1_000_000))
+	job := &TxJob{
+		Kind:       "approve",
+		MarketSlug: "allowance: " + s.Name,
+		Amount:     b.cfg.AllowanceExactUSD,
+		run: func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error) {
+			return b.chain.ApproveUSDCAsync(ctx, spender, amount6, cb)
+		},
+		done: func(job TxJob, err error) {
+			b.notifyTx(job, err)
+			b.bookGas(job)
+			if err != nil {
+				b.recordError(fmt.Errorf("allowance top-up for %s failed: %w", s.Name, err))
+				return
+			}
+			b.syncCollateralAllowance()
+			logging.Logger().Printf("%s may spend $%.2f USDC.e again\n", s.Name, job.Amount)
+		},
+	}
+	if b.queueTx(job) {
+		logging.Logger().Printf("%s allowance down to $%.2f; queued a top-up to $%.2f\n", s.Name, left, b.cfg.AllowanceExactUSD)
+	}
+}
@@ -0,0 +1,136 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+const arbitrageStrategy = "arbitrage"
+
+// arbitrageTask scans every upcoming market, not just the ones being traded,
+// for complements priced off 1 and publishes them as signals. The books it
+// reads also refresh the markets' prices.
+func (b *Bot) arbitrageTask(ctx context.Context, lt *loopTimer, now time.Time) {
+	if len(b.upcoming) == 0 {
+		return
+	}
+	if lt.overBudget() {
+		lt.skip("arbitrage")
+		return
+	}
+	done := lt.begin("arbitrage")
+	defer done()
+
+	refreshed := b.fillMarketPrices(ctx, append([]models.Market(nil), b.upcoming...))
+	copy(b.upcoming, refreshed)
+	b.publishMarkets()
+
+	var signals []models.ArbSignal
+	seen := map[string]bool{}
+	for _, m := range refreshed {
+		for _, s := range b.complementSignals(m, now) {
+			key := s.ConditionID + "/" + s.Kind
+			seen[key] = true
+			signals = append(signals, s)
+			if b.arbSeen[key] {
+				continue
+			}
+			logging.Logger().Printf("Arbitrage %s on %s: %.3f + %.3f = %.3f (edge %.3f)\n", s.Kind, s.MarketSlug, s.UpPrice, s.DownPrice, s.Sum, s.Edge)
+			if b.cfg.ArbTrade && s.Kind == models.ArbBuyBoth {
+				b.tradeComplement(ctx, m, s)
+			}
+		}
+	}
+	b.arbSeen = seen
+	b.mu.Lock()
+	b.state.ArbSignals = signals
+	b.mu.Unlock()
+}
+
+// complementSignals checks one market's top of book against 1 ± ARB_FEE.
+func (b *Bot) complementSignals(m models.Market, now time.Time) []models.ArbSignal {
+	up, down := findYesNoOutcomes(m.Outcomes)
+	if up == nil || down == nil {
+		return nil
+	}
+	var out []models.ArbSignal
+	add := func(kind string, p1, p2, edge float64) {
+		out = append(out, models.ArbSignal{
+			ConditionID: m.ConditionID,
+			MarketSlug:  m.MarketSlug,
+			Kind:        kind,
+			UpPrice:     p1,
+			DownPrice:   p2,
+			Sum:         p1 + p2,
+			Edge:        edge,
+			DetectedAt:  now,
+		})
+	}
+	if up.BestAsk != nil && down.BestAsk != nil {
+		if edge := 1 - b.cfg.ArbFee - (*up.BestAsk + *down.BestAsk); edge > 1e-9 {
+			add(models.ArbBuyBoth, *up.BestAsk, *down.BestAsk, edge)
+		}
+	}
+	if up.BestBid != nil && down.BestBid != nil {
+		if edge := *up.BestBid + *down.BestBid - 1 - b.cfg.ArbFee; edge > 1e-9 {
+			add(models.ArbSellBoth, *up.BestBid, *down.BestBid, edge)
+		}
+	}
+	return out
+}
+
+// tradeComplement takes both asks of a buy_both signal with fill-or-kill
+// orders of equal size; the periodic merge then turns each pair into $1. If
+// the second leg doesn't fill, the first is sold straight back.
+func (b *Bot) tradeComplement(ctx context.Context, m models.Market, s models.ArbSignal) {
+	log := logging.Logger()
+	if b.positionsSold[m.ConditionID] {
+		return // merges for this market are already wound down
+	}
+	up, down := findYesNoOutcomes(m.Outcomes)
+	shares := calculateShares(s.Sum, 2*b.cfg.OrderSizeUSD, b.pairLotSize(ctx, up.TokenID, down.TokenID))
+	required := s.Sum * shares
+	if err := b.checkArbitrage(ctx, required); err != nil {
+		log.Printf("Arbitrage on %s not traded: %v\n", m.MarketSlug, err)
+		return
+	}
+
+	first, err := b.takeAskShares(ctx, m, *up, s.UpPrice, shares, arbitrageStrategy, models.PhaseArbitrage)
+	if err != nil || first.Status != models.OrderStatusFilled {
+		log.Printf("Arbitrage on %s: %s leg did not fill: %v\n", m.MarketSlug, up.Outcome, err)
+		return
+	}
+	b.keepArbOrder(first)
+	second, err := b.takeAskShares(ctx, m, *down, s.DownPrice, shares, arbitrageStrategy, models.PhaseArbitrage)
+	if err != nil || second.Status != models.OrderStatusFilled {
+		log.Printf("Arbitrage on %s: %s leg did not fill (%v), selling %s back\n", m.MarketSlug, down.Outcome, err, up.Outcome)
+		if err := b.sellPositionMarket(ctx, m, *up, shares, models.PhaseExit); err != nil {
+			log.Printf("Arbitrage unwind on %s failed: %v\n", m.MarketSlug, err)
+		}
+		return
+	}
+	b.keepArbOrder(second)
+	log.Printf("Arbitrage on %s: bought %.2f pairs at %.3f, merge pending\n", m.MarketSlug, shares, s.Sum)
+	_ = b.saveOrders()
+	_ = b.saveOrderHistory()
+}
+
+func (b *Bot) checkArbitrage(ctx context.Context, required float64) error {
+	bal, _ := b.chain.USDCBalance(ctx)
+	if bal > 0 && bal < required {
+		return fmt.Errorf("%w: $%.2f < $%.2f", errInsufficientBalance, bal, required)
+	}
+	if err := b.checkCooldown(arbitrageStrategy); err != nil {
+		return err
+	}
+	return b.checkCapital(arbitrageStrategy, required)
+}
+
+func (b *Bot) keepArbOrder(o models.OrderRecord) {
+	b.activeOrders[o.ConditionID] = append(b.activeOrders[o.ConditionID], o)
+	b.orderHistory[o.OrderID] = o
+}
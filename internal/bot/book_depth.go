@@ -0,0 +1,84 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"limitorderbot/internal/clob"
+	"limitorderbot/internal/models"
+)
+
+// bookDepthTicks is how far from the mid resting size counts as depth.
+const bookDepthTicks = 2
+
+// thinBookDetail returns a non-empty explanation when any outcome's book
+// holds less than MIN_BOOK_DEPTH_USD within bookDepthTicks of its mid.
+// Books that can't be fetched are not held against the market.
+func (b *Bot) thinBookDetail(ctx context.Context, m models.Market) string {
+	for _, o := range m.Outcomes {
+		book, err := b.clob.GetOrderBook(ctx, o.TokenID)
+		if err != nil {
+			continue
+		}
+		tick := 0.01
+		if ts, err := b.clob.GetTickSize(ctx, o.TokenID); err == nil {
+			if f, ok := parseTickSize(ts); ok && f > 0 {
+				tick = f
+			}
+		}
+		if depth := bookDepthUSD(book, tick, bookDepthTicks); depth < b.cfg.MinBookDepthUSD {
+			return fmt.Sprintf("%s book has $%.2f within %d ticks of mid < $%.2f", o.Outcome, depth, bookDepthTicks, b.cfg.MinBookDepthUSD)
+		}
+	}
+	return ""
+}
+
+// bookDepthUSD sums the notional of both sides within ticks of the mid; an
+// empty side means there is no mid and no depth.
+func bookDepthUSD(book map[string]any, tick float64, ticks int) float64 {
+	q := bookTop(book)
+	if q.BestBid <= 0 || q.BestAsk <= 0 {
+		return 0
+	}
+	bids, _ := book["bids"].([]any)
+	asks, _ := book["asks"].([]any)
+	mid := (q.BestBid + q.BestAsk) / 2
+	reach := float64(ticks)*tick + 1e-9
+	var depth float64
+	for _, side := range [][]any{bids, asks} {
+		for _, l := range side {
+			if p, size := bookLevel(l); p > 0 && math.Abs(p-mid) <= reach {
+				depth += p * size
+			}
+		}
+	}
+	return depth
+}
+
+// bookTop finds the best levels of a REST book whatever order the levels
+// come in; a missing side is 0.
+func bookTop(book map[string]any) clob.Quote {
+	var q clob.Quote
+	bids, _ := book["bids"].([]any)
+	for _, l := range bids {
+		if p, size := bookLevel(l); size > 0 && p > q.BestBid {
+			q.BestBid, q.BidSize = p, size
+		}
+	}
+	asks, _ := book["asks"].([]any)
+	for _, l := range asks {
+		if p, size := bookLevel(l); size > 0 && p > 0 && (q.BestAsk == 0 || p < q.BestAsk) {
+			q.BestAsk, q.AskSize = p, size
+		}
+	}
+	return q
+}
+
+func bookLevel(l any) (price, size float64) {
+	m, _ := l.(map[string]any)
+	if m == nil {
+		return 0, 0
+	}
+	return asFloat(m["price"]), asFloat(m["size"])
+}
@@ -5,28 +5,62 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"limitorderbot/internal/chain"
 	"limitorderbot/internal/clob"
+	"limitorderbot/internal/clock"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/decisions"
+	"limitorderbot/internal/fillprob"
 	"limitorderbot/internal/gamma"
+	"limitorderbot/internal/gasspend"
 	"limitorderbot/internal/logging"
+	"limitorderbot/internal/marketdata"
 	"limitorderbot/internal/models"
+	"limitorderbot/internal/notify"
+	"limitorderbot/internal/predict"
+	"limitorderbot/internal/rewards"
+	"limitorderbot/internal/runs"
+	"limitorderbot/internal/schema"
+	"limitorderbot/internal/strategy"
+	"limitorderbot/internal/strategy/script"
+	"limitorderbot/internal/webhook"
 )
 
 type Bot struct {
-	cfg      config.Config
-	discover *gamma.Discovery
-	clob     *clob.Client
-	chain    *chain.Client
+	cfg    config.Config
+	source marketdata.Source
+	clob   *clob.Client
+	chain  Chain
+	clock  clock.Clock
+
+	// history serves CLOB price history whichever market source is in use.
+	history *gamma.Discovery
+	// dataHTTP talks to the data-api (wallet positions).
+	dataHTTP *http.Client
+
+	// predictor is optional (PREDICTOR=none leaves it nil).
+	predictor predict.Predictor
+	// custom is the ORDER_MODE strategy from the strategy registry or, for
+	// ORDER_MODE=script, the STRATEGY_SCRIPT file; nil for the other modes.
+	custom strategy.Strategy
 
 	mu sync.Mutex
 
 	state models.BotState
+	// params mirrors the live StrategyParams of cfg for readers off the loop.
+	params StrategyParams
+	// capitalUsed is capitalInUse per budgeted strategy as of the last pass,
+	// plus what reserveCapital granted since.
+	capitalUsed map[string]float64
 
 	trackedMarkets map[string]models.Market
 	ordersPlaced   map[string]bool
@@ -39,33 +73,154 @@ type Bot struct {
 	strategyExecuted map[string]bool
 
 	lastRedemptionCheck *time.Time
+	// redeemDue runs the redeem task on the next loop, ahead of its cadence.
+	redeemDue bool
+	// cadence is the adaptive loop cadence last chosen ("event", "quiet", "").
+	cadence             string
+	upcoming            []models.Market
+	lastResolutionCheck map[string]time.Time
+	lastBalanceSnapshot time.Time
+	lastTransferScan    time.Time
+	lastBookSample      time.Time
+	lastPriceSave       time.Time
+	lastRewardsFetch    time.Time
+	lastFundingAlert    time.Time
+	lastGasAlert        time.Time
+	lastAllowanceCheck  time.Time
+	gasLow              bool
+	lossStreaks         map[string]int
+	lastDecision        map[string]string
+	orderFeeds          map[string]*orderFeed
+	arbSeen             map[string]bool
+	rewardDays          map[string]*rewards.Day
+	gasDays             map[string]*gasspend.Day
+	dust                map[string]dustEntry
+
+	// thinBooks are markets this placement pass skipped for shallow books;
+	// the fallback steps leave them alone too.
+	thinBooks map[string]bool
+
+	// fillModel is the fill-probability estimator offered to strategies,
+	// refitted from orderHistory by fillEstimator.
+	fillModel   *fillprob.Estimator
+	fillModelAt time.Time
+
+	// lastScoringCheck throttles checkOrderScoring.
+	lastScoringCheck time.Time
+
+	// archivedPNL and archivedOrders total the orders compactState moved to
+	// orderArchiveFile; memStats is what MemoryStats reports (guarded by mu).
+	lastCompaction time.Time
+	archivedPNL    float64
+	archivedOrders int
+	memStats       MemoryStats
+
+	// userFeed reports our fills as they happen; orderResync asks the next
+	// order check to poll once after the feed (re)connects.
+	userFeed    *clob.UserFeed
+	orderResync atomic.Bool
+
+	// bookFeed streams the followed markets' books for tick pricing; nil
+	// unless ORDER_MODE=liquidity with LIQUIDITY_PRICING=tick.
+	bookFeed *clob.MarketFeed
+
+	// prewarming guards the single off-loop cache warming pass; warmTokens
+	// (owned by that pass) are the tokens it last warmed. presigned holds
+	// orders signed ahead of placement, keyed by presignKey.
+	prewarming atomic.Bool
+	warmTokens map[string]bool
+	presignMu  sync.Mutex
+	presigned  map[string]clob.SignedOrderJSON
+
+	// runID names this process's run in runs.json, next to its config; it
+	// is fixed in New and tags every order, tx job and log line.
+	runID string
+
+	// sellUnknown are history SELLs the CLOB doesn't know; rebookSells
+	// leaves them as recorded. sellRetries are ones whose lookups failed and
+	// are retried with backoff.
+	sellUnknown map[string]bool
+	sellRetries map[string]sellRetry
+
+	// chainEvents carries state updates from tx confirmation callbacks back to
+	// the loop goroutine, which owns the maps above.
+	chainEvents chan func()
+	txWorker    *txWorker
+
+	loopMetrics LoopMetrics
+	clobHealth  ClobHealth
+
+	// notifier is nil when no channel is configured; the digest fields track
+	// the DIGEST_SCHEDULE period being summarized.
+	notifier       notify.Notifier
+	digestSchedule *notify.Schedule
+	nextDigest     time.Time
+	digestFrom     time.Time
+	digestErrors   int
+	// hooks is nil without WEBHOOKS_FILE.
+	hooks *webhook.Dispatcher
+
+	// Error reactions (see recordError), guarded by mu: rateLimits counts
+	// consecutive passes that hit a rate limit, authErr asks the next pass
+	// to re-derive credentials (authRetriedAt, when it last did), and
+	// netFailed marks the running task for an early retry.
+	rateLimits       int
+	rateLimited      bool
+	authErr          string
+	authRetriedAt    time.Time
+	netFailed        bool
+	lastBalanceAlert time.Time
 
 	ordersFile       string
 	orderHistoryFile string
+	orderArchiveFile string
 	marketsFile      string
 }
 
 func New(cfg config.Config) (*Bot, error) {
+	return NewWithDeps(cfg, Deps{})
+}
+
+// NewWithDeps is New with some clients or the clock supplied by the caller.
+func NewWithDeps(cfg config.Config, deps Deps) (*Bot, error) {
 	closeFn, err := logging.Configure(cfg.LogLevel, cfg.LogFile)
 	if err != nil {
 		return nil, err
 	}
 	_ = closeFn // log file close is process-scoped in this port
 
-	cc, err := clob.NewClient(cfg.ClobAPIURL, cfg.ChainID, cfg.PrivateKey, cfg.SignatureType, cfg.FunderAddress)
-	if err != nil {
-		return nil, err
+	cc := deps.Clob
+	if cc == nil {
+		cc, err = clob.NewClient(cfg.ClobAPIURL, cfg.ChainID, cfg.Keys, cfg.SignatureType, cfg.FunderAddress)
+		if err != nil {
+			return nil, err
+		}
+		cc.SetHTTPClient(cfg.HTTPClient(cfg.ClobHTTP))
+		cc.SetCacheLimits(cfg.ClobCacheSize, time.Duration(cfg.ClobCacheTTLMinutes)*time.Minute)
 	}
-	ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
-	if err != nil {
-		return nil, err
+	ch := deps.Chain
+	if ch == nil {
+		if ch, err = newChain(cfg); err != nil {
+			return nil, err
+		}
+	}
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.System
+	}
+	dataHTTP := deps.DataHTTP
+	if dataHTTP == nil {
+		dataHTTP = cfg.HTTPClient(cfg.DataAPIHTTP)
 	}
 
 	b := &Bot{
 		cfg:              cfg,
-		discover:         gamma.New(cfg.GammaAPIBaseURL),
+		params:           paramsOf(cfg),
+		history:          gamma.New(cfg.GammaAPIBaseURL),
+		dataHTTP:         dataHTTP,
 		clob:             cc,
 		chain:            ch,
+		clock:            clk,
 		trackedMarkets:   map[string]models.Market{},
 		ordersPlaced:     map[string]bool{},
 		activeOrders:     map[string][]models.OrderRecord{},
@@ -74,11 +229,78 @@ func New(cfg config.Config) (*Bot, error) {
 		mergedAmounts:    map[string]float64{},
 		positionsSold:    map[string]bool{},
 		strategyExecuted: map[string]bool{},
+		presigned:        map[string]clob.SignedOrderJSON{},
+		sellUnknown:      map[string]bool{},
+		sellRetries:      map[string]sellRetry{},
+		chainEvents:      make(chan func(), 64),
 		ordersFile:       "bot_orders.json",
 		orderHistoryFile: "order_history.json",
+		orderArchiveFile: "order_history_archive.jsonl",
 		marketsFile:      "markets_state.json",
 	}
 
+	b.history.ClobURL = strings.TrimSuffix(cfg.ClobAPIURL, "/")
+	b.history.HTTP = cfg.HTTPClient(cfg.ClobHTTP)
+	b.history.Clock = clk
+	if deps.DataHTTP != nil {
+		b.history.HTTP = deps.DataHTTP
+	}
+	srcOpts := marketdata.Options{
+		GammaURL:    cfg.GammaAPIBaseURL,
+		ClobURL:     cfg.ClobAPIURL,
+		FixtureFile: cfg.MarketFixtureFile,
+		GammaHTTP:   cfg.HTTPClient(cfg.GammaHTTP),
+		ClobHTTP:    cfg.HTTPClient(cfg.ClobHTTP),
+		Clock:       clk,
+		Horizon:     cfg.DiscoveryHorizonFor,
+	}
+	if b.source = deps.Source; b.source == nil {
+		b.source, err = marketdata.New(cfg.MarketSource, srcOpts)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.MarketSourceFallback != "none" && cfg.MarketSourceFallback != cfg.MarketSource {
+			fb, err := marketdata.New(cfg.MarketSourceFallback, srcOpts)
+			if err != nil {
+				return nil, err
+			}
+			b.source = marketdata.WithFallback(b.source, fb, time.Duration(cfg.MarketSourceTimeoutSeconds)*time.Second)
+		}
+	}
+	b.runID = runs.NewID(clk.Now())
+	b.txWorker = newTxWorker(b.chainEvents, b.runID)
+	b.predictor, err = predict.New(cfg.PredictorName, predict.Options{LookbackMinutes: cfg.PredictorLookbackMinutes})
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(strings.TrimSpace(cfg.OrderMode), "script") {
+		if b.custom, err = script.Load(cfg.StrategyScript); err != nil {
+			return nil, err
+		}
+	} else if b.custom, err = strategy.New(cfg.OrderMode); err != nil {
+		return nil, err
+	}
+	b.lastResolutionCheck = map[string]time.Time{}
+	b.lossStreaks = map[string]int{}
+	b.lastDecision = map[string]string{}
+	b.orderFeeds = map[string]*orderFeed{}
+	b.setupNotifications()
+	if b.hooks, err = webhook.Load(cfg.WebhooksFile); err != nil {
+		return nil, fmt.Errorf("WEBHOOKS_FILE: %w", err)
+	}
+	if b.rewardDays, err = rewards.Load(rewards.DefaultFile); err != nil {
+		logging.Logger().Printf("Failed to load rewards (starting fresh): %v\n", err)
+		b.rewardDays = map[string]*rewards.Day{}
+	}
+	if b.gasDays, err = gasspend.Load(gasspend.DefaultFile); err != nil {
+		logging.Logger().Printf("Failed to load gas spend (starting fresh): %v\n", err)
+		b.gasDays = map[string]*gasspend.Day{}
+	}
+	if b.dust, err = loadDust(); err != nil {
+		logging.Logger().Printf("Failed to load %s (starting fresh): %v\n", DustFile, err)
+		b.dust = map[string]dustEntry{}
+	}
+
 	// initial state
 	b.state.ActiveMarkets = []models.Market{}
 	b.state.PendingOrders = []models.OrderRecord{}
@@ -86,6 +308,23 @@ func New(cfg config.Config) (*Bot, error) {
 	return b, nil
 }
 
+// newChain dials the configured RPC endpoints and applies the tx settings.
+func newChain(cfg config.Config) (*chain.Client, error) {
+	ch, err := chain.New(cfg.RPCURLs, cfg.Keys, cfg.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RPCWSURL != "" {
+		if err := ch.EnableWebsocket(cfg.RPCWSURL); err != nil {
+			logging.Logger().Printf("WARNING: websocket RPC unavailable, polling for receipts: %v\n", err)
+		}
+	}
+	ch.SetConfirmations(uint64(cfg.TxConfirmations))
+	ch.SetConfirmTimeout(time.Duration(cfg.TxConfirmTimeoutSeconds) * time.Second)
+	ch.SetReturnOnBroadcast(cfg.TxReturnOnBroadcast)
+	return ch, nil
+}
+
 func (b *Bot) Close() error {
 	return b.chain.Close()
 }
@@ -96,15 +335,69 @@ func (b *Bot) Start(ctx context.Context) error {
 	logger.Println("Starting Polymarket Limit Order Bot (Go)")
 	logger.Println(strings.Repeat("=", 60))
 	logger.Printf("Wallet address: %s\n", b.clob.Address())
+	logger.Printf("Key source: %s\n", b.cfg.Keys.Source())
+	if b.predictor != nil {
+		logger.Printf("Predictor: %s (max skew %.3f)\n", b.predictor.Name(), b.cfg.PredictorMaxSkew)
+	}
 	logger.Printf("Order size: $%.2f per order\n", b.cfg.OrderSizeUSD)
-	logger.Printf("Spread offset: %.4f\n", b.cfg.SpreadOffset)
+	if b.tickPricing() {
+		logger.Printf("Liquidity pricing: join/improve by one tick (queue >= %.0f shares)\n", b.cfg.LiquidityQueueShares)
+	} else {
+		logger.Printf("Spread offset: %.4f\n", b.cfg.SpreadOffset)
+	}
 	logger.Printf("Order placement window: %d-%d min before start\n", b.cfg.OrderPlacementMinMinutes, b.cfg.OrderPlacementMaxMinutes)
+	logger.Printf("Max concurrent markets: %d\n", b.cfg.MaxConcurrentMarkets)
+	for _, d := range sortedDurations(b.cfg.PlacementWindows) {
+		w := b.cfg.PlacementWindows[d]
+		logger.Printf("  %s markets: %d-%d min before start\n", d, w.MinMinutes, w.MaxMinutes)
+	}
+	if b.digestSchedule != nil {
+		logger.Printf("Account digest: %q, next at %s\n", b.cfg.DigestSchedule, b.nextDigest.Format(time.RFC3339))
+	}
 	logger.Println(strings.Repeat("=", 60))
 
-	// Load persisted state
-	_ = b.loadMarkets()
-	_ = b.loadOrderHistory()
-	_ = b.loadOrders()
+	// A signer/funder mismatch only surfaces later as cryptic order
+	// rejections, so it stops the start; a failed lookup doesn't.
+	if err := b.chain.CheckFunder(ctx, b.cfg.SignatureType, common.HexToAddress(b.cfg.FunderAddress)); err != nil {
+		if errors.Is(err, chain.ErrAccountMismatch) {
+			return err
+		}
+		logger.Printf("WARNING: could not verify SIGNATURE_TYPE/FUNDER_ADDRESS: %v\n", err)
+	}
+
+	run := runs.Run{ID: b.runID, StartedAt: b.clock.Now().UTC(), Config: b.cfg.Snapshot()}
+	if err := runs.Record(runs.DefaultFile, run); err != nil {
+		logger.Printf("Warning: could not record run config: %v\n", err)
+	}
+	logger.Printf("Run %s\n", run.ID)
+	logging.SetRunID(run.ID)
+
+	b.refreshPause()
+
+	// Load persisted state. Files from a newer build stop the start: saving
+	// over them would lose what this build can't read.
+	firstRun := b.firstRun()
+	for _, load := range []func() error{b.loadMarkets, b.loadOrderHistory, b.loadOrders} {
+		if err := load(); err != nil {
+			if errors.Is(err, schema.ErrNewer) {
+				return err
+			}
+			logger.Printf("Warning: could not load state: %v\n", err)
+		}
+	}
+	if err := b.loadOrderArchive(); err != nil {
+		logger.Printf("Warning: could not read %s: %v\n", b.orderArchiveFile, err)
+	}
+	b.seedUpcoming(b.clock.Now())
+
+	// CLOB availability check now and in the background.
+	b.checkClob(ctx)
+	if h := b.ClobHealth(); h.Available {
+		logger.Printf("CLOB API OK (latency %.0fms, clock skew %dms)\n", h.LatencyMS, h.ClockSkewMS)
+	}
+	if b.cfg.ClobHeartbeatSeconds > 0 {
+		go b.runClobHeartbeat(ctx, time.Duration(b.cfg.ClobHeartbeatSeconds)*time.Second)
+	}
 
 	// Initialize balance immediately
 	bal, err := b.chain.USDCBalance(ctx)
@@ -117,18 +410,34 @@ func (b *Bot) Start(ctx context.Context) error {
 	if err == nil && creds.APIKey != "" {
 		b.clob.SetCreds(creds)
 		logger.Println("CLOB API creds derived and set successfully")
+		b.startUserFeed(ctx, creds)
 		// Mirror python: try to update L2 balance allowance on startup.
 		b.updateL2BalanceAllowanceBestEffort(ctx)
+		b.syncHeldAllowances()
 	} else {
 		logger.Printf("WARNING: Could not derive API creds (read-only mode): %v\n", err)
 	}
+	b.importOnFirstRun(ctx, firstRun)
+
+	// On-chain merges/redeems run off the trading loop.
+	go b.txWorker.run(ctx)
+
+	if b.tickPricing() && strings.EqualFold(strings.TrimSpace(b.cfg.OrderMode), "liquidity") {
+		b.bookFeed = clob.NewMarketFeed(b.cfg.ClobWSURL)
+		go b.bookFeed.Run(ctx)
+	}
+
+	if b.cfg.LastMinuteSeconds > 0 {
+		logger.Printf("Last-minute strategy: final %ds before start, %dms ticks\n", b.cfg.LastMinuteSeconds, b.cfg.LastMinuteTickMS)
+		go b.runLastMinute(ctx)
+	}
 
 	// Recover existing open orders from orderbook (if L2 auth available)
 	if b.clob != nil {
 		_ = b.recoverExistingOrders(ctx)
 	}
 
-	now := time.Now()
+	now := b.clock.Now()
 	b.mu.Lock()
 	b.state.IsRunning = true
 	b.state.USDCBalance = bal
@@ -139,8 +448,9 @@ func (b *Bot) Start(ctx context.Context) error {
 
 func (b *Bot) Stop() {
 	b.mu.Lock()
-	defer b.mu.Unlock()
 	b.state.IsRunning = false
+	b.mu.Unlock()
+	b.touchRun(b.clock.Now(), true)
 }
 
 func (b *Bot) GetState() models.BotState {
@@ -149,6 +459,11 @@ func (b *Bot) GetState() models.BotState {
 	return b.state
 }
 
+// RunID names the current run in runs.json.
+func (b *Bot) RunID() string {
+	return b.runID
+}
+
 func (b *Bot) WalletAddress() string {
 	if b.clob == nil {
 		return ""
@@ -156,60 +471,84 @@ func (b *Bot) WalletAddress() string {
 	return b.clob.Address()
 }
 
+// RPCHealth exposes per-endpoint RPC health for the dashboard.
+func (b *Bot) RPCHealth() []chain.EndpointHealth {
+	return b.chain.Health()
+}
+
 func (b *Bot) OrdersPlaced(conditionID string) bool {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	return b.ordersPlaced[conditionID]
 }
 
-func (b *Bot) RunOnce(ctx context.Context) {
-	now := time.Now()
+// redeemTask queues redemptions for resolved positions.
+func (b *Bot) redeemTask(ctx context.Context, lt *loopTimer, now time.Time) {
+	if !b.redeemDue && !b.shouldCheckRedemptions(now) {
+		return
+	}
+	b.redeemDue = false
+	done := lt.begin("redeem")
+	if redeemed, err := b.checkAndRedeemAll(ctx); err != nil {
+		logging.Logger().Printf("Redemption check error: %v\n", err)
+	} else if redeemed > 0 {
+		logging.Logger().Printf("✓ Queued redemption for %d resolved markets\n", redeemed)
+	}
+	t := now
+	b.lastRedemptionCheck = &t
+	done()
+}
+
+// marketsTask discovers markets and places orders for those entering the
+// placement window (falling back to idle placement when nothing is active).
+func (b *Bot) marketsTask(ctx context.Context, lt *loopTimer, now time.Time) {
 	b.mu.Lock()
 	b.state.LastCheck = &now
 	b.mu.Unlock()
-
 	logger := logging.Logger()
 
-	// Step 0: auto redeem (periodic)
-	if b.shouldCheckRedemptions(now) {
-		if redeemed, err := b.checkAndRedeemAll(ctx); err != nil {
-			logger.Printf("Redemption check error: %v\n", err)
-		} else if redeemed > 0 {
-			logger.Printf("✓ Claimed winnings from %d resolved markets\n", redeemed)
-		}
-		t := now
-		b.lastRedemptionCheck = &t
-	}
-
 	// Step 1: discover markets
-	logger.Println("Discovering BTC 15-minute markets...")
-	markets, err := b.discover.DiscoverBTC15mMarkets(ctx)
+	done := lt.begin("discovery")
+	logger.Printf("Discovering markets (%s)...\n", b.source.Name())
+	markets, err := b.source.Discover(ctx)
 	if err != nil {
+		done()
 		b.recordError(err)
 		return
 	}
 	upcoming := b.filterUpcoming(markets, now)
-	// Fill market prices for dashboard (best-effort)
-	upcoming = b.fillMarketPrices(ctx, upcoming)
+	done()
 
-	b.mu.Lock()
-	b.state.ActiveMarkets = upcoming
-	b.mu.Unlock()
+	// Carry the latest prices over until the price task refreshes them.
+	b.upcoming = carryPrices(upcoming, b.upcoming)
+	b.publishMarkets()
 	logger.Printf("Found %d upcoming/active markets\n", len(upcoming))
+	b.startPrewarm(ctx, upcoming, now)
 
 	// Step 2: process markets for order placement
+	done = lt.begin("placement")
+	b.thinBooks = map[string]bool{}
 	for _, m := range upcoming {
 		if b.ordersPlaced[m.ConditionID] {
 			continue
 		}
 		if !shouldPlaceOrders(b.cfg, m, now) {
+			b.recordDecision(m, decisions.Skipped, decisions.ReasonWindow, b.windowDetail(m, now), 0)
 			continue
 		}
 		// Mirror python: skip placing if bot has active work in another market.
 		if hasWork, reason := b.hasActiveMarketWork(ctx, now); hasWork {
 			logger.Printf("Skipping %s - bot is %s\n", m.MarketSlug, reason)
+			b.recordDecision(m, decisions.Skipped, decisions.ReasonBusy, reason, 0)
 			continue
 		}
+		if b.cfg.MinBookDepthUSD > 0 {
+			if detail := b.thinBookDetail(ctx, m); detail != "" {
+				b.thinBooks[m.ConditionID] = true
+				b.recordDecision(m, decisions.Skipped, decisions.ReasonThinBook, detail, 0)
+				continue
+			}
+		}
 		logger.Printf("Placing orders for %s (starts in %.1f minutes)\n", m.MarketSlug, m.TimeUntilStart(now).Minutes())
 		var (
 			orders []models.OrderRecord
@@ -218,16 +557,28 @@ func (b *Bot) RunOnce(ctx context.Context) {
 		switch strings.ToLower(strings.TrimSpace(b.cfg.OrderMode)) {
 		case "liquidity":
 			orders, err = b.placeLiquidityOrders(ctx, m)
+		case "spread_capture":
+			orders, err = b.placeSpreadCaptureOrders(ctx, m)
 		default:
-			orders, err = b.placeSimpleTestOrders(ctx, m, 0.49, 10.0)
+			if b.custom != nil {
+				orders, err = b.placeCustomOrders(ctx, m)
+				break
+			}
+			orders, err = b.placeSimpleTestOrders(ctx, m, testQuotePrice, testQuoteSize)
 		}
+		b.recordPlacementResult(m, orders, err, "")
+		b.tagEntryPhase(orders, m, now)
 		if err != nil {
 			b.recordError(err)
-			continue
+			// Orders returned with an error are ones the placer couldn't
+			// pull back; they are live and still need tracking.
+			if len(orders) == 0 {
+				continue
+			}
 		}
 		if len(orders) > 0 {
 			b.ordersPlaced[m.ConditionID] = true
-			b.activeOrders[m.ConditionID] = orders
+			b.activeOrders[m.ConditionID] = append(b.activeOrders[m.ConditionID], orders...)
 			for _, o := range orders {
 				b.orderHistory[o.OrderID] = o
 			}
@@ -235,44 +586,173 @@ func (b *Bot) RunOnce(ctx context.Context) {
 			_ = b.saveOrderHistory()
 		}
 	}
+	done()
+
+	// Step 3.6: fallback orders if idle (python parity); lowest priority.
+	// A custom strategy owns its placement, so it gets no fallback.
+	if lt.overBudget() {
+		lt.skip("fallback")
+	} else if b.custom == nil {
+		done = lt.begin("fallback")
+		if strings.ToLower(strings.TrimSpace(b.cfg.OrderMode)) == "liquidity" {
+			// For liquidity mode, fallback means placing liquidity orders too.
+			b.placeFallbackLiquidityIfIdle(ctx, upcoming, now)
+		} else {
+			b.placeFallbackOrdersIfIdle(ctx, upcoming, now)
+		}
+		done()
+	}
+}
+
+// pricesTask refreshes books for the nearest markets (the ones the dashboard
+// lists and the strategies act on).
+func (b *Bot) pricesTask(ctx context.Context, lt *loopTimer, now time.Time) {
+	if len(b.upcoming) == 0 {
+		return
+	}
+	done := lt.begin("price_fill")
+	n := min(len(b.upcoming), priceRefreshMarkets)
+	refreshed := b.fillMarketPrices(ctx, append([]models.Market(nil), b.upcoming[:n]...))
+	copy(b.upcoming, refreshed)
+	b.publishMarkets()
+	b.keepPrices(refreshed, now)
+	b.syncBookFeed(refreshed)
+	done()
+
+	if b.cfg.StaleQuoteTicks > 0 {
+		done = lt.begin("stale_quotes")
+		b.cancelStaleQuotes(ctx, refreshed)
+		done()
+	}
+}
 
+// ordersTask tracks fills and runs the fill-driven strategy steps.
+func (b *Bot) ordersTask(ctx context.Context, lt *loopTimer, now time.Time) {
 	// Step 3: check active orders
+	done := lt.begin("order_checks")
 	b.checkActiveOrders(ctx)
+	b.downsizeOrders(ctx)
+	b.sampleRestingOrders(now)
+	b.checkOrderScoring(ctx, now)
+	if strings.ToLower(strings.TrimSpace(b.cfg.OrderMode)) == "spread_capture" {
+		b.manageSpreadCapture(ctx, now)
+	}
+	done()
 
 	// Step 3.5: strategy timeout exit (cancel + merge + sell leftovers)
+	done = lt.begin("strategy_exit")
 	b.checkStrategyExecution(ctx, now)
+	done()
+}
 
-	// Step 3.6: fallback orders if idle (python parity)
-	if strings.ToLower(strings.TrimSpace(b.cfg.OrderMode)) == "liquidity" {
-		// For liquidity mode, fallback means placing liquidity orders too.
-		b.placeFallbackLiquidityIfIdle(ctx, upcoming, now)
+// maintenanceTask does the low-priority bookkeeping: resolutions, rewards,
+// cleanup and the wallet balance.
+func (b *Bot) maintenanceTask(ctx context.Context, lt *loopTimer, now time.Time) {
+	// Record winners of ended markets before cleanup drops them; low priority.
+	if lt.overBudget() {
+		lt.skip("resolution")
 	} else {
-		b.placeFallbackOrdersIfIdle(ctx, upcoming, now)
+		done := lt.begin("resolution")
+		b.resolveEndedMarkets(ctx, now)
+		done()
 	}
 
-	// Step 5: cleanup old markets (>24h) (python parity)
-	b.cleanupOldMarkets(ctx, now)
+	if lt.overBudget() {
+		lt.skip("sell-pnl")
+	} else {
+		done := lt.begin("sell-pnl")
+		b.rebookSells(ctx)
+		done()
+	}
+
+	if lt.overBudget() {
+		lt.skip("rewards")
+	} else {
+		done := lt.begin("rewards")
+		b.refreshRewards(ctx, now)
+		done()
+	}
+
+	// Step 5: cleanup old markets (>24h) (python parity); can wait a loop.
+	if lt.overBudget() {
+		lt.skip("cleanup")
+	} else {
+		done := lt.begin("cleanup")
+		b.cleanupOldMarkets(ctx, now)
+		b.compactState(now)
+		done()
+	}
 
 	// Step 4: refresh balance
+	done := lt.begin("balance")
 	bal, err := b.chain.USDCBalance(ctx)
 	if err == nil {
 		b.mu.Lock()
 		b.state.USDCBalance = bal
 		b.mu.Unlock()
+		b.checkFunding(ctx, now, bal)
+		b.checkRebalance(ctx, bal)
+		b.checkYield(ctx, now, bal)
+	}
+	b.refreshGas(ctx, now)
+	b.checkAllowances(ctx, now)
+	if err == nil {
+		b.recordBalanceSnapshot(ctx, now)
 	}
+	b.scanTransfers(ctx, now)
+	done()
+
+	b.maybeSendDigest(now)
+	b.touchRun(now, false)
+}
 
+// publishState refreshes the dashboard's view of orders and PnL.
+func (b *Bot) publishState() {
 	// Update state.total_pnl from order history (best-effort, parity with python)
-	totalPNL := 0.0
+	totalPNL := b.archivedPNL
 	for _, o := range b.orderHistory {
 		if o.PNLUSD != nil {
 			totalPNL += *o.PNLUSD
 		}
 	}
+	deltas := b.netDeltas()
 	b.mu.Lock()
 	b.state.TotalPNL = totalPNL
+	b.state.NetDelta = deltas
 	b.mu.Unlock()
 
 	b.updateOrderLists()
+	b.recordMapSizes()
+	b.publishCapital()
+}
+
+func (b *Bot) publishMarkets() {
+	markets := append([]models.Market(nil), b.upcoming...)
+	b.mu.Lock()
+	b.state.ActiveMarkets = markets
+	b.mu.Unlock()
+}
+
+// carryPrices copies outcome prices from prev onto freshly discovered markets.
+func carryPrices(markets, prev []models.Market) []models.Market {
+	byCID := make(map[string]models.Market, len(prev))
+	for _, m := range prev {
+		byCID[m.ConditionID] = m
+	}
+	for i, m := range markets {
+		old, ok := byCID[m.ConditionID]
+		if !ok || len(old.Outcomes) != len(m.Outcomes) {
+			continue
+		}
+		outs := append([]models.Outcome(nil), m.Outcomes...)
+		for j := range outs {
+			if outs[j].TokenID == old.Outcomes[j].TokenID {
+				outs[j].BestBid, outs[j].BestAsk, outs[j].Price, outs[j].PriceAt = old.Outcomes[j].BestBid, old.Outcomes[j].BestAsk, old.Outcomes[j].Price, old.Outcomes[j].PriceAt
+			}
+		}
+		markets[i].Outcomes = outs
+	}
+	return markets
 }
 
 func (b *Bot) filterUpcoming(markets []models.Market, now time.Time) []models.Market {
@@ -285,7 +765,7 @@ func (b *Bot) filterUpcoming(markets []models.Market, now time.Time) []models.Ma
 		}
 		timeUntilStart := m.StartTS - nowTs
 		timeUntilEnd := m.EndTS - nowTs
-		if timeUntilEnd > -300 && timeUntilStart <= 86400 {
+		if timeUntilEnd > -300 && timeUntilStart <= int64(b.cfg.DiscoveryHorizonFor(m.Duration()).Seconds()) {
 			out = append(out, m)
 			if _, ok := b.trackedMarkets[m.ConditionID]; !ok {
 				b.trackedMarkets[m.ConditionID] = m
@@ -303,17 +783,56 @@ func (b *Bot) filterUpcoming(markets []models.Market, now time.Time) []models.Ma
 
 func shouldPlaceOrders(cfg config.Config, m models.Market, now time.Time) bool {
 	sec := m.TimeUntilStart(now).Seconds()
-	minS := float64(cfg.OrderPlacementMinMinutes * 60)
-	maxS := float64(cfg.OrderPlacementMaxMinutes * 60)
+	w := cfg.PlacementWindowFor(m.Duration())
+	minS := float64(w.MinMinutes * 60)
+	maxS := float64(w.MaxMinutes * 60)
 	return sec >= minS && sec <= maxS
 }
 
+func tagPhase(orders []models.OrderRecord, phase string) {
+	for i := range orders {
+		orders[i].Phase = phase
+	}
+}
+
+// tagEntryPhase tags the orders of m's placement pass by their own market's
+// window: a custom strategy may place for any market from another's pass, and
+// an order for a market whose window hasn't opened yet is pre-window.
+func (b *Bot) tagEntryPhase(orders []models.OrderRecord, m models.Market, now time.Time) {
+	for i := range orders {
+		market := m
+		if tm, ok := b.trackedMarkets[orders[i].ConditionID]; ok {
+			market = tm
+		}
+		orders[i].Phase = models.PhasePlacementWindow
+		w := b.cfg.PlacementWindowFor(market.Duration())
+		if market.TimeUntilStart(now) > time.Duration(w.MaxMinutes)*time.Minute {
+			orders[i].Phase = models.PhasePreWindow
+		}
+	}
+}
+
+func sortedDurations(m map[time.Duration]config.PlacementWindow) []time.Duration {
+	out := make([]time.Duration, 0, len(m))
+	for d := range m {
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
 func (b *Bot) placeSimpleTestOrders(ctx context.Context, market models.Market, price float64, size float64) ([]models.OrderRecord, error) {
 	// Balance check (best-effort)
 	bal, _ := b.chain.USDCBalance(ctx)
 	required := price * size * 2
 	if bal > 0 && bal < required {
-		return nil, fmt.Errorf("insufficient balance: $%.2f < $%.2f", bal, required)
+		return nil, fmt.Errorf("%w: $%.2f < $%.2f", errInsufficientBalance, bal, required)
+	}
+	if err := b.checkCooldown(b.cfg.StrategyName); err != nil {
+		return nil, err
+	}
+	if err := b.checkCapital(b.cfg.StrategyName, required); err != nil {
+		return nil, err
 	}
 
 	yes, no := findYesNoOutcomes(market.Outcomes)
@@ -323,7 +842,14 @@ func (b *Bot) placeSimpleTestOrders(ctx context.Context, market models.Market, p
 
 	var placed []models.OrderRecord
 	for _, outcome := range []models.Outcome{*yes, *no} {
-		ord, err := b.placeSingleFixed(ctx, market, outcome, price, size, models.OrderSideBuy)
+		p := price
+		if skew := b.predictionSkew(ctx, market, outcome); skew != 0 {
+			p = adjustPriceToTick(price+skew, 0.01)
+		}
+		if b.hasDuplicateOpenOrder(ctx, outcome.TokenID, models.OrderSideBuy, p) {
+			continue
+		}
+		ord, err := b.placeSingleFixed(ctx, market, outcome, p, size, models.OrderSideBuy)
 		if err != nil {
 			// record a failed order
 			msg := err.Error()
@@ -338,12 +864,13 @@ func (b *Bot) placeSimpleTestOrders(ctx context.Context, market models.Market, p
 				Size:            0,
 				SizeUSD:         price * size,
 				Status:          models.OrderStatusFailed,
-				CreatedAt:       time.Now(),
+				CreatedAt:       b.clock.Now(),
 				ErrorMessage:    &msg,
 				TransactionType: "BUY",
 				CostUSD:         floatPtr(price * size),
 				RevenueUSD:      floatPtr(0),
 				PNLUSD:          floatPtr(-(price * size)),
+				RunID:           b.runID,
 			}
 			placed = append(placed, rec)
 			continue
@@ -361,24 +888,43 @@ func (b *Bot) placeSingleFixed(ctx context.Context, market models.Market, outcom
 	if b.clob.Address() == "" {
 		return models.OrderRecord{}, errors.New("wallet address not available")
 	}
-	if side != models.OrderSideBuy {
-		return models.OrderRecord{}, errors.New("only BUY implemented in Go port test strategy")
+	clobSide := clob.OrderSideBuy
+	if side == models.OrderSideSell {
+		// size is shares either way; a SELL can only offer shares we hold.
+		clobSide = clob.OrderSideSell
+		bal, err := b.chain.ERC1155BalanceOf(ctx, b.chain.Addresses().CTF, mustBigInt(outcome.TokenID))
+		if err != nil {
+			return models.OrderRecord{}, fmt.Errorf("read %s balance: %w", outcome.Outcome, err)
+		}
+		if held := toFloat6(bal); held+1e-6 < size {
+			return models.OrderRecord{}, fmt.Errorf("cannot sell %.4f %s shares: only %.4f held", size, outcome.Outcome, held)
+		}
+		if err := b.checkSellBalance(ctx, outcome.TokenID, size); err != nil {
+			return models.OrderRecord{}, err
+		}
 	}
 	orderArgs := clob.OrderArgs{
 		TokenID:    outcome.TokenID,
 		Price:      price,
 		Size:       size,
-		Side:       clob.OrderSideBuy,
+		Side:       clobSide,
 		FeeRateBps: 0,
 		Nonce:      0,
 		Expiration: 0,
 		Taker:      "",
 	}
-
-	signed, _, err := b.clob.CreateOrder(ctx, orderArgs, nil, nil)
-	if err != nil {
+	if err := b.checkOrderMinimums(ctx, outcome.TokenID, price, size); err != nil {
 		return models.OrderRecord{}, err
 	}
+
+	signed, ok := b.takePresigned(outcome.TokenID, clobSide, price, size)
+	if !ok {
+		var err error
+		signed, _, err = b.clob.CreateOrder(ctx, orderArgs, nil, nil)
+		if err != nil {
+			return models.OrderRecord{}, err
+		}
+	}
 	resp, err := b.clob.PostOrder(ctx, signed, clob.OrderTypeGTC)
 	if err != nil {
 		return models.OrderRecord{}, err
@@ -389,32 +935,31 @@ func (b *Bot) placeSingleFixed(ctx context.Context, market models.Market, outcom
 		orderID = fmt.Sprintf("%d", signed.Salt)
 	}
 
-	sizeUSD := price * size
-	cost := sizeUSD
-	pnl := -sizeUSD
 	strategy := b.cfg.StrategyName
-	return models.OrderRecord{
-		OrderID:         orderID,
-		MarketSlug:      market.MarketSlug,
-		ConditionID:     market.ConditionID,
-		TokenID:         outcome.TokenID,
-		Outcome:         outcome.Outcome,
-		Side:            side,
-		Price:           price,
-		Size:            size,
-		SizeUSD:         sizeUSD,
-		Status:          models.OrderStatusPlaced,
-		CreatedAt:       time.Now(),
-		Strategy:        &strategy,
-		TransactionType: "BUY",
-		CostUSD:         &cost,
-		RevenueUSD:      floatPtr(0),
-		PNLUSD:          &pnl,
-	}, nil
+	rec := b.orderRecordForSide(market, outcome, side, orderID, price, size, price*size, &strategy, b.clock.Now())
+	b.hook(webhook.OrderPlaced, rec)
+	return rec, nil
+}
+
+// outcomeMid returns the bid/ask midpoint if both sides are known.
+func outcomeMid(o models.Outcome) *float64 {
+	if o.BestBid == nil || o.BestAsk == nil || *o.BestBid <= 0 || *o.BestAsk <= 0 {
+		return nil
+	}
+	return floatPtr((*o.BestBid + *o.BestAsk) / 2)
+}
+
+// markFirstFill stamps FirstFillAt the first time an order shows any fill.
+func (b *Bot) markFirstFill(o *models.OrderRecord, sizeMatched float64) {
+	if sizeMatched > 0 && o.FirstFillAt == nil {
+		now := b.clock.Now()
+		o.FirstFillAt = &now
+	}
 }
 
 func (b *Bot) checkActiveOrders(ctx context.Context) {
 	changed := false
+	poll := b.pollOrderStatuses()
 	for cid, orders := range b.activeOrders {
 		market, hasMarket := b.trackedMarkets[cid]
 		if !hasMarket {
@@ -431,14 +976,13 @@ func (b *Bot) checkActiveOrders(ctx context.Context) {
 			// Best-effort: attempt periodic merge for orphaned orders, then mark sold when cleared.
 			if !b.positionsSold[cid] {
 				last := b.lastMergeAttempt[cid]
-				if last.IsZero() || time.Since(last) >= 30*time.Second {
+				if last.IsZero() || b.clock.Now().Sub(last) >= 30*time.Second {
 					stub := b.buildOrphanMarket(cid, orders)
 					merged := b.mergePositionsIfPossible(ctx, stub, orders)
 					if merged > 0 {
-						b.trackMerge(stub, merged)
 						changed = true
 					}
-					b.lastMergeAttempt[cid] = time.Now()
+					b.lastMergeAttempt[cid] = b.clock.Now()
 				}
 				if cleared, known := b.walletPositionsCleared(ctx, cid, orders); known && cleared {
 					b.positionsSold[cid] = true
@@ -450,7 +994,7 @@ func (b *Bot) checkActiveOrders(ctx context.Context) {
 		}
 		for i := range orders {
 			o := orders[i]
-			if o.Status != models.OrderStatusPlaced && o.Status != models.OrderStatusPartiallyFilled {
+			if !poll || (o.Status != models.OrderStatusPlaced && o.Status != models.OrderStatusPartiallyFilled) {
 				continue
 			}
 			details, err := b.clob.GetOrder(ctx, o.OrderID)
@@ -464,12 +1008,13 @@ func (b *Bot) checkActiveOrders(ctx context.Context) {
 				origSize = o.Size
 			}
 			o.SizeMatched = &sizeMatched
+			b.markFirstFill(&o, sizeMatched)
 
 			origStatus := o.Status
 			switch {
 			case status == "MATCHED" || (origSize > 0 && sizeMatched >= origSize):
 				o.Status = models.OrderStatusFilled
-				now := time.Now()
+				now := b.clock.Now()
 				o.FilledAt = &now
 			case sizeMatched > 0:
 				o.Status = models.OrderStatusPartiallyFilled
@@ -478,8 +1023,10 @@ func (b *Bot) checkActiveOrders(ctx context.Context) {
 			case status == "OPEN" || status == "PLACED" || status == "LIVE" || status == "ACTIVE":
 				o.Status = models.OrderStatusPlaced
 			}
+			bookFill(&o)
 			if o.Status != origStatus {
 				changed = true
+				b.orderFilled(origStatus, o)
 			}
 			orders[i] = o
 			b.orderHistory[o.OrderID] = o
@@ -488,13 +1035,12 @@ func (b *Bot) checkActiveOrders(ctx context.Context) {
 		// Periodic merge while market is active (every ~30s)
 		if hasMarket && !b.positionsSold[cid] {
 			last := b.lastMergeAttempt[cid]
-			if last.IsZero() || time.Since(last) >= 30*time.Second {
+			if last.IsZero() || b.clock.Now().Sub(last) >= 30*time.Second {
 				merged := b.mergePositionsIfPossible(ctx, market, orders)
 				if merged > 0 {
-					b.trackMerge(market, merged)
 					changed = true
 				}
-				b.lastMergeAttempt[cid] = time.Now()
+				b.lastMergeAttempt[cid] = b.clock.Now()
 			}
 
 			// Sell leftovers 1 minute before end
@@ -502,7 +1048,7 @@ func (b *Bot) checkActiveOrders(ctx context.Context) {
 		}
 
 		// Cancel remaining open orders after market end (+5m)
-		if hasMarket && time.Now().Unix() > market.EndTS+300 {
+		if hasMarket && b.clock.Now().Unix() > market.EndTS+300 {
 			for i := range orders {
 				if orders[i].Status == models.OrderStatusPlaced || orders[i].Status == models.OrderStatusPartiallyFilled {
 					_, _ = b.clob.Cancel(ctx, orders[i].OrderID)
@@ -528,7 +1074,7 @@ func (b *Bot) updateOrderLists() {
 	}
 	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.After(all[j].CreatedAt) })
 
-	nowTs := time.Now().Unix()
+	nowTs := b.clock.Now().Unix()
 	pending := make([]models.OrderRecord, 0)
 	for _, o := range all {
 		if o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled {
@@ -559,12 +1105,16 @@ func (b *Bot) updateOrderLists() {
 	b.mu.Unlock()
 }
 
-func (b *Bot) recordError(err error) {
-	msg := err.Error()
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	b.state.ErrorCount++
-	b.state.LastError = &msg
+// drainChainEvents applies updates queued by tx confirmation callbacks.
+func (b *Bot) drainChainEvents() {
+	for {
+		select {
+		case fn := <-b.chainEvents:
+			fn()
+		default:
+			return
+		}
+	}
 }
 
 func floatPtr(v float64) *float64 { return &v }
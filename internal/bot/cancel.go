@@ -0,0 +1,32 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+)
+
+// cancelOrder cancels one order and reports whether the CLOB says it did.
+// The cancel endpoint answers 200 with {"canceled": [...], "not_canceled":
+// {id: reason}}, so a nil error from the request alone proves nothing: an
+// order that filled meanwhile, or one the CLOB refused to cancel, is still
+// live or matched and must stay tracked.
+func (b *Bot) cancelOrder(ctx context.Context, orderID string) error {
+	resp, err := b.clob.Cancel(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	m, _ := resp.(map[string]any)
+	if ids, ok := m["canceled"].([]any); ok {
+		for _, id := range ids {
+			if asString(id) == orderID {
+				return nil
+			}
+		}
+	}
+	if reasons, ok := m["not_canceled"].(map[string]any); ok {
+		if reason, ok := reasons[orderID]; ok {
+			return fmt.Errorf("order %s not cancelled: %s", orderID, asString(reason))
+		}
+	}
+	return fmt.Errorf("order %s not cancelled: unexpected response %v", orderID, resp)
+}
@@ -0,0 +1,162 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"limitorderbot/internal/cashflows"
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/clob"
+	"limitorderbot/internal/logging"
+)
+
+// transferScanMaxBlocks bounds one log query; public RPC endpoints refuse
+// much wider ranges. A bot that was down longer catches up over a few scans.
+const (
+	transferScanMaxBlocks = 2000
+	transferScanMaxChunks = 5
+)
+
+// scanTransfers records collateral moved into or out of the bot's wallets
+// (the EOA and the funder) by anything but trading as deposits and
+// withdrawals, once every TRANSFER_SCAN_MINUTES. A first scan only marks
+// the current block: history before that is left to the balance samples.
+func (b *Bot) scanTransfers(ctx context.Context, now time.Time) {
+	every := time.Duration(b.cfg.TransferScanMinutes) * time.Minute
+	if every <= 0 || (!b.lastTransferScan.IsZero() && now.Sub(b.lastTransferScan) < every) {
+		return
+	}
+	b.lastTransferScan = now
+	log := logging.Logger()
+	ledger, err := cashflows.Load(cashflows.DefaultFile)
+	if err != nil {
+		log.Printf("Failed to read %s: %v\n", cashflows.DefaultFile, err)
+		return
+	}
+	head, err := b.chain.HeadBlock(ctx)
+	if err != nil {
+		return
+	}
+	if ledger.LastBlock == 0 {
+		if err := cashflows.Record(cashflows.DefaultFile, head, nil); err != nil {
+			log.Printf("Failed to write %s: %v\n", cashflows.DefaultFile, err)
+			return
+		}
+		log.Printf("Watching wallet transfers for deposits/withdrawals from block %d\n", head)
+		return
+	}
+
+	wallets := b.ownWallets()
+	internal := b.internalCounterparties(wallets)
+	from := ledger.LastBlock + 1
+	for chunk := 0; chunk < transferScanMaxChunks && from <= head; chunk++ {
+		to := min(from+transferScanMaxBlocks-1, head)
+		var flows []cashflows.Flow
+		for _, w := range wallets {
+			transfers, err := b.chain.CollateralTransfers(ctx, w, from, to)
+			if err != nil {
+				log.Printf("Transfer scan of blocks %d-%d failed: %v\n", from, to, err)
+				return
+			}
+			for _, t := range transfers {
+				f, ok := b.externalFlow(ctx, w, t, internal)
+				if ok {
+					flows = append(flows, f)
+				}
+			}
+		}
+		if err := cashflows.Record(cashflows.DefaultFile, to, flows); err != nil {
+			log.Printf("Failed to write %s: %v\n", cashflows.DefaultFile, err)
+			return
+		}
+		for _, f := range flows {
+			log.Printf("Recorded %s of $%.2f %s %s (tx=%s); not counted as PnL\n", f.Kind, f.Amount, directionWord(f.Kind), f.Wallet, f.TxHash)
+			b.notifyFlow(f)
+		}
+		from = to + 1
+	}
+}
+
+// externalFlow turns t, seen on wallet w, into a deposit or withdrawal
+// unless it was trading: a transfer with one of the exchange, CTF, adapter,
+// our other wallet or yield pool contracts, or part of a tx the bot sent.
+func (b *Bot) externalFlow(ctx context.Context, w common.Address, t chain.Transfer, internal map[common.Address]bool) (cashflows.Flow, bool) {
+	kind, other := cashflows.Deposit, t.From
+	if t.From == w {
+		kind, other = cashflows.Withdrawal, t.To
+	}
+	if other == w || internal[other] || b.ownTx(t.TxHash) || t.Amount <= 0 {
+		return cashflows.Flow{}, false
+	}
+	at, err := b.chain.BlockTime(ctx, t.Block)
+	if err != nil {
+		at = b.clock.Now().UTC()
+	}
+	return cashflows.Flow{
+		Time:         at,
+		Kind:         kind,
+		Amount:       t.Amount,
+		Wallet:       w.Hex(),
+		Counterparty: other.Hex(),
+		TxHash:       t.TxHash.Hex(),
+		LogIndex:     t.LogIndex,
+		Block:        t.Block,
+	}, true
+}
+
+// ownWallets are the addresses holding the account's collateral.
+func (b *Bot) ownWallets() []common.Address {
+	out := []common.Address{b.chain.Address()}
+	if common.IsHexAddress(b.cfg.FunderAddress) {
+		if f := common.HexToAddress(b.cfg.FunderAddress); f != out[0] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// internalCounterparties are the contracts and wallets collateral moves
+// to and from in the course of trading.
+func (b *Bot) internalCounterparties(wallets []common.Address) map[common.Address]bool {
+	addrs := b.chain.Addresses()
+	out := map[common.Address]bool{addrs.CTF: true}
+	if addrs.NegRiskAdapter != (common.Address{}) {
+		out[addrs.NegRiskAdapter] = true
+	}
+	for _, negRisk := range []bool{false, true} {
+		if cc, err := clob.GetContractConfig(b.cfg.ChainID, negRisk); err == nil {
+			out[common.HexToAddress(cc.Exchange)] = true
+		}
+	}
+	for _, w := range wallets {
+		out[w] = true
+	}
+	for _, a := range []string{b.cfg.YieldPool, b.cfg.YieldReceiptToken, b.cfg.USDCSwapRouter} {
+		if common.IsHexAddress(a) {
+			out[common.HexToAddress(a)] = true
+		}
+	}
+	return out
+}
+
+// ownTx reports whether hash is one of the tx worker's jobs (merges,
+// redemptions, swaps, top-ups, yield moves).
+func (b *Bot) ownTx(hash common.Hash) bool {
+	h := strings.ToLower(hash.Hex())
+	for _, j := range b.txWorker.list() {
+		if strings.ToLower(j.TxHash) == h {
+			return true
+		}
+	}
+	return false
+}
+
+func directionWord(kind string) string {
+	if kind == cashflows.Withdrawal {
+		return "out of"
+	}
+	return "into"
+}
@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"limitorderbot/internal/clob"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// compactEvery is how often the maintenance task compacts in-memory state.
+const compactEvery = time.Hour
+
+// MemoryStats is the cache and state size instrumentation exported at
+// /api/metrics, for spotting growth in long runs.
+type MemoryStats struct {
+	Caches         map[string]clob.CacheStats `json:"caches,omitempty"`
+	Maps           map[string]int             `json:"maps"`
+	ArchivedOrders int                        `json:"archived_orders"`
+	LastCompaction *time.Time                 `json:"last_compaction,omitempty"`
+}
+
+// MemoryStats returns the latest state sizes and the CLOB cache statistics.
+func (b *Bot) MemoryStats() MemoryStats {
+	b.mu.Lock()
+	out := b.memStats
+	out.Maps = make(map[string]int, len(b.memStats.Maps))
+	for k, v := range b.memStats.Maps {
+		out.Maps[k] = v
+	}
+	b.mu.Unlock()
+	if b.clob != nil {
+		out.Caches = b.clob.CacheStats()
+	}
+	return out
+}
+
+// recordMapSizes publishes the sizes of the loop-owned maps.
+func (b *Bot) recordMapSizes() {
+	sizes := map[string]int{
+		"order_history":   len(b.orderHistory),
+		"tracked_markets": len(b.trackedMarkets),
+		"active_orders":   len(b.activeOrders),
+		"upcoming":        len(b.upcoming),
+		"sell_unknown":    len(b.sellUnknown),
+		"sell_retries":    len(b.sellRetries),
+		"order_feeds":     len(b.orderFeeds),
+	}
+	b.presignMu.Lock()
+	sizes["presigned"] = len(b.presigned)
+	b.presignMu.Unlock()
+	b.mu.Lock()
+	b.memStats.Maps = sizes
+	b.mu.Unlock()
+}
+
+// compactState drops expired CLOB cache entries and bookkeeping for orders
+// no longer in the history, and archives settled orders older than
+// ORDER_HISTORY_RETENTION_DAYS. Runs at most once per compactEvery.
+func (b *Bot) compactState(now time.Time) {
+	if !b.lastCompaction.IsZero() && now.Sub(b.lastCompaction) < compactEvery {
+		return
+	}
+	b.lastCompaction = now
+
+	expired := 0
+	if b.clob != nil {
+		expired = b.clob.CompactCaches()
+	}
+	archived := 0
+	if days := b.cfg.OrderHistoryRetentionDays; days > 0 {
+		n, err := b.archiveOrders(now.Add(-time.Duration(days) * 24 * time.Hour))
+		if err != nil {
+			logging.Logger().Printf("Failed to archive old orders: %v\n", err)
+		}
+		archived = n
+	}
+	for id := range b.sellUnknown {
+		if _, ok := b.orderHistory[id]; !ok {
+			delete(b.sellUnknown, id)
+		}
+	}
+	for id := range b.sellRetries {
+		if _, ok := b.orderHistory[id]; !ok {
+			delete(b.sellRetries, id)
+		}
+	}
+	if expired > 0 || archived > 0 {
+		logging.Logger().Printf("Compaction: %d expired cache entries, %d orders archived\n", expired, archived)
+	}
+
+	at := now
+	b.mu.Lock()
+	b.memStats.ArchivedOrders = b.archivedOrders
+	b.memStats.LastCompaction = &at
+	b.mu.Unlock()
+}
+
+// archiveOrders moves settled orders created before cutoff, of markets the
+// bot no longer follows, from the history to the archive file.
+func (b *Bot) archiveOrders(cutoff time.Time) (int, error) {
+	var old []models.OrderRecord
+	for _, o := range b.orderHistory {
+		if !o.CreatedAt.Before(cutoff) || o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled {
+			continue
+		}
+		if _, ok := b.trackedMarkets[o.ConditionID]; ok {
+			continue
+		}
+		if _, ok := b.activeOrders[o.ConditionID]; ok {
+			continue
+		}
+		old = append(old, o)
+	}
+	if len(old) == 0 {
+		return 0, nil
+	}
+
+	f, err := os.OpenFile(b.orderArchiveFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, o := range old {
+		if err := enc.Encode(serializeOrder(o)); err != nil {
+			f.Close()
+			return 0, err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+
+	// Only forget what is safely on disk.
+	for _, o := range old {
+		delete(b.orderHistory, o.OrderID)
+		if o.PNLUSD != nil {
+			b.archivedPNL += *o.PNLUSD
+		}
+	}
+	b.archivedOrders += len(old)
+	return len(old), b.saveOrderHistory()
+}
+
+// loadOrderArchive totals the archived orders so the PnL keeps counting
+// them; the records themselves stay on disk.
+func (b *Bot) loadOrderArchive() error {
+	f, err := os.Open(b.orderArchiveFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	b.archivedPNL, b.archivedOrders = 0, 0
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var om map[string]any
+		if err := json.Unmarshal(sc.Bytes(), &om); err != nil {
+			continue
+		}
+		b.archivedOrders++
+		if v := om["pnl_usd"]; v != nil {
+			b.archivedPNL += asFloat(v)
+		}
+	}
+	return sc.Err()
+}
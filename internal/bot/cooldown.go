@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// recordMarketResult scores a resolved market for every strategy that bought
+// in it: cash flow from fills (merges included) plus the shares still held of
+// the winning outcome. Consecutive losing markets push a strategy into a
+// LossCooldownMinutes pause once they reach LossStreakLimit; a winning market
+// resets its streak. Markets where nothing filled don't count either way.
+func (b *Bot) recordMarketResult(m models.Market, now time.Time) {
+	if b.cfg.LossStreakLimit <= 0 || m.WinningOutcome == "" {
+		return
+	}
+	var cash float64
+	held := map[string]float64{}
+	strategies := map[string]bool{}
+	for _, o := range b.orderHistory {
+		if o.ConditionID != m.ConditionID || o.TransactionType == "REDEEM" {
+			continue
+		}
+		matched := filledShares(o)
+		if matched <= 0 {
+			continue
+		}
+		switch {
+		case o.TransactionType == "MERGE":
+			cash += matched
+			for _, out := range m.Outcomes {
+				held[out.Outcome] -= matched
+			}
+		case o.Side == models.OrderSideBuy:
+			cash -= o.Price * matched
+			held[o.Outcome] += matched
+			if o.Strategy != nil && *o.Strategy != "" {
+				strategies[*o.Strategy] = true
+			}
+		default:
+			cash += o.Price * matched
+			held[o.Outcome] -= matched
+		}
+	}
+	if len(strategies) == 0 {
+		return
+	}
+	result := cash
+	if won := held[m.WinningOutcome]; won > 0 {
+		result += won
+	}
+
+	for name := range strategies {
+		if result >= 0 {
+			b.lossStreaks[name] = 0
+			continue
+		}
+		b.lossStreaks[name]++
+		streak := b.lossStreaks[name]
+		logging.Logger().Printf("Strategy %s lost $%.2f on %s (%d in a row)\n", name, -result, m.MarketSlug, streak)
+		if streak < b.cfg.LossStreakLimit {
+			continue
+		}
+		until := now.Add(time.Duration(b.cfg.LossCooldownMinutes) * time.Minute)
+		b.mu.Lock()
+		b.state.Cooldowns = withCooldown(b.state.Cooldowns, name, until)
+		b.mu.Unlock()
+		b.lossStreaks[name] = 0
+		logging.Logger().Printf("Pausing strategy %s for %d min after %d consecutive losing markets\n", name, b.cfg.LossCooldownMinutes, streak)
+		b.recordError(fmt.Errorf("strategy %s paused until %s after %d consecutive losing markets", name, until.Format(time.RFC3339), streak))
+	}
+}
+
+// strategyPaused reports whether name is in a loss cooldown. Expired entries
+// are dropped (and the resume logged) on the way. Safe from any goroutine.
+func (b *Bot) strategyPaused(name string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	until, ok := b.state.Cooldowns[name]
+	if !ok {
+		return false
+	}
+	if now.Before(until) {
+		return true
+	}
+	b.state.Cooldowns = withCooldown(b.state.Cooldowns, name, time.Time{})
+	logging.Logger().Printf("Strategy %s cooldown over; resuming\n", name)
+	return false
+}
+
+// checkCooldown refuses a placement while the bot is paused or the strategy
+// is cooling down.
+func (b *Bot) checkCooldown(strategy string) error {
+	if p := b.pauseInfo(); p != nil {
+		return fmt.Errorf("bot is %w: %s", errPaused, p.Reason)
+	}
+	if b.strategyPaused(strategy, b.clock.Now()) {
+		return fmt.Errorf("strategy %s is %w", strategy, errCooldown)
+	}
+	return nil
+}
+
+// withCooldown returns a copy of m with name set to until (or removed for a
+// zero time); GetState hands the map out, so it is never mutated in place.
+func withCooldown(m map[string]time.Time, name string, until time.Time) map[string]time.Time {
+	out := make(map[string]time.Time, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	if until.IsZero() {
+		delete(out, name)
+	} else {
+		out[name] = until
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// filledShares prefers the matched size; fully filled records without one count whole.
+func filledShares(o models.OrderRecord) float64 {
+	if o.SizeMatched != nil && *o.SizeMatched > 0 {
+		return *o.SizeMatched
+	}
+	if o.Status == models.OrderStatusFilled {
+		return o.Size
+	}
+	return 0
+}
@@ -0,0 +1,184 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+	"limitorderbot/internal/strategy"
+)
+
+// placeCustomOrders runs the ORDER_MODE strategy registered through the
+// strategy package for one market and returns what it placed.
+func (b *Bot) placeCustomOrders(ctx context.Context, market models.Market) ([]models.OrderRecord, error) {
+	if err := b.checkCooldown(b.cfg.StrategyName); err != nil {
+		return nil, err
+	}
+	sc := &strategyContext{b: b, name: b.custom.Name()}
+	err := b.custom.Place(ctx, sc, market)
+	if err != nil && len(sc.placed) > 0 {
+		// Keep tracking what did go out; the error is only logged.
+		logging.Logger().Printf("Strategy %s: %v\n", sc.name, err)
+		err = nil
+	}
+	return sc.placed, err
+}
+
+// customExit asks a custom strategy implementing strategy.Exiter whether to
+// exit market now; errors count as "no".
+func (b *Bot) customExit(ctx context.Context, market models.Market, orders []models.OrderRecord) bool {
+	ex, ok := b.custom.(strategy.Exiter)
+	if !ok {
+		return false
+	}
+	sc := &strategyContext{b: b, name: b.custom.Name(), exiting: true}
+	exit, err := ex.ShouldExit(ctx, sc, market, append([]models.OrderRecord(nil), orders...))
+	if err != nil {
+		logging.Logger().Printf("Strategy %s exit check for %s: %v\n", sc.name, market.MarketSlug, err)
+		return false
+	}
+	return exit
+}
+
+// strategyContext is the strategy.Context handed to a custom strategy for a
+// single Place or ShouldExit call; it runs on the loop goroutine.
+type strategyContext struct {
+	b      *Bot
+	name   string
+	placed []models.OrderRecord
+	// exiting refuses Place during an Exiter call.
+	exiting bool
+}
+
+var _ strategy.Context = (*strategyContext)(nil)
+
+func (s *strategyContext) Now() time.Time { return s.b.clock.Now() }
+
+func (s *strategyContext) Book(ctx context.Context, tokenID string) (float64, float64, error) {
+	book, err := s.b.clob.GetOrderBook(ctx, tokenID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return bestBidFromBook(book), bestAskFromBook(book), nil
+}
+
+func (s *strategyContext) FillProbability(distance float64, timeToStart time.Duration) (float64, int) {
+	return s.b.fillEstimator().Estimate(distance, timeToStart)
+}
+
+func (s *strategyContext) Positions(ctx context.Context) ([]strategy.Position, error) {
+	pos, err := s.b.fetchWalletPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]strategy.Position, 0, len(pos))
+	for _, p := range pos {
+		out = append(out, strategy.Position{
+			ConditionID:  p.ConditionID,
+			Slug:         p.Slug,
+			Outcome:      p.Outcome,
+			TokenID:      p.Asset,
+			Size:         p.Size,
+			CurPrice:     p.CurPrice,
+			CurrentValue: p.CurrentValue,
+			Redeemable:   p.Redeemable,
+		})
+	}
+	return out, nil
+}
+
+// Place applies the same checks the built-in modes do before posting; the
+// exchange minimums and the SELL balance check happen in placeSingleFixed.
+func (s *strategyContext) Place(ctx context.Context, o strategy.Order) (models.OrderRecord, error) {
+	b := s.b
+	if s.exiting {
+		return models.OrderRecord{}, errors.New("orders can't be placed from an exit check")
+	}
+	if o.Outcome.TokenID == "" || o.Market.ConditionID == "" {
+		return models.OrderRecord{}, errors.New("order needs a market and an outcome token")
+	}
+	if o.Price <= 0 || o.Price >= 1 || o.Size <= 0 {
+		return models.OrderRecord{}, fmt.Errorf("invalid order: price %.4f size %.4f", o.Price, o.Size)
+	}
+	side := o.Side
+	if side == "" {
+		side = models.OrderSideBuy
+	}
+	// Strategies size in shares without knowing the token's lot.
+	lot := b.lotSize(ctx, o.Outcome.TokenID)
+	if o.Size = roundToLot(o.Size, lot); o.Size <= 0 {
+		return models.OrderRecord{}, fmt.Errorf("invalid order: size is below one lot (%g)", lot)
+	}
+	if err := b.checkCooldown(b.cfg.StrategyName); err != nil {
+		return models.OrderRecord{}, err
+	}
+	if side == models.OrderSideBuy {
+		required := o.Price * o.Size
+		if bal, _ := b.chain.USDCBalance(ctx); bal > 0 && bal < required {
+			return models.OrderRecord{}, fmt.Errorf("%w: $%.2f < $%.2f", errInsufficientBalance, bal, required)
+		}
+		if err := b.checkCapital(b.cfg.StrategyName, required+s.pendingBuyUSD()); err != nil {
+			return models.OrderRecord{}, err
+		}
+	}
+	if b.hasDuplicateOpenOrder(ctx, o.Outcome.TokenID, side, o.Price) {
+		return models.OrderRecord{}, fmt.Errorf("duplicate open %s order at %.4f", side, o.Price)
+	}
+	rec, err := b.placeSingleFixed(ctx, o.Market, o.Outcome, o.Price, o.Size, side)
+	if err != nil {
+		strat := b.cfg.StrategyName
+		s.placed = append(s.placed, b.failedOrderRecord(o.Market, o.Outcome, side, o.Price, o.Size, o.Price*o.Size, &strat, b.clock.Now(), err.Error()))
+		return models.OrderRecord{}, err
+	}
+	s.placed = append(s.placed, rec)
+	return rec, nil
+}
+
+// pendingBuyUSD is the notional placed earlier in this call, which
+// capitalInUse can't see until the orders reach activeOrders.
+func (s *strategyContext) pendingBuyUSD() float64 {
+	var sum float64
+	for _, o := range s.placed {
+		if o.Side == models.OrderSideBuy && o.Status != models.OrderStatusFailed {
+			sum += o.SizeUSD
+		}
+	}
+	return sum
+}
+
+func (s *strategyContext) LoadState(v any) (bool, error) {
+	raw, err := os.ReadFile(strategyStateFile(s.name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *strategyContext) SaveState(v any) error {
+	bts, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(strategyStateFile(s.name), bts, 0o644)
+}
+
+func (s *strategyContext) Logf(format string, args ...any) {
+	logging.Logger().Printf("[%s] %s\n", s.name, strings.TrimSuffix(fmt.Sprintf(format, args...), "\n"))
+}
+
+// strategyStateFile sits next to the bot's other state files.
+func strategyStateFile(name string) string {
+	return "strategy_" + strings.ToLower(name) + "_state.json"
+}
@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"limitorderbot/internal/decisions"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// Placement refusals the decisions log tells apart; the messages stay as the
+// placement paths always worded them.
+var (
+	errInsufficientBalance = errors.New("insufficient balance")
+	errCapitalBudget       = errors.New("capital budget exceeded")
+	errCooldown            = errors.New("cooling down after consecutive losses")
+	errPaused              = errors.New("paused")
+	errNoEdge              = errors.New("not enough edge")
+)
+
+// recordDecision appends a placement decision for m to the decisions log.
+// Repeats of the market's previous action and reason are dropped, so a market
+// waiting out its window or a busy bot is logged once, not every tick.
+func (b *Bot) recordDecision(m models.Market, action, reason, detail string, orders int) {
+	key := action + "/" + reason
+	if b.lastDecision[m.ConditionID] == key {
+		return
+	}
+	b.lastDecision[m.ConditionID] = key
+	d := decisions.Decision{
+		Time:        b.clock.Now().UTC(),
+		ConditionID: m.ConditionID,
+		MarketSlug:  m.MarketSlug,
+		Strategy:    b.cfg.StrategyName,
+		Action:      action,
+		Reason:      reason,
+		Detail:      detail,
+		Orders:      orders,
+	}
+	if err := decisions.Append(decisions.DefaultFile, d); err != nil {
+		logging.Logger().Printf("Failed to record placement decision: %v\n", err)
+	}
+}
+
+// recordPlacementResult logs the outcome of a placement attempt.
+func (b *Bot) recordPlacementResult(m models.Market, orders []models.OrderRecord, err error, detail string) {
+	if err != nil {
+		b.recordDecision(m, decisions.Failed, placementReason(err), err.Error(), 0)
+		return
+	}
+	if len(orders) == 0 {
+		b.recordDecision(m, decisions.Failed, decisions.ReasonError, "no orders placed", 0)
+		return
+	}
+	b.recordDecision(m, decisions.Placed, "", detail, len(orders))
+}
+
+func placementReason(err error) string {
+	switch {
+	case errors.Is(err, errInsufficientBalance):
+		return decisions.ReasonBalance
+	case errors.Is(err, errCapitalBudget):
+		return decisions.ReasonBudget
+	case errors.Is(err, errCooldown):
+		return decisions.ReasonCooldown
+	case errors.Is(err, errPaused):
+		return decisions.ReasonPaused
+	case errors.Is(err, errNoEdge):
+		return decisions.ReasonNoEdge
+	case errors.Is(err, errBelowMinimum), errors.Is(err, errOffLot):
+		return decisions.ReasonMinimum
+	}
+	return decisions.ReasonError
+}
+
+// windowDetail explains a window skip: when the market starts vs the window.
+func (b *Bot) windowDetail(m models.Market, now time.Time) string {
+	w := b.cfg.PlacementWindowFor(m.Duration())
+	return fmt.Sprintf("starts in %.1f min; window is %d-%d min before start", m.TimeUntilStart(now).Minutes(), w.MinMinutes, w.MaxMinutes)
+}
@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"limitorderbot/internal/clob"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// samePrice compares order prices with tolerance for float drift after tick rounding.
+func samePrice(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+
+// hasDuplicateOpenOrder reports whether an open order for the same token, side
+// and price already exists, so a retried loop or a fallback placement running
+// alongside the primary one doesn't stack a second identical order. Local
+// tracking is checked first; the exchange's open orders cover anything placed
+// earlier in this cycle or lost from local state. If the exchange can't be
+// queried, only the local check applies.
+func (b *Bot) hasDuplicateOpenOrder(ctx context.Context, tokenID string, side models.OrderSide, price float64) bool {
+	for _, orders := range b.activeOrders {
+		for _, o := range orders {
+			if o.TokenID != tokenID || o.Side != side || !samePrice(o.Price, price) {
+				continue
+			}
+			if o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled {
+				logging.Logger().Printf("Skipping duplicate %s %s @ %.4f: tracked order %s is still open\n", side, shortToken(tokenID), price, o.OrderID)
+				return true
+			}
+		}
+	}
+
+	open, err := b.clob.GetOrders(ctx, &clob.OpenOrderParams{AssetID: tokenID})
+	if err != nil {
+		return false
+	}
+	for _, o := range open {
+		if id := asString(o["asset_id"]); id != "" && id != tokenID {
+			continue
+		}
+		if !strings.EqualFold(asString(o["side"]), string(side)) || !samePrice(asFloat(o["price"]), price) {
+			continue
+		}
+		logging.Logger().Printf("Skipping duplicate %s %s @ %.4f: open order %s already on the book\n", side, shortToken(tokenID), price, asString(o["id"]))
+		return true
+	}
+	return false
+}
+
+func shortToken(tokenID string) string {
+	if len(tokenID) <= 12 {
+		return tokenID
+	}
+	return tokenID[:12] + "..."
+}
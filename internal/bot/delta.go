@@ -0,0 +1,80 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"limitorderbot/internal/models"
+)
+
+// outcomeSign is +1 for the UP/YES side, -1 for DOWN/NO, 0 if unknown.
+func outcomeSign(outcome string) float64 {
+	switch strings.ToUpper(strings.TrimSpace(outcome)) {
+	case "YES", "UP":
+		return 1
+	case "NO", "DOWN":
+		return -1
+	}
+	return 0
+}
+
+// netDeltas sums, per underlying, the UP-minus-DOWN shares across every
+// market still being worked: filled buys, plus what resting buys would add
+// if they fill, less what has been sold. Merges remove one of each side, so
+// they don't move it.
+func (b *Bot) netDeltas() map[string]float64 {
+	out := map[string]float64{}
+	for cid, orders := range b.activeOrders {
+		if b.positionsSold[cid] {
+			continue
+		}
+		m, ok := b.trackedMarkets[cid]
+		if !ok {
+			m = models.Market{ConditionID: cid}
+			if len(orders) > 0 {
+				m.MarketSlug = orders[0].MarketSlug
+			}
+		}
+		u := m.Underlying()
+		for _, o := range orders {
+			sign := outcomeSign(o.Outcome)
+			if sign == 0 {
+				continue
+			}
+			shares := filledShares(o)
+			if o.Side == models.OrderSideSell {
+				out[u] -= sign * shares
+				continue
+			}
+			if o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled {
+				shares = math.Max(shares, o.Size)
+			}
+			out[u] += sign * shares
+		}
+	}
+	return out
+}
+
+// directionalSize shrinks a one-sided BUY of size shares on outcome so the
+// underlying's net delta stays within MAX_NET_DELTA_USD. Buys that reduce
+// the delta are never limited; an error means there is no room at all. It
+// reads the delta published with the state, so it is safe off the loop.
+func (b *Bot) directionalSize(m models.Market, outcome string, size float64) (float64, error) {
+	limit := b.cfg.MaxNetDeltaUSD
+	sign := outcomeSign(outcome)
+	if limit <= 0 || sign == 0 {
+		return size, nil
+	}
+	b.mu.Lock()
+	delta := b.state.NetDelta[m.Underlying()] * sign
+	b.mu.Unlock()
+	room := limit - delta
+	if room >= size {
+		return size, nil
+	}
+	if room <= 0 {
+		return 0, fmt.Errorf("%s net delta %.2f already at MAX_NET_DELTA_USD %.2f", m.Underlying(), delta*sign, limit)
+	}
+	return math.Floor(room*100) / 100, nil
+}
@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/clob"
+	"limitorderbot/internal/clock"
+	"limitorderbot/internal/marketdata"
+)
+
+// Chain is the on-chain surface the bot uses; *chain.Client implements it
+// and tests or replays can substitute their own.
+type Chain interface {
+	Address() common.Address
+	Addresses() chain.Addresses
+	Health() []chain.EndpointHealth
+	Close() error
+
+	USDCBalance(ctx context.Context) (float64, error)
+	NativeUSDCBalance(ctx context.Context) (float64, error)
+	NativeBalanceFloat18(ctx context.Context) (float64, error)
+	ERC1155BalanceOf(ctx context.Context, token common.Address, tokenID *big.Int) (*big.Int, error)
+	NegRiskAmounts(ctx context.Context, yesTokenID, noTokenID *big.Int) ([]*big.Int, error)
+	Payouts(ctx context.Context, conditionID [32]byte, outcomeCount int) (*big.Int, []*big.Int, error)
+	CollateralBalanceOf(ctx context.Context, owner common.Address) (float64, error)
+	ERC20Allowance(ctx context.Context, token, spender common.Address) (*big.Int, error)
+	YieldBalance(ctx context.Context, receipt common.Address) (float64, error)
+	HeadBlock(ctx context.Context) (uint64, error)
+	BlockTime(ctx context.Context, n uint64) (time.Time, error)
+	CollateralTransfers(ctx context.Context, owner common.Address, from, to uint64) ([]chain.Transfer, error)
+	CheckFunder(ctx context.Context, signatureType string, funder common.Address) error
+
+	MergePositionsAsync(ctx context.Context, conditionID [32]byte, amountUSDC6 *big.Int, cb chain.ConfirmFunc) (common.Hash, error)
+	RedeemPositionsAsync(ctx context.Context, conditionID [32]byte, cb chain.ConfirmFunc) (common.Hash, error)
+	RedeemNegRiskPositionsAsync(ctx context.Context, conditionID [32]byte, amounts []*big.Int, cb chain.ConfirmFunc) (common.Hash, error)
+	SwapNativeUSDCAsync(ctx context.Context, router common.Address, fee uint32, amountIn, minOut *big.Int, cb chain.ConfirmFunc) (common.Hash, error)
+	ApproveUSDCAsync(ctx context.Context, spender common.Address, amount *big.Int, cb chain.ConfirmFunc) (common.Hash, error)
+	TransferCollateralAsync(ctx context.Context, to common.Address, amount6 *big.Int, cb chain.ConfirmFunc) (common.Hash, error)
+	YieldDepositAsync(ctx context.Context, pool common.Address, amount6 *big.Int, cb chain.ConfirmFunc) (common.Hash, error)
+	YieldWithdrawAsync(ctx context.Context, pool common.Address, amount6 *big.Int, cb chain.ConfirmFunc) (common.Hash, error)
+}
+
+var _ Chain = (*chain.Client)(nil)
+
+// Deps replaces the clients and clock New builds from config. Nil fields
+// get the defaults, so a test only supplies what it fakes; a CLOB client
+// pointed at a stub server is the usual way to fake the exchange.
+type Deps struct {
+	Clock  clock.Clock
+	Chain  Chain
+	Clob   *clob.Client
+	Source marketdata.Source
+	// DataHTTP serves the data-api (wallet positions) and CLOB price
+	// history requests.
+	DataHTTP *http.Client
+}
@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"limitorderbot/internal/gamma"
+	"limitorderbot/internal/models"
+)
+
+// OutcomePosition is the live holding and book for one outcome token.
+type OutcomePosition struct {
+	Outcome string   `json:"outcome"`
+	TokenID string   `json:"token_id"`
+	Balance float64  `json:"balance"`
+	BestBid *float64 `json:"best_bid"`
+	BestAsk *float64 `json:"best_ask"`
+	Mark    *float64 `json:"mark"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// OutcomePositions reads the wallet's current token balances and book for each
+// outcome of m. It only touches the chain and CLOB clients, so the dashboard
+// can call it from its own goroutine.
+func (b *Bot) OutcomePositions(ctx context.Context, m models.Market) []OutcomePosition {
+	ctf := b.chain.Addresses().CTF
+	out := make([]OutcomePosition, 0, len(m.Outcomes))
+	for _, o := range m.Outcomes {
+		p := OutcomePosition{Outcome: o.Outcome, TokenID: o.TokenID}
+		if o.TokenID == "" {
+			out = append(out, p)
+			continue
+		}
+		if bal, err := b.chain.ERC1155BalanceOf(ctx, ctf, mustBigInt(o.TokenID)); err != nil {
+			p.Error = err.Error()
+		} else {
+			p.Balance = toFloat6(bal)
+		}
+		if book, err := b.clob.GetOrderBook(ctx, o.TokenID); err == nil {
+			bid, ask := bestBidFromBook(book), bestAskFromBook(book)
+			if bid > 0 {
+				p.BestBid = floatPtr(bid)
+			}
+			if ask > 0 {
+				p.BestAsk = floatPtr(ask)
+			}
+			if bid > 0 && ask > 0 {
+				p.Mark = floatPtr((bid + ask) / 2)
+			}
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// PriceHistory fetches a token's CLOB price history between start and end.
+// Like OutcomePositions it only touches an HTTP client, so the dashboard can
+// call it from its own goroutine.
+func (b *Bot) PriceHistory(ctx context.Context, tokenID string, start, end time.Time) ([]gamma.PricePoint, error) {
+	return b.history.GetPriceHistoryRange(ctx, tokenID, start, end)
+}
@@ -0,0 +1,166 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+	"limitorderbot/internal/notify"
+)
+
+// setupNotifications builds the notifier from the NOTIFY_* settings and arms
+// the digest schedule. The first digest covers from startup.
+func (b *Bot) setupNotifications() {
+	var ns []notify.Notifier
+	if b.cfg.NotifyTelegramToken != "" {
+		ns = append(ns, notify.NewTelegram(b.cfg.NotifyTelegramToken, b.cfg.NotifyTelegramChatID))
+	}
+	if b.cfg.NotifySMTPHost != "" {
+		ns = append(ns, &notify.Email{
+			Host:     b.cfg.NotifySMTPHost,
+			Port:     b.cfg.NotifySMTPPort,
+			User:     b.cfg.NotifySMTPUser,
+			Password: b.cfg.NotifySMTPPassword,
+			From:     b.cfg.NotifyEmailFrom,
+			To:       b.cfg.NotifyEmailTo,
+		})
+	}
+	b.notifier = notify.Multi(ns...)
+	if b.cfg.DigestSchedule == "" {
+		return
+	}
+	if b.notifier == nil {
+		logging.Logger().Printf("WARNING: DIGEST_SCHEDULE set but no notification channel configured\n")
+		return
+	}
+	s, err := notify.ParseSchedule(b.cfg.DigestSchedule)
+	if err != nil {
+		return // validated in config
+	}
+	now := b.clock.Now()
+	b.digestSchedule = s
+	b.digestFrom = now
+	b.nextDigest = s.Next(now)
+}
+
+// notify sends in the background so a slow SMTP server or Telegram outage
+// never holds up the loop.
+func (b *Bot) notify(subject, body string) {
+	if b.notifier == nil {
+		return
+	}
+	n := b.notifier
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := n.Send(ctx, subject, body); err != nil {
+			logging.Logger().Printf("Notification %q failed: %v\n", subject, err)
+		}
+	}()
+}
+
+// maybeSendDigest sends the account digest once the schedule comes due.
+func (b *Bot) maybeSendDigest(now time.Time) {
+	if b.digestSchedule == nil || b.nextDigest.IsZero() || now.Before(b.nextDigest) {
+		return
+	}
+	b.mu.Lock()
+	errCount := b.state.ErrorCount
+	lastErr := b.state.LastError
+	balance := b.state.USDCBalance
+	b.mu.Unlock()
+
+	subject := fmt.Sprintf("nicebot digest %s", now.Format("2006-01-02"))
+	b.notify(subject, b.buildDigest(b.digestFrom, now, errCount-b.digestErrors, lastErr, balance))
+	logging.Logger().Printf("Sent account digest for %s - %s\n", b.digestFrom.Format(time.RFC3339), now.Format(time.RFC3339))
+
+	b.digestFrom = now
+	b.digestErrors = errCount
+	b.nextDigest = b.digestSchedule.Next(now)
+}
+
+// buildDigest summarizes order history between from and to plus the current
+// exposure, as plain text readable in both email and Telegram.
+func (b *Bot) buildDigest(from, to time.Time, errCount int, lastErr *string, balance float64) string {
+	var pnl, buyUSD, sellUSD, mergeUSD, redeemUSD float64
+	var buys, sells, merges, redeems int
+	for _, o := range b.orderHistory {
+		at := o.CreatedAt
+		if o.FilledAt != nil {
+			at = *o.FilledAt
+		}
+		if at.Before(from) || !at.Before(to) || o.Status == models.OrderStatusFailed {
+			continue
+		}
+		if o.PNLUSD != nil && o.Status != models.OrderStatusCancelled {
+			pnl += *o.PNLUSD
+		}
+		switch o.TransactionType {
+		case "MERGE":
+			merges++
+			mergeUSD += o.SizeUSD
+		case "REDEEM":
+			redeems++
+			redeemUSD += o.SizeUSD
+		default:
+			shares := filledShares(o)
+			if shares <= 0 {
+				continue
+			}
+			price := o.Price
+			if o.FillPrice != nil {
+				price = *o.FillPrice
+			}
+			if o.Side == models.OrderSideSell {
+				sells++
+				sellUSD += shares * price
+			} else {
+				buys++
+				buyUSD += shares * price
+			}
+		}
+	}
+
+	var exposure float64
+	openOrders, markets := 0, 0
+	for cid, orders := range b.activeOrders {
+		if b.positionsSold[cid] {
+			continue
+		}
+		inMarket := false
+		for _, o := range orders {
+			if o.Side != models.OrderSideBuy {
+				continue
+			}
+			switch o.Status {
+			case models.OrderStatusPlaced, models.OrderStatusPartiallyFilled, models.OrderStatusFilled:
+				exposure += o.SizeUSD
+				inMarket = true
+			}
+			if o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled {
+				openOrders++
+			}
+		}
+		if inMarket {
+			markets++
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Period: %s - %s\n\n", from.Format("2006-01-02 15:04"), to.Format("2006-01-02 15:04 MST"))
+	fmt.Fprintf(&sb, "PnL: $%+.2f\n", pnl)
+	fmt.Fprintf(&sb, "Fills: %d buys ($%.2f), %d sells ($%.2f)\n", buys, buyUSD, sells, sellUSD)
+	fmt.Fprintf(&sb, "Merges: %d ($%.2f)\n", merges, mergeUSD)
+	fmt.Fprintf(&sb, "Redemptions: %d ($%.2f)\n", redeems, redeemUSD)
+	fmt.Fprintf(&sb, "Errors: %d", errCount)
+	if errCount > 0 && lastErr != nil {
+		fmt.Fprintf(&sb, " (last: %s)", *lastErr)
+	}
+	sb.WriteString("\n\n")
+	fmt.Fprintf(&sb, "Exposure: $%.2f across %d markets, %d open orders\n", exposure, markets, openOrders)
+	fmt.Fprintf(&sb, "USDC.e balance: $%.2f\n", balance)
+	return sb.String()
+}
@@ -0,0 +1,193 @@
+package bot
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// downsizeMinUSD ignores overshoots too small to be worth a cancel/re-post.
+const downsizeMinUSD = 1.0
+
+// restingBuy is one live BUY and the unfilled shares it may keep.
+type restingBuy struct {
+	cid  string
+	idx  int
+	keep float64
+}
+
+// downsizeOrders shrinks resting BUYs once the exposure they would add no
+// longer fits: a strategy over its CapitalBudgetUSD, an underlying over
+// MAX_NET_DELTA_USD, or open buys needing more USDC than the wallet holds.
+// The CLOB can't amend a size, so an order is cancelled and its unfilled
+// remainder re-posted smaller at the same price. Newest orders shrink
+// first; a remainder under the market minimums is only cancelled.
+func (b *Bot) downsizeOrders(ctx context.Context) {
+	if !b.cfg.DownsizeOrders || b.clob == nil {
+		return
+	}
+	var buys []*restingBuy
+	for cid, orders := range b.activeOrders {
+		if b.positionsSold[cid] {
+			continue
+		}
+		for i, o := range orders {
+			if o.Side != models.OrderSideBuy || o.OrderID == "" || o.OrderID == "FAILED" {
+				continue
+			}
+			if o.Status != models.OrderStatusPlaced && o.Status != models.OrderStatusPartiallyFilled {
+				continue
+			}
+			if rest := o.Size - filledShares(o); rest > 0 {
+				buys = append(buys, &restingBuy{cid: cid, idx: i, keep: rest})
+			}
+		}
+	}
+	if len(buys) == 0 {
+		return
+	}
+	order := func(r *restingBuy) models.OrderRecord { return b.activeOrders[r.cid][r.idx] }
+	sort.Slice(buys, func(i, j int) bool { return order(buys[i]).CreatedAt.After(order(buys[j]).CreatedAt) })
+
+	// cut trims excess (in USD, or shares when perShare) from the matching
+	// buys, newest first.
+	cut := func(excess float64, perShare bool, match func(models.OrderRecord) bool) {
+		for _, r := range buys {
+			if excess <= 1e-9 {
+				return
+			}
+			o := order(r)
+			if r.keep <= 0 || !match(o) {
+				continue
+			}
+			unit := o.Price
+			if perShare {
+				unit = 1
+			}
+			shares := math.Min(r.keep, excess/unit)
+			r.keep -= shares
+			excess -= shares * unit
+		}
+	}
+
+	for name, s := range b.cfg.Strategies {
+		if s.CapitalBudgetUSD <= 0 {
+			continue
+		}
+		if excess := b.capitalInUse(name) - s.CapitalBudgetUSD; excess >= downsizeMinUSD {
+			cut(excess, false, func(o models.OrderRecord) bool { return o.Strategy != nil && *o.Strategy == name })
+		}
+	}
+	if limit := b.cfg.MaxNetDeltaUSD; limit > 0 {
+		for u, delta := range b.netDeltas() {
+			if math.Abs(delta)-limit < downsizeMinUSD {
+				continue
+			}
+			sign := math.Copysign(1, delta)
+			cut(math.Abs(delta)-limit, true, func(o models.OrderRecord) bool {
+				return outcomeSign(o.Outcome) == sign && b.marketOf(o).Underlying() == u
+			})
+		}
+	}
+	b.mu.Lock()
+	balance := b.state.USDCBalance
+	b.mu.Unlock()
+	var open float64
+	for _, r := range buys {
+		open += r.keep * order(r).Price
+	}
+	if excess := open - balance; excess >= downsizeMinUSD {
+		cut(excess, false, func(models.OrderRecord) bool { return true })
+	}
+
+	changed := false
+	for _, r := range buys {
+		o := order(r)
+		if rest := o.Size - filledShares(o); r.keep < rest-1e-9 {
+			b.downsizeOrder(ctx, r.cid, r.idx, r.keep)
+			changed = true
+		}
+	}
+	if changed {
+		_ = b.saveOrders()
+		_ = b.saveOrderHistory()
+	}
+}
+
+// downsizeOrder cancels one resting BUY and, once the CLOB confirms the
+// cancel, re-posts keep unfilled shares of it at the same price when that
+// still clears the market minimums and the bot may place orders.
+func (b *Bot) downsizeOrder(ctx context.Context, cid string, idx int, keep float64) {
+	log := logging.Logger()
+	orders := b.activeOrders[cid]
+	o := orders[idx]
+	m := b.marketOf(o)
+	outcome := models.Outcome{TokenID: o.TokenID, Outcome: o.Outcome}
+	for _, out := range m.Outcomes {
+		if out.TokenID == o.TokenID {
+			outcome = out
+		}
+	}
+	lot := b.lotSize(ctx, o.TokenID)
+
+	if err := b.cancelOrder(ctx, o.OrderID); err != nil {
+		log.Printf("Oversized order %s not cancelled: %v\n", o.OrderID, err)
+		return
+	}
+	// Fills may have landed between the last status check and the cancel;
+	// only what was still unfilled is re-posted.
+	prev := o.Status
+	if details, err := b.clob.GetOrder(ctx, o.OrderID); err == nil && details != nil {
+		b.applyOrderDetails(&o, details)
+		b.orderFilled(prev, o)
+	}
+	rest := o.Size - filledShares(o)
+	keep = roundToLot(min(keep, max(rest, 0)), lot)
+	repost := keep > 0 && b.checkOrderMinimums(ctx, o.TokenID, o.Price, keep) == nil
+	if o.Status != models.OrderStatusFilled {
+		o.Status = models.OrderStatusCancelled
+	}
+	// The re-post books the kept shares; this record keeps only its fills.
+	cost := filledShares(o) * o.Price
+	o.CostUSD = &cost
+	o.PNLUSD = floatPtr(-cost)
+	orders[idx] = o
+	b.orderHistory[o.OrderID] = o
+	b.activeOrders[cid] = orders
+	if !repost {
+		log.Printf("Cancelled oversized %s %s @ %.3f on %s: %.2f shares too many, nothing left to re-post\n", o.Side, o.Outcome, o.Price, o.MarketSlug, rest)
+		return
+	}
+	// placeSingleFixed checks neither the pause nor cooldowns.
+	strategy := ""
+	if o.Strategy != nil {
+		strategy = *o.Strategy
+	}
+	if err := b.checkCooldown(strategy); err != nil {
+		log.Printf("Cancelled oversized %s %s @ %.3f on %s; not re-posting %.2f shares: %v\n", o.Side, o.Outcome, o.Price, o.MarketSlug, keep, err)
+		return
+	}
+
+	rec, err := b.placeSingleFixed(ctx, m, outcome, o.Price, keep, models.OrderSideBuy)
+	if err != nil {
+		log.Printf("Cancelled oversized %s %s @ %.3f on %s; re-posting %.2f shares failed: %v\n", o.Side, o.Outcome, o.Price, o.MarketSlug, keep, err)
+		return
+	}
+	rec.Strategy = o.Strategy
+	rec.Phase = o.Phase
+	b.activeOrders[cid] = append(b.activeOrders[cid], rec)
+	b.orderHistory[rec.OrderID] = rec
+	log.Printf("Downsized %s %s @ %.3f on %s from %.2f to %.2f shares (order %s)\n", o.Side, o.Outcome, o.Price, o.MarketSlug, rest, keep, rec.OrderID)
+}
+
+// marketOf is the tracked market an order belongs to, or a stand-in built
+// from the order when the market is no longer tracked.
+func (b *Bot) marketOf(o models.OrderRecord) models.Market {
+	if m, ok := b.trackedMarkets[o.ConditionID]; ok {
+		return m
+	}
+	return models.Market{ConditionID: o.ConditionID, MarketSlug: o.MarketSlug}
+}
@@ -0,0 +1,87 @@
+package bot
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"limitorderbot/internal/logging"
+)
+
+// DustFile keeps the redeemable conditions skipped as worth less than
+// MIN_REDEEM_VALUE_USD, with when they were first skipped, so dust survives
+// restarts. Redeeming it is opt-in (REDEEM_DUST_AFTER_HOURS): the bot's EOA
+// can't batch redemptions, so each dust market costs a full tx.
+const DustFile = "redeem_dust.json"
+
+// dustEntry is one condition whose redemption is being put off.
+type dustEntry struct {
+	Slug         string    `json:"slug"`
+	Value        float64   `json:"value_usd"`
+	SkippedSince time.Time `json:"skipped_since"`
+}
+
+func loadDust() (map[string]dustEntry, error) {
+	out := map[string]dustEntry{}
+	raw, err := os.ReadFile(DustFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return out, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func saveDust(dust map[string]dustEntry) error {
+	if len(dust) == 0 {
+		if err := os.Remove(DustFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	bts, err := json.MarshalIndent(dust, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(DustFile, bts, 0o644)
+}
+
+// dueDust merges this check's dust into b.dust, forgetting conditions that
+// are no longer redeemable, and returns the ones that have waited
+// REDEEM_DUST_AFTER_HOURS and should be redeemed now. With the wait at 0
+// nothing is ever due and dust is left to `redeem-all`.
+func (b *Bot) dueDust(dust map[string]dustEntry, redeemable map[string]bool, now time.Time) map[string]bool {
+	changed := false
+	for cid := range b.dust {
+		if !redeemable[cid] {
+			delete(b.dust, cid)
+			changed = true
+		}
+	}
+	due := map[string]bool{}
+	wait := time.Duration(b.cfg.RedeemDustAfterHours) * time.Hour
+	for cid, d := range dust {
+		prev, ok := b.dust[cid]
+		if ok {
+			d.SkippedSince = prev.SkippedSince
+		}
+		if !ok || prev.Value != d.Value {
+			changed = true
+		}
+		b.dust[cid] = d
+		if wait > 0 && now.Sub(d.SkippedSince) >= wait {
+			due[cid] = true
+		}
+	}
+	if changed {
+		if err := saveDust(b.dust); err != nil {
+			logging.Logger().Printf("Failed to save %s: %v\n", DustFile, err)
+		}
+	}
+	return due
+}
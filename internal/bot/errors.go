@@ -0,0 +1,163 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"limitorderbot/internal/apierr"
+	"limitorderbot/internal/logging"
+)
+
+const (
+	// networkRetryDelay is how soon a task that failed on the network runs
+	// again, doubling on each further failure up to its own cadence.
+	networkRetryDelay = 5 * time.Second
+	// rateLimitBackoff holds the loop back after a rate limit without a
+	// Retry-After hint, doubling for each consecutive limited pass.
+	rateLimitBackoff    = 10 * time.Second
+	maxRateLimitBackoff = 5 * time.Minute
+	// authRetryWindow: an auth error this soon after re-deriving the API
+	// credentials means the key itself is refused, so the bot pauses.
+	authRetryWindow   = 10 * time.Minute
+	balanceAlertEvery = time.Hour
+)
+
+// recordError counts err under its apierr kind and sets up the reaction:
+// network errors retry the task early, rate limits back the loop off, auth
+// errors re-derive the API credentials (then pause), and a short balance
+// alerts.
+func (b *Bot) recordError(err error) {
+	kind := apierr.KindOf(err)
+	name := errorKindName(kind)
+	msg := err.Error()
+	now := b.clock.Now()
+
+	b.mu.Lock()
+	b.state.ErrorCount++
+	b.state.LastError = &msg
+	b.state.LastErrorKind = name
+	byKind := maps.Clone(b.state.ErrorsByKind)
+	if byKind == nil {
+		byKind = map[string]int{}
+	}
+	byKind[name]++
+	b.state.ErrorsByKind = byKind
+	var backoff time.Duration
+	switch kind {
+	case apierr.Network:
+		b.netFailed = true
+	case apierr.RateLimited:
+		if !b.rateLimited {
+			b.rateLimited = true
+			b.rateLimits++
+		}
+		backoff = apierr.RetryAfterOf(err)
+		if backoff <= 0 {
+			backoff = min(rateLimitBackoff<<min(b.rateLimits-1, 5), maxRateLimitBackoff)
+		}
+		until := now.Add(backoff)
+		if b.state.BackoffUntil == nil || until.After(*b.state.BackoffUntil) {
+			b.state.BackoffUntil = &until
+		}
+	case apierr.Auth:
+		b.authErr = msg
+	}
+	alert := kind == apierr.InsufficientBalance && now.Sub(b.lastBalanceAlert) >= balanceAlertEvery
+	if alert {
+		b.lastBalanceAlert = now
+	}
+	b.mu.Unlock()
+	b.hookError(msg, name)
+
+	switch {
+	case backoff > 0:
+		logging.Logger().Printf("Rate limited; backing off for %s: %v\n", backoff, err)
+	case alert:
+		logging.Logger().Printf("Insufficient balance: %v\n", err)
+		b.notify("Insufficient balance", fmt.Sprintf("An order or transaction was refused for lack of funds:\n%s\n", msg))
+	}
+}
+
+func errorKindName(k apierr.Kind) string {
+	if k == apierr.Unknown {
+		return "other"
+	}
+	return string(k)
+}
+
+// backoffUntil is when a rate-limit backoff ends (zero when none holds).
+func (b *Bot) backoffUntil() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state.BackoffUntil == nil {
+		return time.Time{}
+	}
+	return *b.state.BackoffUntil
+}
+
+// startPass resets the per-pass error flags before a loop pass.
+func (b *Bot) startPass() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rateLimited = false
+}
+
+// endPass ends a backoff that has run out once a pass got through without
+// being rate limited.
+func (b *Bot) endPass(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rateLimited {
+		return
+	}
+	b.rateLimits = 0
+	if b.state.BackoffUntil != nil && !now.Before(*b.state.BackoffUntil) {
+		b.state.BackoffUntil = nil
+	}
+}
+
+// takeNetFailed reports and clears whether the last task hit a network error.
+func (b *Bot) takeNetFailed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	failed := b.netFailed
+	b.netFailed = false
+	return failed
+}
+
+// recoverAuth answers an auth error from the last pass: the first one
+// re-derives the L2 API credentials, which fixes expired or revoked ones; a
+// repeat within authRetryWindow, or a failed re-derive, pauses the bot and
+// alerts, since retrying with a refused key only piles up errors. A bot
+// that never got credentials (read-only mode) is left alone.
+func (b *Bot) recoverAuth(ctx context.Context) {
+	now := b.clock.Now()
+	b.mu.Lock()
+	last := b.authErr
+	retried := b.authRetriedAt
+	b.authErr = ""
+	b.mu.Unlock()
+	if last == "" || !b.clob.HasCreds() || b.pauseInfo() != nil {
+		return
+	}
+	log := logging.Logger()
+	if retried.IsZero() || now.Sub(retried) >= authRetryWindow {
+		b.mu.Lock()
+		b.authRetriedAt = now
+		b.mu.Unlock()
+		creds, err := b.clob.CreateOrDeriveAPICreds(ctx, 0)
+		if err == nil {
+			b.clob.SetCreds(creds)
+			log.Println("Auth error: re-derived the CLOB API credentials")
+			return
+		}
+		log.Printf("Auth error: re-deriving the CLOB API credentials failed: %v\n", err)
+	}
+	if err := b.Pause("auth error: " + last); err != nil {
+		log.Printf("Failed to pause after auth error: %v\n", err)
+	}
+	b.notify("Bot paused: auth error", fmt.Sprintf(
+		"The CLOB keeps refusing the bot's credentials, so no new orders will be placed until it is resumed.\nLast error: %s\n", last))
+}
@@ -26,7 +26,7 @@ func (b *Bot) placeFallbackLiquidityIfIdle(ctx context.Context, upcoming []model
 		if b.ordersPlaced[m.ConditionID] {
 			continue
 		}
-		if !shouldPlaceOrders(b.cfg, m, now) {
+		if !shouldPlaceOrders(b.cfg, m, now) || b.thinBooks[m.ConditionID] {
 			continue
 		}
 		if pick == nil || m.StartTS < pick.StartTS {
@@ -40,6 +40,8 @@ func (b *Bot) placeFallbackLiquidityIfIdle(ctx context.Context, upcoming []model
 
 	logging.Logger().Printf("Idle state detected. Placing fallback liquidity orders for next market: %s\n", pick.MarketSlug)
 	orders, err := b.placeLiquidityOrders(ctx, *pick)
+	b.recordPlacementResult(*pick, orders, err, "fallback")
+	tagPhase(orders, models.PhaseFallback)
 	if err != nil {
 		b.recordError(err)
 		return
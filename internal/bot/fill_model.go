@@ -0,0 +1,40 @@
+package bot
+
+import (
+	"time"
+
+	"limitorderbot/internal/fillprob"
+	"limitorderbot/internal/models"
+)
+
+// fillModelRefresh is how often the fill estimator is refitted; history only
+// grows by a few orders per market.
+const fillModelRefresh = 10 * time.Minute
+
+// fillEstimator returns the fill-probability model over orderHistory,
+// refitting it when older than fillModelRefresh.
+func (b *Bot) fillEstimator() *fillprob.Estimator {
+	now := b.clock.Now()
+	if b.fillModel != nil && now.Sub(b.fillModelAt) < fillModelRefresh {
+		return b.fillModel
+	}
+	history := make([]models.OrderRecord, 0, len(b.orderHistory))
+	for _, o := range b.orderHistory {
+		history = append(history, o)
+	}
+	b.fillModel = fillprob.Fit(history, b.marketStart)
+	b.fillModelAt = now
+	return b.fillModel
+}
+
+// marketStart finds an order's market start from the tracked markets, or
+// from the slug for markets no longer tracked.
+func (b *Bot) marketStart(o models.OrderRecord) (time.Time, bool) {
+	if m, ok := b.trackedMarkets[o.ConditionID]; ok && m.StartTS > 0 {
+		return m.StartTime(), true
+	}
+	if start, _, ok := models.SlugWindow(o.MarketSlug); ok {
+		return time.Unix(start, 0), true
+	}
+	return time.Time{}, false
+}
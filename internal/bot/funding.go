@@ -0,0 +1,80 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/logging"
+)
+
+// fundingAlertEvery throttles the repeated log line while the alert stands.
+const fundingAlertEvery = 30 * time.Minute
+
+// checkFunding notices funds parked in native USDC while the USDC.e collateral
+// is too low to place orders. It raises an alert on the bot state and, when a
+// swap router is configured, queues a swap of the native USDC into USDC.e.
+func (b *Bot) checkFunding(ctx context.Context, now time.Time, collateral float64) {
+	native, err := b.chain.NativeUSDCBalance(ctx)
+	if err != nil {
+		return
+	}
+	required := b.cfg.OrderSizeUSD * 2
+	var alert *string
+	if collateral < required && native >= b.cfg.USDCSwapMinUSD {
+		msg := fmt.Sprintf("USDC.e balance $%.2f is below the $%.2f needed to place orders while $%.2f sits in native USDC, which Polymarket does not accept", collateral, required, native)
+		alert = &msg
+	}
+	b.mu.Lock()
+	b.state.NativeUSDC = native
+	b.state.FundingAlert = alert
+	b.mu.Unlock()
+	if alert == nil {
+		b.lastFundingAlert = time.Time{}
+		return
+	}
+	if b.lastFundingAlert.IsZero() || now.Sub(b.lastFundingAlert) >= fundingAlertEvery {
+		b.lastFundingAlert = now
+		logging.Logger().Printf("Funding alert: %s\n", *alert)
+	}
+	if b.cfg.USDCSwapRouter != "" {
+		b.queueUSDCSwap(native)
+	}
+}
+
+// queueUSDCSwap hands a native USDC -> USDC.e swap of the whole native
+// balance to the tx worker, accepting at most USDCSwapMaxSlippageBps loss.
+func (b *Bot) queueUSDCSwap(native float64) {
+	if b.txWorker.pending("swap", "") {
+		return
+	}
+	amountIn := big.NewInt(int64(native * 1_000_000))
+	minOut := new(big.Int).Mul(amountIn, big.NewInt(int64(10000-b.cfg.USDCSwapMaxSlippageBps)))
+	minOut.Quo(minOut, big.NewInt(10000))
+	router := common.HexToAddress(b.cfg.USDCSwapRouter)
+	fee := uint32(b.cfg.USDCSwapFee)
+	job := &TxJob{
+		Kind:       "swap",
+		MarketSlug: "USDC -> USDC.e",
+		Amount:     native,
+		run: func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error) {
+			return b.chain.SwapNativeUSDCAsync(ctx, router, fee, amountIn, minOut, cb)
+		},
+		done: func(job TxJob, err error) {
+			b.notifyTx(job, err)
+			b.bookGas(job)
+			if err != nil {
+				b.recordError(fmt.Errorf("USDC swap failed: %w", err))
+				return
+			}
+			logging.Logger().Printf("Swapped $%.2f native USDC into USDC.e\n", job.Amount)
+		},
+	}
+	if b.queueTx(job) {
+		logging.Logger().Printf("Queued swap of $%.2f native USDC into USDC.e via %s\n", native, router.Hex())
+	}
+}
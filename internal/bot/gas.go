@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"limitorderbot/internal/gasspend"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// gasAlertEvery throttles the repeated log line while gas stays low.
+const gasAlertEvery = 30 * time.Minute
+
+// refreshGas reads the wallet's MATIC balance and flips the gas guard that
+// queueTx consults. A failed read leaves the previous verdict in place.
+func (b *Bot) refreshGas(ctx context.Context, now time.Time) {
+	b.publishGasSpend(now)
+	matic, err := b.chain.NativeBalanceFloat18(ctx)
+	if err != nil {
+		return
+	}
+	b.gasLow = b.cfg.MinMatic > 0 && matic < b.cfg.MinMatic
+	var alert *string
+	if b.gasLow {
+		msg := fmt.Sprintf("MATIC balance %.4f is below MIN_MATIC %.4f; merges, redemptions and swaps are paused", matic, b.cfg.MinMatic)
+		alert = &msg
+	}
+	b.mu.Lock()
+	b.state.MaticBalance = matic
+	b.state.GasAlert = alert
+	b.mu.Unlock()
+	if alert == nil {
+		if !b.lastGasAlert.IsZero() {
+			logging.Logger().Printf("MATIC balance %.4f restored; resuming on-chain operations\n", matic)
+		}
+		b.lastGasAlert = time.Time{}
+		return
+	}
+	if b.lastGasAlert.IsZero() || now.Sub(b.lastGasAlert) >= gasAlertEvery {
+		b.lastGasAlert = now
+		logging.Logger().Printf("Gas alert: %s\n", *alert)
+	}
+}
+
+// queueTx hands job to the tx worker unless gas is too low to pay for it.
+// Callers treat a refusal like a deduplicated job and retry on a later tick.
+// The gas a job pays is booked against the day before its own done runs.
+func (b *Bot) queueTx(job *TxJob) bool {
+	if b.gasLow {
+		return false
+	}
+	done := job.done
+	job.done = func(job TxJob, err error) {
+		b.recordGasSpend(job)
+		if done != nil {
+			done(job, err)
+		}
+	}
+	return b.txWorker.enqueue(job)
+}
+
+// dustMergeSets: over the gas budget, merges of fewer sets than this wait.
+const dustMergeSets = 1.0
+
+// recordGasSpend adds what job paid for gas to today's total in
+// gas_spend.json.
+func (b *Bot) recordGasSpend(job TxJob) {
+	if job.GasMatic <= 0 {
+		return
+	}
+	now := b.clock.Now()
+	key := gasspend.DateKey(now)
+	d := b.gasDays[key]
+	if d == nil {
+		d = &gasspend.Day{Date: key}
+		b.gasDays[key] = d
+	}
+	d.Add(job.Kind, job.GasMatic, b.gasUSD(job), now)
+	if err := gasspend.Save(gasspend.DefaultFile, b.gasDays); err != nil {
+		logging.Logger().Printf("Failed to save %s: %v\n", gasspend.DefaultFile, err)
+	}
+	b.publishGasSpend(now)
+	if budget := b.cfg.GasDailyBudgetUSD; budget > 0 && d.USD >= budget && d.USD-b.gasUSD(job) < budget {
+		b.notify("Gas budget reached", fmt.Sprintf("Gas today is $%.4f (%.5f MATIC over %d txs), at GAS_DAILY_BUDGET_USD $%.2f: redemptions and dust merges wait until tomorrow (UTC).", d.USD, d.Matic, d.Txs, budget))
+	}
+}
+
+// publishGasSpend copies today's gas totals into the dashboard state.
+func (b *Bot) publishGasSpend(now time.Time) {
+	var matic, usd float64
+	if d := b.gasDays[gasspend.DateKey(now)]; d != nil {
+		matic, usd = d.Matic, d.USD
+	}
+	b.mu.Lock()
+	b.state.GasTodayMatic = matic
+	b.state.GasTodayUSD = usd
+	b.mu.Unlock()
+}
+
+// overGasBudget reports whether today's gas has reached GAS_DAILY_BUDGET_USD,
+// deferring work that can wait: redemptions and dust merges.
+func (b *Bot) overGasBudget(now time.Time) bool {
+	if b.cfg.GasDailyBudgetUSD <= 0 {
+		return false
+	}
+	d := b.gasDays[gasspend.DateKey(now)]
+	return d != nil && d.USD >= b.cfg.GasDailyBudgetUSD
+}
+
+// gasUSD converts what a tx job paid for gas at MATIC_USD_PRICE.
+func (b *Bot) gasUSD(job TxJob) float64 {
+	return job.GasMatic * b.cfg.MaticUSDPrice
+}
+
+// bookGas records gas a job paid that no MERGE/REDEEM record carries (a
+// reverted tx, a swap or a top-up) as a GAS record, so order-history PnL
+// nets it. Jobs without a condition are overhead no market is charged for.
+func (b *Bot) bookGas(job TxJob) {
+	gas := b.gasUSD(job)
+	if gas <= 0 {
+		return
+	}
+	now := b.clock.Now()
+	key := job.ConditionID
+	if len(key) > 16 {
+		key = key[:16]
+	}
+	rec := models.OrderRecord{
+		OrderID:         fmt.Sprintf("GAS-%s-%s-%d", job.Kind, key, now.UnixNano()),
+		MarketSlug:      job.MarketSlug,
+		ConditionID:     job.ConditionID,
+		Outcome:         "GAS",
+		Side:            models.OrderSideSell,
+		Status:          models.OrderStatusFilled,
+		CreatedAt:       now,
+		FilledAt:        &now,
+		TransactionType: "GAS",
+		RevenueUSD:      floatPtr(0),
+		CostUSD:         floatPtr(gas),
+		PNLUSD:          floatPtr(-gas),
+		GasUSD:          floatPtr(gas),
+		RunID:           b.runID,
+		TxHash:          job.TxHash,
+	}
+	b.orderHistory[rec.OrderID] = rec
+	_ = b.saveOrderHistory()
+}
+
+// gasPtr leaves gas_usd off records that paid none (or weren't priced).
+func gasPtr(gas float64) *float64 {
+	if gas <= 0 {
+		return nil
+	}
+	return &gas
+}
@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"limitorderbot/internal/logging"
+)
+
+// ClobHealth is the last CLOB heartbeat result, shown on /api/status so "API
+// down" can be told apart from "no opportunities".
+type ClobHealth struct {
+	Available   bool       `json:"available"`
+	LastCheck   *time.Time `json:"last_check,omitempty"`
+	LastOK      *time.Time `json:"last_ok,omitempty"`
+	LatencyMS   float64    `json:"latency_ms"`
+	ServerTime  int64      `json:"server_time,omitempty"`
+	ClockSkewMS int64      `json:"clock_skew_ms"`
+	LastError   string     `json:"last_error,omitempty"`
+}
+
+// checkClob calls the CLOB health and time endpoints and records the result.
+func (b *Bot) checkClob(ctx context.Context) {
+	start := time.Now()
+	err := b.clob.GetOk(ctx)
+	latency := time.Since(start)
+	var serverTime int64
+	if err == nil {
+		serverTime, err = b.clob.GetServerTime(ctx)
+	}
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	h := &b.clobHealth
+	wasAvailable := h.Available || h.LastCheck == nil
+	h.LastCheck = &now
+	h.LatencyMS = ms(latency)
+	if err != nil {
+		h.Available = false
+		h.LastError = err.Error()
+		if wasAvailable {
+			logging.Logger().Printf("WARNING: CLOB API unavailable: %v\n", err)
+		}
+		return
+	}
+	if !wasAvailable {
+		logging.Logger().Println("CLOB API available again")
+	}
+	h.Available = true
+	h.LastOK = &now
+	h.LastError = ""
+	h.ServerTime = serverTime
+	// Server time has 1s resolution, so skew below ~1s is noise.
+	h.ClockSkewMS = now.UnixMilli() - serverTime*1000
+}
+
+// runClobHeartbeat re-checks the CLOB every interval until ctx is done.
+func (b *Bot) runClobHeartbeat(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			b.checkClob(cctx)
+			cancel()
+		}
+	}
+}
+
+// ClobHealth returns the latest CLOB heartbeat result.
+func (b *Bot) ClobHealth() ClobHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.clobHealth
+}
@@ -37,6 +37,8 @@ func (b *Bot) cleanupOldMarkets(ctx context.Context, now time.Time) {
 		delete(b.lastMergeAttempt, cid)
 		delete(b.mergedAmounts, cid)
 		delete(b.strategyExecuted, cid)
+		delete(b.lastResolutionCheck, cid)
+		delete(b.lastDecision, cid)
 	}
 
 	_ = b.saveMarkets()
@@ -103,19 +105,22 @@ func (b *Bot) refreshOrphanedOrders(ctx context.Context, conditionID string, ord
 					origSize = o.Size
 				}
 				o.SizeMatched = &sizeMatched
+				b.markFirstFill(&o, sizeMatched)
 				prev := o.Status
 				switch {
 				case status == "MATCHED" || (origSize > 0 && sizeMatched >= origSize):
 					o.Status = models.OrderStatusFilled
-					now := time.Now()
+					now := b.clock.Now()
 					o.FilledAt = &now
 				case sizeMatched > 0:
 					o.Status = models.OrderStatusPartiallyFilled
 				case status == "CANCELLED":
 					o.Status = models.OrderStatusCancelled
 				}
+				bookFill(&o)
 				if o.Status != prev {
 					changed = true
+					b.orderFilled(prev, o)
 				}
 			} else {
 				// If we can't refresh and the orphan market is clearly expired, mark cancelled.
@@ -198,7 +203,7 @@ func (b *Bot) shouldAutoFinalizeOrphan(ctx context.Context, conditionID string,
 			oldest = o.CreatedAt
 		}
 	}
-	return time.Since(oldest) > 24*time.Hour
+	return b.clock.Now().Sub(oldest) > 24*time.Hour
 }
 
 func (b *Bot) isOrphanMarketExpired(marketSlug string) bool {
@@ -225,11 +230,11 @@ func (b *Bot) isOrphanMarketExpired(marketSlug string) bool {
 		start = start*10 + int64(c-'0')
 	}
 	end := start + 15*60
-	return time.Now().Unix() > (end + 300)
+	return b.clock.Now().Unix() > (end + 300)
 }
 
 func (b *Bot) buildOrphanMarket(conditionID string, orders []models.OrderRecord) models.Market {
-	now := time.Now().Unix()
+	now := b.clock.Now().Unix()
 	slug := "orphaned-" + conditionID
 	if len(orders) > 0 && strings.TrimSpace(orders[0].MarketSlug) != "" {
 		slug = orders[0].MarketSlug
@@ -0,0 +1,367 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/clob"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+	"limitorderbot/internal/outcomes"
+)
+
+// importedStrategy tags history records pulled in by ImportHistory, so
+// statistics can tell them from the bot's own orders.
+const importedStrategy = "imported"
+
+// activityPageSize and activityMaxOffset bound the data-api activity
+// paging; the API refuses offsets past its own cap.
+const (
+	activityPageSize  = 500
+	activityMaxOffset = 10_000
+)
+
+// ImportReport counts what ImportHistory added.
+type ImportReport struct {
+	Orders      int // from the CLOB trade history
+	Trades      int // data-api trades the CLOB history didn't cover
+	Redemptions int
+	Merges      int
+	Outcomes    int
+	Skipped     int // activity kinds the history has no record for (splits, rewards, ...)
+	Before      time.Time
+	Errors      []string
+}
+
+// walletActivity is one entry of the data-api /activity feed.
+type walletActivity struct {
+	Timestamp   int64   `json:"timestamp"`
+	ConditionID string  `json:"conditionId"`
+	Type        string  `json:"type"`
+	Size        float64 `json:"size"`
+	USDCSize    float64 `json:"usdcSize"`
+	TxHash      string  `json:"transactionHash"`
+	Price       float64 `json:"price"`
+	Asset       string  `json:"asset"`
+	Side        string  `json:"side"`
+	Outcome     string  `json:"outcome"`
+	Slug        string  `json:"slug"`
+}
+
+// ImportHistory pulls the account's history from before the bot's first
+// order into order_history.json and market_outcomes.json (see
+// importHistory). Records already there are kept, so it can be re-run.
+func (b *Bot) ImportHistory(ctx context.Context) (ImportReport, error) {
+	if err := b.loadOrderHistory(); err != nil {
+		return ImportReport{}, err
+	}
+	if !b.clob.HasCreds() {
+		if creds, err := b.clob.CreateOrDeriveAPICreds(ctx, 0); err == nil && creds.APIKey != "" {
+			b.clob.SetCreds(creds)
+		}
+	}
+	rep := b.importHistory(ctx)
+	return rep, b.saveOrderHistory()
+}
+
+// importHistory adds the wallet's activity predating the bot's own records:
+// its orders from the CLOB trade history (needs API creds), then trades,
+// redemptions and merges from the data-api activity feed that the CLOB
+// history didn't cover, and the resolutions of held markets from the
+// data-api positions. Sources that fail are reported and skipped.
+func (b *Bot) importHistory(ctx context.Context) ImportReport {
+	var rep ImportReport
+	for _, o := range b.orderHistory {
+		if o.Strategy != nil && *o.Strategy == importedStrategy {
+			continue
+		}
+		if rep.Before.IsZero() || o.CreatedAt.Before(rep.Before) {
+			rep.Before = o.CreatedAt
+		}
+	}
+	predates := func(t time.Time) bool { return rep.Before.IsZero() || t.Before(rep.Before) }
+	fail := func(step string, err error) {
+		rep.Errors = append(rep.Errors, fmt.Sprintf("%s: %v", step, err))
+	}
+
+	var activity []walletActivity
+	var positions []polymarketPosition
+	for _, w := range b.ownWallets() {
+		acts, err := b.fetchActivity(ctx, w.Hex())
+		if err != nil {
+			fail("activity "+w.Hex(), err)
+		}
+		activity = append(activity, acts...)
+		ps, err := b.fetchPositionsFor(ctx, w.Hex())
+		if err != nil {
+			fail("positions "+w.Hex(), err)
+		}
+		positions = append(positions, ps...)
+	}
+	slugs := map[string]string{}
+	for _, a := range activity {
+		if a.Slug != "" {
+			slugs[a.ConditionID] = a.Slug
+		}
+	}
+	for _, p := range positions {
+		if p.Slug != "" {
+			slugs[p.ConditionID] = p.Slug
+		}
+	}
+
+	// covered holds the (tx, asset) pairs the CLOB history accounts for.
+	covered := map[string]bool{}
+	fills, err := b.clob.GetFills(ctx, rep.Before)
+	if err != nil {
+		fail("clob trades", err)
+	}
+	byOrder := map[string][]clob.Fill{}
+	for _, f := range fills {
+		covered[f.TxHash+"/"+f.AssetID] = true
+		if f.OrderID != "" && f.Size > 0 && predates(f.Time) {
+			byOrder[f.OrderID] = append(byOrder[f.OrderID], f)
+		}
+	}
+	for id, fs := range byOrder {
+		if _, ok := b.orderHistory[id]; ok {
+			continue
+		}
+		b.orderHistory[id] = importedOrder(id, fs, slugs)
+		rep.Orders++
+	}
+
+	for _, a := range activity {
+		at := time.Unix(a.Timestamp, 0).UTC()
+		if !predates(at) {
+			continue
+		}
+		var rec models.OrderRecord
+		var count *int
+		switch strings.ToUpper(a.Type) {
+		case "TRADE":
+			if covered[a.TxHash+"/"+a.Asset] {
+				continue
+			}
+			rec, count = importedTrade(a, at), &rep.Trades
+		case "REDEEM":
+			rec, count = importedSettlement("REDEEM", a, at), &rep.Redemptions
+		case "MERGE":
+			rec, count = importedSettlement("MERGE", a, at), &rep.Merges
+		default:
+			rep.Skipped++
+			continue
+		}
+		if _, ok := b.orderHistory[rec.OrderID]; ok {
+			continue
+		}
+		b.orderHistory[rec.OrderID] = rec
+		*count++
+	}
+
+	known, err := outcomes.Load(outcomes.DefaultFile)
+	if err != nil {
+		fail("outcomes", err)
+		return rep
+	}
+	for _, p := range positions {
+		if !p.Redeemable || known[p.ConditionID].WinningOutcome != "" {
+			continue
+		}
+		o := models.MarketOutcome{ConditionID: p.ConditionID, MarketSlug: p.Slug, Source: "import", ResolvedAt: b.clock.Now().UTC()}
+		switch {
+		case p.CurPrice >= 0.99:
+			o.WinningOutcome, o.WinningTokenID = p.Outcome, p.Asset
+		case p.CurPrice <= 0.01 && p.OppositeOutcome != "":
+			o.WinningOutcome, o.WinningTokenID = p.OppositeOutcome, p.OppositeAsset
+		default:
+			continue
+		}
+		if err := outcomes.Record(outcomes.DefaultFile, o); err != nil {
+			fail("outcomes", err)
+			continue
+		}
+		known[o.ConditionID] = o
+		rep.Outcomes++
+	}
+	return rep
+}
+
+// importedOrder books one order from its fills, like a filled order of the
+// bot's own.
+func importedOrder(id string, fills []clob.Fill, slugs map[string]string) models.OrderRecord {
+	sort.Slice(fills, func(i, j int) bool { return fills[i].Time.Before(fills[j].Time) })
+	first, last := fills[0], fills[len(fills)-1]
+	var size, notional float64
+	for _, f := range fills {
+		size += f.Size
+		notional += f.Size * f.Price
+	}
+	price := notional / size
+	side := models.OrderSide(first.Side)
+	strategy := importedStrategy
+	rec := models.OrderRecord{
+		OrderID:         id,
+		MarketSlug:      slugs[first.Market],
+		ConditionID:     first.Market,
+		TokenID:         first.AssetID,
+		Outcome:         first.Outcome,
+		Side:            side,
+		Price:           price,
+		Size:            size,
+		SizeUSD:         notional,
+		Status:          models.OrderStatusFilled,
+		SizeMatched:     floatPtr(size),
+		CreatedAt:       first.Time,
+		FilledAt:        &last.Time,
+		FirstFillAt:     &first.Time,
+		Strategy:        &strategy,
+		TransactionType: string(side),
+		FillPrice:       floatPtr(price),
+		TxHash:          last.TxHash,
+	}
+	bookImported(&rec)
+	return rec
+}
+
+// importedTrade books a data-api trade the CLOB history didn't list (it has
+// no order id, so it is keyed by tx and asset).
+func importedTrade(a walletActivity, at time.Time) models.OrderRecord {
+	side := models.OrderSide(strings.ToUpper(a.Side))
+	strategy := importedStrategy
+	asset := a.Asset
+	if len(asset) > 8 {
+		asset = asset[len(asset)-8:]
+	}
+	rec := models.OrderRecord{
+		OrderID:         fmt.Sprintf("TRADE-%s-%s", a.TxHash, asset),
+		MarketSlug:      a.Slug,
+		ConditionID:     a.ConditionID,
+		TokenID:         a.Asset,
+		Outcome:         a.Outcome,
+		Side:            side,
+		Price:           a.Price,
+		Size:            a.Size,
+		SizeUSD:         a.Size * a.Price,
+		Status:          models.OrderStatusFilled,
+		SizeMatched:     floatPtr(a.Size),
+		CreatedAt:       at,
+		FilledAt:        &at,
+		Strategy:        &strategy,
+		TransactionType: string(side),
+		FillPrice:       floatPtr(a.Price),
+		TxHash:          a.TxHash,
+	}
+	bookImported(&rec)
+	return rec
+}
+
+// importedSettlement books a redemption or merge the way trackRedemption
+// and trackMerge do, without the gas (the feed doesn't carry it).
+func importedSettlement(kind string, a walletActivity, at time.Time) models.OrderRecord {
+	cid := a.ConditionID
+	if len(cid) > 16 {
+		cid = cid[:16]
+	}
+	strategy := importedStrategy
+	return models.OrderRecord{
+		OrderID:         fmt.Sprintf("%s-%s-%d", kind, cid, a.Timestamp),
+		MarketSlug:      a.Slug,
+		ConditionID:     a.ConditionID,
+		Outcome:         kind,
+		Side:            models.OrderSideSell,
+		Price:           1.0,
+		Size:            a.USDCSize,
+		SizeUSD:         a.USDCSize,
+		Status:          models.OrderStatusFilled,
+		CreatedAt:       at,
+		FilledAt:        &at,
+		Strategy:        &strategy,
+		TransactionType: kind,
+		RevenueUSD:      floatPtr(a.USDCSize),
+		CostUSD:         floatPtr(0),
+		PNLUSD:          floatPtr(a.USDCSize),
+		TxHash:          a.TxHash,
+	}
+}
+
+// bookImported sets cost, revenue and PnL: a BUY as at placement, a SELL
+// from its fills.
+func bookImported(rec *models.OrderRecord) {
+	if rec.Side == models.OrderSideSell {
+		rec.TransactionType = "SELL"
+		bookFill(rec)
+		return
+	}
+	rec.TransactionType = "BUY"
+	rec.CostUSD = floatPtr(rec.SizeUSD)
+	rec.RevenueUSD = floatPtr(0)
+	rec.PNLUSD = floatPtr(-rec.SizeUSD)
+}
+
+// fetchActivity pages through GET <data-api>/activity?user=<wallet>.
+func (b *Bot) fetchActivity(ctx context.Context, wallet string) ([]walletActivity, error) {
+	var out []walletActivity
+	for offset := 0; offset <= activityMaxOffset; offset += activityPageSize {
+		q := url.Values{}
+		q.Set("user", wallet)
+		q.Set("limit", fmt.Sprint(activityPageSize))
+		q.Set("offset", fmt.Sprint(offset))
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(b.cfg.DataAPIURL, "/")+"/activity?"+q.Encode(), nil)
+		if err != nil {
+			return out, err
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := b.dataHTTP.Do(req)
+		if err != nil {
+			return out, err
+		}
+		var page []walletActivity
+		if resp.StatusCode != 200 {
+			err = fmt.Errorf("activity api status=%d", resp.StatusCode)
+		} else {
+			err = json.NewDecoder(resp.Body).Decode(&page)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return out, err
+		}
+		out = append(out, page...)
+		if len(page) < activityPageSize {
+			break
+		}
+	}
+	return out, nil
+}
+
+// importOnFirstRun imports the account's history when the bot starts without
+// an order history file and IMPORT_HISTORY_ON_FIRST_RUN is on.
+func (b *Bot) importOnFirstRun(ctx context.Context, firstRun bool) {
+	if !firstRun || !b.cfg.ImportHistoryOnFirstRun {
+		return
+	}
+	logger := logging.Logger()
+	rep := b.importHistory(ctx)
+	for _, e := range rep.Errors {
+		logger.Printf("WARNING: history import: %s\n", e)
+	}
+	if n := rep.Orders + rep.Trades + rep.Redemptions + rep.Merges; n > 0 {
+		logger.Printf("Imported %d past orders, %d trades, %d redemptions and %d merges from Polymarket\n", rep.Orders, rep.Trades, rep.Redemptions, rep.Merges)
+		if err := b.saveOrderHistory(); err != nil {
+			logger.Printf("Warning: could not save imported history: %v\n", err)
+		}
+	}
+}
+
+// firstRun reports whether there is no order history file yet.
+func (b *Bot) firstRun() bool {
+	_, err := os.Stat(b.orderHistoryFile)
+	return os.IsNotExist(err)
+}
@@ -0,0 +1,33 @@
+package bot
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"limitorderbot/internal/models"
+)
+
+// killSwitchEnv halts new placement while set; any value other than a false
+// boolean ("0", "false") counts, so a typo fails safe.
+const killSwitchEnv = "KILL_SWITCH"
+
+// killSwitch returns the pause the kill switch imposes, or nil when neither
+// KILL_SWITCH is set nor KILL_SWITCH_FILE exists. Both are checked on every
+// loop pass. Unlike PauseFile, Resume doesn't lift it: the operator unsets
+// the variable or removes the file.
+func (b *Bot) killSwitch() *models.PauseInfo {
+	if v := strings.TrimSpace(os.Getenv(killSwitchEnv)); v != "" {
+		if on, err := strconv.ParseBool(v); err != nil || on {
+			return &models.PauseInfo{Reason: "kill switch (" + killSwitchEnv + ")", Since: b.clock.Now().UTC()}
+		}
+	}
+	if b.cfg.KillSwitchFile == "" {
+		return nil
+	}
+	fi, err := os.Stat(b.cfg.KillSwitchFile)
+	if err != nil {
+		return nil
+	}
+	return &models.PauseInfo{Reason: "kill switch (" + b.cfg.KillSwitchFile + ")", Since: fi.ModTime().UTC()}
+}
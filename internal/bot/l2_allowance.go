@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"limitorderbot/internal/clob"
 	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+	"limitorderbot/internal/webhook"
 )
 
 // updateL2BalanceAllowanceBestEffort mirrors python OrderManager._set_allowances():
@@ -19,7 +22,7 @@ func (b *Bot) updateL2BalanceAllowanceBestEffort(ctx context.Context) {
 	log := logging.Logger()
 
 	params := &clob.BalanceAllowanceParams{
-		AssetType: "COLLATERAL",
+		AssetType: clob.AssetCollateral,
 		// TokenID empty for collateral
 		// SignatureType 0 -> use default (from SIGNATURE_TYPE)
 	}
@@ -53,3 +56,100 @@ func (b *Bot) updateL2BalanceAllowanceBestEffort(ctx context.Context) {
 	}
 }
 
+// syncConditionalAllowances asks the CLOB, in the background, to re-read our
+// balance and allowance of each token. The CLOB checks a SELL against its
+// cached view, which lags fills and merges until it is told to refresh.
+func (b *Bot) syncConditionalAllowances(tokenIDs ...string) {
+	if b.clob == nil || len(tokenIDs) == 0 {
+		return
+	}
+	cc := b.clob
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		for _, id := range tokenIDs {
+			if id == "" {
+				continue
+			}
+			params := &clob.BalanceAllowanceParams{AssetType: clob.AssetConditional, TokenID: id}
+			if _, err := cc.UpdateBalanceAllowance(ctx, params); err != nil {
+				logging.Logger().Printf("WARNING: Could not update CONDITIONAL balance allowance for %s: %v\n", id, err)
+			}
+		}
+	}()
+}
+
+// syncCollateralAllowance asks the CLOB, in the background, to re-read our
+// collateral after funds arrive outside of trading.
+func (b *Bot) syncCollateralAllowance() {
+	if b.clob == nil {
+		return
+	}
+	cc := b.clob
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if _, err := cc.UpdateBalanceAllowance(ctx, &clob.BalanceAllowanceParams{AssetType: clob.AssetCollateral}); err != nil {
+			logging.Logger().Printf("WARNING: Could not update COLLATERAL balance allowance: %v\n", err)
+		}
+	}()
+}
+
+// orderFilled follows up an order update (prev is its status before it). A
+// BUY that starts filling or any order that fills completely changes the
+// shares the CLOB has to credit us with.
+func (b *Bot) orderFilled(prev models.OrderStatus, o models.OrderRecord) {
+	if prev == o.Status {
+		return
+	}
+	switch {
+	case o.Status == models.OrderStatusFilled:
+		b.syncConditionalAllowances(o.TokenID)
+		b.hook(webhook.OrderFilled, o)
+		b.notifyFilled(prev, o)
+	case o.Status == models.OrderStatusPartiallyFilled && o.Side == models.OrderSideBuy:
+		b.syncConditionalAllowances(o.TokenID)
+	}
+}
+
+// syncHeldAllowances refreshes the CLOB's view of the tokens our filled BUYs
+// in still-tracked markets bought, so shares bought while the bot was down
+// can be sold straight away.
+func (b *Bot) syncHeldAllowances() {
+	seen := map[string]bool{}
+	var tokens []string
+	for _, o := range b.orderHistory {
+		if o.Side != models.OrderSideBuy || o.TokenID == "" || seen[o.TokenID] || filledShares(o) <= 0 {
+			continue
+		}
+		if _, ok := b.trackedMarkets[o.ConditionID]; !ok {
+			continue
+		}
+		seen[o.TokenID] = true
+		tokens = append(tokens, o.TokenID)
+	}
+	if len(tokens) > 0 {
+		logging.Logger().Printf("Updating L2 balance allowance (CONDITIONAL) for %d held token(s)...\n", len(tokens))
+	}
+	b.syncConditionalAllowances(tokens...)
+}
+
+// checkSellBalance refuses a SELL of size tokenID shares that the CLOB would
+// reject for balance. If its view is short it asks for a refresh once and
+// reads again before giving up.
+func (b *Bot) checkSellBalance(ctx context.Context, tokenID string, size float64) error {
+	held, err := b.clob.ConditionalBalance(ctx, tokenID)
+	if err == nil && held+1e-6 < size {
+		params := &clob.BalanceAllowanceParams{AssetType: clob.AssetConditional, TokenID: tokenID}
+		if _, err := b.clob.UpdateBalanceAllowance(ctx, params); err == nil {
+			held, err = b.clob.ConditionalBalance(ctx, tokenID)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("read CLOB balance: %w", err)
+	}
+	if held+1e-6 < size {
+		return fmt.Errorf("cannot sell %.4f shares: CLOB balance is %.4f", size, held)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/clob"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+	"limitorderbot/internal/webhook"
+)
+
+const lastMinuteStrategy = "last_minute"
+
+// runLastMinute is the last-minute strategy's own sub-second loop. During the
+// final LastMinuteSeconds before each market starts it follows the books on
+// the CLOB websocket and takes asks that sit LastMinuteEdge below fair value,
+// i.e. quotes that haven't caught up with the other side or the predictor.
+// It reads markets from published state and hands placed orders to the main
+// loop through chainEvents, so the loop keeps sole ownership of its maps.
+func (b *Bot) runLastMinute(ctx context.Context) {
+	feed := clob.NewMarketFeed(b.cfg.ClobWSURL)
+	go feed.Run(ctx)
+
+	window := time.Duration(b.cfg.LastMinuteSeconds) * time.Second
+	tick := time.NewTicker(time.Duration(b.cfg.LastMinuteTickMS) * time.Millisecond)
+	defer tick.Stop()
+	taken := map[string]bool{} // token ids already traded this window
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+		now := b.clock.Now()
+		if b.pauseInfo() != nil || b.strategyPaused(lastMinuteStrategy, now) {
+			continue
+		}
+		var live []models.Market
+		var assets []string
+		for _, m := range b.GetState().ActiveMarkets {
+			if until := m.StartTime().Sub(now); until > 0 && until <= window {
+				live = append(live, m)
+				for _, o := range m.Outcomes {
+					assets = append(assets, o.TokenID)
+				}
+			}
+		}
+		feed.SetAssets(assets)
+		inWindow := map[string]bool{}
+		for _, id := range assets {
+			inWindow[id] = true
+		}
+		for id := range taken {
+			if !inWindow[id] {
+				delete(taken, id)
+			}
+		}
+		for _, m := range live {
+			b.takeStaleQuotes(ctx, feed, m, taken)
+		}
+	}
+}
+
+func (b *Bot) takeStaleQuotes(ctx context.Context, feed *clob.MarketFeed, m models.Market, taken map[string]bool) {
+	yes, no := findYesNoOutcomes(m.Outcomes)
+	if yes == nil || no == nil {
+		return
+	}
+	qYes, ok1 := feed.Quote(yes.TokenID)
+	qNo, ok2 := feed.Quote(no.TokenID)
+	if !ok1 || !ok2 {
+		return
+	}
+	// An old book may be one the websocket stopped updating: its ask is not
+	// evidence of anything still resting there.
+	maxAge := time.Duration(b.cfg.LastMinuteMaxQuoteAgeMS) * time.Millisecond
+	if now := b.clock.Now(); now.Sub(qYes.UpdatedAt) > maxAge || now.Sub(qNo.UpdatedAt) > maxAge {
+		return
+	}
+	// Fair value: the predictor if it has a view, else the complement of the
+	// other outcome's mid.
+	var fairYes, fairNo float64
+	if p, ok := b.predictLastMinute(ctx, m); ok {
+		fairYes, fairNo = p, 1-p
+	} else {
+		if qNo.BestBid > 0 && qNo.BestAsk > 0 {
+			fairYes = 1 - (qNo.BestBid+qNo.BestAsk)/2
+		}
+		if qYes.BestBid > 0 && qYes.BestAsk > 0 {
+			fairNo = 1 - (qYes.BestBid+qYes.BestAsk)/2
+		}
+	}
+	for _, leg := range []struct {
+		outcome models.Outcome
+		quote   clob.Quote
+		fair    float64
+	}{{*yes, qYes, fairYes}, {*no, qNo, fairNo}} {
+		ask := leg.quote.BestAsk
+		if taken[leg.outcome.TokenID] || leg.fair <= 0 || ask <= 0 || ask > leg.fair-b.cfg.LastMinuteEdge {
+			continue
+		}
+		// One shot per outcome per window, whether or not it fills.
+		taken[leg.outcome.TokenID] = true
+		logging.Logger().Printf("Last-minute %s %s: ask %.3f vs fair %.3f, taking\n", m.MarketSlug, leg.outcome.Outcome, ask, leg.fair)
+		rec, err := b.takeAsk(ctx, m, leg.outcome, ask)
+		if err != nil {
+			logging.Logger().Printf("Last-minute order on %s failed: %v\n", m.MarketSlug, err)
+			continue
+		}
+		// A matched FOK is a position: it must reach tracking even if the
+		// queue is full.
+		b.toLoop(ctx, func() {
+			b.activeOrders[rec.ConditionID] = append(b.activeOrders[rec.ConditionID], rec)
+			b.orderHistory[rec.OrderID] = rec
+			_ = b.saveOrders()
+			_ = b.saveOrderHistory()
+		})
+	}
+}
+
+func (b *Bot) predictLastMinute(ctx context.Context, m models.Market) (float64, bool) {
+	if b.predictor == nil {
+		return 0, false
+	}
+	return b.predictor.Predict(ctx, m)
+}
+
+// takeAsk sends a fill-or-kill BUY at ask for ORDER_SIZE_USD, within the
+// wallet balance and the strategy's capital budget.
+func (b *Bot) takeAsk(ctx context.Context, m models.Market, outcome models.Outcome, ask float64) (models.OrderRecord, error) {
+	lot := b.lotSize(ctx, outcome.TokenID)
+	size := calculateShares(ask, b.cfg.OrderSizeUSD, lot)
+	if size <= 0 {
+		return models.OrderRecord{}, fmt.Errorf("no size at %.4f", ask)
+	}
+	size, err := b.directionalSize(m, outcome.Outcome, size)
+	if err != nil {
+		return models.OrderRecord{}, err
+	}
+	size = roundToLot(size, lot)
+	required := ask * size
+	if bal := b.GetState().USDCBalance; bal > 0 && bal < required {
+		return models.OrderRecord{}, fmt.Errorf("%w: $%.2f < $%.2f", errInsufficientBalance, bal, required)
+	}
+	if err := b.reserveCapital(lastMinuteStrategy, required); err != nil {
+		return models.OrderRecord{}, err
+	}
+	return b.takeAskShares(ctx, m, outcome, ask, size, lastMinuteStrategy, models.PhaseLastMinute)
+}
+
+// takeAskShares sends a fill-or-kill BUY of size shares at ask.
+func (b *Bot) takeAskShares(ctx context.Context, m models.Market, outcome models.Outcome, ask, size float64, strategy, phase string) (models.OrderRecord, error) {
+	if err := b.checkOrderMinimums(ctx, outcome.TokenID, ask, size); err != nil {
+		return models.OrderRecord{}, err
+	}
+	signed, _, err := b.clob.CreateOrder(ctx, clob.OrderArgs{
+		TokenID: outcome.TokenID,
+		Price:   ask,
+		Size:    size,
+		Side:    clob.OrderSideBuy,
+	}, nil, nil)
+	if err != nil {
+		return models.OrderRecord{}, err
+	}
+	resp, err := b.clob.PostOrder(ctx, signed, clob.OrderTypeFOK)
+	if err != nil {
+		return models.OrderRecord{}, err
+	}
+	orderID := asString(resp["orderID"])
+	if orderID == "" {
+		orderID = fmt.Sprintf("%d", signed.Salt)
+	}
+	now := b.clock.Now()
+	cost := ask * size
+	rec := models.OrderRecord{
+		OrderID:         orderID,
+		MarketSlug:      m.MarketSlug,
+		ConditionID:     m.ConditionID,
+		TokenID:         outcome.TokenID,
+		Outcome:         outcome.Outcome,
+		Side:            models.OrderSideBuy,
+		Price:           ask,
+		Size:            size,
+		SizeUSD:         cost,
+		Status:          models.OrderStatusPlaced,
+		CreatedAt:       now,
+		Strategy:        &strategy,
+		TransactionType: "BUY",
+		CostUSD:         floatPtr(cost),
+		RevenueUSD:      floatPtr(0),
+		PNLUSD:          floatPtr(-cost),
+		Phase:           phase,
+		RunID:           b.runID,
+	}
+	b.hook(webhook.OrderPlaced, rec)
+	if strings.EqualFold(asString(resp["status"]), "matched") {
+		rec.Status = models.OrderStatusFilled
+		rec.SizeMatched = floatPtr(size)
+		rec.FilledAt = &now
+		rec.FirstFillAt = &now
+		b.orderFilled(models.OrderStatusPlaced, rec)
+	}
+	return rec, nil
+}
+
+// handToLoop queues fn to run on the loop goroutine; it is dropped (and
+// logged) rather than blocking if the queue is full.
+func (b *Bot) handToLoop(fn func()) {
+	select {
+	case b.chainEvents <- fn:
+	default:
+		logging.Logger().Println("WARNING: loop event queue full, dropping update")
+	}
+}
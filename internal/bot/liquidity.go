@@ -10,10 +10,12 @@ import (
 
 	"limitorderbot/internal/clob"
 	"limitorderbot/internal/models"
+	"limitorderbot/internal/webhook"
 )
 
 // placeLiquidityOrders mirrors python OrderManager.place_liquidity_orders:
 // - For each outcome, compute buy at best_bid-spread, sell at best_ask+spread.
+// - LIQUIDITY_PRICING=tick joins or improves the touch instead (tickQuotes).
 // - Size is derived from USD per order: shares = ORDER_SIZE_USD / price.
 // - Prices are clamped to [0.01, 0.99] and rounded to 0.01.
 // - Best-effort orderbook verification marks orders FAILED if not found.
@@ -29,7 +31,13 @@ func (b *Bot) placeLiquidityOrders(ctx context.Context, market models.Market) ([
 	bal, _ := b.chain.USDCBalance(ctx)
 	required := b.cfg.OrderSizeUSD * 2
 	if bal > 0 && bal < required {
-		return nil, fmt.Errorf("insufficient balance: $%.2f < $%.2f", bal, required)
+		return nil, fmt.Errorf("%w: $%.2f < $%.2f", errInsufficientBalance, bal, required)
+	}
+	if err := b.checkCooldown(b.cfg.StrategyName); err != nil {
+		return nil, err
+	}
+	if err := b.checkCapital(b.cfg.StrategyName, required); err != nil {
+		return nil, err
 	}
 
 	// Ensure we have prices.
@@ -51,20 +59,32 @@ func (b *Bot) placeLiquidityOrders(ctx context.Context, market models.Market) ([
 			}
 		}
 
-		buyPrice := adjustPriceToTick(*outcome.BestBid-b.cfg.SpreadOffset, tick)
-		sellPrice := adjustPriceToTick(*outcome.BestAsk+b.cfg.SpreadOffset, tick)
+		var buyPrice, sellPrice float64
+		if b.tickPricing() {
+			q, err := b.topOfBook(ctx, outcome.TokenID)
+			if err != nil || q.BestBid <= 0 || q.BestAsk <= 0 {
+				continue
+			}
+			buyPrice, sellPrice = tickQuotes(q, tick, b.cfg.LiquidityQueueShares)
+		} else {
+			skew := b.predictionSkew(ctx, market, outcome)
+			buyPrice = adjustPriceToTick(*outcome.BestBid-b.cfg.SpreadOffset+skew, tick)
+			sellPrice = adjustPriceToTick(*outcome.BestAsk+b.cfg.SpreadOffset+skew, tick)
+		}
+
+		lot := b.lotSize(ctx, outcome.TokenID)
 
 		// BUY
-		buyShares := calculateShares(buyPrice, b.cfg.OrderSizeUSD)
-		if buyShares > 0 {
+		buyShares := calculateShares(buyPrice, b.cfg.OrderSizeUSD, lot)
+		if buyShares > 0 && !b.hasDuplicateOpenOrder(ctx, outcome.TokenID, models.OrderSideBuy, buyPrice) {
 			o := b.placeSingleOrderBestEffort(ctx, market, outcome, models.OrderSideBuy, buyPrice, buyShares)
 			placed = append(placed, o)
 			time.Sleep(500 * time.Millisecond)
 		}
 
 		// SELL
-		sellShares := calculateShares(sellPrice, b.cfg.OrderSizeUSD)
-		if sellShares > 0 {
+		sellShares := calculateShares(sellPrice, b.cfg.OrderSizeUSD, lot)
+		if sellShares > 0 && !b.hasDuplicateOpenOrder(ctx, outcome.TokenID, models.OrderSideSell, sellPrice) {
 			o := b.placeSingleOrderBestEffort(ctx, market, outcome, models.OrderSideSell, sellPrice, sellShares)
 			placed = append(placed, o)
 			time.Sleep(500 * time.Millisecond)
@@ -77,11 +97,12 @@ func (b *Bot) placeLiquidityOrders(ctx context.Context, market models.Market) ([
 	return b.verifyOrdersInOrderbook(ctx, market, placed), nil
 }
 
-func calculateShares(price float64, usd float64) float64 {
+// calculateShares is how many shares usd buys at price, in whole lots.
+func calculateShares(price float64, usd float64, lot float64) float64 {
 	if price <= 0 {
 		return 0
 	}
-	return math.Round((usd/price)*100) / 100
+	return roundToLot(usd/price, lot)
 }
 
 func adjustPriceToTick(price float64, tick float64) float64 {
@@ -126,7 +147,7 @@ func (b *Bot) placeSingleOrderBestEffort(
 	price float64,
 	size float64,
 ) models.OrderRecord {
-	now := time.Now()
+	now := b.clock.Now()
 	sizeUSD := price * size
 	strategy := b.cfg.StrategyName
 
@@ -146,10 +167,18 @@ func (b *Bot) placeSingleOrderBestEffort(
 		Taker:      "",
 	}
 
+	if err := b.checkOrderMinimums(ctx, outcome.TokenID, price, size); err != nil {
+		return b.failedOrderRecord(market, outcome, side, price, size, sizeUSD, &strategy, now, err.Error())
+	}
+	if side == models.OrderSideSell {
+		if err := b.checkSellBalance(ctx, outcome.TokenID, size); err != nil {
+			return b.failedOrderRecord(market, outcome, side, price, size, sizeUSD, &strategy, now, err.Error())
+		}
+	}
 	signed, _, err := b.clob.CreateOrder(ctx, args, nil, nil)
 	if err != nil {
 		msg := err.Error()
-		return failedOrderRecord(market, outcome, side, price, size, sizeUSD, &strategy, now, msg)
+		return b.failedOrderRecord(market, outcome, side, price, size, sizeUSD, &strategy, now, msg)
 	}
 
 	resp, err := b.clob.PostOrder(ctx, signed, clob.OrderTypeGTC)
@@ -157,7 +186,7 @@ func (b *Bot) placeSingleOrderBestEffort(
 		// Mirror python: if the order was signed, it may still have hit the orderbook.
 		oid := fmt.Sprintf("%d", signed.Salt)
 		msg := fmt.Sprintf("API error (will verify): %v", err)
-		rec := orderRecordForSide(market, outcome, side, oid, price, size, sizeUSD, &strategy, now)
+		rec := b.orderRecordForSide(market, outcome, side, oid, price, size, sizeUSD, &strategy, now)
 		rec.ErrorMessage = &msg
 		// Keep status PLACED for verification step.
 		return rec
@@ -167,10 +196,12 @@ func (b *Bot) placeSingleOrderBestEffort(
 	if orderID == "" {
 		orderID = fmt.Sprintf("%d", signed.Salt)
 	}
-	return orderRecordForSide(market, outcome, side, orderID, price, size, sizeUSD, &strategy, now)
+	rec := b.orderRecordForSide(market, outcome, side, orderID, price, size, sizeUSD, &strategy, now)
+	b.hook(webhook.OrderPlaced, rec)
+	return rec
 }
 
-func orderRecordForSide(
+func (b *Bot) orderRecordForSide(
 	market models.Market,
 	outcome models.Outcome,
 	side models.OrderSide,
@@ -195,6 +226,8 @@ func orderRecordForSide(
 		CreatedAt:       now,
 		Strategy:        strategy,
 		TransactionType: string(side),
+		MidAtPlacement:  outcomeMid(outcome),
+		RunID:           b.runID,
 	}
 	if side == models.OrderSideBuy {
 		cost := sizeUSD
@@ -204,17 +237,14 @@ func orderRecordForSide(
 		rec.PNLUSD = &pnl
 		rec.TransactionType = "BUY"
 	} else {
-		rev := sizeUSD
-		pnl := sizeUSD
-		rec.RevenueUSD = &rev
-		rec.CostUSD = floatPtr(0)
-		rec.PNLUSD = &pnl
+		// Revenue is booked from fills as they arrive (see bookFill).
 		rec.TransactionType = "SELL"
+		bookFill(&rec)
 	}
 	return rec
 }
 
-func failedOrderRecord(
+func (b *Bot) failedOrderRecord(
 	market models.Market,
 	outcome models.Outcome,
 	side models.OrderSide,
@@ -225,7 +255,7 @@ func failedOrderRecord(
 	now time.Time,
 	msg string,
 ) models.OrderRecord {
-	rec := orderRecordForSide(market, outcome, side, "FAILED", price, 0, sizeUSD, strategy, now)
+	rec := b.orderRecordForSide(market, outcome, side, "FAILED", price, 0, sizeUSD, strategy, now)
 	rec.Status = models.OrderStatusFailed
 	rec.ErrorMessage = &msg
 	return rec
@@ -250,6 +280,9 @@ func (b *Bot) verifyOrdersInOrderbook(ctx context.Context, market models.Market,
 		if _, ok := active[o.OrderID]; ok {
 			o.Status = models.OrderStatusPlaced
 			o.ErrorMessage = nil
+		} else if b.confirmOrderPresence(ctx, &o) {
+			// The open-orders listing lagged; the order itself says otherwise.
+			o.ErrorMessage = nil
 		} else {
 			o.Status = models.OrderStatusFailed
 			o.Size = 0
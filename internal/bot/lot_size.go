@@ -0,0 +1,55 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var errOffLot = errors.New("not a multiple of the lot size")
+
+// defaultLotSize is the CLOB's share precision when nothing better is known.
+const defaultLotSize = 0.01
+
+// lotSize returns the share increment for a token: LOT_SIZE when set, else
+// what the CLOB's rounding rules allow for the token's tick size.
+func (b *Bot) lotSize(ctx context.Context, tokenID string) float64 {
+	if b.cfg.LotSize > 0 {
+		return b.cfg.LotSize
+	}
+	if b.clob != nil {
+		if v, err := b.clob.GetLotSize(ctx, tokenID); err == nil && v > 0 {
+			return v
+		}
+	}
+	return defaultLotSize
+}
+
+// pairLotSize is the lot both legs of a paired order can be sized in; lots
+// are powers of ten, so the coarser one is a multiple of the finer.
+func (b *Bot) pairLotSize(ctx context.Context, tokenA, tokenB string) float64 {
+	return math.Max(b.lotSize(ctx, tokenA), b.lotSize(ctx, tokenB))
+}
+
+// roundToLot floors shares to a whole number of lots, so a BUY never spends
+// more than budgeted and a SELL never offers more than is held.
+func roundToLot(shares, lot float64) float64 {
+	if lot <= 0 {
+		lot = defaultLotSize
+	}
+	if shares <= 0 {
+		return 0
+	}
+	return math.Round(math.Floor(shares/lot+1e-9)*lot*1e6) / 1e6
+}
+
+// checkLotSize rejects sizes the CLOB would truncate or refuse for their
+// precision.
+func (b *Bot) checkLotSize(ctx context.Context, tokenID string, size float64) error {
+	lot := b.lotSize(ctx, tokenID)
+	if math.Abs(size-roundToLot(size, lot)) > 1e-9 {
+		return fmt.Errorf("order size %.6f is %w %g", size, errOffLot, lot)
+	}
+	return nil
+}
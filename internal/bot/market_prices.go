@@ -2,13 +2,20 @@ package bot
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"limitorderbot/internal/models"
 )
 
+// pricePersistEvery throttles saving the latest prices with the markets.
+const pricePersistEvery = time.Minute
+
 func (b *Bot) fillMarketPrices(ctx context.Context, markets []models.Market) []models.Market {
 	for i := range markets {
 		m := markets[i]
+		// Fill a copy: the original outcomes may already be published to the dashboard.
+		m.Outcomes = append([]models.Outcome(nil), m.Outcomes...)
 		for j := range m.Outcomes {
 			tok := m.Outcomes[j].TokenID
 			if tok == "" {
@@ -18,6 +25,8 @@ func (b *Bot) fillMarketPrices(ctx context.Context, markets []models.Market) []m
 			if err != nil {
 				continue
 			}
+			at := b.clock.Now()
+			m.Outcomes[j].PriceAt = &at
 			bid := bestBidFromBook(book)
 			ask := bestAskFromBook(book)
 			if bid > 0 {
@@ -35,3 +44,45 @@ func (b *Bot) fillMarketPrices(ctx context.Context, markets []models.Market) []m
 	}
 	return markets
 }
+
+// keepPrices copies freshly read prices onto the tracked markets and saves
+// them now and then, so a restart shows the last-known prices right away.
+func (b *Bot) keepPrices(markets []models.Market, now time.Time) {
+	for _, m := range markets {
+		tracked, ok := b.trackedMarkets[m.ConditionID]
+		if !ok {
+			continue
+		}
+		tracked.Outcomes = carryOutcomePrices(tracked.Outcomes, m.Outcomes)
+		b.trackedMarkets[m.ConditionID] = tracked
+	}
+	if now.Sub(b.lastPriceSave) >= pricePersistEvery {
+		b.lastPriceSave = now
+		_ = b.saveMarkets()
+	}
+}
+
+// carryOutcomePrices returns outs with the prices of the same tokens in from.
+func carryOutcomePrices(outs, from []models.Outcome) []models.Outcome {
+	outs = append([]models.Outcome(nil), outs...)
+	for i := range outs {
+		for _, f := range from {
+			if f.TokenID == outs[i].TokenID && f.PriceAt != nil {
+				outs[i].Price, outs[i].BestBid, outs[i].BestAsk, outs[i].PriceAt = f.Price, f.BestBid, f.BestAsk, f.PriceAt
+			}
+		}
+	}
+	return outs
+}
+
+// seedUpcoming lists the tracked markets that have not ended, with their
+// saved prices, until the first discovery replaces them.
+func (b *Bot) seedUpcoming(now time.Time) {
+	for _, m := range b.trackedMarkets {
+		if !m.IsResolved && m.EndTS-now.Unix() > -300 {
+			b.upcoming = append(b.upcoming, m)
+		}
+	}
+	sort.Slice(b.upcoming, func(i, j int) bool { return b.upcoming[i].StartTS < b.upcoming[j].StartTS })
+	b.publishMarkets()
+}
@@ -0,0 +1,34 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var errBelowMinimum = errors.New("below the CLOB minimum")
+
+// checkOrderMinimums rejects an order the CLOB would refuse for being too
+// small, so the record carries a clear reason instead of the API's opaque
+// rejection. The share minimum is MIN_ORDER_SHARES, or the token's
+// min_order_size from the book when that's 0 (skipped if the book can't be
+// read); the notional minimum is MIN_ORDER_NOTIONAL_USD. The size must also
+// be a whole number of lots (see lotSize).
+func (b *Bot) checkOrderMinimums(ctx context.Context, tokenID string, price, size float64) error {
+	if err := b.checkLotSize(ctx, tokenID, size); err != nil {
+		return err
+	}
+	minShares := b.cfg.MinOrderShares
+	if minShares <= 0 {
+		if v, err := b.clob.GetMinOrderSize(ctx, tokenID); err == nil {
+			minShares = v
+		}
+	}
+	if minShares > 0 && size < minShares-1e-9 {
+		return fmt.Errorf("order %w: %.4f shares < %.4g minimum", errBelowMinimum, size, minShares)
+	}
+	if n := b.cfg.MinOrderNotionalUSD; n > 0 && price*size < n-1e-9 {
+		return fmt.Errorf("order %w: $%.4f notional < $%.2f minimum", errBelowMinimum, price*size, n)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// PauseFile keeps the bot paused across restarts and processes: the panic
+// command writes it and a running bot stops placing orders on its next
+// loop pass. Removing it (Resume, `panic resume`) lifts the pause.
+const PauseFile = "bot_paused.json"
+
+// LoadPause returns the pause recorded in PauseFile, or nil when not paused.
+func LoadPause() (*models.PauseInfo, error) {
+	raw, err := os.ReadFile(PauseFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var p models.PauseInfo
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SavePause records p in PauseFile; nil removes it.
+func SavePause(p *models.PauseInfo) error {
+	if p == nil {
+		if err := os.Remove(PauseFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	bts, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(PauseFile, bts, 0o644)
+}
+
+func (b *Bot) pauseInfo() *models.PauseInfo {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state.Paused
+}
+
+// refreshPause picks up a pause set or lifted by another process, and the
+// kill switch, which takes precedence over PauseFile while engaged.
+func (b *Bot) refreshPause() {
+	p, err := LoadPause()
+	if err != nil {
+		logging.Logger().Printf("Failed to read %s: %v\n", PauseFile, err)
+		return
+	}
+	b.mu.Lock()
+	was := b.state.Paused
+	b.mu.Unlock()
+	if k := b.killSwitch(); k != nil {
+		if was != nil && was.Reason == k.Reason {
+			k.Since = was.Since
+		}
+		p = k
+	}
+	b.mu.Lock()
+	b.state.Paused = p
+	b.mu.Unlock()
+	switch {
+	case p != nil && (was == nil || was.Reason != p.Reason):
+		logging.Logger().Printf("Bot PAUSED (%s); no new orders will be placed\n", p.Reason)
+	case p == nil && was != nil:
+		logging.Logger().Println("Bot resumed")
+	}
+}
+
+// Pause stops new order placement until Resume, surviving restarts.
+func (b *Bot) Pause(reason string) error {
+	p := &models.PauseInfo{Reason: reason, Since: b.clock.Now().UTC()}
+	if err := SavePause(p); err != nil {
+		return err
+	}
+	b.refreshPause()
+	return nil
+}
+
+// Resume lifts a pause.
+func (b *Bot) Resume() error {
+	if err := SavePause(nil); err != nil {
+		return err
+	}
+	b.refreshPause()
+	return nil
+}
+
+// PanicReport is what a panic liquidation did.
+type PanicReport struct {
+	Paused          bool     `json:"paused"`
+	OrdersCancelled bool     `json:"orders_cancelled"`
+	SellsPlaced     int      `json:"sells_placed"`
+	Errors          []string `json:"errors,omitempty"`
+}
+
+// Panic pauses the bot, cancels every open order of the account and
+// market-sells every held position into the best bid, ignoring
+// MIN_SELL_PRICE. It is the emergency exit for a suspected key compromise or
+// an exchange anomaly; each step runs even if an earlier one failed.
+//
+// While the trading loop runs the work is handed to it (it owns the order
+// maps) and Panic waits for the result; otherwise it runs directly.
+func (b *Bot) Panic(ctx context.Context) (PanicReport, error) {
+	if !b.GetState().IsRunning {
+		return b.liquidate(ctx), nil
+	}
+	done := make(chan PanicReport, 1)
+	select {
+	case b.chainEvents <- func() { done <- b.liquidate(ctx) }:
+	case <-ctx.Done():
+		return PanicReport{}, ctx.Err()
+	}
+	select {
+	case rep := <-done:
+		return rep, nil
+	case <-ctx.Done():
+		return PanicReport{}, fmt.Errorf("panic still running on the bot loop: %w", ctx.Err())
+	}
+}
+
+func (b *Bot) liquidate(ctx context.Context) PanicReport {
+	log := logging.Logger()
+	log.Println("PANIC: pausing, cancelling all orders and selling all positions")
+	var rep PanicReport
+	fail := func(step string, err error) {
+		msg := fmt.Sprintf("%s: %v", step, err)
+		rep.Errors = append(rep.Errors, msg)
+		log.Printf("PANIC %s\n", msg)
+	}
+
+	if err := b.Pause("panic"); err != nil {
+		fail("pause", err)
+	} else {
+		rep.Paused = true
+	}
+
+	if err := b.ensureCreds(ctx); err != nil {
+		fail("creds", err)
+	} else if _, err := b.clob.CancelAll(ctx); err != nil {
+		fail("cancel all", err)
+	} else {
+		rep.OrdersCancelled = true
+		for cid, orders := range b.activeOrders {
+			for i := range orders {
+				if orders[i].Status == models.OrderStatusPlaced || orders[i].Status == models.OrderStatusPartiallyFilled {
+					orders[i].Status = models.OrderStatusCancelled
+					b.orderHistory[orders[i].OrderID] = orders[i]
+				}
+			}
+			b.activeOrders[cid] = orders
+		}
+	}
+
+	positions, err := b.fetchWalletPositions(ctx)
+	if err != nil {
+		fail("positions", err)
+	}
+	for _, p := range positions {
+		if p.Redeemable || p.Size <= 0 || p.Asset == "" {
+			continue
+		}
+		market := models.Market{ConditionID: p.ConditionID, MarketSlug: p.Slug}
+		outcome := models.Outcome{TokenID: p.Asset, Outcome: p.Outcome}
+		if err := b.sellAtBid(ctx, market, outcome, p.Size, models.PhasePanic, 0, 0); err != nil {
+			fail(fmt.Sprintf("sell %s %s", p.Slug, p.Outcome), err)
+			continue
+		}
+		rep.SellsPlaced++
+		log.Printf("PANIC sell placed: %.4f %s %s\n", p.Size, p.Slug, p.Outcome)
+	}
+
+	_ = b.saveOrders()
+	_ = b.saveOrderHistory()
+	b.updateOrderLists()
+	log.Printf("PANIC done: %d sell(s) placed, %d error(s)\n", rep.SellsPlaced, len(rep.Errors))
+	return rep
+}
+
+// ensureCreds derives L2 API creds when Start hasn't (CLI use).
+func (b *Bot) ensureCreds(ctx context.Context) error {
+	if b.clob.HasCreds() {
+		return nil
+	}
+	creds, err := b.clob.CreateOrDeriveAPICreds(ctx, 0)
+	if err != nil {
+		return err
+	}
+	b.clob.SetCreds(creds)
+	return nil
+}
@@ -0,0 +1,232 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/config"
+	"limitorderbot/internal/logging"
+)
+
+// ParamsAuditFile records every live parameter change (JSON lines).
+const ParamsAuditFile = "strategy_params_audit.jsonl"
+
+// ErrInvalidParams wraps a parameter update the config checks refuse.
+var ErrInvalidParams = errors.New("invalid strategy parameters")
+
+// StrategyParams are the quoting thresholds, spreads and sizes that may be
+// changed while the bot runs. Changes last until restart: the environment
+// stays the source of truth.
+type StrategyParams struct {
+	OrderSizeUSD         float64 `json:"order_size_usd"`
+	SpreadOffset         float64 `json:"spread_offset"`
+	LiquidityQueueShares float64 `json:"liquidity_queue_shares"`
+	SpreadCaptureMinEdge float64 `json:"spread_capture_min_edge"`
+	LastMinuteEdge       float64 `json:"last_minute_edge"`
+	MaxNetDeltaUSD       float64 `json:"max_net_delta_usd"`
+	MinBookDepthUSD      float64 `json:"min_book_depth_usd"`
+	StaleQuoteTicks      int     `json:"stale_quote_ticks"`
+	MinSellPrice         float64 `json:"min_sell_price"`
+	MarketSellDiscount   float64 `json:"market_sell_discount"`
+	MaxSellSlippage      float64 `json:"max_sell_slippage"`
+
+	Strategies map[string]config.StrategyConfig `json:"strategies"`
+}
+
+// ParamChange is one field a parameter update changed.
+type ParamChange struct {
+	Param string `json:"param"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+type paramsAudit struct {
+	At      time.Time     `json:"at"`
+	Source  string        `json:"source"`
+	Changes []ParamChange `json:"changes"`
+}
+
+func paramsOf(c config.Config) StrategyParams {
+	return StrategyParams{
+		OrderSizeUSD:         c.OrderSizeUSD,
+		SpreadOffset:         c.SpreadOffset,
+		LiquidityQueueShares: c.LiquidityQueueShares,
+		SpreadCaptureMinEdge: c.SpreadCaptureMinEdge,
+		LastMinuteEdge:       c.LastMinuteEdge,
+		MaxNetDeltaUSD:       c.MaxNetDeltaUSD,
+		MinBookDepthUSD:      c.MinBookDepthUSD,
+		StaleQuoteTicks:      c.StaleQuoteTicks,
+		MinSellPrice:         c.MinSellPrice,
+		MarketSellDiscount:   c.MarketSellDiscount,
+		MaxSellSlippage:      c.MaxSellSlippage,
+		Strategies:           maps.Clone(c.Strategies),
+	}
+}
+
+func (p StrategyParams) apply(c *config.Config) {
+	c.OrderSizeUSD = p.OrderSizeUSD
+	c.SpreadOffset = p.SpreadOffset
+	c.LiquidityQueueShares = p.LiquidityQueueShares
+	c.SpreadCaptureMinEdge = p.SpreadCaptureMinEdge
+	c.LastMinuteEdge = p.LastMinuteEdge
+	c.MaxNetDeltaUSD = p.MaxNetDeltaUSD
+	c.MinBookDepthUSD = p.MinBookDepthUSD
+	c.StaleQuoteTicks = p.StaleQuoteTicks
+	c.MinSellPrice = p.MinSellPrice
+	c.MarketSellDiscount = p.MarketSellDiscount
+	c.MaxSellSlippage = p.MaxSellSlippage
+	// Replaced, not edited: other goroutines may hold the old map.
+	c.Strategies = maps.Clone(p.Strategies)
+}
+
+// Merge applies a partial JSON update in the StrategyParams shape: fields
+// left out keep their value, also inside a strategy. Unknown fields and
+// strategies are refused.
+func (p StrategyParams) Merge(body []byte) (StrategyParams, error) {
+	var raw struct {
+		Strategies map[string]json.RawMessage `json:"strategies"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return p, err
+	}
+	strategies := maps.Clone(p.Strategies)
+	p.Strategies = nil
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return p, err
+	}
+	for name, r := range raw.Strategies {
+		s, ok := strategies[name]
+		if !ok {
+			return p, fmt.Errorf("unknown strategy %q", name)
+		}
+		dec := json.NewDecoder(bytes.NewReader(r))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&s); err != nil {
+			return p, fmt.Errorf("strategy %s: %w", name, err)
+		}
+		strategies[name] = s
+	}
+	p.Strategies = strategies
+	return p, nil
+}
+
+// StrategyParams returns the parameters the bot is running with.
+func (b *Bot) StrategyParams() StrategyParams {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	p := b.params
+	p.Strategies = maps.Clone(p.Strategies)
+	return p
+}
+
+// UpdateStrategyParams validates p against the rest of the config and makes
+// it live, recording what changed (and who asked, source) in
+// ParamsAuditFile. While the trading loop runs the change is applied on it,
+// between passes; otherwise directly.
+func (b *Bot) UpdateStrategyParams(ctx context.Context, p StrategyParams, source string) ([]ParamChange, error) {
+	if !b.GetState().IsRunning {
+		return b.applyParams(p, source)
+	}
+	type result struct {
+		changes []ParamChange
+		err     error
+	}
+	done := make(chan result, 1)
+	select {
+	case b.chainEvents <- func() {
+		changes, err := b.applyParams(p, source)
+		done <- result{changes, err}
+	}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case r := <-done:
+		return r.changes, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("parameter update still queued on the bot loop: %w", ctx.Err())
+	}
+}
+
+func (b *Bot) applyParams(p StrategyParams, source string) ([]ParamChange, error) {
+	cfg := b.cfg
+	p.apply(&cfg)
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParams, err)
+	}
+	changes := diffParams(paramsOf(b.cfg), paramsOf(cfg))
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	b.cfg = cfg
+	b.mu.Lock()
+	b.params = paramsOf(cfg)
+	b.mu.Unlock()
+
+	log := logging.Logger()
+	for _, c := range changes {
+		log.Printf("Parameter %s changed from %v to %v by %s\n", c.Param, c.Old, c.New, source)
+	}
+	if err := appendParamsAudit(paramsAudit{At: time.Now(), Source: source, Changes: changes}); err != nil {
+		log.Printf("Parameter audit not written: %v\n", err)
+	}
+	return changes, nil
+}
+
+// diffParams lists the fields that differ, by JSON name; strategy fields are
+// named strategies.<name>.<field>.
+func diffParams(old, cur StrategyParams) []ParamChange {
+	var out []ParamChange
+	diffFields("", reflect.ValueOf(old), reflect.ValueOf(cur), &out)
+	names := make([]string, 0, len(cur.Strategies))
+	for name := range cur.Strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		diffFields("strategies."+name+".", reflect.ValueOf(old.Strategies[name]), reflect.ValueOf(cur.Strategies[name]), &out)
+	}
+	return out
+}
+
+func diffFields(prefix string, old, cur reflect.Value, out *[]ParamChange) {
+	t := cur.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if cur.Field(i).Kind() == reflect.Map {
+			continue
+		}
+		o, n := old.Field(i).Interface(), cur.Field(i).Interface()
+		if o != n {
+			*out = append(*out, ParamChange{Param: prefix + jsonName(t.Field(i)), Old: o, New: n})
+		}
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	if tag := f.Tag.Get("json"); tag != "" && tag != "-" {
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+func appendParamsAudit(e paramsAudit) error {
+	f, err := os.OpenFile(ParamsAuditFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(e)
+}
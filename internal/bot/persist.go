@@ -1,23 +1,76 @@
 package bot
 
 import (
-	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"sort"
 	"time"
 
+	"limitorderbot/internal/cashflows"
+	"limitorderbot/internal/decisions"
+	"limitorderbot/internal/gasspend"
+	"limitorderbot/internal/journal"
 	"limitorderbot/internal/models"
+	"limitorderbot/internal/outcomes"
+	"limitorderbot/internal/rewards"
+	"limitorderbot/internal/runs"
+	"limitorderbot/internal/schema"
+	"limitorderbot/internal/snapshots"
 )
 
+// StateFiles lists every file the bot persists its state in (relative to the
+// working directory, where the bot keeps them), whether or not it exists yet.
+// `state export` archives these.
+func StateFiles() []string {
+	files := []string{
+		"markets_state.json",
+		"bot_orders.json",
+		"order_history.json",
+		"order_history_archive.jsonl",
+		PauseFile,
+		snapshots.DefaultFile,
+		cashflows.DefaultFile,
+		rewards.DefaultFile,
+		gasspend.DefaultFile,
+		outcomes.DefaultFile,
+		decisions.DefaultFile,
+		journal.DefaultFile,
+		runs.DefaultFile,
+		ParamsAuditFile,
+		DustFile,
+	}
+	strategyStates, _ := filepath.Glob(strategyStateFile("*"))
+	return append(files, strategyStates...)
+}
+
+// IsStateFile reports whether name is one of the files StateFiles can list,
+// including a custom strategy's state file that doesn't exist here.
+func IsStateFile(name string) bool {
+	name = filepath.Clean(name)
+	if ok, _ := filepath.Match(strategyStateFile("*"), name); ok {
+		return true
+	}
+	return slices.Contains(StateFiles(), name)
+}
+
 func (b *Bot) saveMarkets() error {
 	out := map[string]any{}
 	for cid, m := range b.trackedMarkets {
 		outs := make([]any, 0, len(m.Outcomes))
 		for _, o := range m.Outcomes {
-			outs = append(outs, map[string]any{
+			om := map[string]any{
 				"token_id": o.TokenID,
 				"outcome":  o.Outcome,
-			})
+			}
+			if o.PriceAt != nil {
+				om["price"] = o.Price
+				om["best_bid"] = o.BestBid
+				om["best_ask"] = o.BestAsk
+				om["price_at"] = o.PriceAt.UTC().Format(time.RFC3339Nano)
+			}
+			outs = append(outs, om)
 		}
 		out[cid] = map[string]any{
 			"condition_id":    m.ConditionID,
@@ -27,10 +80,17 @@ func (b *Bot) saveMarkets() error {
 			"end_timestamp":   m.EndTS,
 			"is_active":       m.IsActive,
 			"is_resolved":     m.IsResolved,
+			"winning_outcome": m.WinningOutcome,
 			"outcomes":        outs,
+
+			"volume":                m.Volume,
+			"liquidity":             m.Liquidity,
+			"tags":                  m.Tags,
+			"resolution_source":     m.ResolutionSource,
+			"uma_resolution_status": m.UMAResolutionStatus,
 		}
 	}
-	bts, err := json.MarshalIndent(out, "", "  ")
+	bts, err := schema.Marshal(out)
 	if err != nil {
 		return err
 	}
@@ -42,10 +102,18 @@ func (b *Bot) loadMarkets() error {
 	if err != nil {
 		return nil
 	}
-	var m map[string]any
-	if err := json.Unmarshal(raw, &m); err != nil {
-		return err
+	data, err := schema.Unmarshal(raw, marketsSteps)
+	if err != nil {
+		return fmt.Errorf("%s: %w", b.marketsFile, err)
+	}
+	priceOf := func(v any) *float64 {
+		if v == nil {
+			return nil
+		}
+		f := asFloat(v)
+		return &f
 	}
+	m, _ := data.(map[string]any)
 	for cid, v := range m {
 		obj, _ := v.(map[string]any)
 		if obj == nil {
@@ -58,12 +126,27 @@ func (b *Bot) loadMarkets() error {
 				if om == nil {
 					continue
 				}
-				outcomes = append(outcomes, models.Outcome{
+				o := models.Outcome{
 					TokenID: asString(om["token_id"]),
 					Outcome: asString(om["outcome"]),
-				})
+				}
+				if at, err := time.Parse(time.RFC3339Nano, asString(om["price_at"])); err == nil {
+					o.PriceAt = &at
+					o.Price, o.BestBid, o.BestAsk = priceOf(om["price"]), priceOf(om["best_bid"]), priceOf(om["best_ask"])
+				}
+				outcomes = append(outcomes, o)
+			}
+		}
+		var tags []string
+		if arr, ok := obj["tags"].([]any); ok {
+			for _, t := range arr {
+				if s, ok := t.(string); ok {
+					tags = append(tags, s)
+				}
 			}
 		}
+		resSource, _ := obj["resolution_source"].(string)
+		umaStatus, _ := obj["uma_resolution_status"].(string)
 		b.trackedMarkets[cid] = models.Market{
 			ConditionID: asString(obj["condition_id"]),
 			MarketSlug:  asString(obj["market_slug"]),
@@ -73,6 +156,14 @@ func (b *Bot) loadMarkets() error {
 			Outcomes:    outcomes,
 			IsActive:    asBool(obj["is_active"]),
 			IsResolved:  asBool(obj["is_resolved"]),
+
+			WinningOutcome: asString(obj["winning_outcome"]),
+
+			Volume:              asFloat(obj["volume"]),
+			Liquidity:           asFloat(obj["liquidity"]),
+			Tags:                tags,
+			ResolutionSource:    resSource,
+			UMAResolutionStatus: umaStatus,
 		}
 	}
 	return nil
@@ -87,7 +178,7 @@ func (b *Bot) saveOrders() error {
 		}
 		out[cid] = arr
 	}
-	bts, err := json.MarshalIndent(out, "", "  ")
+	bts, err := schema.Marshal(out)
 	if err != nil {
 		return err
 	}
@@ -99,10 +190,11 @@ func (b *Bot) loadOrders() error {
 	if err != nil {
 		return nil
 	}
-	var m map[string]any
-	if err := json.Unmarshal(raw, &m); err != nil {
-		return err
+	data, err := schema.Unmarshal(raw, ordersSteps)
+	if err != nil {
+		return fmt.Errorf("%s: %w", b.ordersFile, err)
 	}
+	m, _ := data.(map[string]any)
 	for cid, v := range m {
 		arr, _ := v.([]any)
 		if arr == nil {
@@ -145,7 +237,7 @@ func (b *Bot) saveOrderHistory() error {
 	for _, o := range hist {
 		arr = append(arr, serializeOrder(o))
 	}
-	bts, err := json.MarshalIndent(arr, "", "  ")
+	bts, err := schema.Marshal(arr)
 	if err != nil {
 		return err
 	}
@@ -157,10 +249,11 @@ func (b *Bot) loadOrderHistory() error {
 	if err != nil {
 		return nil
 	}
-	var arr []any
-	if err := json.Unmarshal(raw, &arr); err != nil {
-		return err
+	data, err := schema.Unmarshal(raw, orderHistorySteps)
+	if err != nil {
+		return fmt.Errorf("%s: %w", b.orderHistoryFile, err)
 	}
+	arr, _ := data.([]any)
 	for _, v := range arr {
 		om, _ := v.(map[string]any)
 		if om == nil {
@@ -186,6 +279,10 @@ func serializeOrder(o models.OrderRecord) map[string]any {
 	if o.SizeMatched != nil {
 		sizeMatched = *o.SizeMatched
 	}
+	var firstFillAt any
+	if o.FirstFillAt != nil {
+		firstFillAt = o.FirstFillAt.Format(time.RFC3339Nano)
+	}
 	return map[string]any{
 		"order_id":         o.OrderID,
 		"market_slug":      o.MarketSlug,
@@ -206,6 +303,13 @@ func serializeOrder(o models.OrderRecord) map[string]any {
 		"revenue_usd":      o.RevenueUSD,
 		"cost_usd":         o.CostUSD,
 		"pnl_usd":          o.PNLUSD,
+		"first_fill_at":    firstFillAt,
+		"mid_at_placement": o.MidAtPlacement,
+		"phase":            o.Phase,
+		"fill_price":       o.FillPrice,
+		"run_id":           o.RunID,
+		"gas_usd":          o.GasUSD,
+		"tx_hash":          o.TxHash,
 	}
 }
 
@@ -227,12 +331,25 @@ func parseOrderRecord(m map[string]any) (models.OrderRecord, error) {
 		}
 	}
 
+	var firstFillAt *time.Time
+	if s := asString(m["first_fill_at"]); s != "" && s != "<nil>" {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			firstFillAt = &t
+		}
+	}
+
 	var sizeMatched *float64
 	if v, ok := m["size_matched"]; ok && v != nil {
 		f := asFloat(v)
 		sizeMatched = &f
 	}
 
+	var mid *float64
+	if v, ok := m["mid_at_placement"]; ok && v != nil {
+		f := asFloat(v)
+		mid = &f
+	}
+
 	var errMsg *string
 	if v := m["error_message"]; v != nil {
 		s := asString(v)
@@ -249,6 +366,17 @@ func parseOrderRecord(m map[string]any) (models.OrderRecord, error) {
 		}
 	}
 
+	phase, _ := m["phase"].(string)
+	runID, _ := m["run_id"].(string)
+	txHash, _ := m["tx_hash"].(string)
+	optFloat := func(key string) *float64 {
+		if v, ok := m[key]; ok && v != nil {
+			f := asFloat(v)
+			return &f
+		}
+		return nil
+	}
+
 	rec := models.OrderRecord{
 		OrderID:         asString(m["order_id"]),
 		MarketSlug:      asString(m["market_slug"]),
@@ -266,6 +394,16 @@ func parseOrderRecord(m map[string]any) (models.OrderRecord, error) {
 		ErrorMessage:    errMsg,
 		Strategy:        strategy,
 		TransactionType: asString(m["transaction_type"]),
+		FirstFillAt:     firstFillAt,
+		MidAtPlacement:  mid,
+		Phase:           phase,
+		FillPrice:       optFloat("fill_price"),
+		RunID:           runID,
+		RevenueUSD:      optFloat("revenue_usd"),
+		CostUSD:         optFloat("cost_usd"),
+		PNLUSD:          optFloat("pnl_usd"),
+		GasUSD:          optFloat("gas_usd"),
+		TxHash:          txHash,
 	}
 	return rec, nil
 }
@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/logging"
+)
+
+// PhaseTiming is the wall time of one loop phase.
+type PhaseTiming struct {
+	Name       string  `json:"name"`
+	DurationMS float64 `json:"duration_ms"`
+	Skipped    bool    `json:"skipped,omitempty"`
+}
+
+// PhaseStats aggregates a phase across loops since start.
+type PhaseStats struct {
+	Count   int64   `json:"count"`
+	Skipped int64   `json:"skipped"`
+	TotalMS float64 `json:"total_ms"`
+	MaxMS   float64 `json:"max_ms"`
+	LastMS  float64 `json:"last_ms"`
+}
+
+// LoopMetrics is the instrumentation exported at /api/metrics.
+type LoopMetrics struct {
+	Loops        int64                 `json:"loops"`
+	OverBudget   int64                 `json:"over_budget"`
+	BudgetMS     float64               `json:"budget_ms"`
+	LastStarted  *time.Time            `json:"last_started,omitempty"`
+	LastTotalMS  float64               `json:"last_total_ms"`
+	LastPhases   []PhaseTiming         `json:"last_phases"`
+	PhaseSummary map[string]PhaseStats `json:"phase_summary"`
+}
+
+// loopTimer times the phases of one scheduler tick against a budget.
+type loopTimer struct {
+	start  time.Time
+	budget time.Duration
+	phases []PhaseTiming
+	// quiet ticks (only the fast price/order tasks) are logged only when
+	// over budget.
+	quiet bool
+}
+
+func newLoopTimer(budget time.Duration) *loopTimer {
+	return &loopTimer{start: time.Now(), budget: budget}
+}
+
+// begin starts a phase; call the returned func when it ends.
+func (t *loopTimer) begin(name string) func() {
+	started := time.Now()
+	return func() {
+		t.phases = append(t.phases, PhaseTiming{Name: name, DurationMS: ms(time.Since(started))})
+	}
+}
+
+// overBudget reports whether the loop has used up its budget, in which case
+// lower-priority phases should be skipped.
+func (t *loopTimer) overBudget() bool {
+	return t.budget > 0 && time.Since(t.start) > t.budget
+}
+
+func (t *loopTimer) skip(name string) {
+	t.phases = append(t.phases, PhaseTiming{Name: name, Skipped: true})
+}
+
+func (b *Bot) loopBudget() time.Duration {
+	if b.cfg.LoopBudgetSeconds > 0 {
+		return time.Duration(b.cfg.LoopBudgetSeconds * float64(time.Second))
+	}
+	return 0
+}
+
+// finishLoop logs a one-line phase summary and folds it into the exported metrics.
+func (b *Bot) finishLoop(t *loopTimer) {
+	total := time.Since(t.start)
+	over := t.budget > 0 && total > t.budget
+
+	parts := make([]string, 0, len(t.phases))
+	for _, p := range t.phases {
+		if p.Skipped {
+			parts = append(parts, p.Name+"=skipped")
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s=%.0fms", p.Name, p.DurationMS))
+	}
+	suffix := ""
+	if over {
+		suffix = fmt.Sprintf(" [over budget %.0fms]", ms(t.budget))
+	}
+	if !t.quiet || over {
+		logging.Logger().Printf("Loop timing: total=%.0fms %s%s\n", ms(total), strings.Join(parts, " "), suffix)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m := &b.loopMetrics
+	if m.PhaseSummary == nil {
+		m.PhaseSummary = map[string]PhaseStats{}
+	}
+	m.Loops++
+	if over {
+		m.OverBudget++
+	}
+	m.BudgetMS = ms(t.budget)
+	started := t.start
+	m.LastStarted = &started
+	m.LastTotalMS = ms(total)
+	m.LastPhases = t.phases
+	for _, p := range t.phases {
+		st := m.PhaseSummary[p.Name]
+		if p.Skipped {
+			st.Skipped++
+		} else {
+			st.Count++
+			st.TotalMS += p.DurationMS
+			st.LastMS = p.DurationMS
+			if p.DurationMS > st.MaxMS {
+				st.MaxMS = p.DurationMS
+			}
+		}
+		m.PhaseSummary[p.Name] = st
+	}
+}
+
+// LoopMetrics returns a copy of the loop phase instrumentation.
+func (b *Bot) LoopMetrics() LoopMetrics {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := b.loopMetrics
+	out.LastPhases = append([]PhaseTiming(nil), b.loopMetrics.LastPhases...)
+	out.PhaseSummary = make(map[string]PhaseStats, len(b.loopMetrics.PhaseSummary))
+	for k, v := range b.loopMetrics.PhaseSummary {
+		out.PhaseSummary[k] = v
+	}
+	return out
+}
+
+func ms(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 }
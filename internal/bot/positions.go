@@ -8,25 +8,30 @@ import (
 	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/common"
-
 	"limitorderbot/internal/chain"
 	"limitorderbot/internal/clob"
 	"limitorderbot/internal/logging"
 	"limitorderbot/internal/models"
+	"limitorderbot/internal/webhook"
 )
 
 func (b *Bot) mergePositionsIfPossible(ctx context.Context, market models.Market, orders []models.OrderRecord) float64 {
+	return b.mergeSets(ctx, market, orders, math.Inf(1))
+}
+
+// mergeSets queues a merge bringing the condition's merged sets up to at most
+// limit, as far as the wallet's balances allow, and returns the sets queued.
+func (b *Bot) mergeSets(ctx context.Context, market models.Market, orders []models.OrderRecord, limit float64) float64 {
 	yesToken, noToken := inferYesNoTokenIDs(market, orders)
 	if yesToken == "" || noToken == "" {
 		return 0
 	}
 
-	yesBal, err := b.chain.ERC1155BalanceOf(ctx, common.HexToAddress(chain.CTFAddress), mustBigInt(yesToken))
+	yesBal, err := b.chain.ERC1155BalanceOf(ctx, b.chain.Addresses().CTF, mustBigInt(yesToken))
 	if err != nil {
 		return 0
 	}
-	noBal, err := b.chain.ERC1155BalanceOf(ctx, common.HexToAddress(chain.CTFAddress), mustBigInt(noToken))
+	noBal, err := b.chain.ERC1155BalanceOf(ctx, b.chain.Addresses().CTF, mustBigInt(noToken))
 	if err != nil {
 		return 0
 	}
@@ -36,23 +41,51 @@ func (b *Bot) mergePositionsIfPossible(ctx context.Context, market models.Market
 	if yes <= 0 || no <= 0 {
 		return 0
 	}
-	mergeable := math.Min(yes, no)
+	mergeable := math.Min(math.Min(yes, no), limit)
 	already := b.mergedAmounts[market.ConditionID]
 	mergeAmt := mergeable - already
 	if mergeAmt <= 0.001 {
 		return 0
 	}
+	if mergeAmt < dustMergeSets && b.overGasBudget(b.clock.Now()) {
+		return 0
+	}
 
 	cid, err := chain.ConditionIDFromHex(market.ConditionID)
 	if err != nil {
 		return 0
 	}
-	tx, err := b.chain.MergePositions(ctx, cid, big.NewInt(int64(mergeAmt*1e6)))
-	if err != nil {
-		logging.Logger().Printf("Merge failed: %v\n", err)
+	if b.txWorker.pending("merge", market.ConditionID) {
+		return 0
+	}
+	job := &TxJob{
+		Kind:        "merge",
+		ConditionID: market.ConditionID,
+		MarketSlug:  market.MarketSlug,
+		Amount:      mergeAmt,
+		run:         b.mergeJob(cid, mergeAmt),
+		done: func(job TxJob, err error) {
+			b.notifyTx(job, err)
+			if err != nil {
+				b.recordError(err)
+				b.mergedAmounts[market.ConditionID] = math.Max(0, b.mergedAmounts[market.ConditionID]-job.Amount)
+				b.bookGas(job)
+				return
+			}
+			b.trackMerge(market, job)
+			_ = b.saveOrderHistory()
+			tokens := make([]string, 0, len(market.Outcomes))
+			for _, o := range market.Outcomes {
+				tokens = append(tokens, o.TokenID)
+			}
+			b.syncConditionalAllowances(tokens...)
+		},
+	}
+	if !b.queueTx(job) {
 		return 0
 	}
-	logging.Logger().Printf("Merged %.6f sets for %s (tx=%s)\n", mergeAmt, market.MarketSlug, tx.Hex())
+	logging.Logger().Printf("Queued merge of %.6f sets for %s\n", mergeAmt, market.MarketSlug)
+	// Count queued sets as merged so leftovers/sell sizing and repeat merges see them.
 	b.mergedAmounts[market.ConditionID] = already + mergeAmt
 	return mergeAmt
 }
@@ -61,7 +94,7 @@ func (b *Bot) sellRemainingPositionsIfNeeded(ctx context.Context, market models.
 	if b.positionsSold[market.ConditionID] {
 		return
 	}
-	now := time.Now().Unix()
+	now := b.clock.Now().Unix()
 	if now < (market.EndTS - 60) {
 		return
 	}
@@ -71,8 +104,8 @@ func (b *Bot) sellRemainingPositionsIfNeeded(ctx context.Context, market models.
 		b.positionsSold[market.ConditionID] = true
 		return
 	}
-	yesBal, _ := b.chain.ERC1155BalanceOf(ctx, common.HexToAddress(chain.CTFAddress), mustBigInt(yesToken))
-	noBal, _ := b.chain.ERC1155BalanceOf(ctx, common.HexToAddress(chain.CTFAddress), mustBigInt(noToken))
+	yesBal, _ := b.chain.ERC1155BalanceOf(ctx, b.chain.Addresses().CTF, mustBigInt(yesToken))
+	noBal, _ := b.chain.ERC1155BalanceOf(ctx, b.chain.Addresses().CTF, mustBigInt(noToken))
 	merged := b.mergedAmounts[market.ConditionID]
 
 	remainingYes := math.Max(0, toFloat6(yesBal)-merged)
@@ -84,31 +117,92 @@ func (b *Bot) sellRemainingPositionsIfNeeded(ctx context.Context, market models.
 
 	logging.Logger().Printf("Selling remaining positions for %s (YES=%.4f, NO=%.4f)\n", market.MarketSlug, remainingYes, remainingNo)
 	yesOutcome, noOutcome := findYesNoOutcomes(market.Outcomes)
+	if yesOutcome != nil && remainingYes > 0.01 && b.holdForResolution(ctx, market, *yesOutcome) {
+		remainingYes = 0
+	}
+	if noOutcome != nil && remainingNo > 0.01 && b.holdForResolution(ctx, market, *noOutcome) {
+		remainingNo = 0
+	}
 	if remainingYes > 0.01 && yesOutcome != nil {
-		_ = b.sellPositionMarket(ctx, market, *yesOutcome, remainingYes)
+		_ = b.sellPositionMarket(ctx, market, *yesOutcome, remainingYes, models.PhaseLeftoverSell)
 		time.Sleep(500 * time.Millisecond)
 	}
 	if remainingNo > 0.01 && noOutcome != nil {
-		_ = b.sellPositionMarket(ctx, market, *noOutcome, remainingNo)
+		_ = b.sellPositionMarket(ctx, market, *noOutcome, remainingNo, models.PhaseLeftoverSell)
 	}
 	b.positionsSold[market.ConditionID] = true
 	_ = b.saveOrders()
 	_ = b.saveOrderHistory()
 }
 
-func (b *Bot) sellPositionMarket(ctx context.Context, market models.Market, outcome models.Outcome, size float64) error {
+// holdForResolution reports whether a leftover position should be kept until the
+// market resolves (and auto-redeem claims it) rather than sold before the end.
+// Only positions whose current mid is above the strategy's HoldMinMid are held.
+func (b *Bot) holdForResolution(ctx context.Context, market models.Market, outcome models.Outcome) bool {
+	strat, ok := b.cfg.Strategy()
+	if !ok || !strat.HoldToResolution {
+		return false
+	}
+	book, err := b.clob.GetOrderBook(ctx, outcome.TokenID)
+	if err != nil {
+		return false
+	}
+	bid := bestBidFromBook(book)
+	ask := bestAskFromBook(book)
+	if bid <= 0 || ask <= 0 {
+		return false
+	}
+	mid := (bid + ask) / 2
+	if mid <= strat.HoldMinMid {
+		return false
+	}
+	logging.Logger().Printf("Holding %s %s to resolution (mid=%.3f > %.3f)\n", market.MarketSlug, outcome.Outcome, mid, strat.HoldMinMid)
+	return true
+}
+
+func (b *Bot) sellPositionMarket(ctx context.Context, market models.Market, outcome models.Outcome, size float64, phase string) error {
+	return b.sellAtBid(ctx, market, outcome, size, phase, b.cfg.MinSellPrice, b.cfg.MaxSellSlippage)
+}
+
+// sellAtBid sells size shares MARKET_SELL_DISCOUNT under the best bid, but
+// not below floor and not at all into a bid under it. With maxSlippage set
+// it also stays within maxSlippage of the mid and sells only what the bids
+// inside that band absorb, down to the lowest level it reaches; the rest is
+// kept rather than dumped into a thin bid.
+func (b *Bot) sellAtBid(ctx context.Context, market models.Market, outcome models.Outcome, size float64, phase string, floor, maxSlippage float64) error {
 	// get orderbook bid
 	book, err := b.clob.GetOrderBook(ctx, outcome.TokenID)
 	if err != nil {
 		return err
 	}
-	bestBid := bestBidFromBook(book)
-	if bestBid <= 0 || bestBid < b.cfg.MinSellPrice {
-		return fmt.Errorf("best bid %.4f below MIN_SELL_PRICE %.2f", bestBid, b.cfg.MinSellPrice)
+	q := bookTop(book)
+	bestBid := q.BestBid
+	if bestBid <= 0 || bestBid < floor {
+		return fmt.Errorf("best bid %.4f below minimum sell price %.2f", bestBid, floor)
 	}
 	price := bestBid - b.cfg.MarketSellDiscount
-	if price < b.cfg.MinSellPrice {
-		price = b.cfg.MinSellPrice
+	if price < floor {
+		price = floor
+	}
+	if maxSlippage > 0 {
+		mid := bestBid
+		if q.BestAsk > 0 {
+			mid = (bestBid + q.BestAsk) / 2
+		}
+		if limit := mid - maxSlippage; price < limit {
+			price = limit
+		}
+		if bestBid < price-1e-9 {
+			err := fmt.Errorf("best bid %.4f is more than %.2f below mid %.4f", bestBid, maxSlippage, mid)
+			b.recordRefusedSell(market, outcome, bestBid, size, phase, err)
+			return err
+		}
+		lowest, depth := bidsDownTo(book, price)
+		if depth < size {
+			logging.Logger().Printf("Selling %.4f of %.4f %s %s: bids within %.2f of mid %.4f hold only that much\n", depth, size, market.MarketSlug, outcome.Outcome, maxSlippage, mid)
+			size = depth
+		}
+		price = lowest
 	}
 	// Round to market tick size (best-effort), to avoid CreateOrder tick validation failures.
 	tick := 0.01
@@ -118,6 +212,11 @@ func (b *Bot) sellPositionMarket(ctx context.Context, market models.Market, outc
 		}
 	}
 	price = adjustPriceToTick(price, tick)
+	// Held shares carry fill precision; offer only whole lots.
+	size = roundToLot(size, b.lotSize(ctx, outcome.TokenID))
+	if b.hasDuplicateOpenOrder(ctx, outcome.TokenID, models.OrderSideSell, price) {
+		return nil
+	}
 
 	orderArgs := clob.OrderArgs{
 		TokenID:    outcome.TokenID,
@@ -129,6 +228,14 @@ func (b *Bot) sellPositionMarket(ctx context.Context, market models.Market, outc
 		Expiration: 0,
 		Taker:      "",
 	}
+	if err := b.checkOrderMinimums(ctx, outcome.TokenID, price, size); err != nil {
+		b.recordRefusedSell(market, outcome, price, size, phase, err)
+		return err
+	}
+	if err := b.checkSellBalance(ctx, outcome.TokenID, size); err != nil {
+		b.recordRefusedSell(market, outcome, price, size, phase, err)
+		return err
+	}
 	signed, _, err := b.clob.CreateOrder(ctx, orderArgs, nil, nil)
 	if err != nil {
 		return err
@@ -142,8 +249,6 @@ func (b *Bot) sellPositionMarket(ctx context.Context, market models.Market, outc
 		orderID = fmt.Sprintf("%d", signed.Salt)
 	}
 	sizeUSD := price * size
-	rev := sizeUSD
-	pnl := sizeUSD
 	strategy := b.cfg.StrategyName
 	rec := models.OrderRecord{
 		OrderID:         orderID,
@@ -156,17 +261,47 @@ func (b *Bot) sellPositionMarket(ctx context.Context, market models.Market, outc
 		Size:            size,
 		SizeUSD:         sizeUSD,
 		Status:          models.OrderStatusPlaced,
-		CreatedAt:       time.Now(),
+		CreatedAt:       b.clock.Now(),
 		Strategy:        &strategy,
 		TransactionType: "SELL",
-		RevenueUSD:      &rev,
-		CostUSD:         floatPtr(0),
-		PNLUSD:          &pnl,
+		Phase:           phase,
+		RunID:           b.runID,
 	}
+	bookFill(&rec)
 	b.orderHistory[rec.OrderID] = rec
+	b.hook(webhook.OrderPlaced, rec)
 	return nil
 }
 
+// recordRefusedSell keeps a sell that was never sent in history (under its
+// own key) so the dashboard shows why the position was not sold.
+func (b *Bot) recordRefusedSell(market models.Market, outcome models.Outcome, price, size float64, phase string, err error) {
+	now := b.clock.Now()
+	strategy := b.cfg.StrategyName
+	rec := b.failedOrderRecord(market, outcome, models.OrderSideSell, price, size, price*size, &strategy, now, err.Error())
+	rec.OrderID = fmt.Sprintf("FAILED-%s-%d", outcome.TokenID, now.UnixNano())
+	rec.Phase = phase
+	b.orderHistory[rec.OrderID] = rec
+	logging.Logger().Printf("Not selling %.4f %s %s: %v\n", size, market.MarketSlug, outcome.Outcome, err)
+}
+
+// bidsDownTo sums the bid size resting at limit or better and returns the
+// lowest of those levels, which a sell of that size sweeps down to.
+func bidsDownTo(book map[string]any, limit float64) (lowest, depth float64) {
+	bids, _ := book["bids"].([]any)
+	for _, l := range bids {
+		p, size := bookLevel(l)
+		if size <= 0 || p < limit-1e-9 {
+			continue
+		}
+		depth += size
+		if lowest == 0 || p < lowest {
+			lowest = p
+		}
+	}
+	return lowest, depth
+}
+
 func inferYesNoTokenIDs(market models.Market, orders []models.OrderRecord) (string, string) {
 	var yes, no string
 	for _, o := range orders {
@@ -0,0 +1,28 @@
+package bot
+
+import (
+	"context"
+
+	"limitorderbot/internal/models"
+)
+
+// predictionSkew is the price shift for outcome from the configured predictor:
+// up to +PredictorMaxSkew when it's certain the outcome wins, down to -max when
+// it's certain it loses. Zero without a predictor or when it has no view.
+func (b *Bot) predictionSkew(ctx context.Context, market models.Market, outcome models.Outcome) float64 {
+	if b.predictor == nil || b.cfg.PredictorMaxSkew <= 0 {
+		return 0
+	}
+	yes, _ := findYesNoOutcomes(market.Outcomes)
+	if yes == nil {
+		return 0
+	}
+	p, ok := b.predictor.Predict(ctx, market)
+	if !ok {
+		return 0
+	}
+	if outcome.TokenID != yes.TokenID {
+		p = 1 - p
+	}
+	return (p - 0.5) * 2 * b.cfg.PredictorMaxSkew
+}
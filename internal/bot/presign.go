@@ -0,0 +1,141 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/clob"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// The test strategy quotes both outcomes at a fixed price and size, which
+// is what makes its orders signable ahead of time.
+const (
+	testQuotePrice = 0.49
+	testQuoteSize  = 10.0
+)
+
+type plannedQuote struct {
+	TokenID string
+	Side    string
+	Price   float64
+	Size    float64
+}
+
+func presignKey(tokenID, side string, price, size float64) string {
+	return fmt.Sprintf("%s|%s|%.4f|%.4f", tokenID, side, price, size)
+}
+
+// startPrewarm kicks off cache warming (and pre-signing, if enabled) for
+// the markets just discovered. It runs off the loop and at most one pass at
+// a time; a pass still running when discovery comes round again is not
+// restarted.
+func (b *Bot) startPrewarm(ctx context.Context, upcoming []models.Market, now time.Time) {
+	if !b.cfg.PrewarmOrderCaches || b.clob == nil || !b.prewarming.CompareAndSwap(false, true) {
+		return
+	}
+	planned := b.plannedQuotes(upcoming, now)
+	go func() {
+		defer b.prewarming.Store(false)
+		b.prewarm(ctx, upcoming, planned)
+	}()
+}
+
+// plannedQuotes lists the orders the test strategy will place on markets
+// that enter their placement window before the next discovery or two.
+func (b *Bot) plannedQuotes(upcoming []models.Market, now time.Time) []plannedQuote {
+	if !b.cfg.PresignOrders || !strings.EqualFold(strings.TrimSpace(b.cfg.OrderMode), "test") {
+		return nil
+	}
+	lead := 2 * time.Duration(b.cfg.DiscoveryIntervalSeconds) * time.Second
+	var out []plannedQuote
+	for _, m := range upcoming {
+		if b.ordersPlaced[m.ConditionID] {
+			continue
+		}
+		w := b.cfg.PlacementWindowFor(m.Duration())
+		until := m.TimeUntilStart(now)
+		if until < time.Duration(w.MinMinutes)*time.Minute || until > time.Duration(w.MaxMinutes)*time.Minute+lead {
+			continue
+		}
+		yes, no := findYesNoOutcomes(m.Outcomes)
+		if yes == nil || no == nil {
+			continue
+		}
+		for _, o := range []*models.Outcome{yes, no} {
+			out = append(out, plannedQuote{TokenID: o.TokenID, Side: clob.OrderSideBuy, Price: testQuotePrice, Size: testQuoteSize})
+		}
+	}
+	return out
+}
+
+func (b *Bot) prewarm(ctx context.Context, upcoming []models.Market, planned []plannedQuote) {
+	logger := logging.Logger()
+	current := map[string]bool{}
+	var tokens []string
+	for _, m := range upcoming {
+		for _, o := range m.Outcomes {
+			current[o.TokenID] = true
+			tokens = append(tokens, o.TokenID)
+		}
+	}
+	if err := b.clob.Prewarm(ctx, tokens); err != nil {
+		logger.Printf("Warning: prewarming order caches: %v\n", err)
+	}
+	var stale []string
+	for id := range b.warmTokens {
+		if !current[id] {
+			stale = append(stale, id)
+		}
+	}
+	b.clob.Forget(stale)
+	b.warmTokens = current
+
+	keep := map[string]bool{}
+	signed := 0
+	for _, q := range planned {
+		key := presignKey(q.TokenID, q.Side, q.Price, q.Size)
+		keep[key] = true
+		b.presignMu.Lock()
+		_, have := b.presigned[key]
+		b.presignMu.Unlock()
+		if have {
+			continue
+		}
+		order, _, err := b.clob.CreateOrder(ctx, clob.OrderArgs{TokenID: q.TokenID, Price: q.Price, Size: q.Size, Side: q.Side}, nil, nil)
+		if err != nil {
+			logger.Printf("Warning: pre-signing %s %.2f@%.2f: %v\n", q.Side, q.Size, q.Price, err)
+			continue
+		}
+		b.presignMu.Lock()
+		b.presigned[key] = order
+		b.presignMu.Unlock()
+		signed++
+	}
+	b.presignMu.Lock()
+	for key := range b.presigned {
+		if !keep[key] {
+			delete(b.presigned, key)
+		}
+	}
+	b.presignMu.Unlock()
+	if signed > 0 {
+		logger.Printf("Pre-signed %d planned orders\n", signed)
+	}
+}
+
+// takePresigned hands out (and forgets) a pre-signed order matching the
+// quote, so each signature is posted at most once.
+func (b *Bot) takePresigned(tokenID, side string, price, size float64) (clob.SignedOrderJSON, bool) {
+	key := presignKey(tokenID, side, price, size)
+	b.presignMu.Lock()
+	defer b.presignMu.Unlock()
+	order, ok := b.presigned[key]
+	if ok {
+		delete(b.presigned, key)
+	}
+	return order, ok
+}
@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/logging"
+)
+
+// checkRebalance keeps a proxy-wallet account quoting: orders draw on the
+// funder's collateral, so when that drops below REBALANCE_MIN_PROXY_USD
+// while the EOA holds USDC.e, a transfer topping the funder up to the target
+// is queued on the tx worker.
+func (b *Bot) checkRebalance(ctx context.Context, eoa float64) {
+	if b.cfg.RebalanceMinProxyUSD <= 0 {
+		return
+	}
+	funder := common.HexToAddress(b.cfg.FunderAddress)
+	proxy, err := b.chain.CollateralBalanceOf(ctx, funder)
+	if err != nil {
+		return
+	}
+	b.mu.Lock()
+	b.state.ProxyUSDC = &proxy
+	b.mu.Unlock()
+	if proxy >= b.cfg.RebalanceMinProxyUSD || b.txWorker.pending("rebalance", "") {
+		return
+	}
+	target := b.cfg.RebalanceTargetProxyUSD
+	if target <= 0 {
+		target = 2 * b.cfg.RebalanceMinProxyUSD
+	}
+	amount := math.Min(target-proxy, eoa-b.cfg.RebalanceEOAReserveUSD)
+	if amount < 0.01 {
+		return
+	}
+	amount6 := big.NewInt(int64(amount * 1_000_000))
+	job := &TxJob{
+		Kind:       "rebalance",
+		MarketSlug: "EOA -> proxy",
+		Amount:     amount,
+		run: func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error) {
+			return b.chain.TransferCollateralAsync(ctx, funder, amount6, cb)
+		},
+		done: func(job TxJob, err error) {
+			b.notifyTx(job, err)
+			b.bookGas(job)
+			if err != nil {
+				b.recordError(fmt.Errorf("proxy top-up failed: %w", err))
+				return
+			}
+			logging.Logger().Printf("Moved $%.2f USDC.e from the EOA to the proxy wallet\n", job.Amount)
+		},
+	}
+	if b.queueTx(job) {
+		logging.Logger().Printf("Proxy wallet USDC.e $%.2f is below $%.2f; queued a $%.2f top-up from the EOA\n", proxy, b.cfg.RebalanceMinProxyUSD, amount)
+	}
+}
@@ -0,0 +1,175 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// reconcileGrace skips orders younger than this when looking for ghosts, so
+// an order still propagating through the CLOB isn't flagged.
+const reconcileGrace = 2 * time.Minute
+
+func (b *Bot) reconcileTask(ctx context.Context, lt *loopTimer, now time.Time) {
+	if lt.overBudget() {
+		lt.skip("reconcile")
+		return
+	}
+	done := lt.begin("reconcile")
+	defer done()
+
+	rep := b.reconcile(ctx, now)
+	b.mu.Lock()
+	b.state.Reconciliation = &rep
+	b.mu.Unlock()
+	if len(rep.Issues) > 0 {
+		fixed := 0
+		for _, is := range rep.Issues {
+			if is.Fixed {
+				fixed++
+			}
+		}
+		logging.Logger().Printf("Reconciliation: %d discrepancies (%d fixed)\n", len(rep.Issues), fixed)
+	}
+}
+
+// reconcile compares CLOB open orders, data-api positions and on-chain
+// balances against the bot's maps. With RECONCILE_AUTO_FIX it also corrects
+// what it safely can: ghost orders are re-read and settled once the CLOB
+// reports them done, untracked open orders are adopted and stale
+// positionsSold flags cleared. Untracked positions are only reported.
+func (b *Bot) reconcile(ctx context.Context, now time.Time) models.ReconcileReport {
+	rep := models.ReconcileReport{At: now, Issues: []models.ReconcileIssue{}}
+	fix := b.cfg.ReconcileAutoFix
+	changed := false
+
+	if open, err := b.clob.GetOrders(ctx, nil); err != nil {
+		rep.Errors = append(rep.Errors, "open orders: "+err.Error())
+	} else {
+		openIDs := map[string]bool{}
+		for _, od := range open {
+			if id := asString(od["id"]); id != "" {
+				openIDs[id] = true
+			}
+		}
+		tracked := map[string]bool{}
+		for cid, orders := range b.activeOrders {
+			for i, o := range orders {
+				tracked[o.OrderID] = true
+				live := o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled
+				if !live || openIDs[o.OrderID] || now.Sub(o.CreatedAt) < reconcileGrace {
+					continue
+				}
+				is := models.ReconcileIssue{
+					Kind:        models.ReconcileGhostOrder,
+					ConditionID: cid,
+					MarketSlug:  o.MarketSlug,
+					OrderID:     o.OrderID,
+					Size:        o.Size,
+					Detail:      fmt.Sprintf("%s %s @ %.4f tracked as %s but not open on the CLOB", o.Side, o.Outcome, o.Price, o.Status),
+				}
+				// Only the CLOB's own word that the order is done settles it; a
+				// failed lookup says nothing, so the issue stays reported.
+				if fix {
+					details, err := b.clob.GetOrder(ctx, o.OrderID)
+					switch {
+					case err != nil:
+						is.Detail += "; lookup failed: " + err.Error()
+					case details == nil:
+						is.Detail += "; lookup returned nothing"
+					default:
+						prev := o.Status
+						b.applyOrderDetails(&o, details)
+						b.orderFilled(prev, o)
+						if terminalOrderStatus(asString(details["status"])) && o.Status != models.OrderStatusFilled {
+							o.Status = models.OrderStatusCancelled
+						}
+						is.Fixed = o.Status == models.OrderStatusFilled || o.Status == models.OrderStatusCancelled
+						orders[i] = o
+						b.orderHistory[o.OrderID] = o
+						changed = true
+					}
+				}
+				rep.Issues = append(rep.Issues, is)
+			}
+		}
+		untracked := 0
+		for _, od := range open {
+			id := asString(od["id"])
+			if id == "" || tracked[id] {
+				continue
+			}
+			untracked++
+			rep.Issues = append(rep.Issues, models.ReconcileIssue{
+				Kind:        models.ReconcileUntrackedOrder,
+				ConditionID: asString(od["market"]),
+				MarketSlug:  b.trackedMarkets[asString(od["market"])].MarketSlug,
+				OrderID:     id,
+				Size:        asFloat(od["original_size"]),
+				Detail:      fmt.Sprintf("%s @ %s open on the CLOB but not tracked", asString(od["side"]), asString(od["price"])),
+				Fixed:       fix,
+			})
+		}
+		if fix && untracked > 0 {
+			_ = b.recoverExistingOrders(ctx)
+		}
+	}
+
+	if positions, err := b.fetchWalletPositions(ctx); err != nil {
+		rep.Errors = append(rep.Errors, "positions: "+err.Error())
+	} else {
+		known := map[string]bool{}
+		for cid := range b.activeOrders {
+			known[cid] = true
+		}
+		for _, o := range b.orderHistory {
+			known[o.ConditionID] = true
+		}
+		for _, p := range positions {
+			// Redeemable positions are auto-redeem's business, dust is noise.
+			if p.Redeemable || p.Size <= 0.01 || known[p.ConditionID] {
+				continue
+			}
+			rep.Issues = append(rep.Issues, models.ReconcileIssue{
+				Kind:        models.ReconcileUntrackedPosition,
+				ConditionID: p.ConditionID,
+				MarketSlug:  p.Slug,
+				Size:        p.Size,
+				Detail:      fmt.Sprintf("%.4f %s shares ($%.2f) in the wallet with no orders from the bot", p.Size, p.Outcome, p.CurrentValue),
+			})
+		}
+	}
+
+	// positionsSold ahead of the leftover-sell window means merging and
+	// selling were given up early; if shares are still on chain, retry.
+	for cid, sold := range b.positionsSold {
+		m, ok := b.trackedMarkets[cid]
+		if !sold || !ok || now.Unix() >= m.EndTS-60 {
+			continue
+		}
+		cleared, known := b.walletPositionsCleared(ctx, cid, b.activeOrders[cid])
+		if !known || cleared {
+			continue
+		}
+		is := models.ReconcileIssue{
+			Kind:        models.ReconcileStaleSold,
+			ConditionID: cid,
+			MarketSlug:  m.MarketSlug,
+			Detail:      "marked sold but shares remain on chain",
+		}
+		if fix {
+			delete(b.positionsSold, cid)
+			is.Fixed = true
+		}
+		rep.Issues = append(rep.Issues, is)
+	}
+
+	if changed {
+		_ = b.saveOrders()
+		_ = b.saveOrderHistory()
+	}
+	return rep
+}
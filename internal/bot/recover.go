@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"limitorderbot/internal/logging"
 	"limitorderbot/internal/models"
@@ -91,7 +90,7 @@ func (b *Bot) recoverExistingOrders(ctx context.Context) error {
 			Size:            size,
 			SizeUSD:         price * size,
 			Status:          models.OrderStatusPlaced,
-			CreatedAt:       time.Now(),
+			CreatedAt:       b.clock.Now(),
 			TransactionType: string(side),
 		}
 
@@ -104,6 +103,7 @@ func (b *Bot) recoverExistingOrders(ctx context.Context) error {
 				rec.Status = models.OrderStatusCancelled
 			}
 		}
+		bookFill(&rec)
 
 		b.activeOrders[conditionID] = append(b.activeOrders[conditionID], rec)
 		b.ordersPlaced[conditionID] = true
@@ -4,10 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"limitorderbot/internal/chain"
+	"limitorderbot/internal/logging"
 	"limitorderbot/internal/models"
 )
 
@@ -20,6 +25,13 @@ type polymarketPosition struct {
 	CurPrice     float64 `json:"curPrice"`
 	CurrentValue float64 `json:"currentValue"`
 	Redeemable   bool    `json:"redeemable"`
+	Asset        string  `json:"asset"`
+	OutcomeIndex int     `json:"outcomeIndex"`
+	NegativeRisk bool    `json:"negativeRisk"`
+	// The other outcome of a binary market, which won when this one is
+	// worth nothing at resolution.
+	OppositeOutcome string `json:"oppositeOutcome"`
+	OppositeAsset   string `json:"oppositeAsset"`
 }
 
 func (b *Bot) shouldCheckRedemptions(now time.Time) bool {
@@ -29,25 +41,39 @@ func (b *Bot) shouldCheckRedemptions(now time.Time) bool {
 	return now.Sub(*b.lastRedemptionCheck) >= time.Duration(b.cfg.RedeemCheckIntervalSeconds)*time.Second
 }
 
-func (b *Bot) checkAndRedeemAll(ctx context.Context) (int, error) {
-	// Mirror auto_redeem.py: GET https://data-api.polymarket.com/positions?user=<wallet>
-	wallet := b.chain.Address().Hex()
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://data-api.polymarket.com/positions?user="+wallet, nil)
+// fetchWalletPositions mirrors auto_redeem.py: GET <data-api>/positions?user=<wallet>
+func (b *Bot) fetchWalletPositions(ctx context.Context) ([]polymarketPosition, error) {
+	return b.fetchPositionsFor(ctx, b.chain.Address().Hex())
+}
+
+func (b *Bot) fetchPositionsFor(ctx context.Context, wallet string) ([]polymarketPosition, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(b.cfg.DataAPIURL, "/")+"/positions?user="+wallet, nil)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
-	client := &http.Client{Timeout: 15 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := b.dataHTTP.Do(req)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
-		return 0, fmt.Errorf("positions api status=%d", resp.StatusCode)
+		return nil, fmt.Errorf("positions api status=%d", resp.StatusCode)
 	}
 	var positions []polymarketPosition
 	if err := json.NewDecoder(resp.Body).Decode(&positions); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+func (b *Bot) checkAndRedeemAll(ctx context.Context) (int, error) {
+	if b.overGasBudget(b.clock.Now()) {
+		logging.Logger().Printf("Gas budget of $%.2f reached for today; redemptions wait until tomorrow (UTC)\n", b.cfg.GasDailyBudgetUSD)
+		return 0, nil
+	}
+	positions, err := b.fetchWalletPositions(ctx)
+	if err != nil {
 		return 0, err
 	}
 	if len(positions) == 0 {
@@ -64,17 +90,47 @@ func (b *Bot) checkAndRedeemAll(ctx context.Context) (int, error) {
 		return 0, nil
 	}
 
-	success := 0
+	// Skip dust: a redeem tx costs gas regardless of the amount claimed, so
+	// conditions worth less than MIN_REDEEM_VALUE_USD wait in DustFile and
+	// are only redeemed once they have waited REDEEM_DUST_AFTER_HOURS, when set.
+	now := b.clock.Now()
+	redeemable := map[string]bool{}
+	dust := map[string]dustEntry{}
 	for cid, ps := range by {
-		condBytes, err := chain.ConditionIDFromHex(cid)
-		if err != nil {
+		redeemable[cid] = true
+		value := 0.0
+		for _, p := range ps {
+			value += p.CurrentValue
+		}
+		if value < b.cfg.MinRedeemValueUSD {
+			dust[cid] = dustEntry{Slug: b.slugOf(cid, ps[0].Slug, ps[0].Title), Value: value, SkippedSince: now}
+		}
+	}
+	due := b.dueDust(dust, redeemable, now)
+	skipped := 0
+	skippedValue := 0.0
+	for cid, d := range dust {
+		if due[cid] {
 			continue
 		}
-		tx, err := b.chain.RedeemPositions(ctx, condBytes)
+		skipped++
+		skippedValue += d.Value
+		delete(by, cid)
+	}
+	if skipped > 0 {
+		logging.Logger().Printf("Skipping %d redeemable market(s) below MIN_REDEEM_VALUE_USD=$%.2f (total $%.4f)\n",
+			skipped, b.cfg.MinRedeemValueUSD, skippedValue)
+	}
+	if len(due) > 0 {
+		logging.Logger().Printf("Redeeming %d dust market(s) skipped for over %dh\n", len(due), b.cfg.RedeemDustAfterHours)
+	}
+
+	queued := 0
+	for cid, ps := range by {
+		condBytes, err := chain.ConditionIDFromHex(cid)
 		if err != nil {
 			continue
 		}
-		success++
 
 		amount := 0.0
 		title := ps[0].Title
@@ -84,32 +140,125 @@ func (b *Bot) checkAndRedeemAll(ctx context.Context) (int, error) {
 		for _, p := range ps {
 			amount += p.CurrentValue
 		}
-		// Track redemption in history (best-effort)
-		now := time.Now()
-		rec := models.OrderRecord{
-			OrderID:         fmt.Sprintf("REDEEM-%s-%d", cid[:16], now.Unix()),
-			MarketSlug:      title,
-			ConditionID:     cid,
-			TokenID:         "",
-			Outcome:         "REDEEM",
-			Side:            models.OrderSideSell,
-			Price:           1.0,
-			Size:            amount,
-			SizeUSD:         amount,
-			Status:          models.OrderStatusFilled,
-			CreatedAt:       now,
-			FilledAt:        &now,
-			TransactionType: "REDEEM",
-			RevenueUSD:      floatPtr(amount),
-			CostUSD:         floatPtr(0),
-			PNLUSD:          floatPtr(amount),
-		}
-		_ = tx // tx hash available for logging (omitted from model for 1:1)
-		b.orderHistory[rec.OrderID] = rec
+		run := b.redeemJob(condBytes)
+		if b.isNegRisk(ctx, ps) {
+			amounts, err := b.negRiskAmounts(ctx, ps)
+			if err != nil {
+				logging.Logger().Printf("Skipping neg-risk redeem for %s: %v\n", title, err)
+				continue
+			}
+			run = b.redeemNegRiskJob(condBytes, amounts)
+		}
+		job := &TxJob{
+			Kind:        "redeem",
+			ConditionID: cid,
+			MarketSlug:  b.slugOf(cid, ps[0].Slug, title),
+			Amount:      amount,
+			run:         run,
+			done: func(job TxJob, err error) {
+				b.notifyTx(job, err)
+				if err != nil {
+					b.recordError(err)
+					b.bookGas(job)
+					return
+				}
+				b.trackRedemption(job)
+				_ = b.saveOrderHistory()
+			},
+		}
+		if b.queueTx(job) {
+			queued++
+		}
 	}
+	return queued, nil
+}
+
+// isNegRisk reports whether a condition belongs to a neg-risk market, from the
+// positions API flag or, failing that, the CLOB's neg-risk lookup.
+func (b *Bot) isNegRisk(ctx context.Context, ps []polymarketPosition) bool {
+	for _, p := range ps {
+		if p.NegativeRisk {
+			return true
+		}
+	}
+	for _, p := range ps {
+		if p.Asset == "" {
+			continue
+		}
+		if neg, err := b.clob.GetNegRisk(ctx, p.Asset); err == nil {
+			return neg
+		}
+	}
+	return false
+}
+
+// negRiskAmounts maps a condition's positions onto [YES, NO] token ids by
+// outcome index, computing any the positions lack from the condition id,
+// and reads the balances to redeem.
+func (b *Bot) negRiskAmounts(ctx context.Context, ps []polymarketPosition) ([]*big.Int, error) {
+	var ids [2]*big.Int
+	for _, p := range ps {
+		if p.Asset == "" || p.OutcomeIndex < 0 || p.OutcomeIndex > 1 {
+			continue
+		}
+		ids[p.OutcomeIndex] = mustBigInt(p.Asset)
+	}
+	if (ids[0] == nil || ids[1] == nil) && len(ps) > 0 && strings.HasPrefix(ps[0].ConditionID, "0x") {
+		computed := b.chain.Addresses().OutcomeTokenIDs(common.HexToHash(ps[0].ConditionID), true)
+		for i := range ids {
+			if ids[i] == nil {
+				ids[i] = computed[i]
+			}
+		}
+	}
+	if ids[0] == nil && ids[1] == nil {
+		return nil, fmt.Errorf("positions carry no token ids")
+	}
+	return b.chain.NegRiskAmounts(ctx, ids[0], ids[1])
+}
+
+// slugOf is the market slug of a condition: the position's own, else the
+// one the bot tracked it under, else fallback (e.g. the title) so records
+// stay readable.
+func (b *Bot) slugOf(cid, slug, fallback string) string {
+	if slug != "" {
+		return slug
+	}
+	if m, ok := b.trackedMarkets[cid]; ok && m.MarketSlug != "" {
+		return m.MarketSlug
+	}
+	for _, o := range b.orderHistory {
+		if o.ConditionID == cid && o.MarketSlug != "" && o.TransactionType != "REDEEM" {
+			return o.MarketSlug
+		}
+	}
+	return fallback
+}
 
-	if success > 0 {
-		_ = b.saveOrderHistory()
+func (b *Bot) trackRedemption(job TxJob) {
+	// Track redemption in history (best-effort)
+	now := b.clock.Now()
+	cid, amount, gas := job.ConditionID, job.Amount, b.gasUSD(job)
+	rec := models.OrderRecord{
+		OrderID:         fmt.Sprintf("REDEEM-%s-%d", cid[:16], now.Unix()),
+		MarketSlug:      job.MarketSlug,
+		ConditionID:     cid,
+		TokenID:         "",
+		Outcome:         "REDEEM",
+		Side:            models.OrderSideSell,
+		Price:           1.0,
+		Size:            amount,
+		SizeUSD:         amount,
+		Status:          models.OrderStatusFilled,
+		CreatedAt:       now,
+		FilledAt:        &now,
+		TransactionType: "REDEEM",
+		RevenueUSD:      floatPtr(amount),
+		CostUSD:         floatPtr(gas),
+		PNLUSD:          floatPtr(amount - gas),
+		GasUSD:          gasPtr(gas),
+		RunID:           b.runID,
+		TxHash:          job.TxHash,
 	}
-	return success, nil
+	b.orderHistory[rec.OrderID] = rec
 }
@@ -0,0 +1,205 @@
+package bot
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+	"limitorderbot/internal/outcomes"
+)
+
+const (
+	// resolutionGrace gives the oracle a moment after market end before we ask.
+	resolutionGrace = 2 * time.Minute
+	// resolutionRetry throttles lookups for markets that haven't settled yet.
+	resolutionRetry = time.Minute
+	// resolutionDisputeRetry spaces lookups while the answer is disputed,
+	// which takes days to settle.
+	resolutionDisputeRetry = 30 * time.Minute
+	// resolutionMaxPerLoop bounds the HTTP calls this phase adds to one loop.
+	resolutionMaxPerLoop = 5
+)
+
+// resolveEndedMarkets records the winning outcome of ended markets we traded,
+// on the market record and in the long-lived outcomes file, and settles what
+// we still track in them. The ones still waiting are published with their
+// oracle status.
+func (b *Bot) resolveEndedMarkets(ctx context.Context, now time.Time) {
+	changed := false
+	lookups := 0
+	var pending []models.PendingResolution
+	for cid, m := range b.trackedMarkets {
+		if m.WinningOutcome != "" || now.Before(m.EndTime().Add(resolutionGrace)) {
+			continue
+		}
+		if !b.ordersPlaced[cid] && len(b.activeOrders[cid]) == 0 {
+			continue
+		}
+		if lookups < resolutionMaxPerLoop && b.resolutionDue(m, now) {
+			b.lastResolutionCheck[cid] = now
+			lookups++
+
+			res, status, ok := b.fetchOutcome(ctx, m)
+			if ok {
+				m.WinningOutcome = res.WinningOutcome
+				m.IsResolved = true
+				if status != "" {
+					m.UMAResolutionStatus = status
+				}
+				b.trackedMarkets[cid] = m
+				changed = true
+				if err := outcomes.Record(outcomes.DefaultFile, res); err != nil {
+					logging.Logger().Printf("Failed to record outcome for %s: %v\n", m.MarketSlug, err)
+				}
+				logging.Logger().Printf("Market %s resolved: %s won (via %s)\n", m.MarketSlug, res.WinningOutcome, res.Source)
+				b.recordMarketResult(m, now)
+				b.settleResolved(ctx, m)
+				continue
+			}
+			if status != "" && status != m.UMAResolutionStatus {
+				m.UMAResolutionStatus = status
+				b.trackedMarkets[cid] = m
+				changed = true
+				b.umaStatusChanged(m)
+			}
+		}
+		p := models.PendingResolution{
+			ConditionID: cid,
+			MarketSlug:  m.MarketSlug,
+			EndedAt:     m.EndTime().UTC(),
+			UMAStatus:   m.UMAResolutionStatus,
+		}
+		if last, ok := b.lastResolutionCheck[cid]; ok {
+			p.CheckedAt = &last
+		}
+		pending = append(pending, p)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].EndedAt.Before(pending[j].EndedAt) })
+	b.mu.Lock()
+	b.state.PendingResolutions = pending
+	b.mu.Unlock()
+	if changed {
+		_ = b.saveMarkets()
+		_ = b.saveOrders()
+		_ = b.saveOrderHistory()
+	}
+}
+
+// resolutionDue throttles lookups per market, more so while it is disputed.
+func (b *Bot) resolutionDue(m models.Market, now time.Time) bool {
+	retry := resolutionRetry
+	if strings.EqualFold(m.UMAResolutionStatus, models.UMADisputed) {
+		retry = resolutionDisputeRetry
+	}
+	last, ok := b.lastResolutionCheck[m.ConditionID]
+	return !ok || now.Sub(last) >= retry
+}
+
+// umaUnsettled reports an oracle answer that is still open: proposed and in
+// its challenge window, or disputed and waiting on a vote. Nothing is paid
+// out on-chain before it settles.
+func umaUnsettled(status string) bool {
+	return strings.EqualFold(status, models.UMAProposed) || strings.EqualFold(status, models.UMADisputed)
+}
+
+// umaStatusChanged logs a new oracle status and tells the operator when an
+// answer is disputed, since redemption then waits for days.
+func (b *Bot) umaStatusChanged(m models.Market) {
+	logging.Logger().Printf("Market %s resolution is %s\n", m.MarketSlug, m.UMAResolutionStatus)
+	if !strings.EqualFold(m.UMAResolutionStatus, models.UMADisputed) {
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString("The proposed outcome was disputed and goes to a UMA vote. Redemption waits until it settles.\n")
+	b.writeLinks(&sb, m.MarketSlug, "")
+	b.notify("Resolution disputed for "+m.MarketSlug, sb.String())
+}
+
+// settleResolved finishes a resolved market: its book is closed, so open
+// orders are cancelled and nothing is left to sell, and any shares we hold
+// are now only worth redeeming, which runs on the next loop.
+func (b *Bot) settleResolved(ctx context.Context, m models.Market) {
+	cid := m.ConditionID
+	held := false
+	orders := b.activeOrders[cid]
+	for i := range orders {
+		if orders[i].Status == models.OrderStatusPlaced || orders[i].Status == models.OrderStatusPartiallyFilled {
+			_, _ = b.clob.Cancel(ctx, orders[i].OrderID)
+			orders[i].Status = models.OrderStatusCancelled
+			b.orderHistory[orders[i].OrderID] = orders[i]
+		}
+		if orders[i].Side == models.OrderSideBuy && filledShares(orders[i]) > 0 {
+			held = true
+		}
+	}
+	if len(orders) > 0 {
+		b.activeOrders[cid] = orders
+	}
+	b.positionsSold[cid] = true
+	if held {
+		b.redeemDue = true
+	}
+}
+
+// fetchOutcome asks the market source first and falls back to the CLOB
+// market's token winner flags and the CTF payouts, unless the source's
+// oracle status says the answer is still open. It also returns that status.
+func (b *Bot) fetchOutcome(ctx context.Context, m models.Market) (models.MarketOutcome, string, bool) {
+	out := models.MarketOutcome{
+		ConditionID: m.ConditionID,
+		MarketSlug:  m.MarketSlug,
+		EndTS:       m.EndTS,
+		ResolvedAt:  b.clock.Now(),
+	}
+	res, err := b.source.Resolution(ctx, m)
+	if err == nil && res.Resolved {
+		out.WinningOutcome = res.WinningOutcome
+		out.WinningTokenID = res.WinningTokenID
+		out.Source = b.source.Name()
+		return out, res.Status, true
+	}
+	if umaUnsettled(res.Status) {
+		return out, res.Status, false
+	}
+	if cm, err := b.clob.GetMarket(ctx, m.ConditionID); err == nil {
+		tokens, _ := cm["tokens"].([]any)
+		for _, t := range tokens {
+			tm, _ := t.(map[string]any)
+			if tm == nil || !asBool(tm["winner"]) {
+				continue
+			}
+			out.WinningOutcome = asString(tm["outcome"])
+			out.WinningTokenID = asString(tm["token_id"])
+			out.Source = "clob"
+			return out, res.Status, out.WinningOutcome != ""
+		}
+	}
+	out, ok := b.fetchOnchainOutcome(ctx, m, out)
+	return out, res.Status, ok
+}
+
+// fetchOnchainOutcome reads the CTF payouts, which are set the moment the
+// oracle reports and so lead both APIs. Only a single full winner counts.
+func (b *Bot) fetchOnchainOutcome(ctx context.Context, m models.Market, out models.MarketOutcome) (models.MarketOutcome, bool) {
+	cid, err := chain.ConditionIDFromHex(m.ConditionID)
+	if err != nil || len(m.Outcomes) == 0 {
+		return out, false
+	}
+	den, nums, err := b.chain.Payouts(ctx, cid, len(m.Outcomes))
+	if err != nil || den.Sign() == 0 {
+		return out, false
+	}
+	for i, n := range nums {
+		if n.Cmp(den) == 0 {
+			out.WinningOutcome = m.Outcomes[i].Outcome
+			out.WinningTokenID = m.Outcomes[i].TokenID
+			out.Source = "ctf"
+			return out, true
+		}
+	}
+	return out, false
+}
@@ -0,0 +1,97 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+	"limitorderbot/internal/rewards"
+)
+
+// rewardsFetchEvery throttles the CLOB rewards query; earnings post with a lag.
+const rewardsFetchEvery = time.Hour
+
+// sampleRestingOrders credits each open order's unfilled size with the time
+// since the previous sample, per UTC day and price level, so the liquidity
+// strategy's reward-eligible exposure is visible. Gaps longer than a few loop
+// intervals (bot stopped, loop aborted) are not credited.
+func (b *Bot) sampleRestingOrders(now time.Time) {
+	last := b.lastBookSample
+	b.lastBookSample = now
+	if last.IsZero() {
+		return
+	}
+	dt := now.Sub(last)
+	if maxGap := max(3*time.Duration(b.cfg.OrderStatusIntervalSeconds)*time.Second, 2*time.Minute); dt <= 0 || dt > maxGap {
+		return
+	}
+	day := b.rewardDay(now)
+	changed := false
+	for _, orders := range b.activeOrders {
+		for _, o := range orders {
+			if o.Status != models.OrderStatusPlaced && o.Status != models.OrderStatusPartiallyFilled {
+				continue
+			}
+			remaining := o.Size
+			if o.SizeMatched != nil {
+				remaining -= *o.SizeMatched
+			}
+			if remaining <= 0 {
+				continue
+			}
+			day.AddResting(o.Price, remaining, dt)
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	day.EstimatedUSD = day.SizeSeconds / 3600 * b.cfg.RewardEstimatePerShareHour
+	day.UpdatedAt = now.UTC()
+	b.saveRewards()
+}
+
+// refreshRewards pulls reported earnings for today and yesterday (the latter
+// settles after midnight UTC) at most once per rewardsFetchEvery.
+func (b *Bot) refreshRewards(ctx context.Context, now time.Time) {
+	if !b.lastRewardsFetch.IsZero() && now.Sub(b.lastRewardsFetch) < rewardsFetchEvery {
+		return
+	}
+	b.lastRewardsFetch = now
+	changed := false
+	for _, t := range []time.Time{now.Add(-24 * time.Hour), now} {
+		key := rewards.DateKey(t)
+		earned, err := b.clob.GetUserRewardsTotal(ctx, key)
+		if err != nil {
+			logging.Logger().Printf("Rewards lookup for %s failed: %v\n", key, err)
+			return
+		}
+		if earned <= 0 && b.rewardDays[key] == nil {
+			continue
+		}
+		day := b.rewardDay(t)
+		day.ActualUSD = floatPtr(earned)
+		day.UpdatedAt = now.UTC()
+		changed = true
+	}
+	if changed {
+		b.saveRewards()
+	}
+}
+
+func (b *Bot) rewardDay(t time.Time) *rewards.Day {
+	key := rewards.DateKey(t)
+	d := b.rewardDays[key]
+	if d == nil {
+		d = &rewards.Day{Date: key}
+		b.rewardDays[key] = d
+	}
+	return d
+}
+
+func (b *Bot) saveRewards() {
+	if err := rewards.Save(rewards.DefaultFile, b.rewardDays); err != nil {
+		logging.Logger().Printf("Failed to save rewards: %v\n", err)
+	}
+}
@@ -0,0 +1,77 @@
+package bot
+
+import (
+	"fmt"
+
+	"limitorderbot/internal/models"
+)
+
+// capitalInUse sums the BUY notional a strategy currently has deployed: open or
+// filled buys in markets whose positions haven't been exited yet.
+func (b *Bot) capitalInUse(strategy string) float64 {
+	var used float64
+	for cid, orders := range b.activeOrders {
+		if b.positionsSold[cid] {
+			continue
+		}
+		for _, o := range orders {
+			if o.Side != models.OrderSideBuy || o.Strategy == nil || *o.Strategy != strategy {
+				continue
+			}
+			switch o.Status {
+			case models.OrderStatusPlaced, models.OrderStatusPartiallyFilled, models.OrderStatusFilled:
+				used += o.SizeUSD
+			}
+		}
+	}
+	return used
+}
+
+// checkCapital refuses a placement that would push the strategy past its
+// CapitalBudgetUSD, so one runaway strategy can't consume the whole wallet.
+func (b *Bot) checkCapital(strategy string, required float64) error {
+	s, ok := b.cfg.Strategies[strategy]
+	if !ok || s.CapitalBudgetUSD <= 0 {
+		return nil
+	}
+	used := b.capitalInUse(strategy)
+	if used+required > s.CapitalBudgetUSD {
+		return fmt.Errorf("strategy %s %w: $%.2f in use + $%.2f > $%.2f", strategy, errCapitalBudget, used, required, s.CapitalBudgetUSD)
+	}
+	return nil
+}
+
+// publishCapital snapshots capitalInUse for each budgeted strategy, for
+// reserveCapital off the loop.
+func (b *Bot) publishCapital() {
+	used := map[string]float64{}
+	for name, s := range b.cfg.Strategies {
+		if s.CapitalBudgetUSD > 0 {
+			used[name] = b.capitalInUse(name)
+		}
+	}
+	b.mu.Lock()
+	b.capitalUsed = used
+	b.mu.Unlock()
+}
+
+// reserveCapital is checkCapital for goroutines off the loop, which can't
+// read activeOrders: it checks against the loop's last snapshot and counts
+// required in it, so takes between two snapshots add up too.
+func (b *Bot) reserveCapital(strategy string, required float64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	budget := b.params.Strategies[strategy].CapitalBudgetUSD
+	if budget <= 0 {
+		return nil
+	}
+	used := b.capitalUsed[strategy]
+	if used+required > budget {
+		return fmt.Errorf("strategy %s %w: $%.2f in use + $%.2f > $%.2f", strategy, errCapitalBudget, used, required, budget)
+	}
+	if b.capitalUsed == nil {
+		b.capitalUsed = map[string]float64{}
+	}
+	b.capitalUsed[strategy] = used + required
+	return nil
+}
@@ -0,0 +1,222 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// priceRefreshMarkets bounds the books fetched per price refresh to the
+// nearest markets; the rest only matter once they come closer.
+const priceRefreshMarkets = 10
+
+// task is one piece of the trading loop with its own cadence.
+type task struct {
+	name  string
+	every time.Duration
+	run   func(ctx context.Context, lt *loopTimer, now time.Time)
+	next  time.Time
+	// adaptive tasks follow the event/quiet cadence (see nextRun).
+	adaptive bool
+	// netFailures counts consecutive runs that hit a network error.
+	netFailures int
+}
+
+// newTasks lists the loop's tasks in the order they run when due together.
+func (b *Bot) newTasks() []*task {
+	sec := func(n int) time.Duration { return time.Duration(n) * time.Second }
+	tasks := []*task{
+		{name: "redeem", every: sec(b.cfg.RedeemCheckIntervalSeconds), run: b.redeemTask},
+		{name: "markets", every: sec(b.cfg.DiscoveryIntervalSeconds), run: b.marketsTask, adaptive: true},
+		{name: "prices", every: sec(b.cfg.PriceIntervalSeconds), run: b.pricesTask, adaptive: true},
+		{name: "orders", every: sec(b.cfg.OrderStatusIntervalSeconds), run: b.ordersTask, adaptive: true},
+		{name: "maintenance", every: sec(b.cfg.MaintenanceIntervalSeconds), run: b.maintenanceTask},
+	}
+	if b.cfg.ArbScanIntervalSeconds > 0 {
+		tasks = append(tasks, &task{name: "arbitrage", every: sec(b.cfg.ArbScanIntervalSeconds), run: b.arbitrageTask})
+	}
+	if b.cfg.ReconcileIntervalSeconds > 0 {
+		tasks = append(tasks, &task{name: "reconcile", every: sec(b.cfg.ReconcileIntervalSeconds), run: b.reconcileTask})
+	}
+	return tasks
+}
+
+// Run drives the tasks until ctx is done. Everything runs on this goroutine,
+// which therefore keeps sole ownership of the bot's maps; a slow task only
+// delays the others.
+func (b *Bot) Run(ctx context.Context) {
+	tasks := b.newTasks()
+	for {
+		now := b.clock.Now()
+		if until := b.backoffUntil(); now.Before(until) {
+			if !b.sleepUntil(ctx, until) {
+				return
+			}
+			continue
+		}
+		var due []*task
+		for _, t := range tasks {
+			if !now.Before(t.next) || (t.name == "redeem" && b.redeemDue) {
+				due = append(due, t)
+				t.next = b.nextRun(t, now)
+			}
+		}
+		if len(due) > 0 {
+			b.runTasks(ctx, due, now)
+		}
+
+		wake := tasks[0].next
+		for _, t := range tasks[1:] {
+			if t.next.Before(wake) {
+				wake = t.next
+			}
+		}
+		if !b.sleepUntil(ctx, wake) {
+			return
+		}
+	}
+}
+
+// sleepUntil waits until the bot's clock would read t, reporting false when
+// ctx ends first.
+func (b *Bot) sleepUntil(ctx context.Context, t time.Time) bool {
+	timer := time.NewTimer(t.Sub(b.clock.Now()))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// RunOnce runs every task once, regardless of schedule.
+func (b *Bot) RunOnce(ctx context.Context) {
+	b.runTasks(ctx, b.newTasks(), b.clock.Now())
+}
+
+func (b *Bot) runTasks(ctx context.Context, due []*task, now time.Time) {
+	var timeout time.Duration
+	quiet := true
+	for _, t := range due {
+		timeout = max(timeout, t.every)
+		if t.name != "prices" && t.name != "orders" {
+			quiet = false
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, max(timeout, 10*time.Second))
+	defer cancel()
+
+	lt := newLoopTimer(b.loopBudget())
+	lt.quiet = quiet
+	defer b.finishLoop(lt)
+
+	b.drainChainEvents()
+	b.refreshPause()
+	b.recoverAuth(ctx)
+	b.startPass()
+	for _, t := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		t.run(ctx, lt, now)
+		b.retryOnNetworkError(t, now)
+	}
+	b.endPass(b.clock.Now())
+	b.publishState()
+}
+
+// nextRun schedules t after a run at now. Adaptive tasks run at most every
+// EVENT_INTERVAL_SECONDS within EVENT_WINDOW_SECONDS of a market event, and
+// QUIET_INTERVAL_FACTOR times slower when no order is open and no event is
+// near; a slowed run is still pulled in to the start of the next window.
+func (b *Bot) nextRun(t *task, now time.Time) time.Time {
+	at := now.Add(t.every)
+	if !t.adaptive || b.cfg.EventWindowSeconds <= 0 {
+		return at
+	}
+	window := time.Duration(b.cfg.EventWindowSeconds) * time.Second
+	near := false
+	var upcoming time.Time
+	for _, e := range b.marketEvents() {
+		start := e.Add(-window)
+		if !now.Before(start) && now.Before(e.Add(window)) {
+			near = true
+			break
+		}
+		if start.After(now) && (upcoming.IsZero() || start.Before(upcoming)) {
+			upcoming = start
+		}
+	}
+	cadence := ""
+	switch {
+	case near:
+		cadence = "event"
+		at = now.Add(min(t.every, time.Duration(b.cfg.EventIntervalSeconds)*time.Second))
+	case b.cfg.QuietIntervalFactor > 1 && !b.hasOpenOrders():
+		cadence = "quiet"
+		at = now.Add(time.Duration(float64(t.every) * b.cfg.QuietIntervalFactor))
+	}
+	if !near && !upcoming.IsZero() && upcoming.Before(at) {
+		at = upcoming
+	}
+	if cadence != b.cadence {
+		logging.Logger().Printf("Loop cadence: %s\n", cadenceName(cadence))
+		b.cadence = cadence
+	}
+	return at
+}
+
+// retryOnNetworkError pulls t's next run in after it hit a network error:
+// networkRetryDelay, doubling per consecutive failure, never later than its
+// regular schedule.
+func (b *Bot) retryOnNetworkError(t *task, now time.Time) {
+	if !b.takeNetFailed() {
+		t.netFailures = 0
+		return
+	}
+	t.netFailures++
+	if at := now.Add(networkRetryDelay << min(t.netFailures-1, 6)); at.Before(t.next) {
+		t.next = at
+	}
+}
+
+func cadenceName(c string) string {
+	if c == "" {
+		return "normal"
+	}
+	return c
+}
+
+// marketEvents lists when the markets we see open for placement, start and
+// end.
+func (b *Bot) marketEvents() []time.Time {
+	var out []time.Time
+	add := func(m models.Market) {
+		w := b.cfg.PlacementWindowFor(m.Duration())
+		out = append(out, m.StartTime().Add(-time.Duration(w.MaxMinutes)*time.Minute), m.StartTime(), m.EndTime())
+	}
+	for _, m := range b.upcoming {
+		add(m)
+	}
+	for cid, m := range b.trackedMarkets {
+		if !b.positionsSold[cid] {
+			add(m)
+		}
+	}
+	return out
+}
+
+// hasOpenOrders reports whether any tracked order may still fill.
+func (b *Bot) hasOpenOrders() bool {
+	for _, orders := range b.activeOrders {
+		for _, o := range orders {
+			if o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"limitorderbot/internal/schema"
+)
+
+// Migration steps per state file, indexed by the version they upgrade from.
+var (
+	marketsSteps = []schema.Step{
+		nil, // 0 -> 1: envelope only
+	}
+	ordersSteps = []schema.Step{
+		func(data any) (any, error) { // 0 -> 1
+			m, ok := data.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("expected an object of order lists, got %T", data)
+			}
+			for _, v := range m {
+				arr, _ := v.([]any)
+				migrateOrderRecordsV1(arr)
+			}
+			return m, nil
+		},
+	}
+	orderHistorySteps = []schema.Step{
+		func(data any) (any, error) { // 0 -> 1
+			arr, ok := data.([]any)
+			if !ok {
+				return nil, fmt.Errorf("expected an array of orders, got %T", data)
+			}
+			migrateOrderRecordsV1(arr)
+			return arr, nil
+		},
+	}
+)
+
+// legacyStatuses maps spellings older files (and the python bot) used to the
+// current OrderStatus values.
+var legacyStatuses = map[string]string{
+	"CANCELED": "CANCELLED",
+	"MATCHED":  "FILLED",
+	"LIVE":     "PLACED",
+	"PARTIAL":  "PARTIALLY_FILLED",
+}
+
+// migrateOrderRecordsV1 upper-cases statuses and sides, renames legacy
+// statuses and fills in transaction_type (the side, for plain orders) on
+// records written before it existed.
+func migrateOrderRecordsV1(arr []any) {
+	for _, v := range arr {
+		o, _ := v.(map[string]any)
+		if o == nil {
+			continue
+		}
+		status, _ := o["status"].(string)
+		status = strings.ToUpper(strings.TrimSpace(status))
+		if s, ok := legacyStatuses[status]; ok {
+			status = s
+		}
+		o["status"] = status
+		side, _ := o["side"].(string)
+		side = strings.ToUpper(strings.TrimSpace(side))
+		o["side"] = side
+		if tt, _ := o["transaction_type"].(string); tt == "" && (side == "BUY" || side == "SELL") {
+			o["transaction_type"] = side
+		}
+	}
+}
+
+// DecodeOrderHistory reads order_history.json in any schema version, for
+// readers outside the bot (the dashboard).
+func DecodeOrderHistory(raw []byte) ([]map[string]any, error) {
+	data, err := schema.Unmarshal(raw, orderHistorySteps)
+	if err != nil {
+		return nil, err
+	}
+	arr, _ := data.([]any)
+	out := make([]map[string]any, 0, len(arr))
+	for _, v := range arr {
+		if m, ok := v.(map[string]any); ok {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
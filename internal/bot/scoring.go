@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// scoringCheckEvery throttles the order scoring check; the CLOB only
+// re-evaluates scoring on its rewards sampling cadence anyway.
+const scoringCheckEvery = time.Minute
+
+// scoringBatch caps the order ids sent per /orders-scoring request.
+const scoringBatch = 50
+
+// checkOrderScoring asks the CLOB which resting orders currently earn
+// liquidity rewards and publishes the answer for the dashboard. Orders that
+// stop scoring are logged once; a failed check keeps the last answer.
+func (b *Bot) checkOrderScoring(ctx context.Context, now time.Time) {
+	if b.clob == nil || !b.clob.HasCreds() || now.Sub(b.lastScoringCheck) < scoringCheckEvery {
+		return
+	}
+	b.lastScoringCheck = now
+
+	var ids []string
+	for _, orders := range b.activeOrders {
+		for _, o := range orders {
+			if o.OrderID == "" || o.OrderID == "FAILED" {
+				continue
+			}
+			if o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled {
+				ids = append(ids, o.OrderID)
+			}
+		}
+	}
+
+	b.mu.Lock()
+	prev := b.state.OrderScoring
+	b.mu.Unlock()
+
+	scoring := make(map[string]bool, len(ids))
+	for start := 0; start < len(ids); start += scoringBatch {
+		batch := ids[start:min(start+scoringBatch, len(ids))]
+		res, err := b.clob.AreOrdersScoring(ctx, batch)
+		if err != nil {
+			logging.Logger().Printf("Order scoring check failed: %v\n", err)
+			return
+		}
+		for id, ok := range res {
+			scoring[id] = ok
+			if was, seen := prev[id]; !ok && (!seen || was) {
+				logging.Logger().Printf("Order %s is not scoring for rewards\n", id)
+			}
+		}
+	}
+
+	b.mu.Lock()
+	b.state.OrderScoring = scoring
+	b.mu.Unlock()
+}
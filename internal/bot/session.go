@@ -0,0 +1,28 @@
+package bot
+
+import (
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/runs"
+)
+
+// touchRun refreshes this run's entry in runs.json with its error count and
+// a liveness stamp, so /api/sessions can tell how long it lasted even if the
+// process dies; ended marks a clean stop.
+func (b *Bot) touchRun(now time.Time, ended bool) {
+	b.mu.Lock()
+	errs := b.state.ErrorCount
+	b.mu.Unlock()
+	now = now.UTC()
+	err := runs.Update(runs.DefaultFile, b.runID, func(r *runs.Run) {
+		r.LastSeenAt = &now
+		r.ErrorCount = errs
+		if ended {
+			r.EndedAt = &now
+		}
+	})
+	if err != nil {
+		logging.Logger().Printf("Failed to update run %s: %v\n", b.runID, err)
+	}
+}
@@ -0,0 +1,50 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/snapshots"
+)
+
+// recordBalanceSnapshot samples what the account holds (USDC.e, MATIC and
+// the marked value of its positions) together with the cumulative trading
+// PnL into the history file behind the PnL chart, at most once per
+// BalanceSnapshotMinutes. Equity moving without PnL to match is money moved
+// in or out of the wallet.
+func (b *Bot) recordBalanceSnapshot(ctx context.Context, now time.Time) {
+	every := time.Duration(b.cfg.BalanceSnapshotMinutes) * time.Minute
+	if every <= 0 || (!b.lastBalanceSnapshot.IsZero() && now.Sub(b.lastBalanceSnapshot) < every) {
+		return
+	}
+	b.lastBalanceSnapshot = now
+	b.mu.Lock()
+	st := b.state
+	b.mu.Unlock()
+	pnl := st.TotalPNL
+	snap := snapshots.BalanceSnapshot{
+		Time:         now.UTC(),
+		USDCBalance:  st.USDCBalance,
+		MaticBalance: st.MaticBalance,
+		TradingPNL:   &pnl,
+	}
+	if st.ProxyUSDC != nil {
+		snap.ProxyUSDC = *st.ProxyUSDC
+	}
+	if st.YieldUSDC != nil {
+		snap.YieldUSDC = *st.YieldUSDC
+	}
+	if positions, err := b.fetchWalletPositions(ctx); err != nil {
+		logging.Logger().Printf("Balance snapshot without position value: %v\n", err)
+	} else {
+		value := 0.0
+		for _, p := range positions {
+			value += p.CurrentValue
+		}
+		snap.PositionValueUSD = &value
+	}
+	if err := snapshots.Append(snapshots.DefaultFile, snap); err != nil {
+		logging.Logger().Printf("Failed to record balance snapshot: %v\n", err)
+	}
+}
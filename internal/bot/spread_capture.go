@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// placeSpreadCaptureOrders posts equal-size BUYs on both outcomes at the best
+// bids, lowered symmetrically until the pair costs at most 1 - MinEdge. If
+// both fill, merging the pair returns $1 per share for a locked-in profit.
+func (b *Bot) placeSpreadCaptureOrders(ctx context.Context, market models.Market) ([]models.OrderRecord, error) {
+	market = b.fillMarketPrices(ctx, []models.Market{market})[0]
+	yes, no := findYesNoOutcomes(market.Outcomes)
+	if yes == nil || no == nil {
+		return nil, errors.New("could not find both outcomes (Yes/No or Up/Down)")
+	}
+	if yes.BestBid == nil || no.BestBid == nil {
+		return nil, fmt.Errorf("no bids on %s to quote against", market.MarketSlug)
+	}
+
+	tick := 0.01
+	for _, o := range []*models.Outcome{yes, no} {
+		if ts, err := b.clob.GetTickSize(ctx, o.TokenID); err == nil {
+			if f, ok := parseTickSize(ts); ok && f > tick {
+				tick = f
+			}
+		}
+	}
+	target := 1 - b.cfg.SpreadCaptureMinEdge
+	yesPrice, noPrice := *yes.BestBid, *no.BestBid
+	if excess := yesPrice + noPrice - target; excess > 0 {
+		// Round the cut up to whole ticks so the rounded pair stays under target.
+		cut := math.Ceil(excess/2/tick-1e-9) * tick
+		yesPrice -= cut
+		noPrice -= cut
+	}
+	yesPrice = adjustPriceToTick(yesPrice, tick)
+	noPrice = adjustPriceToTick(noPrice, tick)
+	pair := yesPrice + noPrice
+	if pair > target+1e-9 {
+		return nil, fmt.Errorf("spread capture on %s: %w, pair costs %.4f > %.4f", market.MarketSlug, errNoEdge, pair, target)
+	}
+
+	// Equal shares on both legs so every double fill merges completely.
+	shares := calculateShares(pair, 2*b.cfg.OrderSizeUSD, b.pairLotSize(ctx, yes.TokenID, no.TokenID))
+	required := pair * shares
+	bal, _ := b.chain.USDCBalance(ctx)
+	if bal > 0 && bal < required {
+		return nil, fmt.Errorf("%w: $%.2f < $%.2f", errInsufficientBalance, bal, required)
+	}
+	if err := b.checkCooldown(b.cfg.StrategyName); err != nil {
+		return nil, err
+	}
+	if err := b.checkCapital(b.cfg.StrategyName, required); err != nil {
+		return nil, err
+	}
+
+	logging.Logger().Printf("Spread capture %s: %s@%.3f + %s@%.3f = %.3f x %.2f shares\n",
+		market.MarketSlug, yes.Outcome, yesPrice, no.Outcome, noPrice, pair, shares)
+	legs := []struct {
+		outcome models.Outcome
+		price   float64
+	}{{*yes, yesPrice}, {*no, noPrice}}
+	// The pair is all or nothing: one leg alone is a naked position.
+	for _, leg := range legs {
+		if b.hasDuplicateOpenOrder(ctx, leg.outcome.TokenID, models.OrderSideBuy, leg.price) {
+			return nil, fmt.Errorf("spread capture %s: %s leg already open at %.3f", market.MarketSlug, leg.outcome.Outcome, leg.price)
+		}
+	}
+	var placed []models.OrderRecord
+	for _, leg := range legs {
+		ord, err := b.placeSingleFixed(ctx, market, leg.outcome, leg.price, shares, models.OrderSideBuy)
+		if err != nil {
+			// Without the second leg the first is a naked position: pull it.
+			// A leg the CLOB won't cancel is returned so it stays tracked.
+			var live []models.OrderRecord
+			for _, p := range placed {
+				if cerr := b.cancelOrder(ctx, p.OrderID); cerr != nil {
+					logging.Logger().Printf("Spread capture %s: %v\n", market.MarketSlug, cerr)
+					live = append(live, p)
+					continue
+				}
+				p.Status = models.OrderStatusCancelled
+				b.orderHistory[p.OrderID] = p
+			}
+			return live, fmt.Errorf("spread capture %s leg failed: %w", leg.outcome.Outcome, err)
+		}
+		placed = append(placed, ord)
+		time.Sleep(500 * time.Millisecond)
+	}
+	return placed, nil
+}
+
+// manageSpreadCapture merges a market as soon as both legs have filled, and
+// unwinds it when only one side has filled SpreadCaptureUnwindSeconds after
+// its first fill: the open remainder is cancelled, any matched pairs merged
+// and the unpaired shares sold.
+func (b *Bot) manageSpreadCapture(ctx context.Context, now time.Time) {
+	unwindAfter := time.Duration(b.cfg.SpreadCaptureUnwindSeconds) * time.Second
+	changed := false
+	for cid, orders := range b.activeOrders {
+		market, ok := b.trackedMarkets[cid]
+		if !ok || b.strategyExecuted[cid] || b.positionsSold[cid] {
+			continue
+		}
+		var legs, filled int
+		var firstFill *time.Time
+		for _, o := range orders {
+			if o.Side != models.OrderSideBuy {
+				continue
+			}
+			legs++
+			if o.Status == models.OrderStatusFilled {
+				filled++
+			}
+			if o.FirstFillAt != nil && (firstFill == nil || o.FirstFillAt.Before(*firstFill)) {
+				firstFill = o.FirstFillAt
+			}
+		}
+		if legs < 2 || firstFill == nil {
+			continue
+		}
+
+		if filled == legs {
+			logging.Logger().Printf("Spread capture %s: both legs filled, merging\n", market.MarketSlug)
+			b.mergePositionsIfPossible(ctx, market, orders)
+			b.lastMergeAttempt[cid] = now
+			b.strategyExecuted[cid] = true
+			changed = true
+			continue
+		}
+		if now.Sub(*firstFill) < unwindAfter {
+			continue
+		}
+
+		logging.Logger().Printf("Spread capture %s: only one side filled after %s, unwinding\n", market.MarketSlug, unwindAfter)
+		if !b.cancelForExit(ctx, orders) {
+			b.activeOrders[cid] = orders
+			changed = true
+			continue
+		}
+		b.exitPositions(ctx, market, orders)
+		b.activeOrders[cid] = orders
+		b.strategyExecuted[cid] = true
+		changed = true
+	}
+	if changed {
+		_ = b.saveOrders()
+		_ = b.saveOrderHistory()
+	}
+}
@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// cancelStaleQuotes cancels resting orders the book has moved away from: a
+// BUY more than STALE_QUOTE_TICKS below the best bid, or a SELL that far
+// above the best ask, will not fill and only ties up capital. Only markets
+// whose books were just refreshed are checked. In liquidity mode, whose
+// quotes follow the book, a market left without fills or live orders is
+// unmarked so the placement step quotes it again; fixed-price modes would
+// only re-place the same dead quotes.
+func (b *Bot) cancelStaleQuotes(ctx context.Context, markets []models.Market) {
+	if b.cfg.StaleQuoteTicks <= 0 {
+		return
+	}
+	log := logging.Logger()
+	requote := strings.ToLower(strings.TrimSpace(b.cfg.OrderMode)) == "liquidity"
+	changed := false
+	for _, m := range markets {
+		orders := b.activeOrders[m.ConditionID]
+		if len(orders) == 0 {
+			continue
+		}
+		books := map[string]models.Outcome{}
+		for _, o := range m.Outcomes {
+			books[o.TokenID] = o
+		}
+		cancelled := false
+		for i := range orders {
+			o := &orders[i]
+			if o.Status != models.OrderStatusPlaced && o.Status != models.OrderStatusPartiallyFilled {
+				continue
+			}
+			book, ok := books[o.TokenID]
+			if !ok {
+				continue
+			}
+			ticks, ok := b.ticksBehindBook(ctx, *o, book)
+			if !ok || ticks <= float64(b.cfg.StaleQuoteTicks) {
+				continue
+			}
+			// An unconfirmed cancel (e.g. the order filled meanwhile) leaves
+			// the order as it is for the status refresh to settle.
+			if err := b.cancelOrder(ctx, o.OrderID); err != nil {
+				log.Printf("Stale quote %s not cancelled: %v\n", o.OrderID, err)
+				continue
+			}
+			log.Printf("Cancelled stale %s %s @ %.3f on %s: %.0f ticks behind the book\n", o.Side, o.Outcome, o.Price, m.MarketSlug, ticks)
+			o.Status = models.OrderStatusCancelled
+			b.orderHistory[o.OrderID] = *o
+			cancelled = true
+		}
+		if !cancelled {
+			continue
+		}
+		b.activeOrders[m.ConditionID] = orders
+		changed = true
+		if requote && !anyLiveOrFilled(orders) {
+			delete(b.ordersPlaced, m.ConditionID)
+			// Let the decisions log show the requote, not dedupe it.
+			delete(b.lastDecision, m.ConditionID)
+		}
+	}
+	if changed {
+		_ = b.saveOrders()
+		_ = b.saveOrderHistory()
+	}
+}
+
+// ticksBehindBook is how many ticks o sits behind the best price on its own
+// side of the book; false when that side is empty.
+func (b *Bot) ticksBehindBook(ctx context.Context, o models.OrderRecord, book models.Outcome) (float64, bool) {
+	tick := 0.01
+	if ts, err := b.clob.GetTickSize(ctx, o.TokenID); err == nil {
+		if f, ok := parseTickSize(ts); ok && f > 0 {
+			tick = f
+		}
+	}
+	var gap float64
+	switch {
+	case o.Side == models.OrderSideBuy && book.BestBid != nil && *book.BestBid > 0:
+		gap = *book.BestBid - o.Price
+	case o.Side == models.OrderSideSell && book.BestAsk != nil && *book.BestAsk > 0:
+		gap = o.Price - *book.BestAsk
+	default:
+		return 0, false
+	}
+	return math.Round(gap/tick*1e6) / 1e6, true
+}
+
+func anyLiveOrFilled(orders []models.OrderRecord) bool {
+	for _, o := range orders {
+		switch {
+		case o.Status == models.OrderStatusPlaced, o.Status == models.OrderStatusPartiallyFilled, o.Status == models.OrderStatusFilled:
+			return true
+		case o.SizeMatched != nil && *o.SizeMatched > 0:
+			return true
+		}
+	}
+	return false
+}
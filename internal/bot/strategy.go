@@ -3,12 +3,11 @@ package bot
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/common"
-
-	"limitorderbot/internal/chain"
+	"limitorderbot/internal/config"
 	"limitorderbot/internal/logging"
 	"limitorderbot/internal/models"
 )
@@ -40,37 +39,39 @@ func (b *Bot) checkStrategyExecution(ctx context.Context, now time.Time) {
 			continue
 		}
 
-		// Wait until market started
-		if now.Unix() < market.StartTS {
-			continue
-		}
+		// Wait until market started, unless the fills or a custom strategy say
+		// to exit now
+		fills := orderFills(orders)
 		sinceStart := now.Sub(market.StartTime())
-		if sinceStart < time.Duration(strat.ExitTimeoutSeconds)*time.Second {
-			continue
+		timeout := exitTimeout(strat, market, fills)
+		if now.Unix() < market.StartTS || sinceStart < timeout {
+			switch {
+			case b.mergedOut(strat, market, fills):
+				logging.Logger().Printf("Strategy '%s' exiting %s early: both sides filled and merged (%.2f sets)\n",
+					b.cfg.StrategyName, market.MarketSlug, math.Min(fills.yes, fills.no))
+			case b.customExit(ctx, market, orders):
+				logging.Logger().Printf("Strategy '%s' asked to exit %s\n", b.cfg.StrategyName, market.MarketSlug)
+			default:
+				continue
+			}
+		} else {
+			logging.Logger().Printf("Strategy '%s' timeout reached for %s (sinceStart=%ds, timeout=%ds, filled=%.0f%%)\n",
+				b.cfg.StrategyName, market.MarketSlug, int(sinceStart.Seconds()), int(timeout.Seconds()), fills.ratio()*100)
 		}
 
-		logging.Logger().Printf("Strategy '%s' timeout reached for %s (sinceStart=%ds, timeout=%ds)\n",
-			b.cfg.StrategyName, market.MarketSlug, int(sinceStart.Seconds()), strat.ExitTimeoutSeconds)
-
-		// Step 1: cancel unfilled
-		if strat.CancelUnfilled {
-			for i := range orders {
-				if orders[i].Status == models.OrderStatusPlaced || orders[i].Status == models.OrderStatusPartiallyFilled {
-					_, _ = b.clob.Cancel(ctx, orders[i].OrderID)
-					orders[i].Status = models.OrderStatusCancelled
-					b.orderHistory[orders[i].OrderID] = orders[i]
-				}
-			}
+		// Step 1: cancel unfilled; an order the CLOB hasn't let go of yet
+		// holds the exit back to the next pass
+		if strat.CancelUnfilled && !b.cancelForExit(ctx, orders) {
+			b.activeOrders[cid] = orders
+			_ = b.saveOrders()
+			_ = b.saveOrderHistory()
+			continue
 		}
 
-		// Step 2: merge, then sell leftovers immediately (not waiting for market end)
+		// Step 2: merge the matched pairs, then sell the excess immediately (not
+		// waiting for market end)
 		if strat.MarketSellFilled {
-			merged := b.mergePositionsIfPossible(ctx, market, orders)
-			if merged > 0 {
-				b.trackMerge(market, merged)
-			}
-			// Force sell leftovers now
-			b.sellLeftoversNow(ctx, market, orders)
+			b.exitPositions(ctx, market, orders)
 		}
 
 		b.activeOrders[cid] = orders
@@ -80,33 +81,162 @@ func (b *Bot) checkStrategyExecution(ctx context.Context, now time.Time) {
 	}
 }
 
-func (b *Bot) sellLeftoversNow(ctx context.Context, market models.Market, orders []models.OrderRecord) {
+// fillState is how far a market's BUY orders have filled, per side.
+type fillState struct {
+	yes, no float64 // filled shares
+	ordered float64 // shares ordered on both sides
+	open    bool    // some order can still fill
+}
+
+func (f fillState) ratio() float64 {
+	if f.ordered <= 0 {
+		return 0
+	}
+	return (f.yes + f.no) / f.ordered
+}
+
+func orderFills(orders []models.OrderRecord) fillState {
+	var f fillState
+	for _, o := range orders {
+		if o.Side != models.OrderSideBuy {
+			continue
+		}
+		if o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled {
+			f.open = true
+		}
+		f.ordered += o.Size
+		switch strings.ToUpper(strings.TrimSpace(o.Outcome)) {
+		case "YES", "UP":
+			f.yes += filledShares(o)
+		case "NO", "DOWN":
+			f.no += filledShares(o)
+		}
+	}
+	return f
+}
+
+// exitTimeout is how long after start the strategy holds a market: its
+// ExitTimeoutSeconds, plus ExtendSeconds once the fill ratio reaches
+// ExtendFillRatio. An extension never runs into the final minute, which
+// belongs to the leftover sell.
+func exitTimeout(strat config.StrategyConfig, market models.Market, f fillState) time.Duration {
+	timeout := time.Duration(strat.ExitTimeoutSeconds) * time.Second
+	if strat.ExtendFillRatio <= 0 || strat.ExtendSeconds <= 0 || f.ratio() < strat.ExtendFillRatio {
+		return timeout
+	}
+	latest := market.EndTime().Add(-time.Minute).Sub(market.StartTime())
+	return max(timeout, min(timeout+time.Duration(strat.ExtendSeconds)*time.Second, latest))
+}
+
+// mergedOut reports whether ExitWhenMerged applies: no order can fill any
+// more, both sides filled, and every matched pair is merged or queued.
+func (b *Bot) mergedOut(strat config.StrategyConfig, market models.Market, f fillState) bool {
+	if !strat.ExitWhenMerged || f.open || f.yes <= 0 || f.no <= 0 {
+		return false
+	}
+	if b.txWorker.pending("merge", market.ConditionID) {
+		return false
+	}
+	return b.mergedAmounts[market.ConditionID] >= math.Min(f.yes, f.no)-0.01
+}
+
+// cancelForExit cancels the orders that can still fill and re-reads each
+// one's matched size, so a fill that landed before the cancel is counted.
+// A cancelled order keeps its partial fill in SizeMatched. An order counts
+// as cancelled only once the CLOB confirms the cancel or reports it done;
+// otherwise it stays open and cancelForExit reports false, so the exit is
+// retried next pass instead of sizing from fills that may still change.
+func (b *Bot) cancelForExit(ctx context.Context, orders []models.OrderRecord) bool {
+	settled := true
+	for i := range orders {
+		o := &orders[i]
+		if o.Status != models.OrderStatusPlaced && o.Status != models.OrderStatusPartiallyFilled {
+			continue
+		}
+		cerr := b.cancelOrder(ctx, o.OrderID)
+		prev := o.Status
+		done := false
+		if details, err := b.clob.GetOrder(ctx, o.OrderID); err == nil && details != nil {
+			b.applyOrderDetails(o, details)
+			b.orderFilled(prev, *o)
+			done = o.Status == models.OrderStatusFilled || terminalOrderStatus(asString(details["status"]))
+		}
+		switch {
+		case o.Status == models.OrderStatusFilled:
+		case cerr == nil || done:
+			o.Status = models.OrderStatusCancelled
+		default:
+			logging.Logger().Printf("Exit: %v; retrying next pass\n", cerr)
+			settled = false
+		}
+		b.orderHistory[o.OrderID] = *o
+	}
+	return settled
+}
+
+// terminalOrderStatus reports whether a CLOB order status means the order
+// can no longer fill.
+func terminalOrderStatus(status string) bool {
+	switch strings.ToUpper(status) {
+	case "MATCHED", "CANCELED", "CANCELLED":
+		return true
+	}
+	return false
+}
+
+// exitPositions unwinds what a market's orders bought: the pairs matched
+// across both sides are merged (queued on the tx worker; history is recorded
+// once it confirms) and only each side's excess over them is sold. Shares
+// the wallet holds beyond these orders' fills are left alone.
+func (b *Bot) exitPositions(ctx context.Context, market models.Market, orders []models.OrderRecord) {
+	f := orderFills(orders)
+	if f.ordered <= 0 {
+		// Nothing to size from; fall back to the wallet balances.
+		b.mergePositionsIfPossible(ctx, market, orders)
+		b.sellLeftoversNow(ctx, market, orders, math.Inf(1), math.Inf(1))
+		return
+	}
+	pairs := math.Min(f.yes, f.no)
+	logging.Logger().Printf("Exit %s: matched YES=%.4f NO=%.4f, merging %.4f sets, excess YES=%.4f NO=%.4f\n",
+		market.MarketSlug, f.yes, f.no, pairs, f.yes-pairs, f.no-pairs)
+	b.mergeSets(ctx, market, orders, pairs)
+	b.sellLeftoversNow(ctx, market, orders, f.yes-pairs, f.no-pairs)
+}
+
+// sellLeftoversNow sells up to maxYes/maxNo of each side's unmerged balance
+// at market, without waiting for market end.
+func (b *Bot) sellLeftoversNow(ctx context.Context, market models.Market, orders []models.OrderRecord, maxYes, maxNo float64) {
 	yesToken, noToken := inferYesNoTokenIDs(market, orders)
 	if yesToken == "" || noToken == "" {
 		return
 	}
-	ctf := common.HexToAddress(chain.CTFAddress)
+	ctf := b.chain.Addresses().CTF
 	yesBal, _ := b.chain.ERC1155BalanceOf(ctx, ctf, mustBigInt(yesToken))
 	noBal, _ := b.chain.ERC1155BalanceOf(ctx, ctf, mustBigInt(noToken))
-	_ = yesBal
-	_ = noBal
 	// Reuse existing sell logic but bypass end-time check by calling sellPositionMarket directly.
 	yesOutcome, noOutcome := findYesNoOutcomes(market.Outcomes)
 	merged := b.mergedAmounts[market.ConditionID]
-	remainingYes := toFloat6(yesBal) - merged
-	remainingNo := toFloat6(noBal) - merged
+	remainingYes := math.Min(toFloat6(yesBal)-merged, maxYes)
+	remainingNo := math.Min(toFloat6(noBal)-merged, maxNo)
+	if yesOutcome != nil && remainingYes > 0.01 && b.holdForResolution(ctx, market, *yesOutcome) {
+		remainingYes = 0
+	}
+	if noOutcome != nil && remainingNo > 0.01 && b.holdForResolution(ctx, market, *noOutcome) {
+		remainingNo = 0
+	}
 	if yesOutcome != nil && remainingYes > 0.01 {
-		_ = b.sellPositionMarket(ctx, market, *yesOutcome, remainingYes)
+		_ = b.sellPositionMarket(ctx, market, *yesOutcome, remainingYes, models.PhaseExit)
 		time.Sleep(500 * time.Millisecond)
 	}
 	if noOutcome != nil && remainingNo > 0.01 {
-		_ = b.sellPositionMarket(ctx, market, *noOutcome, remainingNo)
+		_ = b.sellPositionMarket(ctx, market, *noOutcome, remainingNo, models.PhaseExit)
 	}
 	b.positionsSold[market.ConditionID] = true
 }
 
-func (b *Bot) trackMerge(market models.Market, merged float64) {
-	now := time.Now()
+func (b *Bot) trackMerge(market models.Market, job TxJob) {
+	now := b.clock.Now()
+	merged, gas := job.Amount, b.gasUSD(job)
 	rev := merged
 	rec := models.OrderRecord{
 		OrderID:         fmt.Sprintf("MERGE-%s-%d", market.ConditionID[:16], now.Unix()),
@@ -123,8 +253,11 @@ func (b *Bot) trackMerge(market models.Market, merged float64) {
 		FilledAt:        &now,
 		TransactionType: "MERGE",
 		RevenueUSD:      &rev,
-		CostUSD:         floatPtr(0),
-		PNLUSD:          &rev,
+		CostUSD:         floatPtr(gas),
+		PNLUSD:          floatPtr(rev - gas),
+		GasUSD:          gasPtr(gas),
+		RunID:           b.runID,
+		TxHash:          job.TxHash,
 	}
 	b.orderHistory[rec.OrderID] = rec
 }
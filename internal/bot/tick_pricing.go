@@ -0,0 +1,66 @@
+package bot
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"limitorderbot/internal/clob"
+	"limitorderbot/internal/models"
+)
+
+// bookFeedMaxAge is how old a websocket book may be before the REST book is
+// used instead (a dropped connection leaves the last books in place).
+const bookFeedMaxAge = 30 * time.Second
+
+// tickPricing reports whether the liquidity strategy joins/improves the
+// touch (LIQUIDITY_PRICING=tick) instead of offsetting by SpreadOffset.
+func (b *Bot) tickPricing() bool {
+	return b.cfg.LiquidityPricing == "tick"
+}
+
+// syncBookFeed points the websocket book feed at the markets the price task
+// follows.
+func (b *Bot) syncBookFeed(markets []models.Market) {
+	if b.bookFeed == nil {
+		return
+	}
+	var assets []string
+	for _, m := range markets {
+		for _, o := range m.Outcomes {
+			assets = append(assets, o.TokenID)
+		}
+	}
+	b.bookFeed.SetAssets(assets)
+}
+
+// topOfBook returns the best prices and the size queued at each, from the
+// websocket feed when it has a recent book, else from the REST book.
+func (b *Bot) topOfBook(ctx context.Context, tokenID string) (clob.Quote, error) {
+	if b.bookFeed != nil {
+		if q, ok := b.bookFeed.Quote(tokenID); ok && time.Since(q.UpdatedAt) < bookFeedMaxAge && q.BestBid > 0 && q.BestAsk > 0 {
+			return q, nil
+		}
+	}
+	book, err := b.clob.GetOrderBook(ctx, tokenID)
+	if err != nil {
+		return clob.Quote{}, err
+	}
+	q := bookTop(book)
+	q.UpdatedAt = time.Now()
+	return q, nil
+}
+
+// tickQuotes joins the best bid and ask, stepping one tick inside when at
+// least queue shares already wait at that price and the spread has room.
+// Both sides never improve into each other.
+func tickQuotes(q clob.Quote, tick, queue float64) (buy, sell float64) {
+	buy, sell = q.BestBid, q.BestAsk
+	if q.BidSize >= queue && q.BestBid+tick < q.BestAsk-1e-9 {
+		buy = q.BestBid + tick
+	}
+	if q.AskSize >= queue && q.BestAsk-tick > buy+1e-9 {
+		sell = q.BestAsk - tick
+	}
+	return math.Round(buy*1e6) / 1e6, math.Round(sell*1e6) / 1e6
+}
@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"limitorderbot/internal/cashflows"
+	"limitorderbot/internal/models"
+)
+
+// notifyFilled messages an order that just filled completely (prev is its
+// status before the update) when NOTIFY_TRADES is on.
+func (b *Bot) notifyFilled(prev models.OrderStatus, o models.OrderRecord) {
+	if !b.cfg.NotifyTrades || prev == models.OrderStatusFilled || o.Status != models.OrderStatusFilled {
+		return
+	}
+	price := o.Price
+	if o.FillPrice != nil {
+		price = *o.FillPrice
+	}
+	shares := filledShares(o)
+	subject := fmt.Sprintf("Filled %s %s @ %.3f on %s", o.Side, o.Outcome, price, o.MarketSlug)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %.2f %s shares @ %.3f ($%.2f)\n", o.Side, shares, o.Outcome, price, shares*price)
+	if o.Strategy != nil {
+		fmt.Fprintf(&sb, "Strategy: %s", *o.Strategy)
+		if o.Phase != "" {
+			fmt.Fprintf(&sb, " (%s)", o.Phase)
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "Order: %s\n", o.OrderID)
+	b.writeLinks(&sb, o.MarketSlug, "")
+	b.notify(subject, sb.String())
+}
+
+// notifyTx messages the outcome of an on-chain job when NOTIFY_TRADES is on,
+// and posts it to the webhooks either way.
+func (b *Bot) notifyTx(job TxJob, err error) {
+	b.hookTx(job, err)
+	if !b.cfg.NotifyTrades {
+		return
+	}
+	kind := strings.ToUpper(job.Kind[:1]) + job.Kind[1:]
+	verdict := "confirmed"
+	if err != nil {
+		verdict = "failed"
+	}
+	subject := fmt.Sprintf("%s %s for %s", kind, verdict, job.MarketSlug)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Amount: $%.2f\n", job.Amount)
+	if gas := b.gasUSD(job); gas > 0 {
+		fmt.Fprintf(&sb, "Gas: %.5f MATIC ($%.4f)\n", job.GasMatic, gas)
+	}
+	if err != nil {
+		fmt.Fprintf(&sb, "Error: %v\n", err)
+	}
+	slug := ""
+	if job.ConditionID != "" {
+		slug = job.MarketSlug
+	}
+	b.writeLinks(&sb, slug, job.TxHash)
+	b.notify(subject, sb.String())
+}
+
+// notifyFlow messages a deposit or withdrawal found on the wallet when
+// NOTIFY_TRADES is on.
+func (b *Bot) notifyFlow(f cashflows.Flow) {
+	if !b.cfg.NotifyTrades {
+		return
+	}
+	subject := fmt.Sprintf("%s of $%.2f detected", strings.ToUpper(f.Kind[:1])+f.Kind[1:], f.Amount)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Wallet: %s\nCounterparty: %s\n", f.Wallet, f.Counterparty)
+	sb.WriteString("Recorded as a cash flow, not trading PnL.\n")
+	b.writeLinks(&sb, "", f.TxHash)
+	b.notify(subject, sb.String())
+}
+
+// writeLinks appends the market and tx links that exist.
+func (b *Bot) writeLinks(sb *strings.Builder, slug, txHash string) {
+	if u := b.cfg.MarketURL(slug); u != "" {
+		fmt.Fprintf(sb, "Market: %s\n", u)
+	}
+	if u := b.cfg.TxURL(txHash); u != "" {
+		fmt.Fprintf(sb, "Tx: %s\n", u)
+	}
+}
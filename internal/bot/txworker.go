@@ -0,0 +1,218 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/logging"
+)
+
+type TxJobStatus string
+
+const (
+	TxJobQueued    TxJobStatus = "QUEUED"
+	TxJobSent      TxJobStatus = "SENT"
+	TxJobConfirmed TxJobStatus = "CONFIRMED"
+	TxJobFailed    TxJobStatus = "FAILED"
+)
+
+// TxJob is one on-chain operation handed to the background worker.
+type TxJob struct {
+	ID          string      `json:"id"`
+	Kind        string      `json:"kind"`
+	ConditionID string      `json:"condition_id"`
+	MarketSlug  string      `json:"market_slug"`
+	Amount      float64     `json:"amount"`
+	Status      TxJobStatus `json:"status"`
+	TxHash      string      `json:"tx_hash,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	QueuedAt    time.Time   `json:"queued_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	RunID       string      `json:"run_id,omitempty"`
+	// GasMatic is the native token the tx paid for gas once mined, reverted
+	// or not.
+	GasMatic float64 `json:"gas_matic,omitempty"`
+
+	// run sends the tx; done is posted to the loop goroutine with the outcome.
+	run  func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error)
+	done func(job TxJob, err error)
+}
+
+const txJobHistory = 200
+
+// txWorker serializes merges/redeems off the trading loop. One job runs at a
+// time so nonces stay ordered and a congested chain only delays this queue.
+type txWorker struct {
+	mu     sync.Mutex
+	jobs   []*TxJob
+	queue  chan *TxJob
+	events chan<- func()
+	runID  string
+}
+
+func newTxWorker(events chan<- func(), runID string) *txWorker {
+	return &txWorker{queue: make(chan *TxJob, 64), events: events, runID: runID}
+}
+
+func (w *txWorker) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-w.queue:
+			w.process(ctx, job)
+		}
+	}
+}
+
+func (w *txWorker) process(ctx context.Context, job *TxJob) {
+	log := logging.Logger()
+	confirmed := make(chan error, 1)
+	hash, err := job.run(ctx, func(_ common.Hash, rcpt *types.Receipt, err error) {
+		if gas := chain.GasCost(rcpt); gas > 0 {
+			w.mu.Lock()
+			job.GasMatic = gas
+			w.mu.Unlock()
+		}
+		confirmed <- err
+	})
+	if err == nil {
+		w.update(job, TxJobSent, hash.Hex(), nil)
+		log.Printf("%s tx sent for %s (tx=%s)\n", job.Kind, job.MarketSlug, hash.Hex())
+		select {
+		case err = <-confirmed:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	if err != nil {
+		w.update(job, TxJobFailed, "", err)
+		log.Printf("%s failed for %s: %v\n", job.Kind, job.MarketSlug, err)
+	} else {
+		w.update(job, TxJobConfirmed, "", nil)
+		log.Printf("%s confirmed for %s\n", job.Kind, job.MarketSlug)
+	}
+	if job.done != nil {
+		snapshot := w.snapshot(job)
+		w.post(ctx, job, func() { job.done(snapshot, err) })
+	}
+}
+
+// post hands a job's outcome to the loop goroutine. Outcomes carry
+// bookkeeping (redemptions, gas) so a full queue is logged and waited out
+// until the loop drains it between passes; only shutdown drops one.
+func (w *txWorker) post(ctx context.Context, job *TxJob, fn func()) {
+	select {
+	case w.events <- fn:
+		return
+	default:
+	}
+	logging.Logger().Printf("WARNING: loop event queue full, %s outcome for %s waits for the loop\n", job.Kind, job.MarketSlug)
+	select {
+	case w.events <- fn:
+	case <-ctx.Done():
+		logging.Logger().Printf("WARNING: shutting down, dropping %s outcome for %s\n", job.Kind, job.MarketSlug)
+	}
+}
+
+// enqueue adds a job unless one of the same kind for the same condition is
+// still pending. Returns false when deduplicated or the queue is full.
+func (w *txWorker) enqueue(job *TxJob) bool {
+	w.mu.Lock()
+	for _, j := range w.jobs {
+		if j.Kind == job.Kind && j.ConditionID == job.ConditionID && (j.Status == TxJobQueued || j.Status == TxJobSent) {
+			w.mu.Unlock()
+			return false
+		}
+	}
+	now := time.Now()
+	job.ID = fmt.Sprintf("%s-%d", job.Kind, now.UnixNano())
+	job.Status = TxJobQueued
+	job.QueuedAt = now
+	job.UpdatedAt = now
+	job.RunID = w.runID
+	w.jobs = append(w.jobs, job)
+	if len(w.jobs) > txJobHistory {
+		w.jobs = w.jobs[len(w.jobs)-txJobHistory:]
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.queue <- job:
+		return true
+	default:
+		w.update(job, TxJobFailed, "", fmt.Errorf("tx queue full"))
+		return false
+	}
+}
+
+func (w *txWorker) pending(kind, conditionID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, j := range w.jobs {
+		if j.Kind == kind && j.ConditionID == conditionID && (j.Status == TxJobQueued || j.Status == TxJobSent) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *txWorker) update(job *TxJob, status TxJobStatus, hash string, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	job.Status = status
+	if hash != "" {
+		job.TxHash = hash
+	}
+	if err != nil {
+		job.Error = err.Error()
+	}
+	job.UpdatedAt = time.Now()
+}
+
+func (w *txWorker) snapshot(job *TxJob) TxJob {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return *job
+}
+
+// list returns jobs newest first.
+func (w *txWorker) list() []TxJob {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make([]TxJob, 0, len(w.jobs))
+	for i := len(w.jobs) - 1; i >= 0; i-- {
+		out = append(out, *w.jobs[i])
+	}
+	return out
+}
+
+// TxJobs exposes the background transaction queue for the dashboard.
+func (b *Bot) TxJobs() []TxJob {
+	return b.txWorker.list()
+}
+
+func (b *Bot) mergeJob(cid [32]byte, amount float64) func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error) {
+	return func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error) {
+		return b.chain.MergePositionsAsync(ctx, cid, big.NewInt(int64(amount*1e6)), cb)
+	}
+}
+
+func (b *Bot) redeemJob(cid [32]byte) func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error) {
+	return func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error) {
+		return b.chain.RedeemPositionsAsync(ctx, cid, cb)
+	}
+}
+
+func (b *Bot) redeemNegRiskJob(cid [32]byte, amounts []*big.Int) func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error) {
+	return func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error) {
+		return b.chain.RedeemNegRiskPositionsAsync(ctx, cid, amounts, cb)
+	}
+}
@@ -0,0 +1,247 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/clob"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// feedFillTTL is how long an order's feed fills are remembered after its
+// last event, to skip repeated MINED/CONFIRMED events of the same trade.
+const feedFillTTL = time.Hour
+
+// orderFeed is what the user channel has said about one of our orders. Fill
+// sizes are never added up from events: matched is the exchange's cumulative
+// size_matched from order events, and trades holds this order's part of
+// each trade by trade id, so a trade seen twice or after its order event
+// counts once. Trades also give the fill price.
+type orderFeed struct {
+	at      time.Time
+	matched float64
+	trades  map[string]tradeFill
+}
+
+type tradeFill struct {
+	size, price float64
+}
+
+// filled is the order's matched size as far as the feed knows: the larger
+// of the exchange's figure and the trades seen, since either may arrive
+// first.
+func (f *orderFeed) filled() float64 {
+	var sum float64
+	for _, t := range f.trades {
+		sum += t.size
+	}
+	return max(f.matched, sum)
+}
+
+// fillPrice is the volume-weighted price of the trades seen, or 0.
+func (f *orderFeed) fillPrice() float64 {
+	var size, notional float64
+	for _, t := range f.trades {
+		size += t.size
+		notional += t.size * t.price
+	}
+	if size <= 0 {
+		return 0
+	}
+	return notional / size
+}
+
+func (b *Bot) orderFeed(orderID string, now time.Time) *orderFeed {
+	f := b.orderFeeds[orderID]
+	if f == nil {
+		f = &orderFeed{trades: map[string]tradeFill{}}
+		b.orderFeeds[orderID] = f
+	}
+	f.at = now
+	return f
+}
+
+// startUserFeed follows our own fills on the CLOB user channel. While it is
+// connected, checkActiveOrders stops polling GetOrder; each (re)connect asks
+// for one polling pass to pick up anything missed while it was down.
+func (b *Bot) startUserFeed(ctx context.Context, creds clob.ApiCreds) {
+	feed := clob.NewUserFeed(b.cfg.ClobUserWSURL, creds)
+	feed.OnConnect = func() {
+		b.orderResync.Store(true)
+		logging.Logger().Println("CLOB user channel connected; tracking fills from trade events")
+	}
+	feed.OnTrade = func(t clob.UserTrade) {
+		b.toLoop(ctx, func() { b.applyTrade(t) })
+	}
+	feed.OnOrder = func(u clob.UserOrder) {
+		b.toLoop(ctx, func() { b.applyOrderUpdate(u) })
+	}
+	b.userFeed = feed
+	go feed.Run(ctx)
+}
+
+// toLoop is the blocking form of handToLoop for updates that must not be
+// dropped; it only gives up when ctx ends.
+func (b *Bot) toLoop(ctx context.Context, fn func()) {
+	select {
+	case b.chainEvents <- fn:
+	case <-ctx.Done():
+	}
+}
+
+// pollOrderStatuses reports whether checkActiveOrders has to poll GetOrder:
+// always without a live user channel, and once after each (re)connect.
+func (b *Bot) pollOrderStatuses() bool {
+	if b.userFeed == nil || !b.userFeed.Connected() {
+		return true
+	}
+	return b.orderResync.Swap(false)
+}
+
+// applyTrade records a trade's part of each matching tracked order and
+// sets the order's fill from the feed state. A FAILED status drops the trade
+// and asks for a polling pass, since the exchange's own matched size may
+// still include it.
+func (b *Bot) applyTrade(t clob.UserTrade) {
+	now := b.clock.Now()
+	for id, f := range b.orderFeeds {
+		if now.Sub(f.at) > feedFillTTL {
+			delete(b.orderFeeds, id)
+		}
+	}
+
+	if strings.EqualFold(t.Status, "FAILED") {
+		reverted := false
+		for orderID, f := range b.orderFeeds {
+			if _, ok := f.trades[t.ID]; !ok {
+				continue
+			}
+			delete(f.trades, t.ID)
+			f.matched = 0
+			reverted = true
+			b.updateTrackedOrder(orderID, func(o *models.OrderRecord) {
+				matched := f.filled()
+				o.SizeMatched = &matched
+				if price := f.fillPrice(); price > 0 {
+					o.FillPrice = &price
+				} else {
+					o.FillPrice = nil
+				}
+				if matched > 0 {
+					o.Status = models.OrderStatusPartiallyFilled
+				} else {
+					o.Status = models.OrderStatusPlaced
+				}
+				o.FilledAt = nil
+			})
+		}
+		if reverted {
+			b.orderResync.Store(true)
+			logging.Logger().Printf("Trade %s failed on chain; fills reverted, re-polling orders\n", t.ID)
+		}
+		return
+	}
+
+	var applied []string
+	apply := func(orderID string, size, price float64) {
+		if orderID == "" || size <= 0 {
+			return
+		}
+		if f := b.orderFeeds[orderID]; f != nil {
+			if _, seen := f.trades[t.ID]; seen {
+				return
+			}
+		}
+		found := b.updateTrackedOrder(orderID, func(o *models.OrderRecord) {
+			f := b.orderFeed(orderID, now)
+			f.trades[t.ID] = tradeFill{size: size, price: price}
+			matched := max(filledShares(*o), f.filled())
+			if o.Size > 0 {
+				matched = min(matched, o.Size)
+			}
+			avg := f.fillPrice()
+			o.SizeMatched = &matched
+			o.FillPrice = &avg
+			b.markFirstFill(o, matched)
+			if o.Size > 0 && matched >= o.Size-1e-9 {
+				o.Status = models.OrderStatusFilled
+				o.FilledAt = &now
+			} else {
+				o.Status = models.OrderStatusPartiallyFilled
+			}
+		})
+		if found {
+			applied = append(applied, orderID)
+			logging.Logger().Printf("Fill: order %s trade %s %.4f shares @ %.4f (%s)\n", orderID, t.ID, size, price, t.Status)
+		}
+	}
+	apply(t.TakerOrderID, t.Size, t.Price)
+	for _, m := range t.MakerOrders {
+		apply(m.OrderID, m.MatchedAmount, m.Price)
+	}
+	if len(applied) == 0 {
+		return
+	}
+	_ = b.saveOrders()
+	_ = b.saveOrderHistory()
+}
+
+// applyOrderUpdate takes the exchange's cumulative matched size and
+// cancellations from order events; trade events carry the prices.
+func (b *Bot) applyOrderUpdate(u clob.UserOrder) {
+	now := b.clock.Now()
+	changed := b.updateTrackedOrder(u.ID, func(o *models.OrderRecord) {
+		f := b.orderFeed(u.ID, now)
+		f.matched = max(f.matched, u.SizeMatched)
+		if matched := f.filled(); matched > filledShares(*o) {
+			o.SizeMatched = &matched
+			b.markFirstFill(o, matched)
+			if u.OriginalSize > 0 && matched >= u.OriginalSize-1e-9 {
+				o.Status = models.OrderStatusFilled
+				o.FilledAt = &now
+			} else {
+				o.Status = models.OrderStatusPartiallyFilled
+			}
+		}
+		if strings.EqualFold(u.Type, "CANCELLATION") && (o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled) {
+			o.Status = models.OrderStatusCancelled
+		}
+	})
+	if changed {
+		_ = b.saveOrders()
+		_ = b.saveOrderHistory()
+	}
+}
+
+// updateTrackedOrder applies fn to the tracked order with id orderID and
+// reports whether it was found. Orders in activeOrders are updated there and
+// in orderHistory; orders only in orderHistory (leftover sells, orders of
+// markets no longer tracked) are updated in place.
+func (b *Bot) updateTrackedOrder(orderID string, fn func(o *models.OrderRecord)) bool {
+	for cid, orders := range b.activeOrders {
+		for i := range orders {
+			if orders[i].OrderID != orderID {
+				continue
+			}
+			prev := orders[i].Status
+			fn(&orders[i])
+			bookFill(&orders[i])
+			b.activeOrders[cid] = orders
+			b.orderHistory[orderID] = orders[i]
+			b.orderFilled(prev, orders[i])
+			return true
+		}
+	}
+	o, ok := b.orderHistory[orderID]
+	if !ok {
+		return false
+	}
+	prev := o.Status
+	fn(&o)
+	bookFill(&o)
+	b.orderHistory[orderID] = o
+	b.orderFilled(prev, o)
+	return true
+}
@@ -0,0 +1,101 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+const (
+	// verifyAttempts and verifyBackoff bound the per-order GetOrder retries a
+	// placement spends before falling back to the book.
+	verifyAttempts = 3
+	verifyBackoff  = 500 * time.Millisecond
+)
+
+// confirmOrderPresence double-checks an order missing from the open-orders
+// listing, which can lag right after placement. The order is fetched by id
+// (with retries) and its reported status applied; if the CLOB never returns
+// it, a price level on the book holding at least the order's remaining size
+// is taken as presence. Only when both checks come up empty is the order really missing.
+func (b *Bot) confirmOrderPresence(ctx context.Context, o *models.OrderRecord) bool {
+	if o.OrderID == "" {
+		return false
+	}
+	for attempt := 0; attempt < verifyAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return false
+			case <-time.After(verifyBackoff * time.Duration(attempt)):
+			}
+		}
+		details, err := b.clob.GetOrder(ctx, o.OrderID)
+		if err != nil || details == nil || (asString(details["id"]) == "" && asString(details["status"]) == "") {
+			continue
+		}
+		prev := o.Status
+		b.applyOrderDetails(o, details)
+		b.orderFilled(prev, *o)
+		logging.Logger().Printf("Order %s missing from open orders but found by id (status %s)\n", o.OrderID, o.Status)
+		return true
+	}
+
+	if b.restingAtPrice(ctx, o.TokenID, o.Side, o.Price, o.Size-filledShares(*o)) {
+		logging.Logger().Printf("Order %s not returned by the CLOB yet, but the book rests at %.4f; keeping it\n", o.OrderID, o.Price)
+		o.Status = models.OrderStatusPlaced
+		return true
+	}
+	return false
+}
+
+// applyOrderDetails maps a GetOrder response onto the record, like the
+// status refresh in checkOrderStatuses.
+func (b *Bot) applyOrderDetails(o *models.OrderRecord, details map[string]any) {
+	status := strings.ToUpper(asString(details["status"]))
+	sizeMatched := asFloat(details["size_matched"])
+	origSize := asFloat(details["original_size"])
+	if origSize == 0 {
+		origSize = o.Size
+	}
+	o.SizeMatched = &sizeMatched
+	b.markFirstFill(o, sizeMatched)
+	switch {
+	case status == "MATCHED" || (origSize > 0 && sizeMatched >= origSize):
+		o.Status = models.OrderStatusFilled
+		now := b.clock.Now()
+		o.FilledAt = &now
+	case sizeMatched > 0:
+		o.Status = models.OrderStatusPartiallyFilled
+	case status == "CANCELLED":
+		o.Status = models.OrderStatusCancelled
+	default:
+		o.Status = models.OrderStatusPlaced
+	}
+	bookFill(o)
+}
+
+// restingAtPrice reports whether the token's book has at least size resting
+// at price on the order's side (bids for BUY, asks for SELL). A thinner level
+// can't contain the order, whoever else quotes there.
+func (b *Bot) restingAtPrice(ctx context.Context, tokenID string, side models.OrderSide, price, size float64) bool {
+	book, err := b.clob.GetOrderBook(ctx, tokenID)
+	if err != nil {
+		return false
+	}
+	key := "bids"
+	if side == models.OrderSideSell {
+		key = "asks"
+	}
+	levels, _ := book[key].([]any)
+	for _, l := range levels {
+		lm, _ := l.(map[string]any)
+		if lm != nil && samePrice(asFloat(lm["price"]), price) && asFloat(lm["size"]) > 0 && asFloat(lm["size"]) >= size-1e-9 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/webhook"
+)
+
+// hook posts an event to the WEBHOOKS_FILE hooks subscribed to it, in the
+// background like notify.
+func (b *Bot) hook(event string, data any) {
+	if !b.hooks.Wants(event) {
+		return
+	}
+	e, err := webhook.NewEvent(event, b.clock.Now(), b.runID, data)
+	if err != nil {
+		logging.Logger().Printf("Webhook %s: %v\n", event, err)
+		return
+	}
+	d := b.hooks
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := d.Send(ctx, e); err != nil {
+			logging.Logger().Printf("Webhook %s failed: %v\n", event, err)
+		}
+	}()
+}
+
+// hookTx posts a finished on-chain job: merges and redemptions under their
+// own events, the rest as tx.
+func (b *Bot) hookTx(job TxJob, err error) {
+	event := webhook.Tx
+	switch job.Kind {
+	case "merge":
+		event = webhook.Merge
+	case "redeem":
+		event = webhook.Redemption
+	}
+	if err != nil && job.Error == "" {
+		job.Error = err.Error()
+	}
+	b.hook(event, job)
+}
+
+// hookError posts an error recorded by recordError.
+func (b *Bot) hookError(msg, kind string) {
+	b.hook(webhook.Error, map[string]string{"message": msg, "kind": kind})
+}
@@ -7,15 +7,21 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
-	"limitorderbot/internal/chain"
 	"limitorderbot/internal/logging"
 	"limitorderbot/internal/models"
 )
 
-// hasActiveMarketWork mirrors python bot._has_active_market_work():
-// - If any live orders exist, we consider the bot "busy".
-// - If any unmerged positions exist (wallet balances), we consider the bot "busy".
+// hasActiveMarketWork mirrors python bot._has_active_market_work(), with up
+// to MaxConcurrentMarkets markets allowed to be in flight at once:
+// - A market with live orders is "busy".
+// - A market with unmerged positions (wallet balances) is "busy".
+// The bot is at capacity once MaxConcurrentMarkets markets are busy; the
+// default of 1 keeps the python behaviour of one market at a time.
 func (b *Bot) hasActiveMarketWork(ctx context.Context, now time.Time) (bool, string) {
+	limit := max(b.cfg.MaxConcurrentMarkets, 1)
+	var reasons []string
+	busy := map[string]bool{}
+
 	// Check 1: live orders
 	for cid, orders := range b.activeOrders {
 		live := 0
@@ -26,13 +32,17 @@ func (b *Bot) hasActiveMarketWork(ctx context.Context, now time.Time) (bool, str
 		}
 		if live > 0 {
 			name := marketNameForCID(b.trackedMarkets, cid)
-			return true, "waiting for " + itoa(live) + " orders to fill in " + name
+			busy[cid] = true
+			reasons = append(reasons, "waiting for "+itoa(live)+" orders to fill in "+name)
+			if len(reasons) >= limit {
+				return true, atCapacity(reasons, limit)
+			}
 		}
 	}
 
 	// Check 2: unprocessed positions (filled but not merged/sold)
 	for cid, orders := range b.activeOrders {
-		if b.positionsSold[cid] {
+		if busy[cid] || b.positionsSold[cid] {
 			continue
 		}
 		hasFilled := false
@@ -58,20 +68,38 @@ func (b *Bot) hasActiveMarketWork(ctx context.Context, now time.Time) (bool, str
 		// If we can't verify, don't block (python behavior).
 		if known && !cleared {
 			name := marketNameForCID(b.trackedMarkets, cid)
-			return true, "waiting to merge positions in " + name
+			reasons = append(reasons, "waiting to merge positions in "+name)
+			if len(reasons) >= limit {
+				return true, atCapacity(reasons, limit)
+			}
 		}
 	}
 
 	return false, ""
 }
 
+// atCapacity words the busy reason: the single market's reason when one
+// market at a time is allowed, otherwise the count and each market's reason.
+func atCapacity(reasons []string, limit int) string {
+	if limit == 1 {
+		return reasons[0]
+	}
+	return "at MAX_CONCURRENT_MARKETS=" + itoa(limit) + " (" + strings.Join(reasons, "; ") + ")"
+}
+
 func (b *Bot) walletPositionsCleared(ctx context.Context, conditionID string, orders []models.OrderRecord) (cleared bool, known bool) {
-	// Token IDs are the only thing we need; if missing, treat as unknown.
-	yesToken, noToken := inferYesNoTokenIDs(models.Market{ConditionID: conditionID}, orders)
+	// Token IDs are the only thing we need: from the orders or tracked
+	// market, else computed from the condition (the bot's markets aren't
+	// neg-risk). Without a condition id, treat as unknown.
+	yesToken, noToken := inferYesNoTokenIDs(b.trackedMarkets[conditionID], orders)
 	if yesToken == "" || noToken == "" {
-		return true, false
+		if !strings.HasPrefix(conditionID, "0x") {
+			return true, false
+		}
+		ids := b.chain.Addresses().OutcomeTokenIDs(common.HexToHash(conditionID), false)
+		yesToken, noToken = ids[0].String(), ids[1].String()
 	}
-	ctf := common.HexToAddress(chain.CTFAddress)
+	ctf := b.chain.Addresses().CTF
 	yesBal, err1 := b.chain.ERC1155BalanceOf(ctx, ctf, mustBigInt(yesToken))
 	noBal, err2 := b.chain.ERC1155BalanceOf(ctx, ctf, mustBigInt(noToken))
 	if err1 != nil || err2 != nil {
@@ -101,7 +129,7 @@ func (b *Bot) placeFallbackOrdersIfIdle(ctx context.Context, upcoming []models.M
 		if b.ordersPlaced[m.ConditionID] {
 			continue
 		}
-		if !shouldPlaceOrders(b.cfg, m, now) {
+		if !shouldPlaceOrders(b.cfg, m, now) || b.thinBooks[m.ConditionID] {
 			continue
 		}
 		if pick == nil || m.StartTS < pick.StartTS {
@@ -114,7 +142,9 @@ func (b *Bot) placeFallbackOrdersIfIdle(ctx context.Context, upcoming []models.M
 	}
 
 	logging.Logger().Printf("Idle state detected. Placing fallback orders for next market: %s\n", pick.MarketSlug)
-	orders, err := b.placeSimpleTestOrders(ctx, *pick, 0.49, 10.0)
+	orders, err := b.placeSimpleTestOrders(ctx, *pick, testQuotePrice, testQuoteSize)
+	b.recordPlacementResult(*pick, orders, err, "fallback")
+	tagPhase(orders, models.PhaseFallback)
 	if err != nil {
 		b.recordError(err)
 		return
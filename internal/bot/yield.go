@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// checkYield parks idle collateral when YIELD_POOL is set: whatever the
+// wallet holds beyond yieldNeed goes into the pool, and the pool is drawn
+// on as soon as the wallet falls short of it. Moves under
+// YIELD_MIN_MOVE_USD aren't worth their gas, except a withdrawal that
+// covers a shortfall.
+func (b *Bot) checkYield(ctx context.Context, now time.Time, wallet float64) {
+	if b.cfg.YieldPool == "" {
+		return
+	}
+	parked, err := b.chain.YieldBalance(ctx, common.HexToAddress(b.cfg.YieldReceiptToken))
+	if err != nil {
+		return
+	}
+	b.mu.Lock()
+	b.state.YieldUSDC = &parked
+	b.mu.Unlock()
+	if b.txWorker.pending("park", "") || b.txWorker.pending("unpark", "") {
+		return
+	}
+	need := b.yieldNeed(now)
+	switch {
+	case wallet < need && parked >= 0.01:
+		amount := min(parked, max(need-wallet, b.cfg.YieldMinMoveUSD))
+		b.queueYieldWithdraw(amount, parked, need)
+	case wallet-need >= b.cfg.YieldMinMoveUSD:
+		b.queueYieldDeposit(wallet-need, need)
+	}
+}
+
+// yieldNeed is the collateral the wallet should keep on hand: the working
+// capital, the unfilled notional of every open BUY (the CLOB cancels orders
+// the wallet can no longer back), plus 2x ORDER_SIZE_USD for each market
+// whose placement window opens within YIELD_LOOKAHEAD_MINUTES and has no
+// orders yet.
+func (b *Bot) yieldNeed(now time.Time) float64 {
+	need := b.cfg.YieldWorkingCapitalUSD
+	for _, orders := range b.activeOrders {
+		for _, o := range orders {
+			if o.Side != models.OrderSideBuy {
+				continue
+			}
+			if o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled {
+				need += o.Price * max(o.Size-filledShares(o), 0)
+			}
+		}
+	}
+	horizon := now.Add(time.Duration(b.cfg.YieldLookaheadMinutes) * time.Minute)
+	for _, m := range b.upcoming {
+		if b.ordersPlaced[m.ConditionID] || !m.StartTime().After(now) {
+			continue
+		}
+		w := b.cfg.PlacementWindowFor(m.Duration())
+		if m.StartTime().Add(-time.Duration(w.MaxMinutes) * time.Minute).Before(horizon) {
+			need += b.cfg.OrderSizeUSD * 2
+		}
+	}
+	return need
+}
+
+func (b *Bot) queueYieldDeposit(amount, need float64) {
+	pool := common.HexToAddress(b.cfg.YieldPool)
+	amount6 := big.NewInt(int64(amount * 1_000_000))
+	job := &TxJob{
+		Kind:       "park",
+		MarketSlug: "wallet -> yield pool",
+		Amount:     amount,
+		run: func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error) {
+			return b.chain.YieldDepositAsync(ctx, pool, amount6, cb)
+		},
+		done: func(job TxJob, err error) {
+			b.notifyTx(job, err)
+			b.bookGas(job)
+			if err != nil {
+				b.recordError(fmt.Errorf("yield deposit failed: %w", err))
+				return
+			}
+			logging.Logger().Printf("Parked $%.2f USDC.e in the yield pool\n", job.Amount)
+		},
+	}
+	if b.queueTx(job) {
+		logging.Logger().Printf("Wallet holds $%.2f over the $%.2f it needs; queued a deposit into the yield pool\n", amount, need)
+	}
+}
+
+// queueYieldWithdraw takes amount out of the pool, or everything when that
+// is (nearly) all of parked, so no interest dust is left behind.
+func (b *Bot) queueYieldWithdraw(amount, parked, need float64) {
+	pool := common.HexToAddress(b.cfg.YieldPool)
+	var amount6 *big.Int
+	if parked-amount >= 0.01 {
+		amount6 = big.NewInt(int64(amount * 1_000_000))
+	}
+	job := &TxJob{
+		Kind:       "unpark",
+		MarketSlug: "yield pool -> wallet",
+		Amount:     amount,
+		run: func(ctx context.Context, cb chain.ConfirmFunc) (common.Hash, error) {
+			return b.chain.YieldWithdrawAsync(ctx, pool, amount6, cb)
+		},
+		done: func(job TxJob, err error) {
+			b.notifyTx(job, err)
+			b.bookGas(job)
+			if err != nil {
+				b.recordError(fmt.Errorf("yield withdraw failed: %w", err))
+				return
+			}
+			b.syncCollateralAllowance()
+			logging.Logger().Printf("Withdrew $%.2f USDC.e from the yield pool\n", job.Amount)
+		},
+	}
+	if b.queueTx(job) {
+		logging.Logger().Printf("Wallet is short of the $%.2f it needs; queued a $%.2f withdrawal from the yield pool\n", need, amount)
+	}
+}
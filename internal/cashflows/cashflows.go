@@ -0,0 +1,118 @@
+package cashflows
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultFile holds the wallet's external deposits and withdrawals.
+const DefaultFile = "cash_flows.json"
+
+const (
+	Deposit    = "deposit"
+	Withdrawal = "withdrawal"
+)
+
+// Flow is collateral moved into or out of the account by something other
+// than trading: the operator topping up or paying out.
+type Flow struct {
+	Time   time.Time `json:"time"`
+	Kind   string    `json:"kind"`
+	Amount float64   `json:"amount_usd"`
+	// Wallet is the bot's address the money reached or left; Counterparty
+	// the other side of the transfer.
+	Wallet       string `json:"wallet"`
+	Counterparty string `json:"counterparty"`
+	TxHash       string `json:"tx_hash"`
+	LogIndex     uint   `json:"log_index"`
+	Block        uint64 `json:"block"`
+}
+
+// Signed is Amount as it moves equity: negative for a withdrawal.
+func (f Flow) Signed() float64 {
+	if f.Kind == Withdrawal {
+		return -f.Amount
+	}
+	return f.Amount
+}
+
+// Ledger is the file's content: the flows found and the last block the
+// wallet's transfers were scanned through.
+type Ledger struct {
+	LastBlock uint64 `json:"last_block"`
+	Flows     []Flow `json:"flows"`
+}
+
+// Totals sums deposits and withdrawals at or after since.
+func (l Ledger) Totals(since time.Time) (deposits, withdrawals float64) {
+	for _, f := range l.Flows {
+		if f.Time.Before(since) {
+			continue
+		}
+		if f.Kind == Withdrawal {
+			withdrawals += f.Amount
+		} else {
+			deposits += f.Amount
+		}
+	}
+	return deposits, withdrawals
+}
+
+var mu sync.Mutex
+
+// Load reads the ledger. A missing file is empty.
+func Load(path string) (Ledger, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load(path)
+}
+
+// Record appends flows not already recorded (by tx hash and log index) and
+// moves the scan cursor to lastBlock.
+func Record(path string, lastBlock uint64, flows []Flow) error {
+	mu.Lock()
+	defer mu.Unlock()
+	l, err := load(path)
+	if err != nil {
+		return err
+	}
+	type key struct {
+		tx  string
+		idx uint
+	}
+	seen := map[key]bool{}
+	for _, f := range l.Flows {
+		seen[key{f.TxHash, f.LogIndex}] = true
+	}
+	for _, f := range flows {
+		if !seen[key{f.TxHash, f.LogIndex}] {
+			l.Flows = append(l.Flows, f)
+		}
+	}
+	if lastBlock > l.LastBlock {
+		l.LastBlock = lastBlock
+	}
+	bts, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bts, 0o644)
+}
+
+func load(path string) (Ledger, error) {
+	var l Ledger
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return l, nil
+		}
+		return l, err
+	}
+	if err := json.Unmarshal(raw, &l); err != nil {
+		return l, err
+	}
+	return l, nil
+}
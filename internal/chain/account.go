@@ -0,0 +1,121 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"limitorderbot/internal/apierr"
+)
+
+// ErrAccountMismatch marks a SIGNATURE_TYPE/FUNDER_ADDRESS combination the
+// exchange would reject orders for, as opposed to a failed lookup.
+var ErrAccountMismatch = errors.New("SIGNATURE_TYPE/FUNDER_ADDRESS mismatch")
+
+var safeABI = mustABI(`[{"inputs":[{"name":"owner","type":"address"}],"name":"isOwner","outputs":[{"name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"getThreshold","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`)
+
+// CheckFunder verifies that orders signed by the client's key under
+// signatureType can be filled from funder (empty: the signer itself). An
+// ErrAccountMismatch says what to change; any other error is a failed RPC
+// and proves nothing either way.
+//
+// A Safe is checked for the signer being an owner able to sign alone.
+// Polymarket proxy wallets don't expose their owner, so for POLY_PROXY only
+// the contract's presence, and that it isn't a Safe, is checked.
+func (c *Client) CheckFunder(ctx context.Context, signatureType string, funder common.Address) error {
+	if signatureType == "EOA" {
+		if funder != (common.Address{}) && funder != c.address {
+			return mismatch("SIGNATURE_TYPE=EOA makes the signer %s the maker, but FUNDER_ADDRESS is %s: "+
+				"set SIGNATURE_TYPE=POLY_PROXY (email/Magic login) or POLY_GNOSIS_SAFE (browser wallet login), or remove FUNDER_ADDRESS",
+				c.address.Hex(), funder.Hex())
+		}
+		return nil
+	}
+	if funder == (common.Address{}) || funder == c.address {
+		return mismatch("SIGNATURE_TYPE=%s needs FUNDER_ADDRESS set to your Polymarket deposit address, not the signer %s",
+			signatureType, c.address.Hex())
+	}
+	var code []byte
+	err := c.pool.do(ctx, func(ec *ethclient.Client) error {
+		var err error
+		code, err = ec.CodeAt(ctx, funder, nil)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(code) == 0 {
+		return mismatch("FUNDER_ADDRESS %s has no contract on chain %d: copy the deposit address from your Polymarket profile "+
+			"(a proxy is only deployed after the first deposit) and check CHAIN_ID", funder.Hex(), c.chainID.Int64())
+	}
+	threshold, err := c.safeThreshold(ctx, funder)
+	if err != nil {
+		return err
+	}
+	switch {
+	case signatureType == "POLY_PROXY" && threshold != nil:
+		return mismatch("FUNDER_ADDRESS %s is a Gnosis Safe: set SIGNATURE_TYPE=POLY_GNOSIS_SAFE", funder.Hex())
+	case signatureType == "POLY_GNOSIS_SAFE" && threshold == nil:
+		return mismatch("FUNDER_ADDRESS %s is not a Gnosis Safe: accounts opened with email/Magic login use SIGNATURE_TYPE=POLY_PROXY",
+			funder.Hex())
+	case signatureType == "POLY_GNOSIS_SAFE":
+		owner, err := c.safeIsOwner(ctx, funder)
+		if err != nil {
+			return err
+		}
+		if !owner {
+			return mismatch("signer %s is not an owner of the Safe %s: check PRIVATE_KEY is the wallet you log in to Polymarket with",
+				c.address.Hex(), funder.Hex())
+		}
+		if threshold.Cmp(big.NewInt(1)) > 0 {
+			return mismatch("the Safe %s needs %s signatures per transaction; the bot can only sign alone", funder.Hex(), threshold)
+		}
+	}
+	return nil
+}
+
+func mismatch(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrAccountMismatch, fmt.Sprintf(format, args...))
+}
+
+// safeThreshold returns a Safe's signature threshold, or nil when target
+// doesn't answer getThreshold (it isn't a Safe).
+func (c *Client) safeThreshold(ctx context.Context, target common.Address) (*big.Int, error) {
+	data, err := safeABI.Pack("getThreshold")
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.callContract(ctx, ethereum.CallMsg{To: &target, Data: data})
+	if apierr.Is(err, apierr.Network) || apierr.Is(err, apierr.RateLimited) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, nil
+	}
+	out, err := safeABI.Unpack("getThreshold", res)
+	if err != nil {
+		return nil, nil
+	}
+	return out[0].(*big.Int), nil
+}
+
+func (c *Client) safeIsOwner(ctx context.Context, safe common.Address) (bool, error) {
+	data, err := safeABI.Pack("isOwner", c.address)
+	if err != nil {
+		return false, err
+	}
+	res, err := c.callContract(ctx, ethereum.CallMsg{To: &safe, Data: data})
+	if err != nil {
+		return false, err
+	}
+	out, err := safeABI.Unpack("isOwner", res)
+	if err != nil {
+		return false, err
+	}
+	return out[0].(bool), nil
+}
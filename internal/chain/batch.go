@@ -0,0 +1,117 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RedeemCall is one condition of a batch redeem: through the CTF, or through
+// the NegRiskAdapter with per-outcome amounts when Amounts is set.
+type RedeemCall struct {
+	ConditionID [32]byte
+	Amounts     []*big.Int
+}
+
+// RedeemBatchViaProxy redeems every call in one transaction, run from the
+// key's Polymarket proxy wallet (proxy, the POLY_PROXY funder) through the
+// proxy factory. Positions the EOA holds itself can't be batched this way:
+// the CTF pays out msg.sender, so each of those needs its own tx.
+func (c *Client) RedeemBatchViaProxy(ctx context.Context, proxy common.Address, calls []RedeemCall) (common.Hash, error) {
+	gas, err := c.EstimateRedeemBatchViaProxy(ctx, proxy, calls)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	pcalls, err := c.redeemProxyCalls(ctx, proxy, calls)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := c.sendWithGas(ctx, gas+gas/4, common.HexToAddress(ProxyFactoryAddress), proxyFactoryABI, "proxy", pcalls)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if c.returnOnBroadcast {
+		c.OnConfirmed(tx.Hash(), logReceipt("redeem batch"))
+		return tx.Hash(), nil
+	}
+	_, err = c.WaitConfirmed(ctx, tx.Hash())
+	return tx.Hash(), err
+}
+
+// EstimateRedeemBatchViaProxy is the gas RedeemBatchViaProxy would use.
+func (c *Client) EstimateRedeemBatchViaProxy(ctx context.Context, proxy common.Address, calls []RedeemCall) (uint64, error) {
+	pcalls, err := c.redeemProxyCalls(ctx, proxy, calls)
+	if err != nil {
+		return 0, err
+	}
+	return c.estimate(ctx, common.HexToAddress(ProxyFactoryAddress), proxyFactoryABI, "proxy", pcalls)
+}
+
+// redeemProxyCalls encodes calls for the proxy factory, approving the
+// NegRiskAdapter for the proxy's CTF tokens first when a neg-risk redeem
+// needs it.
+func (c *Client) redeemProxyCalls(ctx context.Context, proxy common.Address, calls []RedeemCall) ([]proxyCall, error) {
+	if c.chainID.Int64() != 137 {
+		return nil, errors.New("proxy wallet factory is only known on Polygon (chain 137)")
+	}
+	if len(calls) == 0 {
+		return nil, errors.New("nothing to redeem")
+	}
+	var out []proxyCall
+	add := func(to common.Address, data []byte) {
+		out = append(out, proxyCall{TypeCode: proxyCallTypeCall, To: to, Value: big.NewInt(0), Data: data})
+	}
+	approved := false
+	for _, call := range calls {
+		if call.Amounts == nil {
+			data, err := erc1155ABI.Pack("redeemPositions", c.addrs.Collateral, [32]byte{}, call.ConditionID, []*big.Int{big.NewInt(1), big.NewInt(2)})
+			if err != nil {
+				return nil, err
+			}
+			add(c.addrs.CTF, data)
+			continue
+		}
+		if c.addrs.NegRiskAdapter == (common.Address{}) {
+			return nil, errors.New("no NegRiskAdapter on this chain")
+		}
+		if !approved {
+			ok, err := c.isApprovedForAll(ctx, proxy, c.addrs.NegRiskAdapter)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				data, err := erc1155ABI.Pack("setApprovalForAll", c.addrs.NegRiskAdapter, true)
+				if err != nil {
+					return nil, err
+				}
+				add(c.addrs.CTF, data)
+			}
+			approved = true
+		}
+		data, err := negRiskAdapterABI.Pack("redeemPositions", call.ConditionID, call.Amounts)
+		if err != nil {
+			return nil, err
+		}
+		add(c.addrs.NegRiskAdapter, data)
+	}
+	return out, nil
+}
+
+func (c *Client) isApprovedForAll(ctx context.Context, owner, operator common.Address) (bool, error) {
+	data, err := erc1155ABI.Pack("isApprovedForAll", owner, operator)
+	if err != nil {
+		return false, err
+	}
+	res, err := c.callContract(ctx, ethereum.CallMsg{To: &c.addrs.CTF, Data: data})
+	if err != nil {
+		return false, err
+	}
+	out, err := erc1155ABI.Unpack("isApprovedForAll", res)
+	if err != nil {
+		return false, err
+	}
+	return out[0].(bool), nil
+}
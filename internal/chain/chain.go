@@ -2,9 +2,10 @@ package chain
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
 	"strings"
 	"time"
 
@@ -12,56 +13,159 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+
+	"limitorderbot/internal/keys"
 )
 
 const (
 	USDCeAddress = "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"
 	USDCAddress  = "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359"
 	CTFAddress   = "0x4D97DCd97eC945f40cF65F87097ACe5EA0476045"
+
+	// Amoy testnet (chain 80002); collateral/CTF match clob.GetContractConfig.
+	AmoyCollateralAddress = "0x9c4e1703476e875070ee25b56a58b008cfb8fa78"
+	AmoyUSDCAddress       = "0x41E94Eb019C0762f9Bfcf9Fb1E58725BfB0e7582"
+	AmoyCTFAddress        = "0x69308FB512518e39F9b16112fA8d994F4e2Bf8bB"
+
+	// NegRiskAdapterAddress wraps CTF positions of neg-risk markets (Polygon).
+	NegRiskAdapterAddress = "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296"
 )
 
+// Addresses are the token contracts the bot touches on one chain.
+type Addresses struct {
+	Collateral     common.Address // CTF collateral (USDC.e on Polygon)
+	USDC           common.Address // native USDC, informational only
+	CTF            common.Address
+	NegRiskAdapter common.Address // zero where neg-risk markets aren't supported
+}
+
+// AddressesForChain returns the contract set for Polygon (137) or Amoy (80002).
+func AddressesForChain(chainID int64) (Addresses, error) {
+	switch chainID {
+	case 137:
+		return Addresses{
+			Collateral:     common.HexToAddress(USDCeAddress),
+			USDC:           common.HexToAddress(USDCAddress),
+			CTF:            common.HexToAddress(CTFAddress),
+			NegRiskAdapter: common.HexToAddress(NegRiskAdapterAddress),
+		}, nil
+	case 80002:
+		return Addresses{
+			Collateral: common.HexToAddress(AmoyCollateralAddress),
+			USDC:       common.HexToAddress(AmoyUSDCAddress),
+			CTF:        common.HexToAddress(AmoyCTFAddress),
+		}, nil
+	}
+	return Addresses{}, fmt.Errorf("unsupported chain id %d", chainID)
+}
+
 var (
 	erc20ABI   = mustABI(`[{"constant":true,"inputs":[{"name":"_owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"balance","type":"uint256"}],"type":"function"},{"constant":true,"inputs":[{"name":"_owner","type":"address"},{"name":"_spender","type":"address"}],"name":"allowance","outputs":[{"name":"","type":"uint256"}],"type":"function"},{"constant":false,"inputs":[{"name":"_spender","type":"address"},{"name":"_value","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"type":"function"}]`)
 	erc1155ABI = mustABI(`[{"constant":true,"inputs":[{"name":"account","type":"address"},{"name":"id","type":"uint256"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"type":"function"},{"constant":true,"inputs":[{"name":"account","type":"address"},{"name":"operator","type":"address"}],"name":"isApprovedForAll","outputs":[{"name":"","type":"bool"}],"type":"function"},{"constant":false,"inputs":[{"name":"operator","type":"address"},{"name":"approved","type":"bool"}],"name":"setApprovalForAll","outputs":[],"type":"function"},{"constant":false,"inputs":[{"name":"collateralToken","type":"address"},{"name":"parentCollectionId","type":"bytes32"},{"name":"conditionId","type":"bytes32"},{"name":"partition","type":"uint256[]"},{"name":"amount","type":"uint256"}],"name":"mergePositions","outputs":[],"type":"function"},{"constant":false,"inputs":[{"name":"collateralToken","type":"address"},{"name":"parentCollectionId","type":"bytes32"},{"name":"conditionId","type":"bytes32"},{"name":"indexSets","type":"uint256[]"}],"name":"redeemPositions","outputs":[],"type":"function"}]`)
+	// NegRiskAdapter.redeemPositions takes per-outcome amounts instead of
+	// index sets: amounts[0] of the YES token and amounts[1] of the NO token.
+	negRiskAdapterABI = mustABI(`[{"constant":false,"inputs":[{"name":"_conditionId","type":"bytes32"},{"name":"_amounts","type":"uint256[]"}],"name":"redeemPositions","outputs":[],"type":"function"}]`)
+	// The CTF's public payout arrays, set when the oracle reports.
+	ctfPayoutABI = mustABI(`[{"constant":true,"inputs":[{"name":"","type":"bytes32"}],"name":"payoutDenominator","outputs":[{"name":"","type":"uint256"}],"type":"function"},{"constant":true,"inputs":[{"name":"","type":"bytes32"},{"name":"","type":"uint256"}],"name":"payoutNumerators","outputs":[{"name":"","type":"uint256"}],"type":"function"}]`)
 )
 
 type Client struct {
-	rpcURL  string
+	rpcURLs []string
 	chainID *big.Int
-	ec      *ethclient.Client
+	pool    *endpointPool
+	ws      *ethclient.Client // optional, for receipt subscriptions
+
+	// confirmations is how many blocks must be built on a receipt before it
+	// counts; 0 accepts the first receipt seen.
+	confirmations uint64
+	// confirmTimeout bounds each wait for a receipt; returnOnBroadcast makes
+	// the blocking writes return once sent, with watch tracking the receipt.
+	confirmTimeout    time.Duration
+	returnOnBroadcast bool
+	// dryRun simulates writes instead of sending them (see SetDryRun).
+	dryRun bool
 
-	privateKey *ecdsa.PrivateKey
-	address    common.Address
+	// life is cancelled by Close, stopping receipt watchers.
+	life     context.Context
+	stopLife context.CancelFunc
+
+	addrs   Addresses
+	signer  keys.Signer
+	address common.Address
 }
 
-func New(rpcURL string, privateKeyHex string, chainID int64) (*Client, error) {
-	pk, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x"))
+// New dials every URL in rpcURLs; reads fail over between them based on
+// per-endpoint health (see endpoints.go).
+func New(rpcURLs []string, key keys.Provider, chainID int64) (*Client, error) {
+	return NewWithHTTP(rpcURLs, key, chainID, nil)
+}
+
+// NewWithHTTP is New with HTTP(S) endpoints dialed through h, e.g. to point
+// the client at a stub node in tests. A nil h uses the rpc default.
+func NewWithHTTP(rpcURLs []string, key keys.Provider, chainID int64, h *http.Client) (*Client, error) {
+	if key == nil {
+		return nil, errors.New("no wallet key configured")
+	}
+	addrs, err := AddressesForChain(chainID)
 	if err != nil {
 		return nil, err
 	}
-	ec, err := ethclient.Dial(rpcURL)
+	signer, err := keys.SignerFor(key)
 	if err != nil {
 		return nil, err
 	}
-	addr := crypto.PubkeyToAddress(pk.PublicKey)
+	pool, err := dialPool(rpcURLs, h)
+	if err != nil {
+		return nil, err
+	}
+	life, stop := context.WithCancel(context.Background())
 	return &Client{
-		rpcURL:     rpcURL,
-		chainID:    big.NewInt(chainID),
-		ec:         ec,
-		privateKey: pk,
-		address:    addr,
+		rpcURLs:        rpcURLs,
+		chainID:        big.NewInt(chainID),
+		pool:           pool,
+		confirmTimeout: defaultConfirmTimeout,
+		life:           life,
+		stopLife:       stop,
+		addrs:          addrs,
+		signer:         signer,
+		address:        signer.Address(),
 	}, nil
 }
 
-func (c *Client) Close() error                 { c.ec.Close(); return nil }
-func (c *Client) Address() common.Address      { return c.address }
-func (c *Client) EthClient() *ethclient.Client { return c.ec }
+func (c *Client) Close() error {
+	c.stopLife()
+	c.pool.close()
+	if c.ws != nil {
+		c.ws.Close()
+	}
+	return nil
+}
+
+func (c *Client) Address() common.Address { return c.address }
+
+// Addresses returns the collateral/CTF contracts for the client's chain.
+func (c *Client) Addresses() Addresses { return c.addrs }
+
+// EthClient returns the currently healthiest endpoint's client.
+func (c *Client) EthClient() *ethclient.Client { return c.pool.best().ec }
+
+// Health reports per-endpoint latency/error stats and circuit state.
+func (c *Client) Health() []EndpointHealth { return c.pool.health() }
+
+func (c *Client) callContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	var res []byte
+	err := c.pool.do(ctx, func(ec *ethclient.Client) error {
+		var err error
+		res, err = ec.CallContract(ctx, msg, nil)
+		return err
+	})
+	return res, err
+}
 
 func (c *Client) USDCBalance(ctx context.Context) (float64, error) {
-	contract := common.HexToAddress(USDCeAddress)
-	return c.ERC20BalanceFloat6(ctx, contract)
+	return c.ERC20BalanceFloat6(ctx, c.addrs.Collateral)
 }
 
 func (c *Client) ERC20BalanceOf(ctx context.Context, token, owner common.Address) (*big.Int, error) {
@@ -69,7 +173,7 @@ func (c *Client) ERC20BalanceOf(ctx context.Context, token, owner common.Address
 	if err != nil {
 		return nil, err
 	}
-	res, err := c.ec.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	res, err := c.callContract(ctx, ethereum.CallMsg{To: &token, Data: data})
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +195,13 @@ func (c *Client) ERC20BalanceFloat6(ctx context.Context, token common.Address) (
 }
 
 func (c *Client) NativeBalance(ctx context.Context) (*big.Int, error) {
-	return c.ec.BalanceAt(ctx, c.address, nil)
+	var bal *big.Int
+	err := c.pool.do(ctx, func(ec *ethclient.Client) error {
+		var err error
+		bal, err = ec.BalanceAt(ctx, c.address, nil)
+		return err
+	})
+	return bal, err
 }
 
 func (c *Client) NativeBalanceFloat18(ctx context.Context) (float64, error) {
@@ -109,7 +219,7 @@ func (c *Client) ERC20Allowance(ctx context.Context, token, spender common.Addre
 	if err != nil {
 		return nil, err
 	}
-	res, err := c.ec.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	res, err := c.callContract(ctx, ethereum.CallMsg{To: &token, Data: data})
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +235,7 @@ func (c *Client) ERC1155IsApprovedForAll(ctx context.Context, token, operator co
 	if err != nil {
 		return false, err
 	}
-	res, err := c.ec.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	res, err := c.callContract(ctx, ethereum.CallMsg{To: &token, Data: data})
 	if err != nil {
 		return false, err
 	}
@@ -137,11 +247,17 @@ func (c *Client) ERC1155IsApprovedForAll(ctx context.Context, token, operator co
 }
 
 func (c *Client) ERC1155BalanceOf(ctx context.Context, token common.Address, tokenID *big.Int) (*big.Int, error) {
-	data, err := erc1155ABI.Pack("balanceOf", c.address, tokenID)
+	return c.ERC1155BalanceOfOwner(ctx, token, c.address, tokenID)
+}
+
+// ERC1155BalanceOfOwner is ERC1155BalanceOf for another holder, e.g. the
+// proxy wallet.
+func (c *Client) ERC1155BalanceOfOwner(ctx context.Context, token, owner common.Address, tokenID *big.Int) (*big.Int, error) {
+	data, err := erc1155ABI.Pack("balanceOf", owner, tokenID)
 	if err != nil {
 		return nil, err
 	}
-	res, err := c.ec.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	res, err := c.callContract(ctx, ethereum.CallMsg{To: &token, Data: data})
 	if err != nil {
 		return nil, err
 	}
@@ -153,18 +269,28 @@ func (c *Client) ERC1155BalanceOf(ctx context.Context, token common.Address, tok
 }
 
 func (c *Client) ApproveUSDC(ctx context.Context, spender common.Address, amount *big.Int) (common.Hash, error) {
-	return c.transact(ctx, common.HexToAddress(USDCeAddress), erc20ABI, "approve", spender, amount)
+	return c.transact(ctx, c.addrs.Collateral, erc20ABI, "approve", spender, amount)
+}
+
+// ApproveUSDCAsync is the non-blocking variant of ApproveUSDC.
+func (c *Client) ApproveUSDCAsync(ctx context.Context, spender common.Address, amount *big.Int, cb ConfirmFunc) (common.Hash, error) {
+	tx, err := c.send(ctx, c.addrs.Collateral, erc20ABI, "approve", spender, amount)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	c.OnConfirmed(tx.Hash(), cb)
+	return tx.Hash(), nil
 }
 
 func (c *Client) SetCTFApprovalForAll(ctx context.Context, operator common.Address, approved bool) (common.Hash, error) {
-	return c.transact(ctx, common.HexToAddress(CTFAddress), erc1155ABI, "setApprovalForAll", operator, approved)
+	return c.transact(ctx, c.addrs.CTF, erc1155ABI, "setApprovalForAll", operator, approved)
 }
 
 func (c *Client) MergePositions(ctx context.Context, conditionID [32]byte, amountUSDC6 *big.Int) (common.Hash, error) {
 	parent := [32]byte{}
 	partition := []*big.Int{big.NewInt(1), big.NewInt(2)}
-	return c.transact(ctx, common.HexToAddress(CTFAddress), erc1155ABI, "mergePositions",
-		common.HexToAddress(USDCeAddress),
+	return c.transact(ctx, c.addrs.CTF, erc1155ABI, "mergePositions",
+		c.addrs.Collateral,
 		parent,
 		conditionID,
 		partition,
@@ -175,39 +301,215 @@ func (c *Client) MergePositions(ctx context.Context, conditionID [32]byte, amoun
 func (c *Client) RedeemPositions(ctx context.Context, conditionID [32]byte) (common.Hash, error) {
 	parent := [32]byte{}
 	indexSets := []*big.Int{big.NewInt(1), big.NewInt(2)}
-	return c.transact(ctx, common.HexToAddress(CTFAddress), erc1155ABI, "redeemPositions",
-		common.HexToAddress(USDCeAddress),
+	return c.transact(ctx, c.addrs.CTF, erc1155ABI, "redeemPositions",
+		c.addrs.Collateral,
 		parent,
 		conditionID,
 		indexSets,
 	)
 }
 
-func (c *Client) transact(ctx context.Context, to common.Address, a abi.ABI, method string, args ...any) (common.Hash, error) {
-	auth, err := bind.NewKeyedTransactorWithChainID(c.privateKey, c.chainID)
+// RedeemNegRiskPositions redeems a neg-risk market through the NegRiskAdapter;
+// the CTF's index-set redeem reverts for these conditions.
+func (c *Client) RedeemNegRiskPositions(ctx context.Context, conditionID [32]byte, amounts []*big.Int) (common.Hash, error) {
+	if c.addrs.NegRiskAdapter == (common.Address{}) {
+		return common.Hash{}, fmt.Errorf("no NegRiskAdapter on chain %s", c.chainID)
+	}
+	return c.transact(ctx, c.addrs.NegRiskAdapter, negRiskAdapterABI, "redeemPositions", conditionID, amounts)
+}
+
+// NegRiskAmounts reads the wallet's balances of a neg-risk market's YES and
+// NO tokens, in the order NegRiskAdapter.redeemPositions expects.
+func (c *Client) NegRiskAmounts(ctx context.Context, yesTokenID, noTokenID *big.Int) ([]*big.Int, error) {
+	return c.NegRiskAmountsOf(ctx, c.address, yesTokenID, noTokenID)
+}
+
+// NegRiskAmountsOf is NegRiskAmounts for the positions owner holds.
+func (c *Client) NegRiskAmountsOf(ctx context.Context, owner common.Address, yesTokenID, noTokenID *big.Int) ([]*big.Int, error) {
+	amounts := make([]*big.Int, 2)
+	for i, id := range []*big.Int{yesTokenID, noTokenID} {
+		if id == nil {
+			amounts[i] = new(big.Int)
+			continue
+		}
+		bal, err := c.ERC1155BalanceOfOwner(ctx, c.addrs.CTF, owner, id)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = bal
+	}
+	return amounts, nil
+}
+
+// Payouts reads how the CTF settled conditionID. The denominator stays zero
+// until the oracle reports; after that one share of outcome i redeems for
+// numerators[i]/denominator collateral.
+func (c *Client) Payouts(ctx context.Context, conditionID [32]byte, outcomeCount int) (*big.Int, []*big.Int, error) {
+	den, err := c.ctfView(ctx, "payoutDenominator", conditionID)
+	if err != nil || den.Sign() == 0 {
+		return den, nil, err
+	}
+	nums := make([]*big.Int, outcomeCount)
+	for i := range nums {
+		if nums[i], err = c.ctfView(ctx, "payoutNumerators", conditionID, big.NewInt(int64(i))); err != nil {
+			return nil, nil, err
+		}
+	}
+	return den, nums, nil
+}
+
+func (c *Client) ctfView(ctx context.Context, method string, args ...any) (*big.Int, error) {
+	data, err := ctfPayoutABI.Pack(method, args...)
 	if err != nil {
-		return common.Hash{}, err
+		return nil, err
+	}
+	res, err := c.callContract(ctx, ethereum.CallMsg{To: &c.addrs.CTF, Data: data})
+	if err != nil {
+		return nil, err
+	}
+	out, err := ctfPayoutABI.Unpack(method, res)
+	if err != nil {
+		return nil, err
 	}
-	auth.Context = ctx
+	return out[0].(*big.Int), nil
+}
 
-	// Reasonable defaults; we still estimate gas.
-	auth.GasLimit = 300_000
-	auth.GasPrice, _ = c.ec.SuggestGasPrice(ctx)
+// MergePositionsAsync sends mergePositions and returns as soon as the tx is
+// broadcast; cb is invoked from a background goroutine once it is mined.
+func (c *Client) MergePositionsAsync(ctx context.Context, conditionID [32]byte, amountUSDC6 *big.Int, cb ConfirmFunc) (common.Hash, error) {
+	parent := [32]byte{}
+	partition := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	tx, err := c.send(ctx, c.addrs.CTF, erc1155ABI, "mergePositions",
+		c.addrs.Collateral,
+		parent,
+		conditionID,
+		partition,
+		amountUSDC6,
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	c.OnConfirmed(tx.Hash(), cb)
+	return tx.Hash(), nil
+}
 
-	bound := bind.NewBoundContract(to, a, c.ec, c.ec, c.ec)
-	tx, err := bound.Transact(auth, method, args...)
+// RedeemPositionsAsync is the non-blocking variant of RedeemPositions.
+func (c *Client) RedeemPositionsAsync(ctx context.Context, conditionID [32]byte, cb ConfirmFunc) (common.Hash, error) {
+	parent := [32]byte{}
+	indexSets := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	tx, err := c.send(ctx, c.addrs.CTF, erc1155ABI, "redeemPositions",
+		c.addrs.Collateral,
+		parent,
+		conditionID,
+		indexSets,
+	)
 	if err != nil {
 		return common.Hash{}, err
 	}
-	// wait (similar to python wait_for_transaction_receipt timeout=120)
-	_, err = bind.WaitMined(context.WithoutCancel(ctx), c.ec, tx)
+	c.OnConfirmed(tx.Hash(), cb)
+	return tx.Hash(), nil
+}
+
+// RedeemNegRiskPositionsAsync is the non-blocking variant of RedeemNegRiskPositions.
+func (c *Client) RedeemNegRiskPositionsAsync(ctx context.Context, conditionID [32]byte, amounts []*big.Int, cb ConfirmFunc) (common.Hash, error) {
+	if c.addrs.NegRiskAdapter == (common.Address{}) {
+		return common.Hash{}, fmt.Errorf("no NegRiskAdapter on chain %s", c.chainID)
+	}
+	tx, err := c.send(ctx, c.addrs.NegRiskAdapter, negRiskAdapterABI, "redeemPositions", conditionID, amounts)
 	if err != nil {
-		// not fatal for returning tx hash
+		return common.Hash{}, err
+	}
+	c.OnConfirmed(tx.Hash(), cb)
+	return tx.Hash(), nil
+}
+
+// transact sends a tx and waits for it to confirm, unless the client
+// returns on broadcast, in which case a watcher logs how it ends.
+func (c *Client) transact(ctx context.Context, to common.Address, a abi.ABI, method string, args ...any) (common.Hash, error) {
+	if c.returnOnBroadcast {
+		tx, err := c.send(ctx, to, a, method, args...)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		c.OnConfirmed(tx.Hash(), logReceipt(method))
 		return tx.Hash(), nil
 	}
+	return c.transactConfirmed(ctx, to, a, method, args...)
+}
+
+// transactConfirmed always waits, for steps a later tx depends on (e.g. an
+// approve before a swap). Cancelling ctx stops the wait, not the tx; the
+// hash comes back with the error so callers can still point at it.
+func (c *Client) transactConfirmed(ctx context.Context, to common.Address, a abi.ABI, method string, args ...any) (common.Hash, error) {
+	tx, err := c.send(ctx, to, a, method, args...)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if _, err := c.WaitConfirmed(ctx, tx.Hash()); err != nil {
+		if ctx.Err() != nil {
+			return tx.Hash(), fmt.Errorf("tx %s sent, stopped waiting for receipt: %w", tx.Hash().Hex(), err)
+		}
+		return tx.Hash(), err
+	}
 	return tx.Hash(), nil
 }
 
+// transactOpts signs transactions through c.signer so a remote signer works
+// the same as a local key.
+func (c *Client) transactOpts(ctx context.Context) *bind.TransactOpts {
+	txSigner := types.LatestSignerForChainID(c.chainID)
+	return &bind.TransactOpts{
+		From:    c.address,
+		Context: ctx,
+		Signer: func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if from != c.address {
+				return nil, bind.ErrNotAuthorized
+			}
+			sig, err := c.signer.SignDigest(txSigner.Hash(tx))
+			if err != nil {
+				return nil, err
+			}
+			return tx.WithSignature(txSigner, sig)
+		},
+	}
+}
+
+func (c *Client) send(ctx context.Context, to common.Address, a abi.ABI, method string, args ...any) (*types.Transaction, error) {
+	// Reasonable default for single calls; see sendWithGas for larger ones.
+	return c.sendWithGas(ctx, 300_000, to, a, method, args...)
+}
+
+func (c *Client) sendWithGas(ctx context.Context, gasLimit uint64, to common.Address, a abi.ABI, method string, args ...any) (*types.Transaction, error) {
+	out, err := c.simulate(ctx, to, a, method, args...)
+	if err != nil {
+		return nil, classify(err)
+	}
+	if c.dryRun {
+		return nil, c.dryRunResult(ctx, to, a, method, out, args...)
+	}
+	auth := c.transactOpts(ctx)
+
+	// Writes stick to a single endpoint: resending on another node after an
+	// ambiguous failure could double-submit with a fresh nonce.
+	e := c.pool.best()
+	ec := e.ec
+
+	auth.GasLimit = gasLimit
+	auth.GasPrice, _ = ec.SuggestGasPrice(ctx)
+
+	bound := bind.NewBoundContract(to, a, ec, ec, ec)
+	start := time.Now()
+	tx, err := bound.Transact(auth, method, args...)
+	if err != nil {
+		if !isApplicationError(err) && ctx.Err() == nil {
+			c.pool.record(e, time.Since(start), err)
+		}
+		return nil, classify(err)
+	}
+	c.pool.record(e, time.Since(start), nil)
+	return tx, nil
+}
+
 func mustABI(raw string) abi.ABI {
 	a, err := abi.JSON(strings.NewReader(raw))
 	if err != nil {
@@ -0,0 +1,221 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"limitorderbot/internal/logging"
+)
+
+// defaultConfirmTimeout mirrors python wait_for_transaction_receipt(timeout=120).
+const defaultConfirmTimeout = 120 * time.Second
+
+// ConfirmFunc is called once a transaction is mined (rcpt set) or waiting
+// failed (err set). A mined-but-reverted tx has rcpt.Status == 0 and a
+// *RevertError.
+type ConfirmFunc func(hash common.Hash, rcpt *types.Receipt, err error)
+
+// EnableWebsocket dials a websocket RPC endpoint used to wait for receipts on
+// new-head notifications instead of polling. Optional; polling is the fallback.
+func (c *Client) EnableWebsocket(wsURL string) error {
+	ws, err := ethclient.Dial(wsURL)
+	if err != nil {
+		return err
+	}
+	if c.ws != nil {
+		c.ws.Close()
+	}
+	c.ws = ws
+	return nil
+}
+
+// SetConfirmations makes WaitConfirmed hold a receipt until n blocks are
+// built on top of it, so a tx reorged out of the chain isn't reported mined.
+func (c *Client) SetConfirmations(n uint64) { c.confirmations = n }
+
+// SetConfirmTimeout bounds how long WaitConfirmed waits for a receipt.
+func (c *Client) SetConfirmTimeout(d time.Duration) {
+	if d > 0 {
+		c.confirmTimeout = d
+	}
+}
+
+// SetReturnOnBroadcast makes Approve, Merge and Redeem return as soon as the
+// tx is sent instead of blocking until it confirms; the outcome is logged
+// when the receipt arrives.
+func (c *Client) SetReturnOnBroadcast(v bool) { c.returnOnBroadcast = v }
+
+func logReceipt(method string) ConfirmFunc {
+	return func(hash common.Hash, _ *types.Receipt, err error) {
+		if err != nil {
+			logging.Logger().Printf("%s tx %s failed: %v\n", method, hash.Hex(), err)
+			return
+		}
+		logging.Logger().Printf("%s tx %s confirmed\n", method, hash.Hex())
+	}
+}
+
+// WaitConfirmed blocks until hash is mined (and buried under the configured
+// confirmations) or the confirm timeout elapses. A reverted tx returns its receipt
+// with a *RevertError.
+func (c *Client) WaitConfirmed(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.confirmTimeout)
+	defer cancel()
+
+	var rcpt *types.Receipt
+	for {
+		var err error
+		rcpt, err = c.waitMined(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		if c.confirmations == 0 {
+			break
+		}
+		final, err := c.waitDepth(ctx, hash, rcpt)
+		if err != nil {
+			return nil, err
+		}
+		if final != nil {
+			rcpt = final
+			break
+		}
+		// reorged out: wait for it to be included again
+	}
+	if rcpt.Status != types.ReceiptStatusSuccessful {
+		// The replay is one bounded call; finish it even if ctx is cancelled
+		// so the error still says why the tx reverted.
+		rctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		reason := c.revertReason(rctx, hash, rcpt)
+		cancel()
+		return rcpt, &RevertError{Hash: hash, Block: rcpt.BlockNumber.Uint64(), Reason: reason}
+	}
+	return rcpt, nil
+}
+
+func (c *Client) waitMined(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	if c.ws != nil {
+		rcpt, err := c.waitViaSubscription(ctx, hash)
+		if err != nil && ctx.Err() == nil {
+			// subscription dropped: fall back to polling for the remaining time
+			return c.waitViaPolling(ctx, hash)
+		}
+		return rcpt, err
+	}
+	return c.waitViaPolling(ctx, hash)
+}
+
+// waitDepth polls until rcpt's block has c.confirmations blocks on top, then
+// re-reads the receipt. It returns nil (and no error) when the tx has dropped
+// out of the canonical chain; a tx re-included in another block is followed.
+func (c *Client) waitDepth(ctx context.Context, hash common.Hash, rcpt *types.Receipt) (*types.Receipt, error) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		var head uint64
+		err := c.pool.do(ctx, func(ec *ethclient.Client) error {
+			var err error
+			head, err = ec.BlockNumber(ctx)
+			return err
+		})
+		if err == nil && head >= rcpt.BlockNumber.Uint64()+c.confirmations {
+			var cur *types.Receipt
+			err = c.pool.do(ctx, func(ec *ethclient.Client) error {
+				var err error
+				cur, err = ec.TransactionReceipt(ctx, hash)
+				return err
+			})
+			switch {
+			case errors.Is(err, ethereum.NotFound):
+				return nil, nil
+			case err == nil && cur.BlockHash == rcpt.BlockHash:
+				return cur, nil
+			case err == nil:
+				rcpt = cur
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// OnConfirmed waits for hash in the background and invokes cb from that
+// goroutine. Close stops the wait, reporting the cancellation to cb.
+func (c *Client) OnConfirmed(hash common.Hash, cb ConfirmFunc) {
+	go func() {
+		rcpt, err := c.WaitConfirmed(c.life, hash)
+		if cb != nil {
+			cb(hash, rcpt, err)
+		}
+	}()
+}
+
+func (c *Client) waitViaSubscription(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	heads := make(chan *types.Header, 16)
+	sub, err := c.ws.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+	for {
+		rcpt, err := c.ws.TransactionReceipt(ctx, hash)
+		if err == nil {
+			return rcpt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("head subscription closed")
+			}
+			return nil, err
+		case <-heads:
+		}
+	}
+}
+
+func (c *Client) waitViaPolling(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		var rcpt *types.Receipt
+		err := c.pool.do(ctx, func(ec *ethclient.Client) error {
+			var err error
+			rcpt, err = ec.TransactionReceipt(ctx, hash)
+			return err
+		})
+		if err == nil {
+			return rcpt, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// GasCost is what a mined tx paid in native token (POL/MATIC): gas used at
+// the effective gas price. 0 when rcpt is nil or the node omits the price.
+func GasCost(rcpt *types.Receipt) float64 {
+	if rcpt == nil || rcpt.EffectiveGasPrice == nil {
+		return 0
+	}
+	wei := new(big.Int).Mul(new(big.Int).SetUint64(rcpt.GasUsed), rcpt.EffectiveGasPrice)
+	f, _ := new(big.Rat).SetFrac(wei, big.NewInt(1_000_000_000_000_000_000)).Float64()
+	return f
+}
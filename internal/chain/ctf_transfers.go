@@ -0,0 +1,98 @@
+package chain
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ERC1155 transfer events; both index (operator, from, to).
+var (
+	transferSingleTopic = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	transferBatchTopic  = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+
+	transferBatchData = func() abi.Arguments {
+		ids, _ := abi.NewType("uint256[]", "", nil)
+		return abi.Arguments{{Type: ids}, {Type: ids}}
+	}()
+)
+
+// CTFTransfer is one token moved by a CTF TransferSingle log, or one entry
+// of a TransferBatch.
+type CTFTransfer struct {
+	Block    uint64
+	TxHash   common.Hash
+	LogIndex uint
+	From     common.Address
+	To       common.Address
+	TokenID  *big.Int
+	Amount   *big.Int // raw, 6 decimals
+}
+
+// CTFTransfersTo lists CTF tokens received by owner in blocks [from, to],
+// in chain order. Like CollateralTransfers, callers keep ranges short.
+func (c *Client) CTFTransfersTo(ctx context.Context, owner common.Address, from, to uint64) ([]CTFTransfer, error) {
+	q := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{c.addrs.CTF},
+		Topics: [][]common.Hash{
+			{transferSingleTopic, transferBatchTopic},
+			nil,
+			nil,
+			{common.BytesToHash(owner.Bytes())},
+		},
+	}
+	var logs []types.Log
+	err := c.pool.do(ctx, func(ec *ethclient.Client) error {
+		var err error
+		logs, err = ec.FilterLogs(ctx, q)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out []CTFTransfer
+	for _, lg := range logs {
+		if len(lg.Topics) < 4 {
+			continue
+		}
+		t := CTFTransfer{
+			Block:    lg.BlockNumber,
+			TxHash:   lg.TxHash,
+			LogIndex: lg.Index,
+			From:     common.BytesToAddress(lg.Topics[2].Bytes()),
+			To:       common.BytesToAddress(lg.Topics[3].Bytes()),
+		}
+		switch lg.Topics[0] {
+		case transferSingleTopic:
+			if len(lg.Data) < 64 {
+				continue
+			}
+			t.TokenID = new(big.Int).SetBytes(lg.Data[:32])
+			t.Amount = new(big.Int).SetBytes(lg.Data[32:64])
+			out = append(out, t)
+		case transferBatchTopic:
+			vals, err := transferBatchData.Unpack(lg.Data)
+			if err != nil || len(vals) != 2 {
+				continue
+			}
+			ids, _ := vals[0].([]*big.Int)
+			amounts, _ := vals[1].([]*big.Int)
+			for i := range ids {
+				if i >= len(amounts) {
+					break
+				}
+				t.TokenID, t.Amount = ids[i], amounts[i]
+				out = append(out, t)
+			}
+		}
+	}
+	return out, nil
+}
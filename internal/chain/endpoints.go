@@ -0,0 +1,248 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"limitorderbot/internal/apierr"
+)
+
+const (
+	// breakerThreshold consecutive transport failures open an endpoint's circuit.
+	breakerThreshold = 3
+	breakerBaseDelay = 30 * time.Second
+	breakerMaxDelay  = 5 * time.Minute
+)
+
+// EndpointHealth is a point-in-time view of one RPC endpoint.
+type EndpointHealth struct {
+	URL          string     `json:"url"`
+	Healthy      bool       `json:"healthy"`
+	LatencyMS    float64    `json:"latency_ms"`
+	Calls        int64      `json:"calls"`
+	Errors       int64      `json:"errors"`
+	ConsecErrors int        `json:"consecutive_errors"`
+	LastError    string     `json:"last_error,omitempty"`
+	OpenUntil    *time.Time `json:"open_until,omitempty"`
+}
+
+type endpoint struct {
+	url string
+	ec  *ethclient.Client
+
+	latency   time.Duration // EWMA
+	calls     int64
+	errs      int64
+	consec    int
+	lastErr   string
+	openUntil time.Time
+}
+
+type endpointPool struct {
+	mu        sync.Mutex
+	endpoints []*endpoint
+}
+
+func dialPool(urls []string, h *http.Client) (*endpointPool, error) {
+	p := &endpointPool{}
+	var firstErr error
+	for _, u := range urls {
+		var opts []rpc.ClientOption
+		if h != nil {
+			opts = append(opts, rpc.WithHTTPClient(h))
+		}
+		rc, err := rpc.DialOptions(context.Background(), u, opts...)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		p.endpoints = append(p.endpoints, &endpoint{url: u, ec: ethclient.NewClient(rc)})
+	}
+	if len(p.endpoints) == 0 {
+		if firstErr == nil {
+			firstErr = errors.New("no RPC endpoints configured")
+		}
+		return nil, firstErr
+	}
+	return p, nil
+}
+
+func (p *endpointPool) close() {
+	for _, e := range p.endpoints {
+		e.ec.Close()
+	}
+}
+
+// ordered returns endpoints with closed circuits first (lowest latency first),
+// followed by open ones so a fully-tripped pool still gets a chance to recover.
+func (p *endpointPool) ordered() []*endpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	out := make([]*endpoint, len(p.endpoints))
+	copy(out, p.endpoints)
+	sort.SliceStable(out, func(i, j int) bool {
+		oi, oj := out[i].openUntil.After(now), out[j].openUntil.After(now)
+		if oi != oj {
+			return !oi
+		}
+		return out[i].latency < out[j].latency
+	})
+	return out
+}
+
+func (p *endpointPool) best() *endpoint {
+	return p.ordered()[0]
+}
+
+func (p *endpointPool) record(e *endpoint, took time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e.calls++
+	if e.latency == 0 {
+		e.latency = took
+	} else {
+		e.latency = (e.latency*4 + took) / 5
+	}
+	if err == nil {
+		e.consec = 0
+		e.openUntil = time.Time{}
+		return
+	}
+	e.errs++
+	e.consec++
+	e.lastErr = err.Error()
+	if e.consec >= breakerThreshold {
+		d := breakerBaseDelay * time.Duration(e.consec-breakerThreshold+1)
+		if d > breakerMaxDelay {
+			d = breakerMaxDelay
+		}
+		e.openUntil = time.Now().Add(d)
+	}
+}
+
+// do runs fn against endpoints in health order, failing over on transport
+// errors and on JSON-RPC errors that are the node's (rate limits, server
+// trouble). Other JSON-RPC errors (reverts, bad params) are returned as-is
+// since another node would answer the same way.
+func (p *endpointPool) do(ctx context.Context, fn func(ec *ethclient.Client) error) error {
+	var lastErr error
+	for _, e := range p.ordered() {
+		start := time.Now()
+		err := fn(e.ec)
+		if err == nil || isApplicationError(err) {
+			p.record(e, time.Since(start), nil)
+			return classify(err)
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		p.record(e, time.Since(start), err)
+		lastErr = err
+	}
+	return classify(lastErr)
+}
+
+func (p *endpointPool) health() []EndpointHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	out := make([]EndpointHealth, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		h := EndpointHealth{
+			URL:          e.url,
+			Healthy:      !e.openUntil.After(now),
+			LatencyMS:    float64(e.latency.Microseconds()) / 1000,
+			Calls:        e.calls,
+			Errors:       e.errs,
+			ConsecErrors: e.consec,
+			LastError:    e.lastErr,
+		}
+		if e.openUntil.After(now) {
+			t := e.openUntil
+			h.OpenUntil = &t
+		}
+		out = append(out, h)
+	}
+	return out
+}
+
+// classify tags err with its apierr kind: JSON-RPC errors are the node
+// refusing the call (for lack of funds or otherwise), anything else is the
+// transport.
+func classify(err error) error {
+	var httpErr rpc.HTTPError
+	var rpcErr rpc.Error
+	switch {
+	case err == nil, errors.Is(err, ethereum.NotFound):
+		return err
+	case errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests,
+		errors.As(err, &rpcErr) && isRateLimited(rpcErr):
+		return apierr.Wrap(apierr.RateLimited, err)
+	case apierr.KindOf(err) == apierr.InsufficientBalance:
+		return apierr.Wrap(apierr.InsufficientBalance, err)
+	case isApplicationError(err):
+		return apierr.Wrap(apierr.Validation, err)
+	}
+	return apierr.Wrap(apierr.Network, err)
+}
+
+// isApplicationError reports whether err is the call's own fault (a revert,
+// bad params, a refused tx), which every node would answer alike. JSON-RPC
+// errors that are the node's problem instead, rate limits and server
+// trouble, are not: the next endpoint may well serve the call.
+func isApplicationError(err error) bool {
+	if errors.Is(err, ethereum.NotFound) {
+		return true
+	}
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && !isNodeError(rpcErr) && !isRateLimited(rpcErr)
+}
+
+// isRateLimited reports a JSON-RPC error that is a provider's rate limit:
+// -32005 (EIP-1474 "limit exceeded") or the wording providers use under
+// other codes.
+func isRateLimited(e rpc.Error) bool {
+	if e.ErrorCode() == -32005 {
+		return true
+	}
+	msg := strings.ToLower(e.Error())
+	return strings.Contains(msg, "rate limit") || strings.Contains(msg, "too many requests") ||
+		(strings.Contains(msg, "exceeded") && strings.Contains(msg, "limit"))
+}
+
+// nodeErrorMessages are the node-side failures providers report under the
+// generic -32000 code, which execution errors share.
+var nodeErrorMessages = []string{
+	"header not found", "missing trie node", "unknown block", "internal error",
+	"timeout", "timed out", "unavailable", "try again", "capacity", "busy",
+}
+
+// isNodeError reports a JSON-RPC error that is the node failing rather than
+// the call: -32603 (internal error), -32601 (a method this provider lacks),
+// or a -32000-range error worded as in nodeErrorMessages.
+func isNodeError(e rpc.Error) bool {
+	switch code := e.ErrorCode(); {
+	case code == -32603, code == -32601:
+		return true
+	case code <= -32000 && code >= -32099:
+		msg := strings.ToLower(e.Error())
+		for _, m := range nodeErrorMessages {
+			if strings.Contains(msg, m) {
+				return true
+			}
+		}
+	}
+	return false
+}
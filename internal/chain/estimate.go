@@ -0,0 +1,52 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// GasPrice is the node's suggested gas price, the one send pays.
+func (c *Client) GasPrice(ctx context.Context) (*big.Int, error) {
+	var price *big.Int
+	err := c.pool.do(ctx, func(ec *ethclient.Client) error {
+		var err error
+		price, err = ec.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+// EstimateRedeemPositions is the gas RedeemPositions would use; an error
+// usually means the call would revert.
+func (c *Client) EstimateRedeemPositions(ctx context.Context, conditionID [32]byte) (uint64, error) {
+	indexSets := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	return c.estimate(ctx, c.addrs.CTF, erc1155ABI, "redeemPositions", c.addrs.Collateral, [32]byte{}, conditionID, indexSets)
+}
+
+// EstimateRedeemNegRiskPositions is the gas RedeemNegRiskPositions would use.
+func (c *Client) EstimateRedeemNegRiskPositions(ctx context.Context, conditionID [32]byte, amounts []*big.Int) (uint64, error) {
+	if c.addrs.NegRiskAdapter == (common.Address{}) {
+		return 0, fmt.Errorf("no NegRiskAdapter on chain %s", c.chainID)
+	}
+	return c.estimate(ctx, c.addrs.NegRiskAdapter, negRiskAdapterABI, "redeemPositions", conditionID, amounts)
+}
+
+func (c *Client) estimate(ctx context.Context, to common.Address, a abi.ABI, method string, args ...any) (uint64, error) {
+	data, err := a.Pack(method, args...)
+	if err != nil {
+		return 0, err
+	}
+	var gas uint64
+	err = c.pool.do(ctx, func(ec *ethclient.Client) error {
+		var err error
+		gas, err = ec.EstimateGas(ctx, ethereum.CallMsg{From: c.address, To: &to, Data: data})
+		return err
+	})
+	return gas, err
+}
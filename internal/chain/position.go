@@ -0,0 +1,126 @@
+package chain
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// NegRiskWrappedCollateralAddress is the collateral neg-risk positions are
+// minted against on Polygon: the NegRiskAdapter's wrapped USDC.e.
+const NegRiskWrappedCollateralAddress = "0x3A3BD7bb9528E159577F7C2e685CC81A765002E2"
+
+// altbn128 field modulus; the CTF maps collections onto the curve
+// y² = x³ + 3 over it (CTHelpers.getCollectionId).
+var (
+	bnP      = mustBig("21888242871839275222246405745257275088696311157297823662689037894645226208583")
+	bnB      = big.NewInt(3)
+	bnSqrtEx = new(big.Int).Rsh(new(big.Int).Add(bnP, big.NewInt(1)), 2) // P ≡ 3 mod 4
+)
+
+// CollectionID is the CTF collection of indexSet under conditionID with no
+// parent collection, the only kind Polymarket uses.
+func CollectionID(conditionID [32]byte, indexSet *big.Int) [32]byte {
+	h := crypto.Keccak256(conditionID[:], common.LeftPadBytes(indexSet.Bytes(), 32))
+	x := new(big.Int).SetBytes(h)
+	odd := x.Bit(255) == 1
+
+	var y, yy big.Int
+	for {
+		x.Add(x, big.NewInt(1)).Mod(x, bnP)
+		yy.Mul(x, x).Mul(&yy, x).Add(&yy, bnB).Mod(&yy, bnP)
+		y.Exp(&yy, bnSqrtEx, bnP)
+		if new(big.Int).Exp(&y, big.NewInt(2), bnP).Cmp(&yy) == 0 {
+			break
+		}
+	}
+	if odd != (y.Bit(0) == 1) {
+		y.Sub(bnP, &y)
+	}
+	if y.Bit(0) == 1 {
+		x.SetBit(x, 254, x.Bit(254)^1)
+	}
+	var out [32]byte
+	x.FillBytes(out[:])
+	return out
+}
+
+// PositionID is the ERC1155 token id of indexSet under conditionID, backed
+// by collateral (USDC.e, or NegRiskWrappedCollateralAddress for neg-risk
+// markets).
+func PositionID(collateral common.Address, conditionID [32]byte, indexSet *big.Int) *big.Int {
+	coll := CollectionID(conditionID, indexSet)
+	return new(big.Int).SetBytes(crypto.Keccak256(collateral.Bytes(), coll[:]))
+}
+
+// IndexSet is the index set of a single outcome (0 = YES/UP, 1 = NO/DOWN).
+func IndexSet(outcome int) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(outcome))
+}
+
+// PositionCollateral is the collateral a market's positions are minted
+// against: the wrapped collateral for neg-risk markets, else Collateral.
+func (a Addresses) PositionCollateral(negRisk bool) common.Address {
+	if negRisk && a.NegRiskAdapter != (common.Address{}) {
+		return common.HexToAddress(NegRiskWrappedCollateralAddress)
+	}
+	return a.Collateral
+}
+
+// OutcomeTokenIDs are the token ids of a binary market's two outcomes, in
+// outcome order.
+func (a Addresses) OutcomeTokenIDs(conditionID [32]byte, negRisk bool) [2]*big.Int {
+	collateral := a.PositionCollateral(negRisk)
+	return [2]*big.Int{
+		PositionID(collateral, conditionID, IndexSet(0)),
+		PositionID(collateral, conditionID, IndexSet(1)),
+	}
+}
+
+// PositionRef is what a CTF token id stands for.
+type PositionRef struct {
+	ConditionID common.Hash
+	Outcome     int // outcome index; its index set is 1<<Outcome
+	NegRisk     bool
+}
+
+// PositionIndex maps token ids back to their condition and outcome. Position
+// ids are one-way hashes, so it only knows the conditions added to it: it
+// computes their ids (plain and neg-risk) and matches against those.
+type PositionIndex struct {
+	addrs Addresses
+	refs  map[string]PositionRef
+}
+
+// NewPositionIndex returns an empty index for one chain's contracts.
+func NewPositionIndex(addrs Addresses) *PositionIndex {
+	return &PositionIndex{addrs: addrs, refs: map[string]PositionRef{}}
+}
+
+// Add registers a binary condition's token ids.
+func (x *PositionIndex) Add(conditionID [32]byte) {
+	for _, negRisk := range []bool{false, true} {
+		if negRisk && x.addrs.NegRiskAdapter == (common.Address{}) {
+			continue
+		}
+		for i, id := range x.addrs.OutcomeTokenIDs(conditionID, negRisk) {
+			x.refs[id.String()] = PositionRef{ConditionID: conditionID, Outcome: i, NegRisk: negRisk}
+		}
+	}
+}
+
+// Lookup returns the position a token id belongs to, if its condition was
+// added.
+func (x *PositionIndex) Lookup(tokenID *big.Int) (PositionRef, bool) {
+	ref, ok := x.refs[tokenID.String()]
+	return ref, ok
+}
+
+func mustBig(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("bad constant " + s)
+	}
+	return v
+}
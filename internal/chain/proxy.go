@@ -0,0 +1,78 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ProxyFactoryAddress is Polymarket's proxy wallet factory on Polygon. Its
+// proxy() forwards calls to the caller's own proxy wallet, the funder
+// address of SIGNATURE_TYPE=POLY_PROXY accounts.
+const ProxyFactoryAddress = "0xaB45c5A4B0c941a2F231C04C3f49182e1A254052"
+
+var (
+	erc20TransferABI = mustABI(`[{"constant":false,"inputs":[{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"type":"function"}]`)
+	proxyFactoryABI  = mustABI(`[{"inputs":[{"components":[{"name":"typeCode","type":"uint8"},{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}],"name":"calls","type":"tuple[]"}],"name":"proxy","outputs":[{"name":"returnValues","type":"bytes[]"}],"stateMutability":"payable","type":"function"}]`)
+)
+
+// proxyCallTypeCall is ProxyCall.CallType CALL (0 is INVALID, 2 DELEGATECALL).
+const proxyCallTypeCall = 1
+
+type proxyCall struct {
+	TypeCode uint8
+	To       common.Address
+	Value    *big.Int
+	Data     []byte
+}
+
+// CollateralBalanceOf returns owner's CTF collateral (6 decimals) as a float.
+func (c *Client) CollateralBalanceOf(ctx context.Context, owner common.Address) (float64, error) {
+	bal, err := c.ERC20BalanceOf(ctx, c.addrs.Collateral, owner)
+	if err != nil {
+		return 0, err
+	}
+	f, _ := new(big.Rat).SetFrac(bal, big.NewInt(1_000_000)).Float64()
+	return f, nil
+}
+
+// TransferCollateral sends amount6 of collateral from the EOA to `to`, e.g.
+// to top up the proxy wallet.
+func (c *Client) TransferCollateral(ctx context.Context, to common.Address, amount6 *big.Int) (common.Hash, error) {
+	return c.transact(ctx, c.addrs.Collateral, erc20TransferABI, "transfer", to, amount6)
+}
+
+// TransferCollateralAsync is the non-blocking variant of TransferCollateral.
+func (c *Client) TransferCollateralAsync(ctx context.Context, to common.Address, amount6 *big.Int, cb ConfirmFunc) (common.Hash, error) {
+	tx, err := c.send(ctx, c.addrs.Collateral, erc20TransferABI, "transfer", to, amount6)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	c.OnConfirmed(tx.Hash(), cb)
+	return tx.Hash(), nil
+}
+
+// WithdrawFromProxy has the EOA's Polymarket proxy wallet send amount6 of
+// collateral back to the EOA. Gnosis Safe funders are not supported: their
+// transactions need Safe signatures, not a factory call.
+func (c *Client) WithdrawFromProxy(ctx context.Context, amount6 *big.Int) (common.Hash, error) {
+	factory, calls, err := c.proxyTransfer(c.address, amount6)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return c.transact(ctx, factory, proxyFactoryABI, "proxy", calls)
+}
+
+func (c *Client) proxyTransfer(to common.Address, amount6 *big.Int) (common.Address, []proxyCall, error) {
+	if c.chainID.Int64() != 137 {
+		return common.Address{}, nil, errors.New("proxy wallet factory is only known on Polygon (chain 137)")
+	}
+	data, err := erc20TransferABI.Pack("transfer", to, amount6)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	calls := []proxyCall{{TypeCode: proxyCallTypeCall, To: c.addrs.Collateral, Value: big.NewInt(0), Data: data}}
+	return common.HexToAddress(ProxyFactoryAddress), calls, nil
+}
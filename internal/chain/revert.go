@@ -0,0 +1,97 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// RevertError is returned for a transaction that was mined with status 0.
+// Reason is the decoded revert string when replaying the call recovered one.
+type RevertError struct {
+	Hash   common.Hash
+	Block  uint64
+	Reason string
+}
+
+func (e *RevertError) Error() string {
+	msg := fmt.Sprintf("tx %s reverted in block %d", e.Hash.Hex(), e.Block)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// IsRevert reports whether err is (or wraps) a mined-but-reverted tx.
+func IsRevert(err error) bool {
+	var re *RevertError
+	return errors.As(err, &re)
+}
+
+// revertReason replays a reverted tx as an eth_call on the state just before
+// the block it was mined in (the parent block) and decodes the revert data.
+// Best-effort: "" when the node gives nothing back (e.g. out of gas, or state
+// for that block is pruned).
+func (c *Client) revertReason(ctx context.Context, hash common.Hash, rcpt *types.Receipt) string {
+	var tx *types.Transaction
+	err := c.pool.do(ctx, func(ec *ethclient.Client) error {
+		var err error
+		tx, _, err = ec.TransactionByHash(ctx, hash)
+		return err
+	})
+	if err != nil || tx.To() == nil {
+		return ""
+	}
+	msg := ethereum.CallMsg{
+		From:     c.address,
+		To:       tx.To(),
+		Gas:      tx.Gas(),
+		GasPrice: tx.GasPrice(),
+		Value:    tx.Value(),
+		Data:     tx.Data(),
+	}
+	var block *big.Int
+	if rcpt.BlockNumber != nil && rcpt.BlockNumber.Sign() > 0 {
+		block = new(big.Int).Sub(rcpt.BlockNumber, big.NewInt(1))
+	}
+	var callErr error
+	_ = c.pool.do(ctx, func(ec *ethclient.Client) error {
+		_, callErr = ec.CallContract(ctx, msg, block)
+		if callErr != nil && !isApplicationError(callErr) {
+			return callErr
+		}
+		return nil
+	})
+	if callErr == nil {
+		if rcpt.GasUsed >= tx.Gas() {
+			return "out of gas"
+		}
+		return ""
+	}
+	return decodeRevert(callErr)
+}
+
+// decodeRevert pulls Error(string) out of an eth_call error's revert data,
+// falling back to the node's message.
+func decodeRevert(err error) string {
+	var de interface{ ErrorData() interface{} }
+	if errors.As(err, &de) {
+		if s, ok := de.ErrorData().(string); ok {
+			if data, derr := hexutil.Decode(s); derr == nil {
+				if reason, uerr := abi.UnpackRevert(data); uerr == nil {
+					return reason
+				}
+			}
+		}
+	}
+	return strings.TrimPrefix(err.Error(), "execution reverted: ")
+}
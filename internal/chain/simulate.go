@@ -0,0 +1,89 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrDryRun is returned, wrapped in a *DryRunError, by every write while
+// SetDryRun is on: the call was simulated and nothing was sent.
+var ErrDryRun = errors.New("dry run: transaction not sent")
+
+// DryRunError reports a write that was simulated instead of sent.
+type DryRunError struct {
+	Method string
+	To     common.Address
+	Gas    uint64 // estimated; 0 when the estimate failed
+	Output []any  // the method's decoded return values, if any
+}
+
+func (e *DryRunError) Error() string {
+	s := fmt.Sprintf("dry run: %s on %s simulated OK", e.Method, e.To.Hex())
+	if e.Gas > 0 {
+		s += fmt.Sprintf(" (gas ~%d)", e.Gas)
+	}
+	if len(e.Output) > 0 {
+		s += fmt.Sprintf(", returns %v", e.Output)
+	}
+	return s + "; not sent"
+}
+
+func (e *DryRunError) Is(target error) bool { return target == ErrDryRun }
+
+// SimulationError is a write whose eth_call simulation reverted, so it was
+// never sent.
+type SimulationError struct {
+	Method string
+	Reason string // decoded revert reason, else the node's message
+	Err    error
+}
+
+func (e *SimulationError) Error() string {
+	return fmt.Sprintf("%s would revert: %s", e.Method, e.Reason)
+}
+
+func (e *SimulationError) Unwrap() error { return e.Err }
+
+// SetDryRun makes every write simulate with eth_call and return a
+// *DryRunError instead of signing and sending.
+func (c *Client) SetDryRun(v bool) { c.dryRun = v }
+
+// DryRun reports whether SetDryRun is on.
+func (c *Client) DryRun() bool { return c.dryRun }
+
+// simulate runs method as an eth_call from the wallet, so a write that would
+// revert fails here, with its reason, rather than on chain for gas. It
+// returns the decoded outputs.
+func (c *Client) simulate(ctx context.Context, to common.Address, a abi.ABI, method string, args ...any) ([]any, error) {
+	data, err := a.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.callContract(ctx, ethereum.CallMsg{From: c.address, To: &to, Data: data})
+	if err != nil {
+		if !isApplicationError(err) {
+			return nil, err
+		}
+		return nil, &SimulationError{Method: method, Reason: decodeRevert(err), Err: err}
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	out, err := a.Unpack(method, res)
+	if err != nil {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// dryRunResult is the *DryRunError for a simulated method, with its gas
+// estimate.
+func (c *Client) dryRunResult(ctx context.Context, to common.Address, a abi.ABI, method string, out []any, args ...any) error {
+	gas, _ := c.estimate(ctx, to, a, method, args...)
+	return &DryRunError{Method: method, To: to, Gas: gas, Output: out}
+}
@@ -0,0 +1,90 @@
+package chain
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// swapRouterABI is the Uniswap V3 SwapRouter exactInputSingle entry point
+// (the variant whose params carry a deadline).
+var swapRouterABI = mustABI(`[{"inputs":[{"components":[{"name":"tokenIn","type":"address"},{"name":"tokenOut","type":"address"},{"name":"fee","type":"uint24"},{"name":"recipient","type":"address"},{"name":"deadline","type":"uint256"},{"name":"amountIn","type":"uint256"},{"name":"amountOutMinimum","type":"uint256"},{"name":"sqrtPriceLimitX96","type":"uint160"}],"name":"params","type":"tuple"}],"name":"exactInputSingle","outputs":[{"name":"amountOut","type":"uint256"}],"stateMutability":"payable","type":"function"}]`)
+
+// swapDeadline bounds how long a signed swap may sit in the mempool.
+const swapDeadline = 10 * time.Minute
+
+type exactInputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	Fee               *big.Int
+	Recipient         common.Address
+	Deadline          *big.Int
+	AmountIn          *big.Int
+	AmountOutMinimum  *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+// SwapNativeUSDCAsync swaps amountIn (6 decimals) of native USDC into the CTF
+// collateral through a Uniswap V3 style router. The router allowance is
+// topped up first (waiting for that approval); the swap itself returns as
+// soon as it is sent and cb fires on confirmation.
+func (c *Client) SwapNativeUSDCAsync(ctx context.Context, router common.Address, fee uint32, amountIn, minOut *big.Int, cb ConfirmFunc) (common.Hash, error) {
+	params, err := c.swapParams(ctx, router, c.addrs.USDC, c.addrs.Collateral, fee, amountIn, minOut)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := c.send(ctx, router, swapRouterABI, "exactInputSingle", params)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	c.OnConfirmed(tx.Hash(), cb)
+	return tx.Hash(), nil
+}
+
+// SwapUSDC swaps amountIn (6 decimals) between the CTF collateral and native
+// USDC through a Uniswap V3 style router: collateral into native USDC when
+// toNative is set, native USDC into collateral otherwise. It approves the
+// router when needed and waits for the swap like transact.
+func (c *Client) SwapUSDC(ctx context.Context, router common.Address, fee uint32, toNative bool, amountIn, minOut *big.Int) (common.Hash, error) {
+	tokenIn, tokenOut := c.addrs.USDC, c.addrs.Collateral
+	if toNative {
+		tokenIn, tokenOut = tokenOut, tokenIn
+	}
+	params, err := c.swapParams(ctx, router, tokenIn, tokenOut, fee, amountIn, minOut)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return c.transact(ctx, router, swapRouterABI, "exactInputSingle", params)
+}
+
+// swapParams tops up the router's tokenIn allowance (waiting for that
+// approval) and builds the exactInputSingle params paying out to the wallet.
+func (c *Client) swapParams(ctx context.Context, router, tokenIn, tokenOut common.Address, fee uint32, amountIn, minOut *big.Int) (exactInputSingleParams, error) {
+	allow, err := c.ERC20Allowance(ctx, tokenIn, router)
+	if err != nil {
+		return exactInputSingleParams{}, err
+	}
+	if allow.Cmp(amountIn) < 0 {
+		if _, err := c.transactConfirmed(ctx, tokenIn, erc20ABI, "approve", router, amountIn); err != nil {
+			return exactInputSingleParams{}, err
+		}
+	}
+	return exactInputSingleParams{
+		TokenIn:           tokenIn,
+		TokenOut:          tokenOut,
+		Fee:               big.NewInt(int64(fee)),
+		Recipient:         c.address,
+		Deadline:          big.NewInt(time.Now().Add(swapDeadline).Unix()),
+		AmountIn:          amountIn,
+		AmountOutMinimum:  minOut,
+		SqrtPriceLimitX96: big.NewInt(0),
+	}, nil
+}
+
+// NativeUSDCBalance returns the wallet's native USDC, which the CTF does not
+// accept as collateral.
+func (c *Client) NativeUSDCBalance(ctx context.Context) (float64, error) {
+	return c.ERC20BalanceFloat6(ctx, c.addrs.USDC)
+}
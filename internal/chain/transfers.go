@@ -0,0 +1,107 @@
+package chain
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// transferTopic is the ERC20 Transfer(address,address,uint256) event.
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// Transfer is one collateral Transfer log.
+type Transfer struct {
+	Block    uint64
+	TxHash   common.Hash
+	LogIndex uint
+	From     common.Address
+	To       common.Address
+	Amount   float64 // collateral, 6 decimals applied
+}
+
+// HeadBlock returns the latest block number.
+func (c *Client) HeadBlock(ctx context.Context) (uint64, error) {
+	var head uint64
+	err := c.pool.do(ctx, func(ec *ethclient.Client) error {
+		var err error
+		head, err = ec.BlockNumber(ctx)
+		return err
+	})
+	return head, err
+}
+
+// BlockTime returns when block n was built.
+func (c *Client) BlockTime(ctx context.Context, n uint64) (time.Time, error) {
+	var h *types.Header
+	err := c.pool.do(ctx, func(ec *ethclient.Client) error {
+		var err error
+		h, err = ec.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+		return err
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(h.Time), 0).UTC(), nil
+}
+
+// CollateralTransfers lists collateral Transfer logs into or out of owner
+// in blocks [from, to], in chain order. Public RPC endpoints cap log
+// queries at a few thousand blocks, so callers keep ranges short.
+func (c *Client) CollateralTransfers(ctx context.Context, owner common.Address, from, to uint64) ([]Transfer, error) {
+	ownerTopic := common.BytesToHash(owner.Bytes())
+	queries := [][][]common.Hash{
+		{{transferTopic}, {ownerTopic}},
+		{{transferTopic}, nil, {ownerTopic}},
+	}
+	seen := map[common.Hash]map[uint]bool{}
+	var out []Transfer
+	for _, topics := range queries {
+		q := ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{c.addrs.Collateral},
+			Topics:    topics,
+		}
+		var logs []types.Log
+		err := c.pool.do(ctx, func(ec *ethclient.Client) error {
+			var err error
+			logs, err = ec.FilterLogs(ctx, q)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, lg := range logs {
+			if len(lg.Topics) < 3 || len(lg.Data) < 32 || seen[lg.TxHash][lg.Index] {
+				continue
+			}
+			if seen[lg.TxHash] == nil {
+				seen[lg.TxHash] = map[uint]bool{}
+			}
+			seen[lg.TxHash][lg.Index] = true
+			amount, _ := new(big.Float).Quo(new(big.Float).SetInt(new(big.Int).SetBytes(lg.Data[:32])), big.NewFloat(1e6)).Float64()
+			out = append(out, Transfer{
+				Block:    lg.BlockNumber,
+				TxHash:   lg.TxHash,
+				LogIndex: lg.Index,
+				From:     common.BytesToAddress(lg.Topics[1].Bytes()),
+				To:       common.BytesToAddress(lg.Topics[2].Bytes()),
+				Amount:   amount,
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Block != out[j].Block {
+			return out[i].Block < out[j].Block
+		}
+		return out[i].LogIndex < out[j].LogIndex
+	})
+	return out, nil
+}
@@ -10,39 +10,33 @@ import (
 	"github.com/spf13/cobra"
 
 	"limitorderbot/internal/chain"
+	"limitorderbot/internal/clob"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
 )
 
-var spenderList = []struct {
-	Addr string
-	Name string
-}{
-	{"0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E", "CTF Exchange"},
-	{"0xC5d563A36AE78145C45a50134d48A1215220f80a", "Neg Risk CTF Exchange"},
-	{"0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296", "Neg Risk Adapter"},
-}
-
 func newAllowancesCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "allowances",
-		Short: "检查/设置 Polymarket 交易所需 allowances",
+		Short: i18n.T("allowances.short"),
 	}
 	cmd.AddCommand(newAllowancesCheckCmd())
 	cmd.AddCommand(newAllowancesSetUSDCCmd())
 	cmd.AddCommand(newAllowancesSetAllCmd())
+	cmd.AddCommand(newAllowancesRevokeCmd())
 	return cmd
 }
 
 func newAllowancesCheckCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "check",
-		Short: "检查 USDC allowance + CTF approval",
+		Short: i18n.T("allowances.check.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
@@ -53,11 +47,11 @@ func newAllowancesCheckCmd() *cobra.Command {
 
 			fmt.Printf("Wallet: %s\n", ch.Address().Hex())
 			allGood := true
-			usdc := common.HexToAddress(chain.USDCeAddress)
-			ctf := common.HexToAddress(chain.CTFAddress)
+			usdc := ch.Addresses().Collateral
+			ctf := ch.Addresses().CTF
 
-			for _, s := range spenderList {
-				sp := common.HexToAddress(s.Addr)
+			for _, s := range clob.Spenders(cfg.ChainID) {
+				sp := common.HexToAddress(s.Address)
 				allow, err := ch.ERC20Allowance(ctx, usdc, sp)
 				if err != nil {
 					return err
@@ -70,9 +64,11 @@ func newAllowancesCheckCmd() *cobra.Command {
 					return err
 				}
 				fmt.Printf("\n%s:\n", s.Name)
-				fmt.Printf("  Address: %s\n", s.Addr)
+				fmt.Printf("  Address: %s\n", s.Address)
 				fmt.Printf("  USDC Allowance: $%.2f", allowF)
-				if allow.Sign() > 0 {
+				if cfg.AllowanceExactUSD > 0 && allow.Sign() > 0 && allowF < cfg.AllowanceExactUSD/2 {
+					fmt.Printf(" [LOW] (the bot tops it up to $%.2f when SIGNATURE_TYPE=EOA)\n", cfg.AllowanceExactUSD)
+				} else if allow.Sign() > 0 {
 					fmt.Printf(" [OK]\n")
 				} else {
 					fmt.Printf(" [NOT SET]\n")
@@ -100,19 +96,30 @@ func newAllowancesCheckCmd() *cobra.Command {
 
 func newAllowancesSetAllCmd() *cobra.Command {
 	var approveUSDC float64
+	var exact, dryRun bool
 	cmd := &cobra.Command{
 		Use:   "set-all",
-		Short: "为三个 spender 设置 USDC approve + CTF setApprovalForAll",
+		Short: i18n.T("allowances.set_all.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			if exact {
+				if approveUSDC > 0 {
+					return fmt.Errorf("--exact and --approve-usdc are mutually exclusive")
+				}
+				if cfg.AllowanceExactUSD <= 0 {
+					return fmt.Errorf("--exact needs ALLOWANCE_EXACT_USD: the working capital to approve")
+				}
+				approveUSDC = cfg.AllowanceExactUSD
+			}
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
 			defer ch.Close()
+			ch.SetDryRun(dryRun)
 
 			ctx, cancel := chain.WithTimeout(context.Background(), 2*time.Minute)
 			defer cancel()
@@ -124,23 +131,18 @@ func newAllowancesSetAllCmd() *cobra.Command {
 				amount = big.NewInt(1_000_000 * 1_000_000) // 1,000,000 USDC
 			}
 
-			for _, s := range spenderList {
-				sp := common.HexToAddress(s.Addr)
-				fmt.Printf("\nProcessing %s (%s)\n", s.Name, s.Addr)
+			for _, s := range clob.Spenders(cfg.ChainID) {
+				sp := common.HexToAddress(s.Address)
+				fmt.Printf("\nProcessing %s (%s)\n", s.Name, s.Address)
+				if allow, err := ch.ERC20Allowance(ctx, ch.Addresses().Collateral, sp); err == nil {
+					fmt.Printf("  USDC allowance: $%.2f → $%.2f\n", toFloat6(allow), toFloat6(amount))
+				}
 
 				tx1, err := ch.ApproveUSDC(ctx, sp, amount)
-				if err != nil {
-					fmt.Printf("  USDC approve ERROR: %v\n", err)
-				} else {
-					fmt.Printf("  USDC approve TX: %s\n", tx1.Hex())
-				}
+				txLine("USDC approve", tx1, err)
 
 				tx2, err := ch.SetCTFApprovalForAll(ctx, sp, true)
-				if err != nil {
-					fmt.Printf("  CTF approval ERROR: %v\n", err)
-				} else {
-					fmt.Printf("  CTF approval TX: %s\n", tx2.Hex())
-				}
+				txLine("CTF approval", tx2, err)
 			}
 
 			fmt.Println("\nDone.")
@@ -148,6 +150,87 @@ func newAllowancesSetAllCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().Float64Var(&approveUSDC, "approve-usdc", 0, "approve amount in USDC (default 1,000,000)")
+	cmd.Flags().BoolVar(&exact, "exact", false, "approve only ALLOWANCE_EXACT_USD (the bot tops it up as fills use it)")
+	addDryRunFlag(cmd, &dryRun)
+	return cmd
+}
+
+// newAllowancesRevokeCmd zeroes the USDC allowances and CTF approvals that
+// set-all granted, skipping those already clear.
+func newAllowancesRevokeCmd() *cobra.Command {
+	var spender string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: i18n.T("allowances.revoke.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			spenders := clob.Spenders(cfg.ChainID)
+			if spender != "" {
+				if !common.IsHexAddress(spender) {
+					return fmt.Errorf("invalid --spender %q", spender)
+				}
+				spenders = []clob.Spender{{Address: spender, Name: "Spender"}}
+			}
+			ch, err := newChainClient(cfg)
+			if err != nil {
+				return err
+			}
+			defer ch.Close()
+			ch.SetDryRun(dryRun)
+
+			ctx, cancel := chain.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			fmt.Printf("Wallet: %s\n", ch.Address().Hex())
+			failed := 0
+			for _, s := range spenders {
+				sp := common.HexToAddress(s.Address)
+				fmt.Printf("\nProcessing %s (%s)\n", s.Name, s.Address)
+
+				allow, err := ch.ERC20Allowance(ctx, ch.Addresses().Collateral, sp)
+				switch {
+				case err != nil:
+					fmt.Printf("  USDC allowance ERROR: %v\n", err)
+					failed++
+				case allow.Sign() == 0:
+					fmt.Printf("  USDC allowance: already 0\n")
+				default:
+					fmt.Printf("  USDC allowance: $%.2f → $0.00\n", toFloat6(allow))
+					if tx, err := ch.ApproveUSDC(ctx, sp, big.NewInt(0)); !txLine("USDC revoke", tx, err) {
+						failed++
+					}
+				}
+
+				approved, err := ch.ERC1155IsApprovedForAll(ctx, ch.Addresses().CTF, sp)
+				switch {
+				case err != nil:
+					fmt.Printf("  CTF approval ERROR: %v\n", err)
+					failed++
+				case !approved:
+					fmt.Printf("  CTF approval: already revoked\n")
+				default:
+					if tx, err := ch.SetCTFApprovalForAll(ctx, sp, false); !txLine("CTF revoke", tx, err) {
+						failed++
+					}
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d revocation(s) failed", failed)
+			}
+			if dryRun {
+				fmt.Println("\nDry run done; nothing was sent.")
+				return nil
+			}
+			fmt.Println("\nDone. Orders can't fill until you run `allowances set-all` again.")
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&spender, "spender", "", "revoke only this spender (0x...); default all exchange contracts")
+	addDryRunFlag(cmd, &dryRun)
 	return cmd
 }
 
@@ -155,23 +238,29 @@ func newAllowancesSetAllCmd() *cobra.Command {
 func newAllowancesSetUSDCCmd() *cobra.Command {
 	var spender string
 	var approveUSDC float64
+	var dryRun bool
 	cmd := &cobra.Command{
 		Use:   "set-usdc",
-		Short: "为单个 spender 设置 USDC allowance（等价 set_allowance.py）",
+		Short: i18n.T("allowances.set.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
 			defer ch.Close()
+			ch.SetDryRun(dryRun)
 
 			if spender == "" {
 				// Default to Neg Risk CTF Exchange (python set_allowance.py).
-				spender = "0xC5d563A36AE78145C45a50134d48A1215220f80a"
+				cc, err := clob.GetContractConfig(cfg.ChainID, true)
+				if err != nil {
+					return err
+				}
+				spender = cc.Exchange
 			}
 
 			ctx, cancel := chain.WithTimeout(context.Background(), 2*time.Minute)
@@ -187,6 +276,9 @@ func newAllowancesSetUSDCCmd() *cobra.Command {
 			fmt.Printf("Approving: %.2f USDC\n", float64(amount.Int64())/1_000_000)
 
 			tx, err := ch.ApproveUSDC(ctx, common.HexToAddress(spender), amount)
+			if isDryRun(err) {
+				return nil
+			}
 			if err != nil {
 				return err
 			}
@@ -196,5 +288,6 @@ func newAllowancesSetUSDCCmd() *cobra.Command {
 	}
 	cmd.Flags().StringVar(&spender, "spender", "", "spender address (0x...)")
 	cmd.Flags().Float64Var(&approveUSDC, "approve-usdc", 0, "approve amount in USDC (default 1,000,000)")
+	addDryRunFlag(cmd, &dryRun)
 	return cmd
 }
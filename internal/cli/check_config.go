@@ -1,29 +1,65 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 
+	"limitorderbot/internal/chain"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
 )
 
 func newCheckConfigCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "check-config",
-		Short: "检查 .env 配置并退出",
+		Short: i18n.T("check_config.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
 			fmt.Println("\n✓ Configuration is valid!")
-			fmt.Printf("  - Wallet address will be derived from private key\n")
+			fmt.Printf("  - Network: %s (chain %d)\n", cfg.Network, cfg.ChainID)
+			fmt.Printf("  - Wallet address will be derived from %s\n", cfg.Keys.Source())
 			fmt.Printf("  - Order size: $%.2f per order\n", cfg.OrderSizeUSD)
 			fmt.Printf("  - Spread offset: %.4f\n", cfg.SpreadOffset)
-			fmt.Printf("  - Check interval: %ds\n", cfg.CheckIntervalSeconds)
+			fmt.Printf("  - Discovery interval: %ds\n", cfg.DiscoveryIntervalSeconds)
+			fmt.Printf("  - Order status interval: %ds\n", cfg.OrderStatusIntervalSeconds)
+			fmt.Printf("  - Price interval: %ds\n", cfg.PriceIntervalSeconds)
 			fmt.Printf("  - Dashboard: http://%s:%d\n", cfg.DashboardHost, cfg.DashboardPort)
-			return nil
+			return checkAccount(cfg)
 		},
 	}
 }
+
+// checkAccount runs the bot's startup signer/funder check. Only a mismatch
+// fails the command; an unreachable RPC is reported and skipped.
+func checkAccount(cfg config.Config) error {
+	ch, err := newChainClient(cfg)
+	if err != nil {
+		fmt.Printf("  ! Signer/funder not checked: %v\n", err)
+		return nil
+	}
+	defer ch.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	fmt.Printf("  - Signer: %s (%s)\n", ch.Address().Hex(), cfg.SignatureType)
+	if cfg.FunderAddress != "" {
+		fmt.Printf("  - Funder: %s\n", common.HexToAddress(cfg.FunderAddress).Hex())
+	}
+	err = ch.CheckFunder(ctx, cfg.SignatureType, common.HexToAddress(cfg.FunderAddress))
+	switch {
+	case errors.Is(err, chain.ErrAccountMismatch):
+		return err
+	case err != nil:
+		fmt.Printf("  ! Signer/funder not checked: %v\n", err)
+	default:
+		fmt.Println("  ✓ Signer and funder match SIGNATURE_TYPE")
+	}
+	return nil
+}
@@ -11,16 +11,29 @@ import (
 	"limitorderbot/internal/clob"
 	"limitorderbot/internal/config"
 	"limitorderbot/internal/gamma"
+	"limitorderbot/internal/i18n"
 	"limitorderbot/internal/models"
 )
 
+// newCLOBClient builds a CLOB client with the CLOB_HTTP_* tuning applied.
+func newCLOBClient(cfg config.Config) (*clob.Client, error) {
+	cc, err := clob.NewClient(cfg.ClobAPIURL, cfg.ChainID, cfg.Keys, cfg.SignatureType, cfg.FunderAddress)
+	if err != nil {
+		return nil, err
+	}
+	cc.SetHTTPClient(cfg.HTTPClient(cfg.ClobHTTP))
+	cc.SetCacheLimits(cfg.ClobCacheSize, time.Duration(cfg.ClobCacheTTLMinutes)*time.Minute)
+	return cc, nil
+}
+
 func newCLOBCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "clob",
-		Short: "Polymarket CLOB 工具：查单/更新 L2 allowance/测试下单",
+		Short: i18n.T("clob.short"),
 	}
 	cmd.AddCommand(newCLOBOpenOrdersCmd())
 	cmd.AddCommand(newCLOBUpdateL2BalanceCmd())
+	cmd.AddCommand(newCLOBScoringCmd())
 	cmd.AddCommand(newCLOBPlaceTestCmd())
 	return cmd
 }
@@ -30,13 +43,13 @@ func newCLOBOpenOrdersCmd() *cobra.Command {
 	var assetID string
 	cmd := &cobra.Command{
 		Use:   "open-orders",
-		Short: "查询当前钱包的 open orders（等价 check_open_orders.py）",
+		Short: i18n.T("clob.orders.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			cc, err := clob.NewClient(cfg.ClobAPIURL, cfg.ChainID, cfg.PrivateKey, cfg.SignatureType, cfg.FunderAddress)
+			cc, err := newCLOBClient(cfg)
 			if err != nil {
 				return err
 			}
@@ -70,19 +83,61 @@ func newCLOBOpenOrdersCmd() *cobra.Command {
 	return cmd
 }
 
+func newCLOBScoringCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "scoring <order-id>...",
+		Short: i18n.T("clob.scoring.short"),
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			cc, err := newCLOBClient(cfg)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+			defer cancel()
+			creds, err := cc.CreateOrDeriveAPICreds(ctx, 0)
+			if err != nil {
+				return err
+			}
+			cc.SetCreds(creds)
+
+			if len(args) == 1 {
+				ok, err := cc.IsOrderScoring(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%s scoring=%v\n", args[0], ok)
+				return nil
+			}
+			scoring, err := cc.AreOrdersScoring(ctx, args)
+			if err != nil {
+				return err
+			}
+			for _, id := range args {
+				fmt.Printf("%s scoring=%v\n", id, scoring[id])
+			}
+			return nil
+		},
+	}
+}
+
 func newCLOBUpdateL2BalanceCmd() *cobra.Command {
 	var assetType string
 	var tokenID string
 	var signatureType int
 	cmd := &cobra.Command{
 		Use:   "update-l2-balance",
-		Short: "调用 /balance-allowance/update 并输出 /balance-allowance（等价 update_l2_balance.py）",
+		Short: i18n.T("clob.update_balance.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			cc, err := clob.NewClient(cfg.ClobAPIURL, cfg.ChainID, cfg.PrivateKey, cfg.SignatureType, cfg.FunderAddress)
+			cc, err := newCLOBClient(cfg)
 			if err != nil {
 				return err
 			}
@@ -95,7 +150,7 @@ func newCLOBUpdateL2BalanceCmd() *cobra.Command {
 			cc.SetCreds(creds)
 
 			if assetType == "" {
-				assetType = "COLLATERAL"
+				assetType = clob.AssetCollateral
 			}
 			params := &clob.BalanceAllowanceParams{
 				AssetType:     strings.ToUpper(assetType),
@@ -128,16 +183,23 @@ func newCLOBUpdateL2BalanceCmd() *cobra.Command {
 func newCLOBPlaceTestCmd() *cobra.Command {
 	var price float64
 	var size float64
+	var side string
 	var yes bool
 	cmd := &cobra.Command{
 		Use:   "place-test",
-		Short: "在第一个可用 BTC 15m 市场下 2 笔测试单（等价 place_test_order.py/test_small_order.py）",
+		Short: i18n.T("clob.place_test.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			side = strings.ToUpper(strings.TrimSpace(side))
+			if side != clob.OrderSideBuy && side != clob.OrderSideSell {
+				return fmt.Errorf("--side must be BUY or SELL, got %q", side)
+			}
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
 			disc := gamma.New(cfg.GammaAPIBaseURL)
+			disc.HTTP = cfg.HTTPClient(cfg.GammaHTTP)
+			disc.Horizon = cfg.DiscoveryHorizonFor(15 * time.Minute)
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
 			markets, err := disc.DiscoverBTC15mMarkets(ctx)
@@ -149,13 +211,13 @@ func newCLOBPlaceTestCmd() *cobra.Command {
 			}
 			m := markets[0]
 			fmt.Printf("Using market: %s\n", m.MarketSlug)
-			fmt.Printf("Price: %.2f, Size: %.2f shares\n", price, size)
+			fmt.Printf("Side: %s, Price: %.2f, Size: %.2f shares\n", side, price, size)
 			if !yes {
 				fmt.Println("Dry-run: add --yes to actually place orders.")
 				return nil
 			}
 
-			cc, err := clob.NewClient(cfg.ClobAPIURL, cfg.ChainID, cfg.PrivateKey, cfg.SignatureType, cfg.FunderAddress)
+			cc, err := newCLOBClient(cfg)
 			if err != nil {
 				return err
 			}
@@ -172,11 +234,22 @@ func newCLOBPlaceTestCmd() *cobra.Command {
 
 			placed := 0
 			for _, out := range []models.Outcome{*yesOut, *noOut} {
+				if side == clob.OrderSideSell {
+					// Only shares already held can be offered; skip the outcomes we don't hold.
+					held, err := cc.ConditionalBalance(ctx, out.TokenID)
+					if err != nil {
+						return err
+					}
+					if held < size {
+						fmt.Printf("Skipping SELL %s: hold %.4f shares < %.2f\n", out.Outcome, held, size)
+						continue
+					}
+				}
 				args := clob.OrderArgs{
 					TokenID:    out.TokenID,
 					Price:      price,
 					Size:       size,
-					Side:       clob.OrderSideBuy,
+					Side:       side,
 					FeeRateBps: 0,
 					Nonce:      0,
 					Expiration: 0,
@@ -190,7 +263,7 @@ func newCLOBPlaceTestCmd() *cobra.Command {
 					return err
 				}
 				placed++
-				fmt.Printf("Placed BUY %s token_id=%s resp=%v\n", out.Outcome, out.TokenID, resp)
+				fmt.Printf("Placed %s %s token_id=%s resp=%v\n", side, out.Outcome, out.TokenID, resp)
 			}
 			fmt.Printf("\nPlaced %d order(s)\n", placed)
 			return nil
@@ -198,7 +271,8 @@ func newCLOBPlaceTestCmd() *cobra.Command {
 	}
 	cmd.Flags().Float64Var(&price, "price", 0.49, "limit price")
 	cmd.Flags().Float64Var(&size, "size", 10.0, "shares per order")
-	cmd.Flags().BoolVar(&yes, "yes", false, "确认下单")
+	cmd.Flags().StringVar(&side, "side", "BUY", i18n.T("clob.flag.side"))
+	cmd.Flags().BoolVar(&yes, "yes", false, i18n.T("clob.flag.yes"))
 	return cmd
 }
 
@@ -4,16 +4,18 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"os"
 	"sort"
+	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 
 	"limitorderbot/internal/chain"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
 )
 
 const (
@@ -23,33 +25,37 @@ const (
 func newCTFCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "ctf",
-		Short: "CTF (ERC1155) 工具：扫描/查余额",
+		Short: i18n.T("ctf.short"),
 	}
 	cmd.AddCommand(newCTFScanCmd())
 	cmd.AddCommand(newCTFBalanceCmd())
+	cmd.AddCommand(newCTFPositionIDCmd())
+	cmd.AddCommand(newCTFIdentifyCmd())
 	return cmd
 }
 
 func newCTFScanCmd() *cobra.Command {
-	var blocks int64
+	var blocks, chunk int64
 	cmd := &cobra.Command{
 		Use:   "scan",
-		Short: "扫描最近 N 个区块内转入的 CTF tokenId，并输出当前余额",
+		Short: i18n.T("ctf.scan.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			if chunk <= 0 {
+				return fmt.Errorf("--chunk must be positive")
+			}
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
 			defer ch.Close()
 
-			ctx, cancel := chain.WithTimeout(context.Background(), 60*time.Second)
-			defer cancel()
-
-			latest, err := ch.EthClient().BlockNumber(ctx)
+			ctx, cancel := chain.WithTimeout(context.Background(), 30*time.Second)
+			latest, err := ch.HeadBlock(ctx)
+			cancel()
 			if err != nil {
 				return err
 			}
@@ -61,35 +67,44 @@ func newCTFScanCmd() *cobra.Command {
 			fmt.Printf("Wallet: %s\n", ch.Address().Hex())
 			fmt.Printf("Scanning blocks %d to %d...\n\n", from, latest)
 
-			logs, err := ch.EthClient().FilterLogs(ctx, ethereum.FilterQuery{
-				FromBlock: big.NewInt(from),
-				ToBlock:   big.NewInt(int64(latest)),
-				Addresses: []common.Address{common.HexToAddress(chain.CTFAddress)},
-				Topics: [][]common.Hash{
-					{common.HexToHash(transferSingleTopic)},
-					nil,
-					nil,
-					{topicAddress(ch.Address())},
-				},
-			})
-			if err != nil {
-				return err
+			// Query in chunks, halving one the RPC rejects (most cap log
+			// ranges or result counts).
+			var transfers []chain.CTFTransfer
+			step := uint64(chunk)
+			for start := uint64(from); start <= latest; {
+				end := min(start+step-1, latest)
+				ctx, cancel := chain.WithTimeout(context.Background(), 30*time.Second)
+				got, err := ch.CTFTransfersTo(ctx, ch.Address(), start, end)
+				cancel()
+				if err != nil {
+					if step == 1 {
+						fmt.Fprintln(os.Stderr)
+						return fmt.Errorf("blocks %d-%d: %w", start, end, err)
+					}
+					step = max(step/2, 1)
+					continue
+				}
+				transfers = append(transfers, got...)
+				fmt.Fprintf(os.Stderr, "\r  blocks %d/%d scanned, %d transfer(s)", end-uint64(from)+1, latest-uint64(from)+1, len(transfers))
+				start = end + 1
 			}
-			if len(logs) == 0 {
+			fmt.Fprintln(os.Stderr)
+			if len(transfers) == 0 {
 				fmt.Println("No recent transfers found.")
 				return nil
 			}
 
+			ctx, cancel = chain.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+			idx, labels := walletPositionIndex(ctx, cfg, ch)
+
 			tokenIDs := map[string]struct{}{}
-			for _, lg := range logs {
-				id, amt, ok := decodeTransferSingle(lg)
-				if !ok {
-					continue
-				}
-				tokenIDs[id.String()] = struct{}{}
-				fmt.Printf("Token ID: %s\n", id.String())
-				fmt.Printf("  Amount received: %.6f shares\n", toFloat6(amt))
-				fmt.Printf("  Block: %d\n\n", lg.BlockNumber)
+			for _, t := range transfers {
+				tokenIDs[t.TokenID.String()] = struct{}{}
+				fmt.Printf("Token ID: %s\n", t.TokenID.String())
+				printTokenCondition(idx, labels, t.TokenID)
+				fmt.Printf("  Amount received: %.6f shares\n", toFloat6(t.Amount))
+				fmt.Printf("  Block: %d\n\n", t.Block)
 			}
 
 			fmt.Println(repeat("=", 60))
@@ -105,13 +120,14 @@ func newCTFScanCmd() *cobra.Command {
 			for _, idStr := range ids {
 				id := new(big.Int)
 				id.SetString(idStr, 10)
-				bal, err := ch.ERC1155BalanceOf(ctx, common.HexToAddress(chain.CTFAddress), id)
+				bal, err := ch.ERC1155BalanceOf(ctx, ch.Addresses().CTF, id)
 				if err != nil {
 					fmt.Printf("Token %s: ERROR %v\n", idStr, err)
 					continue
 				}
 				f := toFloat6(bal)
 				fmt.Printf("Token ID: %s\n", idStr)
+				printTokenCondition(idx, labels, id)
 				fmt.Printf("  Current balance: %.6f shares\n", f)
 				if bal.Sign() > 0 {
 					total += f
@@ -126,14 +142,141 @@ func newCTFScanCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().Int64Var(&blocks, "blocks", 10_000, "lookback blocks (default ~5 hours on Polygon)")
+	cmd.Flags().Int64Var(&chunk, "chunk", 2_000, "blocks per log query; halved automatically when the RPC rejects a range")
+	return cmd
+}
+
+// walletPositionIndex indexes the conditions of the wallet's data-api
+// markets, with each outcome's label. Best effort: a data-api error leaves
+// it empty.
+func walletPositionIndex(ctx context.Context, cfg config.Config, ch *chain.Client) (*chain.PositionIndex, map[chain.PositionRef]string) {
+	idx := chain.NewPositionIndex(ch.Addresses())
+	labels := map[chain.PositionRef]string{}
+	positions, err := fetchPositions(ctx, cfg.HTTPClient(cfg.DataAPIHTTP), cfg.DataAPIURL, ch.Address().Hex())
+	if err != nil {
+		fmt.Printf("(condition ids unavailable: %v)\n\n", err)
+		return idx, labels
+	}
+	for _, p := range positions {
+		if !strings.HasPrefix(p.ConditionID, "0x") {
+			continue
+		}
+		cid := common.HexToHash(p.ConditionID)
+		idx.Add(cid)
+		labels[chain.PositionRef{ConditionID: cid, Outcome: p.OutcomeIndex, NegRisk: p.NegativeRisk}] = p.Outcome
+	}
+	return idx, labels
+}
+
+func printTokenCondition(idx *chain.PositionIndex, labels map[chain.PositionRef]string, id *big.Int) {
+	ref, ok := idx.Lookup(id)
+	if !ok {
+		fmt.Printf("  Condition: unknown (not among the wallet's data-api markets)\n")
+		return
+	}
+	kind := ""
+	if ref.NegRisk {
+		kind = ", neg-risk"
+	}
+	if label := labels[ref]; label != "" {
+		fmt.Printf("  Condition: %s (outcome %d %q%s)\n", ref.ConditionID.Hex(), ref.Outcome, label, kind)
+		return
+	}
+	fmt.Printf("  Condition: %s (outcome %d%s)\n", ref.ConditionID.Hex(), ref.Outcome, kind)
+}
+
+func newCTFPositionIDCmd() *cobra.Command {
+	var conditionID string
+	var negRisk bool
+	cmd := &cobra.Command{
+		Use:   "position-id",
+		Short: i18n.T("ctf.position_id.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			cid, err := parseConditionID(conditionID)
+			if err != nil {
+				return err
+			}
+			addrs, err := chain.AddressesForChain(cfg.ChainID)
+			if err != nil {
+				return err
+			}
+			collateral := addrs.PositionCollateral(negRisk)
+			fmt.Printf("Condition:  %s\n", common.Hash(cid).Hex())
+			fmt.Printf("Collateral: %s\n", collateral.Hex())
+			for i, id := range addrs.OutcomeTokenIDs(cid, negRisk) {
+				coll := chain.CollectionID(cid, chain.IndexSet(i))
+				fmt.Printf("Outcome %d (index set %d)\n", i, chain.IndexSet(i))
+				fmt.Printf("  Collection ID: %s\n", common.Hash(coll).Hex())
+				fmt.Printf("  Token ID:      %s\n", id)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&conditionID, "condition-id", "", "condition id (0x-prefixed bytes32)")
+	cmd.Flags().BoolVar(&negRisk, "neg-risk", false, "neg-risk market (positions backed by the adapter's wrapped collateral)")
+	_ = cmd.MarkFlagRequired("condition-id")
 	return cmd
 }
 
+func newCTFIdentifyCmd() *cobra.Command {
+	var conditionIDs []string
+	cmd := &cobra.Command{
+		Use:   "identify <token-id>...",
+		Short: i18n.T("ctf.identify.short"),
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			ch, err := newChainClient(cfg)
+			if err != nil {
+				return err
+			}
+			defer ch.Close()
+			ctx, cancel := chain.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			idx, labels := walletPositionIndex(ctx, cfg, ch)
+			for _, s := range conditionIDs {
+				cid, err := parseConditionID(s)
+				if err != nil {
+					return err
+				}
+				idx.Add(cid)
+			}
+			for _, s := range args {
+				id, ok := new(big.Int).SetString(s, 10)
+				if !ok {
+					return fmt.Errorf("invalid token id %q", s)
+				}
+				fmt.Printf("Token ID: %s\n", id)
+				printTokenCondition(idx, labels, id)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVar(&conditionIDs, "condition-id", nil, "extra condition ids to match against, beyond the wallet's data-api markets")
+	return cmd
+}
+
+func parseConditionID(s string) ([32]byte, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "0x") || len(s) != 66 {
+		return [32]byte{}, fmt.Errorf("invalid condition id %q (want 0x + 64 hex chars)", s)
+	}
+	return common.HexToHash(s), nil
+}
+
 func newCTFBalanceCmd() *cobra.Command {
 	var tokenID string
 	cmd := &cobra.Command{
 		Use:   "balance",
-		Short: "查询指定 tokenId 的 CTF balanceOf",
+		Short: i18n.T("ctf.balance.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -147,7 +290,7 @@ func newCTFBalanceCmd() *cobra.Command {
 				return fmt.Errorf("invalid token id")
 			}
 
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
@@ -155,7 +298,7 @@ func newCTFBalanceCmd() *cobra.Command {
 			ctx, cancel := chain.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
 
-			bal, err := ch.ERC1155BalanceOf(ctx, common.HexToAddress(chain.CTFAddress), id)
+			bal, err := ch.ERC1155BalanceOf(ctx, ch.Addresses().CTF, id)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"limitorderbot/internal/bot"
+	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
+)
+
+func newStateImportHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import-history",
+		Short: i18n.T("state.import_history.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			b, err := bot.New(cfg)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+			rep, err := b.ImportHistory(ctx)
+			if err != nil {
+				return err
+			}
+			if rep.Before.IsZero() {
+				fmt.Println("No local history yet: importing everything")
+			} else {
+				fmt.Printf("Importing activity before the bot's first order (%s)\n", rep.Before.Format(time.RFC3339))
+			}
+			fmt.Printf("  Orders (CLOB trade history): %d\n", rep.Orders)
+			fmt.Printf("  Trades (data-api only):      %d\n", rep.Trades)
+			fmt.Printf("  Redemptions:                 %d\n", rep.Redemptions)
+			fmt.Printf("  Merges:                      %d\n", rep.Merges)
+			fmt.Printf("  Market outcomes:             %d\n", rep.Outcomes)
+			if rep.Skipped > 0 {
+				fmt.Printf("  Skipped (splits, rewards, ...): %d\n", rep.Skipped)
+			}
+			for _, e := range rep.Errors {
+				fmt.Printf("  ✗ %s\n", e)
+			}
+			if len(rep.Errors) > 0 {
+				return fmt.Errorf("import finished with %d error(s)", len(rep.Errors))
+			}
+			return nil
+		},
+	}
+}
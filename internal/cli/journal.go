@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"limitorderbot/internal/i18n"
+	"limitorderbot/internal/journal"
+	"limitorderbot/internal/models"
+)
+
+func newJournalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "journal",
+		Short: i18n.T("journal.short"),
+	}
+	cmd.AddCommand(newJournalAddCmd())
+	cmd.AddCommand(newJournalListCmd())
+	return cmd
+}
+
+func newJournalAddCmd() *cobra.Command {
+	var conditionID string
+	var orderID string
+	var note string
+	var tags []string
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: i18n.T("journal.add.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a, err := journal.Append(journal.DefaultFile, models.Annotation{
+				ConditionID: conditionID,
+				OrderID:     orderID,
+				Note:        note,
+				Tags:        tags,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✓ Note saved (%s)\n", a.CreatedAt.Format("2006-01-02 15:04:05"))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&conditionID, "condition-id", "", "market condition id (0x...)")
+	cmd.Flags().StringVar(&orderID, "order-id", "", "order id")
+	cmd.Flags().StringVar(&note, "note", "", "free-form note")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "tag (repeatable or comma separated)")
+	return cmd
+}
+
+func newJournalListCmd() *cobra.Command {
+	var conditionID string
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: i18n.T("journal.list.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all, err := journal.Load(journal.DefaultFile)
+			if err != nil {
+				return err
+			}
+			var out []models.Annotation
+			for _, a := range all {
+				if conditionID != "" && a.ConditionID != conditionID {
+					continue
+				}
+				out = append(out, a)
+			}
+			if asJSON {
+				b, _ := json.MarshalIndent(out, "", "  ")
+				fmt.Println(string(b))
+				return nil
+			}
+			if len(out) == 0 {
+				fmt.Println("No notes found.")
+				return nil
+			}
+			for _, a := range out {
+				target := "cid=" + a.ConditionID
+				if a.OrderID != "" {
+					target = "order=" + a.OrderID
+				}
+				tags := ""
+				if len(a.Tags) > 0 {
+					tags = " [" + strings.Join(a.Tags, ", ") + "]"
+				}
+				fmt.Printf("%s  %s%s  %s\n", a.CreatedAt.Format("2006-01-02 15:04:05"), target, tags, a.Note)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&conditionID, "condition-id", "", "condition id filter")
+	cmd.Flags().BoolVar(&asJSON, "json", false, i18n.T("flag.json"))
+	return cmd
+}
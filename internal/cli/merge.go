@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -10,14 +11,16 @@ import (
 
 	"limitorderbot/internal/chain"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
 )
 
 func newMergeCmd() *cobra.Command {
 	var conditionID string
 	var amount float64
+	var dryRun bool
 	cmd := &cobra.Command{
 		Use:   "merge",
-		Short: "按 condition_id 调用 CTF.mergePositions 合并 YES/NO 回 USDC",
+		Short: i18n.T("merge.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -27,7 +30,7 @@ func newMergeCmd() *cobra.Command {
 				return fmt.Errorf("--condition-id is required (0x...)")
 			}
 			if amount <= 0 {
-				return fmt.Errorf("--amount must be > 0 (单位: sets / USDC)")
+				return errors.New(i18n.T("merge.err.amount"))
 			}
 			cid, err := chain.ConditionIDFromHex(conditionID)
 			if err != nil {
@@ -35,23 +38,31 @@ func newMergeCmd() *cobra.Command {
 			}
 
 			amountUSDC6 := big.NewInt(int64(amount * 1e6))
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
 			defer ch.Close()
+			ch.SetDryRun(dryRun)
 
 			ctx, cancel := chain.WithTimeout(context.Background(), 2*time.Minute)
 			defer cancel()
+			if changes, err := mergeChanges(ctx, ch, cid, amount); err == nil {
+				printChanges(changes)
+			}
 			tx, err := ch.MergePositions(ctx, cid, amountUSDC6)
+			if isDryRun(err) {
+				return nil
+			}
 			if err != nil {
 				return err
 			}
-			fmt.Printf("✓ Merge tx sent: %s\n", tx.Hex())
+			fmt.Printf("✓ Merge tx confirmed: %s\n", tx.Hex())
 			return nil
 		},
 	}
 	cmd.Flags().StringVar(&conditionID, "condition-id", "", "condition id (0x...)")
 	cmd.Flags().Float64Var(&amount, "amount", 0, "merge amount (float, sets; will be scaled by 1e6)")
+	addDryRunFlag(cmd, &dryRun)
 	return cmd
 }
@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/spf13/cobra"
+
+	"limitorderbot/internal/bot"
+	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// newMonitorCmd runs the bot with a terminal UI in place of the web
+// dashboard: status, active markets, open orders, recent fills and the log
+// tail, refreshed from the bot's state in-process.
+func newMonitorCmd() *cobra.Command {
+	var refresh time.Duration
+	cmd := &cobra.Command{
+		Use:   "monitor",
+		Short: i18n.T("monitor.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			if refresh <= 0 {
+				return fmt.Errorf("--refresh must be > 0")
+			}
+
+			app := tview.NewApplication()
+			ui := newMonitorUI(app)
+			// Route log lines into the log pane before the bot configures
+			// logging; LOG_FILE still gets everything.
+			logging.SetConsole(ui.logs)
+
+			b, err := bot.New(cfg)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			ctx, cancel := signalContext()
+			defer cancel()
+			if err := b.Start(ctx); err != nil {
+				return err
+			}
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				_ = runBotLoop(ctx, b, cfg)
+			}()
+			go func() {
+				t := time.NewTicker(refresh)
+				defer t.Stop()
+				for {
+					app.QueueUpdateDraw(func() { ui.render(b, cfg) })
+					select {
+					case <-ctx.Done():
+						app.Stop()
+						return
+					case <-t.C:
+					}
+				}
+			}()
+
+			err = app.Run()
+			cancel()
+			<-done
+			return err
+		},
+	}
+	cmd.Flags().DurationVar(&refresh, "refresh", 2*time.Second, i18n.T("monitor.flag.refresh"))
+	return cmd
+}
+
+type monitorUI struct {
+	status  *tview.TextView
+	markets *tview.Table
+	orders  *tview.Table
+	fills   *tview.Table
+	logs    *tview.TextView
+}
+
+func newMonitorUI(app *tview.Application) *monitorUI {
+	ui := &monitorUI{
+		status:  tview.NewTextView(),
+		markets: tview.NewTable().SetFixed(1, 0),
+		orders:  tview.NewTable().SetFixed(1, 0),
+		fills:   tview.NewTable().SetFixed(1, 0),
+		logs:    tview.NewTextView().SetMaxLines(500).SetScrollable(true),
+	}
+	ui.logs.SetChangedFunc(func() { app.Draw() })
+	for _, p := range []struct {
+		box   *tview.Box
+		title string
+	}{
+		{ui.status.Box, "Status"},
+		{ui.markets.Box, "Active markets"},
+		{ui.orders.Box, "Open orders"},
+		{ui.fills.Box, "Recent fills"},
+		{ui.logs.Box, "Log (q to quit)"},
+	} {
+		p.box.SetBorder(true).SetTitle(" " + p.title + " ")
+	}
+
+	tables := tview.NewFlex().
+		AddItem(ui.markets, 0, 1, false).
+		AddItem(ui.orders, 0, 1, false)
+	root := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(ui.status, 9, 0, false).
+		AddItem(tables, 0, 2, false).
+		AddItem(ui.fills, 0, 1, false).
+		AddItem(ui.logs, 0, 1, false)
+	app.SetRoot(root, true)
+	app.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Rune() == 'q' || ev.Key() == tcell.KeyEscape {
+			app.Stop()
+			return nil
+		}
+		return ev
+	})
+	return ui
+}
+
+func (ui *monitorUI) render(b *bot.Bot, cfg config.Config) {
+	state := b.GetState()
+	now := time.Now()
+
+	var sb strings.Builder
+	running := "stopped"
+	if state.IsRunning {
+		running = "running"
+	}
+	fmt.Fprintf(&sb, "%s  %s  wallet %s\n", now.Format("15:04:05"), running, b.WalletAddress())
+	fmt.Fprintf(&sb, "USDC.e $%.2f  native USDC $%.2f  MATIC %.3f  PnL $%.2f\n",
+		state.USDCBalance, state.NativeUSDC, state.MaticBalance, state.TotalPNL)
+	if state.YieldUSDC != nil {
+		fmt.Fprintf(&sb, "parked in yield pool $%.2f\n", *state.YieldUSDC)
+	}
+	if state.GasTodayMatic > 0 || cfg.GasDailyBudgetUSD > 0 {
+		fmt.Fprintf(&sb, "gas today %.4f MATIC ($%.4f)", state.GasTodayMatic, state.GasTodayUSD)
+		if cfg.GasDailyBudgetUSD > 0 {
+			fmt.Fprintf(&sb, " of $%.2f budget", cfg.GasDailyBudgetUSD)
+		}
+		sb.WriteString("\n")
+	}
+	clob := b.ClobHealth()
+	clobState := "down"
+	if clob.Available {
+		clobState = fmt.Sprintf("ok %.0fms", clob.LatencyMS)
+	}
+	fmt.Fprintf(&sb, "CLOB %s  errors %d  markets %d  open orders %d\n",
+		clobState, state.ErrorCount, len(state.ActiveMarkets), len(state.PendingOrders))
+	if len(state.NetDelta) > 0 {
+		keys := make([]string, 0, len(state.NetDelta))
+		for k := range state.NetDelta {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		sb.WriteString("net delta:")
+		for _, k := range keys {
+			fmt.Fprintf(&sb, " %s %+.1f", k, state.NetDelta[k])
+		}
+		if cfg.MaxNetDeltaUSD > 0 {
+			fmt.Fprintf(&sb, " (cap $%.0f)", cfg.MaxNetDeltaUSD)
+		}
+		sb.WriteString("\n")
+	}
+	if len(state.ErrorsByKind) > 0 {
+		keys := make([]string, 0, len(state.ErrorsByKind))
+		for k := range state.ErrorsByKind {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		sb.WriteString("errors by kind:")
+		for _, k := range keys {
+			fmt.Fprintf(&sb, " %s %d", k, state.ErrorsByKind[k])
+		}
+		sb.WriteString("\n")
+	}
+	if state.BackoffUntil != nil && now.Before(*state.BackoffUntil) {
+		fmt.Fprintf(&sb, "! rate limited; backing off until %s\n", state.BackoffUntil.Format("15:04:05"))
+	}
+	for _, alert := range []*string{state.FundingAlert, state.GasAlert, state.LastError} {
+		if alert != nil && *alert != "" {
+			sb.WriteString("! " + *alert + "\n")
+		}
+	}
+	for _, p := range state.PendingResolutions {
+		if p.UMAStatus == models.UMADisputed {
+			sb.WriteString("! " + p.MarketSlug + " resolution disputed; redemption waits for the UMA vote\n")
+		}
+	}
+	ui.status.SetText(sb.String())
+
+	fillTable(ui.markets, []string{"Market", "Ends in", "Prices"}, len(state.ActiveMarkets), func(i int) []string {
+		m := state.ActiveMarkets[i]
+		var prices []string
+		for _, o := range m.Outcomes {
+			if o.Price != nil {
+				prices = append(prices, fmt.Sprintf("%s %.3f", o.Outcome, *o.Price))
+			}
+		}
+		return []string{m.MarketSlug, m.EndTime().Sub(now).Truncate(time.Second).String(), strings.Join(prices, " / ")}
+	})
+
+	fillTable(ui.orders, []string{"Market", "Side", "Outcome", "Price", "Size", "Status"}, len(state.PendingOrders), func(i int) []string {
+		o := state.PendingOrders[i]
+		return []string{o.MarketSlug, string(o.Side), o.Outcome, fmt.Sprintf("%.3f", o.Price), fmt.Sprintf("%.2f", o.Size), string(o.Status)}
+	})
+
+	fills := recentFills(state.RecentOrders, 20)
+	fillTable(ui.fills, []string{"Filled", "Market", "Side", "Outcome", "Price", "Shares"}, len(fills), func(i int) []string {
+		o := fills[i]
+		price := o.Price
+		if o.FillPrice != nil {
+			price = *o.FillPrice
+		}
+		return []string{fillTime(o).Format("15:04:05"), o.MarketSlug, string(o.Side), o.Outcome, fmt.Sprintf("%.3f", price), fmt.Sprintf("%.2f", matched(o))}
+	})
+}
+
+func fillTable(t *tview.Table, header []string, n int, row func(i int) []string) {
+	t.Clear()
+	for c, h := range header {
+		t.SetCell(0, c, tview.NewTableCell(h).SetAttributes(tcell.AttrBold).SetSelectable(false))
+	}
+	for i := 0; i < n; i++ {
+		for c, v := range row(i) {
+			t.SetCell(i+1, c, tview.NewTableCell(v).SetExpansion(1))
+		}
+	}
+}
+
+// recentFills returns the newest orders with any matched size.
+func recentFills(orders []models.OrderRecord, limit int) []models.OrderRecord {
+	var out []models.OrderRecord
+	for _, o := range orders {
+		if matched(o) > 0 {
+			out = append(out, o)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return fillTime(out[i]).After(fillTime(out[j])) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+func matched(o models.OrderRecord) float64 {
+	if o.SizeMatched != nil {
+		return *o.SizeMatched
+	}
+	if o.Status == models.OrderStatusFilled {
+		return o.Size
+	}
+	return 0
+}
+
+func fillTime(o models.OrderRecord) time.Time {
+	if o.FilledAt != nil {
+		return *o.FilledAt
+	}
+	if o.FirstFillAt != nil {
+		return *o.FirstFillAt
+	}
+	return o.CreatedAt
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"limitorderbot/internal/bot"
+	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
+)
+
+// newPanicCmd liquidates from a separate process: it works whether or not a
+// bot is running, and a running bot sees the pause file on its next order
+// check and stops placing orders.
+func newPanicCmd() *cobra.Command {
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "panic",
+		Short: i18n.T("panic.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !yes {
+				return errors.New("this cancels every order and sells every position; re-run with --yes to proceed")
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			b, err := bot.New(cfg)
+			if err != nil {
+				return err
+			}
+			defer b.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			rep, err := b.Panic(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Paused: %v\n", rep.Paused)
+			fmt.Printf("All orders cancelled: %v\n", rep.OrdersCancelled)
+			fmt.Printf("Sell orders placed: %d\n", rep.SellsPlaced)
+			for _, e := range rep.Errors {
+				fmt.Printf("  ✗ %s\n", e)
+			}
+			if len(rep.Errors) > 0 {
+				return fmt.Errorf("panic finished with %d error(s)", len(rep.Errors))
+			}
+			fmt.Printf("Bot stays paused until `panic resume` (or delete %s).\n", bot.PauseFile)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&yes, "yes", false, i18n.T("panic.flag.yes"))
+	cmd.AddCommand(newPanicResumeCmd())
+	return cmd
+}
+
+func newPanicResumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume",
+		Short: i18n.T("panic.resume.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bot.SavePause(nil); err != nil {
+				return err
+			}
+			fmt.Println("✓ Pause lifted; a running bot resumes on its next loop pass (unless the kill switch is engaged)")
+			return nil
+		},
+	}
+}
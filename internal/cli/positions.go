@@ -9,14 +9,14 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"limitorderbot/internal/chain"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
 )
 
 func newPositionsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "positions",
-		Short: "Polymarket Data API positions 工具（等价 get_positions_api.py）",
+		Short: i18n.T("positions.short"),
 	}
 	cmd.AddCommand(newPositionsListCmd())
 	cmd.AddCommand(newPositionsRawCmd())
@@ -27,13 +27,13 @@ func newPositionsListCmd() *cobra.Command {
 	var redeemableOnly bool
 	cmd := &cobra.Command{
 		Use:   "list",
-		Short: "列出 positions（可选仅 redeemable）",
+		Short: i18n.T("positions.list.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
@@ -42,7 +42,7 @@ func newPositionsListCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 			defer cancel()
 
-			ps, err := fetchPositions(ctx, ch.Address().Hex())
+			ps, err := fetchPositions(ctx, cfg.HTTPClient(cfg.DataAPIHTTP), cfg.DataAPIURL, ch.Address().Hex())
 			if err != nil {
 				return err
 			}
@@ -71,20 +71,20 @@ func newPositionsListCmd() *cobra.Command {
 			return nil
 		},
 	}
-	cmd.Flags().BoolVar(&redeemableOnly, "redeemable-only", false, "仅显示 redeemable=true")
+	cmd.Flags().BoolVar(&redeemableOnly, "redeemable-only", false, i18n.T("positions.flag.redeemable_only"))
 	return cmd
 }
 
 func newPositionsRawCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "raw",
-		Short: "输出 Data API 原始 JSON",
+		Short: i18n.T("positions.raw.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
@@ -93,7 +93,7 @@ func newPositionsRawCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 			defer cancel()
 
-			ps, err := fetchPositions(ctx, ch.Address().Hex())
+			ps, err := fetchPositions(ctx, cfg.HTTPClient(cfg.DataAPIHTTP), cfg.DataAPIURL, ch.Address().Hex())
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/i18n"
+)
+
+// Every write is simulated with eth_call before it is sent (a revert fails
+// with its reason instead of costing gas); --dry-run stops there.
+
+func addDryRunFlag(cmd *cobra.Command, v *bool) {
+	cmd.Flags().BoolVar(v, "dry-run", false, i18n.T("flag.dry_run"))
+}
+
+// isDryRun reports whether err is a simulated-but-not-sent write, printing
+// what the simulation found.
+func isDryRun(err error) bool {
+	if !errors.Is(err, chain.ErrDryRun) {
+		return false
+	}
+	fmt.Printf("○ %v\n", err)
+	return true
+}
+
+// txLine prints one write's outcome under label and reports whether it
+// succeeded (a dry run counts).
+func txLine(label string, tx common.Hash, err error) bool {
+	switch {
+	case isDryRun(err):
+		return true
+	case err != nil:
+		fmt.Printf("  %s ERROR: %v\n", label, err)
+		return false
+	}
+	fmt.Printf("  %s TX: %s\n", label, tx.Hex())
+	return true
+}
+
+// balanceChange is one balance a write is expected to move.
+type balanceChange struct {
+	Label  string
+	Before float64
+	Delta  float64
+}
+
+func printChanges(changes []balanceChange) {
+	fmt.Println("Expected balance changes:")
+	for _, c := range changes {
+		fmt.Printf("  %-24s %14.6f → %14.6f (%+.6f)\n", c.Label, c.Before, c.Before+c.Delta, c.Delta)
+	}
+}
+
+// outcomeBalances reads the wallet's balances of a binary condition's two
+// tokens (plain CTF positions, or the neg-risk ones).
+func outcomeBalances(ctx context.Context, ch *chain.Client, cid [32]byte, negRisk bool) ([2]*big.Int, error) {
+	var out [2]*big.Int
+	for i, id := range ch.Addresses().OutcomeTokenIDs(cid, negRisk) {
+		bal, err := ch.ERC1155BalanceOf(ctx, ch.Addresses().CTF, id)
+		if err != nil {
+			return out, err
+		}
+		out[i] = bal
+	}
+	return out, nil
+}
+
+// mergeChanges: amount of each outcome becomes amount of collateral.
+func mergeChanges(ctx context.Context, ch *chain.Client, cid [32]byte, amount float64) ([]balanceChange, error) {
+	usdc, err := ch.USDCBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bals, err := outcomeBalances(ctx, ch, cid, false)
+	if err != nil {
+		return nil, err
+	}
+	return []balanceChange{
+		{"USDC.e", usdc, amount},
+		{"outcome 0 shares", toFloat6(bals[0]), -amount},
+		{"outcome 1 shares", toFloat6(bals[1]), -amount},
+	}, nil
+}
+
+// redeemChanges: every share held burns for its payout share of collateral.
+// Unresolved conditions have no payout yet, which the redeem would revert on.
+func redeemChanges(ctx context.Context, ch *chain.Client, r redeemCall) ([]balanceChange, error) {
+	den, nums, err := ch.Payouts(ctx, r.cond, 2)
+	if err != nil {
+		return nil, err
+	}
+	if den == nil || den.Sign() == 0 {
+		return nil, errors.New("condition is not resolved yet: nothing to redeem")
+	}
+	bals, err := outcomeBalances(ctx, ch, r.cond, r.negRisk)
+	if err != nil {
+		return nil, err
+	}
+	usdc, err := ch.USDCBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	payout := new(big.Int)
+	var changes []balanceChange
+	for i, bal := range bals {
+		if i < len(nums) {
+			payout.Add(payout, new(big.Int).Div(new(big.Int).Mul(bal, nums[i]), den))
+		}
+		changes = append(changes, balanceChange{fmt.Sprintf("outcome %d shares", i), toFloat6(bal), -toFloat6(bal)})
+	}
+	return append([]balanceChange{{"USDC.e", usdc, toFloat6(payout)}}, changes...), nil
+}
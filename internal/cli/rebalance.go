@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
+)
+
+func newWalletRebalanceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebalance",
+		Short: i18n.T("wallet.rebalance.short"),
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: i18n.T("wallet.rebalance.status.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withFunder(func(ctx context.Context, cfg config.Config, ch *chain.Client, funder common.Address) error {
+				return printRebalanceBalances(ctx, ch, funder)
+			})
+		},
+	})
+	cmd.AddCommand(newRebalanceMoveCmd("to-proxy", i18n.T("wallet.rebalance.to_proxy"), true,
+		func(ctx context.Context, cfg config.Config, ch *chain.Client, funder common.Address, amount6 *big.Int) (common.Hash, error) {
+			return ch.TransferCollateral(ctx, funder, amount6)
+		}))
+	cmd.AddCommand(newRebalanceMoveCmd("to-eoa", i18n.T("wallet.rebalance.to_eoa"), false,
+		func(ctx context.Context, cfg config.Config, ch *chain.Client, funder common.Address, amount6 *big.Int) (common.Hash, error) {
+			if strings.ToUpper(cfg.SignatureType) != "POLY_PROXY" {
+				return common.Hash{}, errors.New("withdrawing from the funder is only supported for SIGNATURE_TYPE=POLY_PROXY; move Safe funds from the Safe UI")
+			}
+			return ch.WithdrawFromProxy(ctx, amount6)
+		}))
+	return cmd
+}
+
+func newRebalanceMoveCmd(use, short string, toProxy bool, move func(ctx context.Context, cfg config.Config, ch *chain.Client, funder common.Address, amount6 *big.Int) (common.Hash, error)) *cobra.Command {
+	var amount float64
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if amount <= 0 {
+				return errors.New("--amount must be > 0")
+			}
+			return withFunder(func(ctx context.Context, cfg config.Config, ch *chain.Client, funder common.Address) error {
+				ch.SetDryRun(dryRun)
+				delta := amount
+				if toProxy {
+					delta = -amount
+				}
+				eoa, err1 := ch.CollateralBalanceOf(ctx, ch.Address())
+				proxy, err2 := ch.CollateralBalanceOf(ctx, funder)
+				if err1 == nil && err2 == nil {
+					printChanges([]balanceChange{{"EOA USDC.e", eoa, delta}, {"Proxy USDC.e", proxy, -delta}})
+				}
+				amount6 := big.NewInt(int64(amount * 1_000_000))
+				hash, err := move(ctx, cfg, ch, funder, amount6)
+				if isDryRun(err) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				fmt.Printf("✓ Moved %.6f USDC.e (tx=%s)\n", amount, hash.Hex())
+				return printRebalanceBalances(ctx, ch, funder)
+			})
+		},
+	}
+	cmd.Flags().Float64Var(&amount, "amount", 0, i18n.T("wallet.rebalance.flag.amount"))
+	addDryRunFlag(cmd, &dryRun)
+	return cmd
+}
+
+// withFunder runs fn with a chain client and the configured funder address.
+func withFunder(fn func(ctx context.Context, cfg config.Config, ch *chain.Client, funder common.Address) error) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if !common.IsHexAddress(cfg.FunderAddress) {
+		return errors.New("FUNDER_ADDRESS is not set; rebalancing is for proxy-wallet accounts")
+	}
+	ch, err := newChainClient(cfg)
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.TxConfirmTimeoutSeconds+60)*time.Second)
+	defer cancel()
+	return fn(ctx, cfg, ch, common.HexToAddress(cfg.FunderAddress))
+}
+
+func printRebalanceBalances(ctx context.Context, ch *chain.Client, funder common.Address) error {
+	eoa, err := ch.CollateralBalanceOf(ctx, ch.Address())
+	if err != nil {
+		return err
+	}
+	proxy, err := ch.CollateralBalanceOf(ctx, funder)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("EOA   %s  USDC.e %.6f\n", ch.Address().Hex(), eoa)
+	fmt.Printf("Proxy %s  USDC.e %.6f\n", funder.Hex(), proxy)
+	return nil
+}
@@ -3,19 +3,22 @@ package cli
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"limitorderbot/internal/chain"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
 )
 
 func newRedeemCmd() *cobra.Command {
 	var conditionID string
+	var negRisk, dryRun bool
 	cmd := &cobra.Command{
 		Use:   "redeem",
-		Short: "按 condition_id 调用 CTF.redeemPositions 赎回",
+		Short: i18n.T("redeem.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -24,19 +27,43 @@ func newRedeemCmd() *cobra.Command {
 			if conditionID == "" {
 				return fmt.Errorf("--condition-id is required (0x...)")
 			}
-			cid, err := chain.ConditionIDFromHex(conditionID)
-			if err != nil {
+			if _, err := chain.ConditionIDFromHex(conditionID); err != nil {
 				return err
 			}
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
 			defer ch.Close()
+			ch.SetDryRun(dryRun)
 
 			ctx, cancel := chain.WithTimeout(context.Background(), 2*time.Minute)
 			defer cancel()
-			tx, err := ch.RedeemPositions(ctx, cid)
+			// The positions API tells us whether the market is neg-risk and
+			// which token ids to read balances for.
+			var ps []polymarketPosition
+			if all, err := fetchPositions(ctx, cfg.HTTPClient(cfg.DataAPIHTTP), cfg.DataAPIURL, ch.Address().Hex()); err == nil {
+				for _, p := range all {
+					if strings.EqualFold(p.ConditionID, conditionID) {
+						ps = append(ps, p)
+					}
+				}
+			} else if negRisk {
+				return fmt.Errorf("neg-risk redeem needs the positions API: %w", err)
+			}
+			call, err := planRedeem(ctx, ch, ch.Address(), conditionID, ps, negRisk)
+			if err != nil {
+				return err
+			}
+			changes, err := redeemChanges(ctx, ch, call)
+			if err != nil {
+				return err
+			}
+			printChanges(changes)
+			tx, err := call.send(ctx, ch)
+			if isDryRun(err) {
+				return nil
+			}
 			if err != nil {
 				return err
 			}
@@ -45,5 +72,7 @@ func newRedeemCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&conditionID, "condition-id", "", "condition id (0x...)")
+	cmd.Flags().BoolVar(&negRisk, "neg-risk", false, i18n.T("redeem.flag.neg_risk"))
+	addDryRunFlag(cmd, &dryRun)
 	return cmd
 }
@@ -4,17 +4,21 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"os"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 
 	"limitorderbot/internal/chain"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
 )
 
 type polymarketPosition struct {
@@ -26,29 +30,47 @@ type polymarketPosition struct {
 	CurPrice     float64 `json:"curPrice"`
 	CurrentValue float64 `json:"currentValue"`
 	Redeemable   bool    `json:"redeemable"`
+	Asset        string  `json:"asset"`
+	OutcomeIndex int     `json:"outcomeIndex"`
+	NegativeRisk bool    `json:"negativeRisk"`
 }
 
+// deferGasShare: above --max-gas-price, a market whose redeem gas costs more
+// than this share of its value waits for cheaper gas.
+const deferGasShare = 0.05
+
 func newRedeemAllCmd() *cobra.Command {
-	var yes bool
-	var limit int
+	var yes, dryRun bool
+	var limit, batch int
+	var minValue, maxGasPrice float64
 	cmd := &cobra.Command{
 		Use:   "redeem-all",
-		Short: "列出并批量赎回所有 redeemable markets（等价 redeem_positions.py/auto_redeem.py）",
+		Short: i18n.T("redeem_all.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			if batch > 0 && (cfg.SignatureType != "POLY_PROXY" || cfg.FunderAddress == "") {
+				return errors.New("--batch needs SIGNATURE_TYPE=POLY_PROXY and FUNDER_ADDRESS: the CTF pays out the caller, so only the proxy wallet's positions can be redeemed together")
+			}
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
 			defer ch.Close()
+			ch.SetDryRun(dryRun)
 
 			ctx, cancel := context.WithTimeout(context.Background(), 25*time.Second)
 			defer cancel()
 
-			positions, err := fetchPositions(ctx, ch.Address().Hex())
+			// --batch redeems the proxy wallet's positions through the proxy
+			// factory; otherwise the EOA's, one tx each.
+			holder := ch.Address()
+			if batch > 0 {
+				holder = common.HexToAddress(cfg.FunderAddress)
+			}
+			positions, err := fetchPositions(ctx, cfg.HTTPClient(cfg.DataAPIHTTP), cfg.DataAPIURL, holder.Hex())
 			if err != nil {
 				return err
 			}
@@ -66,10 +88,14 @@ func newRedeemAllCmd() *cobra.Command {
 			}
 
 			type item struct {
-				cid   string
-				title string
-				value float64
-				count int
+				cid       string
+				title     string
+				value     float64
+				count     int
+				positions []polymarketPosition
+				call      redeemCall
+				gas       uint64
+				gasUSD    float64
 			}
 			var items []item
 			for cid, ps := range byCID {
@@ -81,23 +107,112 @@ func newRedeemAllCmd() *cobra.Command {
 				for _, p := range ps {
 					sum += p.CurrentValue
 				}
-				items = append(items, item{cid: cid, title: title, value: sum, count: len(ps)})
+				items = append(items, item{cid: cid, title: title, value: sum, count: len(ps), positions: ps})
+			}
+			if !cmd.Flags().Changed("min-value") {
+				minValue = cfg.MinRedeemValueUSD
+			}
+			dust := 0
+			kept := items[:0]
+			for _, it := range items {
+				if it.value < minValue {
+					dust++
+					continue
+				}
+				kept = append(kept, it)
+			}
+			items = kept
+			if dust > 0 {
+				fmt.Printf("Skipping %d market(s) below min value $%.2f (gas would exceed winnings)\n", dust, minValue)
+			}
+			if len(items) == 0 {
+				fmt.Println("No redeemable positions above min value.")
+				return nil
 			}
 			sort.Slice(items, func(i, j int) bool { return items[i].value > items[j].value })
 			if limit > 0 && limit < len(items) {
 				items = items[:limit]
 			}
 
+			price, err := ch.GasPrice(ctx)
+			if err != nil {
+				return fmt.Errorf("gas price: %w", err)
+			}
+			gwei := weiToFloat(price, 1e9)
+			var estimated []item
+			for _, it := range items {
+				if it.call, err = planRedeem(ctx, ch, holder, it.cid, it.positions, false); err == nil && batch == 0 {
+					it.gas, err = it.call.estimate(ctx, ch)
+				}
+				if err != nil {
+					fmt.Printf("Skipping cid=%s: gas estimate failed (the redeem would likely revert): %v\n", it.cid, err)
+					continue
+				}
+				estimated = append(estimated, it)
+			}
+			items = estimated
+			if batch > 0 {
+				// A batch's gas is shared evenly by its markets.
+				estimated = nil
+				for start := 0; start < len(items); start += batch {
+					chunk := items[start:min(start+batch, len(items))]
+					gas, err := ch.EstimateRedeemBatchViaProxy(ctx, holder, batchCalls(chunk, func(it item) redeemCall { return it.call }))
+					if err != nil {
+						fmt.Printf("Skipping a batch of %d market(s): gas estimate failed: %v\n", len(chunk), err)
+						continue
+					}
+					for _, it := range chunk {
+						it.gas = gas / uint64(len(chunk))
+						estimated = append(estimated, it)
+					}
+				}
+				items = estimated
+			}
+			for i := range items {
+				items[i].gasUSD = weiToFloat(new(big.Int).Mul(new(big.Int).SetUint64(items[i].gas), price), 1e18) * cfg.MaticUSDPrice
+			}
+
+			// Above --max-gas-price only markets whose gas stays a small
+			// share of their value are worth redeeming now.
+			if maxGasPrice > 0 && gwei > maxGasPrice {
+				var deferred int
+				var deferredValue float64
+				kept := items[:0]
+				for _, it := range items {
+					if it.gasUSD > deferGasShare*it.value {
+						deferred++
+						deferredValue += it.value
+						continue
+					}
+					kept = append(kept, it)
+				}
+				items = kept
+				if deferred > 0 {
+					fmt.Printf("Gas is %.1f gwei, above --max-gas-price %.1f: deferring %d market(s) worth %.4f USD whose gas exceeds %.0f%% of their value\n",
+						gwei, maxGasPrice, deferred, deferredValue, deferGasShare*100)
+				}
+			}
+			if len(items) == 0 {
+				fmt.Println("Nothing to redeem now.")
+				return nil
+			}
+
 			fmt.Printf("Wallet: %s\n\n", ch.Address().Hex())
 			fmt.Printf("Redeemable markets: %d\n\n", len(items))
-			total := 0.0
+			var total, totalGasUSD float64
+			var totalGas uint64
 			for i, it := range items {
 				total += it.value
-				fmt.Printf("%2d) %.4f USD  (%d pos)  %s  cid=%s\n", i+1, it.value, it.count, it.title, it.cid)
+				totalGas += it.gas
+				totalGasUSD += it.gasUSD
+				fmt.Printf("%2d) %.4f USD  gas ~%d (%.4f USD)  (%d pos)  %s  cid=%s\n", i+1, it.value, it.gas, it.gasUSD, it.count, it.title, it.cid)
 			}
 			fmt.Printf("\nEstimated total value: %.4f USD\n", total)
+			fmt.Printf("Estimated gas:         %d units at %.1f gwei = %.6f MATIC (%.4f USD at MATIC_USD_PRICE)\n",
+				totalGas, gwei, weiToFloat(new(big.Int).Mul(new(big.Int).SetUint64(totalGas), price), 1e18), totalGasUSD)
+			fmt.Printf("Net after gas:         %.4f USD\n", total-totalGasUSD)
 
-			if !yes {
+			if !yes && !dryRun {
 				fmt.Print("\nProceed to redeem all listed conditionIds? Type 'yes' to continue: ")
 				in := bufio.NewReader(os.Stdin)
 				line, _ := in.ReadString('\n')
@@ -108,46 +223,136 @@ func newRedeemAllCmd() *cobra.Command {
 			}
 
 			fmt.Println("\nRedeeming...")
-			redeemed := 0
-			for _, it := range items {
-				cond, err := chain.ConditionIDFromHex(it.cid)
-				if err != nil {
-					fmt.Printf("skip cid=%s: %v\n", it.cid, err)
-					continue
+			if dryRun {
+				fmt.Println("(dry run: simulating each redeem, nothing is sent)")
+			}
+			redeemed, simulated := 0, 0
+			if batch > 0 {
+				for start := 0; start < len(items); start += batch {
+					chunk := items[start:min(start+batch, len(items))]
+					tx, err := ch.RedeemBatchViaProxy(ctx, holder, batchCalls(chunk, func(it item) redeemCall { return it.call }))
+					if isDryRun(err) {
+						simulated += len(chunk)
+						continue
+					}
+					if err != nil {
+						fmt.Printf("fail batch of %d: %v\n", len(chunk), err)
+						continue
+					}
+					redeemed += len(chunk)
+					fmt.Printf("ok batch of %d tx=%s\n", len(chunk), tx.Hex())
 				}
-				tx, err := ch.RedeemPositions(ctx, cond)
-				if err != nil {
-					fmt.Printf("fail cid=%s: %v\n", it.cid, err)
-					continue
+			} else {
+				for _, it := range items {
+					tx, err := it.call.send(ctx, ch)
+					if isDryRun(err) {
+						simulated++
+						continue
+					}
+					if err != nil {
+						fmt.Printf("fail cid=%s: %v\n", it.cid, err)
+						continue
+					}
+					redeemed++
+					fmt.Printf("ok cid=%s tx=%s\n", it.cid, tx.Hex())
 				}
-				redeemed++
-				fmt.Printf("ok cid=%s tx=%s\n", it.cid, tx.Hex())
+			}
+			if dryRun {
+				fmt.Printf("\nDry run done. %d of %d market(s) would redeem; nothing was sent.\n", simulated, len(items))
+				return nil
 			}
 			fmt.Printf("\nDone. Redeemed %d market(s).\n", redeemed)
 			return nil
 		},
 	}
-	cmd.Flags().BoolVar(&yes, "yes", false, "跳过确认直接执行")
-	cmd.Flags().IntVar(&limit, "limit", 0, "最多处理前 N 个（按 currentValue 降序）")
+	cmd.Flags().BoolVar(&yes, "yes", false, i18n.T("redeem_all.flag.yes"))
+	cmd.Flags().IntVar(&limit, "limit", 0, i18n.T("redeem_all.flag.limit"))
+	cmd.Flags().Float64Var(&minValue, "min-value", 0, i18n.T("redeem_all.flag.min_value"))
+	cmd.Flags().Float64Var(&maxGasPrice, "max-gas-price", 0, i18n.T("redeem_all.flag.max_gas_price"))
+	cmd.Flags().IntVar(&batch, "batch", 0, i18n.T("redeem_all.flag.batch"))
+	addDryRunFlag(cmd, &dryRun)
 	return cmd
 }
 
 func newClaimWinningsCmd() *cobra.Command {
-	// Python 的 claim_winnings.py 在实际使用中等价于“批量 redeem 可赎回头寸”；
-	// 这里提供别名命令，保持工具链 1:1 可用性。
+	// The Python claim_winnings.py is in practice "batch-redeem redeemable
+	// positions", so keep it as an alias for 1:1 parity with that toolchain.
 	cmd := newRedeemAllCmd()
 	cmd.Use = "claim-winnings"
-	cmd.Short = "别名：redeem-all（等价 claim_winnings.py）"
+	cmd.Short = i18n.T("claim_winnings.short")
 	return cmd
 }
 
-func fetchPositions(ctx context.Context, wallet string) ([]polymarketPosition, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://data-api.polymarket.com/positions?user="+wallet, nil)
+// redeemCall is how one condition is redeemed: neg-risk markets (flagged by
+// the positions API, or forced) go through the NegRiskAdapter with the
+// wallet's YES/NO balances; everything else through CTF.redeemPositions.
+type redeemCall struct {
+	cond    [32]byte
+	negRisk bool
+	amounts []*big.Int
+}
+
+// planRedeem reads neg-risk amounts from holder, the wallet whose positions
+// are redeemed.
+func planRedeem(ctx context.Context, ch *chain.Client, holder common.Address, cid string, ps []polymarketPosition, forceNegRisk bool) (redeemCall, error) {
+	cond, err := chain.ConditionIDFromHex(cid)
+	if err != nil {
+		return redeemCall{}, err
+	}
+	call := redeemCall{cond: cond, negRisk: forceNegRisk}
+	var ids [2]*big.Int
+	for _, p := range ps {
+		call.negRisk = call.negRisk || p.NegativeRisk
+		if p.Asset != "" && p.OutcomeIndex >= 0 && p.OutcomeIndex <= 1 {
+			ids[p.OutcomeIndex], _ = new(big.Int).SetString(p.Asset, 10)
+		}
+	}
+	if !call.negRisk {
+		return call, nil
+	}
+	if ids[0] == nil && ids[1] == nil {
+		return redeemCall{}, fmt.Errorf("neg-risk market %s: no positions with token ids to redeem", cid)
+	}
+	if call.amounts, err = ch.NegRiskAmountsOf(ctx, holder, ids[0], ids[1]); err != nil {
+		return redeemCall{}, err
+	}
+	return call, nil
+}
+
+func (r redeemCall) estimate(ctx context.Context, ch *chain.Client) (uint64, error) {
+	if r.negRisk {
+		return ch.EstimateRedeemNegRiskPositions(ctx, r.cond, r.amounts)
+	}
+	return ch.EstimateRedeemPositions(ctx, r.cond)
+}
+
+func (r redeemCall) send(ctx context.Context, ch *chain.Client) (common.Hash, error) {
+	if r.negRisk {
+		return ch.RedeemNegRiskPositions(ctx, r.cond, r.amounts)
+	}
+	return ch.RedeemPositions(ctx, r.cond)
+}
+
+// batchCalls turns a batch's redeem plans into proxy factory calls.
+func batchCalls[T any](items []T, call func(T) redeemCall) []chain.RedeemCall {
+	out := make([]chain.RedeemCall, 0, len(items))
+	for _, it := range items {
+		r := call(it)
+		rc := chain.RedeemCall{ConditionID: r.cond}
+		if r.negRisk {
+			rc.Amounts = r.amounts
+		}
+		out = append(out, rc)
+	}
+	return out
+}
+
+func fetchPositions(ctx context.Context, client *http.Client, dataAPIURL, wallet string) ([]polymarketPosition, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(dataAPIURL, "/")+"/positions?user="+wallet, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
-	client := &http.Client{Timeout: 15 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -163,3 +368,9 @@ func fetchPositions(ctx context.Context, wallet string) ([]polymarketPosition, e
 	return positions, nil
 }
 
+// weiToFloat scales an integer amount down by unit (1e9 for gwei, 1e18 for
+// whole MATIC).
+func weiToFloat(v *big.Int, unit float64) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), big.NewFloat(unit)).Float64()
+	return f
+}
@@ -3,11 +3,18 @@ package cli
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
 )
 
 func Execute() int {
+	i18n.SetLang(config.Language())
+
 	root := &cobra.Command{
 		Use:   "polymarket-bot",
 		Short: "Polymarket Limit Order Bot (Go port)",
@@ -27,6 +34,11 @@ func Execute() int {
 	root.AddCommand(newClaimWinningsCmd())
 	root.AddCommand(newPositionsCmd())
 	root.AddCommand(newWalletCmd())
+	root.AddCommand(newJournalCmd())
+	root.AddCommand(newMonitorCmd())
+	root.AddCommand(newPanicCmd())
+	root.AddCommand(newStrategyCmd())
+	root.AddCommand(newStateCmd())
 
 	if err := root.Execute(); err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
@@ -34,3 +46,16 @@ func Execute() int {
 	}
 	return 0
 }
+
+// newChainClient dials the configured RPCs with the bot's confirmation
+// settings. CLI commands always wait for receipts so they can report the
+// result, whatever TX_RETURN_ON_BROADCAST says.
+func newChainClient(cfg config.Config) (*chain.Client, error) {
+	ch, err := chain.New(cfg.RPCURLs, cfg.Keys, cfg.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	ch.SetConfirmations(uint64(cfg.TxConfirmations))
+	ch.SetConfirmTimeout(time.Duration(cfg.TxConfirmTimeoutSeconds) * time.Second)
+	return ch, nil
+}
@@ -6,13 +6,13 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/spf13/cobra"
 
 	"limitorderbot/internal/bot"
 	"limitorderbot/internal/config"
 	"limitorderbot/internal/dashboard"
+	"limitorderbot/internal/i18n"
 	"limitorderbot/internal/logging"
 )
 
@@ -20,7 +20,7 @@ func newRunCmd() *cobra.Command {
 	var mode string
 	cmd := &cobra.Command{
 		Use:   "run",
-		Short: "运行 bot / dashboard / both",
+		Short: i18n.T("run.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -63,36 +63,19 @@ func newRunCmd() *cobra.Command {
 			}
 		},
 	}
-	cmd.Flags().StringVar(&mode, "mode", "both", "运行模式: bot|dashboard|both")
+	cmd.Flags().StringVar(&mode, "mode", "both", i18n.T("run.flag.mode"))
 	return cmd
 }
 
 func runBotLoop(ctx context.Context, b *bot.Bot, cfg config.Config) error {
 	log := logging.Logger()
-	ticker := time.NewTicker(time.Duration(cfg.CheckIntervalSeconds) * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			log.Println("Shutdown requested")
-			b.Stop()
-			return nil
-		default:
-		}
-
-		loopCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.CheckIntervalSeconds)*time.Second)
-		b.RunOnce(loopCtx)
-		cancel()
-
-		log.Printf("Sleeping for %d seconds...\n", cfg.CheckIntervalSeconds)
-		select {
-		case <-ctx.Done():
-			b.Stop()
-			return nil
-		case <-ticker.C:
-		}
-	}
+	log.Printf("Task intervals: discovery=%ds orders=%ds prices=%ds maintenance=%ds redeem=%ds\n",
+		cfg.DiscoveryIntervalSeconds, cfg.OrderStatusIntervalSeconds, cfg.PriceIntervalSeconds,
+		cfg.MaintenanceIntervalSeconds, cfg.RedeemCheckIntervalSeconds)
+	b.Run(ctx)
+	log.Println("Shutdown requested")
+	b.Stop()
+	return nil
 }
 
 func signalContext() (context.Context, context.CancelFunc) {
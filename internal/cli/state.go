@@ -0,0 +1,316 @@
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"limitorderbot/internal/bot"
+	"limitorderbot/internal/i18n"
+)
+
+// stateManifest is the first entry of a state archive.
+type stateManifest struct {
+	CreatedAt time.Time `json:"created_at"`
+	Host      string    `json:"host,omitempty"`
+	Files     []string  `json:"files"`
+	Secrets   bool      `json:"secrets"`
+}
+
+const stateManifestName = "manifest.json"
+
+func newStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: i18n.T("state.short"),
+	}
+	cmd.AddCommand(newStateExportCmd())
+	cmd.AddCommand(newStateImportCmd())
+	cmd.AddCommand(newStateImportHistoryCmd())
+	return cmd
+}
+
+// secretFiles are what --with-secrets adds: .env (private key, API creds)
+// and the keystore KEYSTORE_FILE points at, if any.
+func secretFiles() []string {
+	files := []string{".env"}
+	if ks := os.Getenv("KEYSTORE_FILE"); ks != "" {
+		files = append(files, ks)
+	}
+	return files
+}
+
+func newStateExportCmd() *cobra.Command {
+	var withSecrets bool
+	cmd := &cobra.Command{
+		Use:   "export <archive.tar.gz>",
+		Short: i18n.T("state.export.short"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files := bot.StateFiles()
+			if withSecrets {
+				files = append(files, secretFiles()...)
+			}
+			var present []string
+			for _, f := range files {
+				if !safeStatePath(f) {
+					fmt.Printf("Skipping %s: only files under the working directory are archived\n", f)
+					continue
+				}
+				if _, err := os.Stat(f); err == nil {
+					present = append(present, f)
+				} else if !errors.Is(err, os.ErrNotExist) {
+					return err
+				}
+			}
+			if len(present) == 0 {
+				return errors.New("no state files found in the working directory")
+			}
+			host, _ := os.Hostname()
+			manifest := stateManifest{CreatedAt: time.Now().UTC(), Host: host, Files: present, Secrets: withSecrets}
+			if err := writeStateArchive(args[0], manifest); err != nil {
+				return err
+			}
+			for _, f := range present {
+				fmt.Printf("  %s\n", f)
+			}
+			fmt.Printf("✓ Exported %d file(s) to %s\n", len(present), args[0])
+			if withSecrets {
+				fmt.Println("The archive contains the private key and API credentials; keep it safe.")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&withSecrets, "with-secrets", false, i18n.T("state.flag.secrets"))
+	return cmd
+}
+
+// writeStateArchive writes the manifest and its files to a gzipped tar. The
+// archive is only readable by its owner since it may hold secrets.
+func writeStateArchive(path string, manifest stateManifest) (err error) {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := addTarEntry(tw, stateManifestName, raw, 0o644, manifest.CreatedAt); err != nil {
+		return err
+	}
+	for _, f := range manifest.Files {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return err
+		}
+		raw, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		if err := addTarEntry(tw, f, raw, int64(fi.Mode().Perm()), fi.ModTime()); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func addTarEntry(tw *tar.Writer, name string, data []byte, mode int64, mod time.Time) error {
+	hdr := &tar.Header{Name: filepath.ToSlash(name), Mode: mode, Size: int64(len(data)), ModTime: mod}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+func newStateImportCmd() *cobra.Command {
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "import <archive.tar.gz>",
+		Short: i18n.T("state.import.short"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, entries, err := readStateArchive(args[0])
+			if err != nil {
+				return err
+			}
+			if err := validateManifest(manifest); err != nil {
+				return err
+			}
+			allowed := map[string]bool{}
+			for _, f := range manifest.Files {
+				allowed[f] = true
+			}
+			for name := range entries {
+				if !allowed[name] {
+					return fmt.Errorf("archive entry %q is not in its manifest", name)
+				}
+				if !safeStatePath(name) {
+					return fmt.Errorf("archive entry %q points outside the working directory", name)
+				}
+			}
+			// Everything is checked before the first write so a bad archive
+			// can't leave the state half-restored.
+			for _, name := range manifest.Files {
+				if _, ok := entries[name]; !ok {
+					return fmt.Errorf("archive is missing %s", name)
+				}
+			}
+			if !force {
+				for _, name := range manifest.Files {
+					if _, err := os.Stat(name); err == nil {
+						return fmt.Errorf("%s already exists (use --force to overwrite)", name)
+					}
+				}
+			}
+			for _, name := range manifest.Files {
+				e := entries[name]
+				if dir := filepath.Dir(name); dir != "." {
+					if err := os.MkdirAll(dir, 0o700); err != nil {
+						return err
+					}
+				}
+				// Owner-only whatever the archive says: state and secrets alike
+				// hold keys, API creds or trading history. WriteFile keeps the
+				// mode of a file it overwrites, hence the Chmod.
+				if err := os.WriteFile(name, e.data, stateFileMode); err != nil {
+					return err
+				}
+				if err := os.Chmod(name, stateFileMode); err != nil {
+					return err
+				}
+				fmt.Printf("  %s\n", name)
+			}
+			fmt.Printf("✓ Imported %d file(s) exported %s from %q\n", len(manifest.Files), manifest.CreatedAt.Format(time.RFC3339), manifest.Host)
+			fmt.Println("Start the bot from this directory to pick the state up.")
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&force, "force", false, i18n.T("state.flag.force"))
+	return cmd
+}
+
+// stateFileMode is the mode import writes every file with.
+const stateFileMode os.FileMode = 0o600
+
+// maxStateEntrySize bounds one archive entry, so a crafted archive can't
+// exhaust memory on import.
+const maxStateEntrySize = 256 << 20
+
+type stateEntry struct {
+	data []byte
+}
+
+// validateManifest refuses manifests export could not have written: no
+// files, a creation time that is missing or in the future, a host name
+// that isn't one, or files besides the state files (and, with the secrets
+// flag, the secret files).
+func validateManifest(m stateManifest) error {
+	if len(m.Files) == 0 {
+		return errors.New("bad manifest: no files")
+	}
+	if m.CreatedAt.IsZero() || m.CreatedAt.After(time.Now().Add(time.Hour)) {
+		return fmt.Errorf("bad manifest: created_at %s", m.CreatedAt.Format(time.RFC3339))
+	}
+	if len(m.Host) > 255 || strings.ContainsFunc(m.Host, func(r rune) bool { return r < 0x20 || r == 0x7f }) {
+		return fmt.Errorf("bad manifest: host %q", m.Host)
+	}
+	secrets := map[string]bool{}
+	if m.Secrets {
+		for _, f := range secretFiles() {
+			secrets[filepath.Clean(f)] = true
+		}
+	}
+	for _, f := range m.Files {
+		if bot.IsStateFile(f) || secrets[filepath.Clean(f)] {
+			continue
+		}
+		if !m.Secrets {
+			return fmt.Errorf("bad manifest: %s is not a state file and the archive has no secrets flag", f)
+		}
+		return fmt.Errorf("bad manifest: %s is neither a state file nor a secret file", f)
+	}
+	return nil
+}
+
+func readStateArchive(path string) (stateManifest, map[string]stateEntry, error) {
+	var manifest stateManifest
+	f, err := os.Open(path)
+	if err != nil {
+		return manifest, nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return manifest, nil, err
+	}
+	tr := tar.NewReader(gz)
+	entries := map[string]stateEntry{}
+	haveManifest := false
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return manifest, nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return manifest, nil, fmt.Errorf("archive entry %q is not a regular file", hdr.Name)
+		}
+		if hdr.Size > maxStateEntrySize {
+			return manifest, nil, fmt.Errorf("archive entry %q is too large (%d bytes)", hdr.Name, hdr.Size)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxStateEntrySize+1))
+		if err != nil {
+			return manifest, nil, err
+		}
+		if len(data) > maxStateEntrySize {
+			return manifest, nil, fmt.Errorf("archive entry %q is too large", hdr.Name)
+		}
+		name := filepath.FromSlash(hdr.Name)
+		if name == stateManifestName {
+			if err := json.Unmarshal(data, &manifest); err != nil {
+				return manifest, nil, fmt.Errorf("bad manifest: %w", err)
+			}
+			haveManifest = true
+			continue
+		}
+		entries[name] = stateEntry{data: data}
+	}
+	if !haveManifest {
+		return manifest, nil, errors.New("not a state archive: no manifest")
+	}
+	return manifest, entries, nil
+}
+
+// safeStatePath accepts relative paths that stay inside the working
+// directory, which is where import writes them back.
+func safeStatePath(name string) bool {
+	if filepath.IsAbs(name) {
+		return false
+	}
+	clean := filepath.Clean(name)
+	return clean != ".." && !strings.HasPrefix(clean, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"limitorderbot/internal/i18n"
+	"limitorderbot/internal/strategy"
+)
+
+func newStrategyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "strategy",
+		Short: i18n.T("strategy.short"),
+	}
+	cmd.AddCommand(newStrategyListCmd())
+	cmd.AddCommand(newStrategyScaffoldCmd())
+	return cmd
+}
+
+func newStrategyListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: i18n.T("strategy.list.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, n := range strategy.Builtin {
+				fmt.Printf("%-20s built-in\n", n)
+			}
+			for _, n := range strategy.Names() {
+				fmt.Printf("%-20s registered\n", n)
+			}
+			return nil
+		},
+	}
+}
+
+var strategyNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// newStrategyScaffoldCmd writes <dir>/<name>/<name>.go: a registered strategy
+// that compiles as-is and shows each part of the strategy.Context.
+func newStrategyScaffoldCmd() *cobra.Command {
+	var (
+		dir   string
+		force bool
+	)
+	cmd := &cobra.Command{
+		Use:   "scaffold <name>",
+		Short: i18n.T("strategy.scaffold.short"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !strategyNameRe.MatchString(name) {
+				return fmt.Errorf("strategy name %q must be lowercase letters, digits and underscores", name)
+			}
+			if strategy.IsBuiltin(name) {
+				return fmt.Errorf("%q is a built-in order mode", name)
+			}
+			pkgDir := filepath.Join(dir, name)
+			path := filepath.Join(pkgDir, name+".go")
+			if _, err := os.Stat(path); err == nil && !force {
+				return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+			} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			if err := os.MkdirAll(pkgDir, 0o755); err != nil {
+				return err
+			}
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			err = scaffoldTmpl.Execute(f, map[string]string{
+				"Name":    name,
+				"Package": strings.ReplaceAll(name, "_", ""),
+				"Type":    camelCase(name),
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✓ Wrote %s\n", path)
+			fmt.Println("Next:")
+			fmt.Printf("  1. import it for its side effects in cmd/polymarket-bot/main.go:\n       _ \"limitorderbot/%s\"\n", filepath.ToSlash(pkgDir))
+			fmt.Printf("  2. set ORDER_MODE=%s (and STRATEGY_NAME for its budget and exit rules)\n", name)
+			fmt.Println("  3. go build ./... && polymarket-bot strategy list")
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "internal/strategy", i18n.T("strategy.scaffold.flag.dir"))
+	cmd.Flags().BoolVar(&force, "force", false, i18n.T("strategy.scaffold.flag.force"))
+	return cmd
+}
+
+func camelCase(name string) string {
+	var sb strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return sb.String()
+}
+
+var scaffoldTmpl = template.Must(template.New("strategy").Parse(`// Package {{.Package}} is the {{.Name}} strategy (ORDER_MODE={{.Name}}).
+package {{.Package}}
+
+import (
+	"context"
+	"fmt"
+
+	"limitorderbot/internal/models"
+	"limitorderbot/internal/strategy"
+)
+
+func init() {
+	strategy.Register("{{.Name}}", func() (strategy.Strategy, error) {
+		return &{{.Type}}{Price: 0.45, Size: 5}, nil
+	})
+}
+
+// {{.Type}} bids Price for Size shares of both outcomes while the book
+// leaves room below the ask. Replace Place with your own logic.
+type {{.Type}} struct {
+	Price float64
+	Size  float64
+}
+
+// state is persisted between calls and across restarts.
+type state struct {
+	Markets int ` + "`json:\"markets\"`" + `
+}
+
+func (s *{{.Type}}) Name() string { return "{{.Name}}" }
+
+// Place is called once per market when its placement window opens. Orders
+// go through sc.Place, which applies the bot's risk checks; the bot then
+// tracks fills, exits and redemption for them.
+func (s *{{.Type}}) Place(ctx context.Context, sc strategy.Context, market models.Market) error {
+	var st state
+	if _, err := sc.LoadState(&st); err != nil {
+		return err
+	}
+
+	yes, no := strategy.YesNo(market)
+	if yes == nil || no == nil {
+		return fmt.Errorf("market %s has no Yes/No pair", market.MarketSlug)
+	}
+	for _, outcome := range []models.Outcome{*yes, *no} {
+		_, ask, err := sc.Book(ctx, outcome.TokenID)
+		if err != nil {
+			return err
+		}
+		if ask > 0 && s.Price >= ask {
+			sc.Logf("%s ask %.2f is at or below our price; skipping", outcome.Outcome, ask)
+			continue
+		}
+		if _, err := sc.Place(ctx, strategy.Order{
+			Market:  market,
+			Outcome: outcome,
+			Side:    models.OrderSideBuy,
+			Price:   s.Price,
+			Size:    s.Size,
+		}); err != nil {
+			return err
+		}
+	}
+
+	st.Markets++
+	return sc.SaveState(st)
+}
+`))
@@ -8,15 +8,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"limitorderbot/internal/chain"
-	"limitorderbot/internal/clob"
 	"limitorderbot/internal/config"
 	"limitorderbot/internal/gamma"
+	"limitorderbot/internal/i18n"
 )
 
 func newTestConnectionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "test-connection",
-		Short: "测试 Gamma/CLOB/RPC 连接",
+		Short: i18n.T("test_connection.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -31,12 +31,14 @@ func newTestConnectionCmd() *cobra.Command {
 			fmt.Printf("  - Signature Type: %s\n", cfg.SignatureType)
 			fmt.Printf("  - Order Size: $%.2f\n", cfg.OrderSizeUSD)
 			fmt.Printf("  - Spread Offset: %.4f\n", cfg.SpreadOffset)
-			fmt.Printf("  - Check Interval: %ds\n", cfg.CheckIntervalSeconds)
+			fmt.Printf("  - Discovery Interval: %ds\n", cfg.DiscoveryIntervalSeconds)
 
 			fmt.Println("\n" + repeat("=", 60))
 			fmt.Println("GAMMA API TEST")
 			fmt.Println(repeat("=", 60))
 			disc := gamma.New(cfg.GammaAPIBaseURL)
+			disc.HTTP = cfg.HTTPClient(cfg.GammaHTTP)
+			disc.Horizon = cfg.DiscoveryHorizonFor(15 * time.Minute)
 			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 			defer cancel()
 			markets, err := disc.DiscoverBTC15mMarkets(ctx)
@@ -53,13 +55,21 @@ func newTestConnectionCmd() *cobra.Command {
 			fmt.Println("\n" + repeat("=", 60))
 			fmt.Println("CLOB CLIENT TEST")
 			fmt.Println(repeat("=", 60))
-			cc, err := clob.NewClient(cfg.ClobAPIURL, cfg.ChainID, cfg.PrivateKey, cfg.SignatureType, cfg.FunderAddress)
+			cc, err := newCLOBClient(cfg)
 			if err != nil {
 				return fmt.Errorf("[FAIL] CLOB client init error: %w", err)
 			}
 			fmt.Printf("[OK] CLOB signer initialized\n")
 			fmt.Printf("  - Wallet address: %s\n", cc.Address())
 
+			ctxOk, cancelOk := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancelOk()
+			if err := cc.GetOk(ctxOk); err != nil {
+				fmt.Printf("[WARNING] CLOB health check failed: %v\n", err)
+			} else if ts, err := cc.GetServerTime(ctxOk); err == nil {
+				fmt.Printf("[OK] CLOB API reachable (server time %s)\n", time.Unix(ts, 0).UTC().Format(time.RFC3339))
+			}
+
 			// Derive creds (best-effort; some users run read-only)
 			ctx2, cancel2 := context.WithTimeout(context.Background(), 20*time.Second)
 			defer cancel2()
@@ -71,7 +81,7 @@ func newTestConnectionCmd() *cobra.Command {
 				fmt.Printf("[WARNING] Could not derive CLOB API creds (read-only OK): %v\n", err)
 			}
 
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return fmt.Errorf("[FAIL] RPC client init error: %w", err)
 			}
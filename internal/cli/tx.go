@@ -10,14 +10,14 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 
-	"limitorderbot/internal/chain"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
 )
 
 func newTxCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "tx",
-		Short: "交易/回执解析工具（等价 get_token_ids_from_tx.py）",
+		Short: i18n.T("tx.short"),
 	}
 	cmd.AddCommand(newTxTokenIDsCmd())
 	return cmd
@@ -28,13 +28,13 @@ func newTxTokenIDsCmd() *cobra.Command {
 	var onlyIncoming bool
 	cmd := &cobra.Command{
 		Use:   "token-ids",
-		Short: "从交易回执里解析 CTF TransferSingle 的 tokenId/amount",
+		Short: i18n.T("tx.token_ids.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
@@ -50,7 +50,7 @@ func newTxTokenIDsCmd() *cobra.Command {
 			}
 
 			wallet := ch.Address()
-			ctfAddr := common.HexToAddress(chain.CTFAddress)
+			ctfAddr := ch.Addresses().CTF
 
 			fmt.Printf("Wallet: %s\n", wallet.Hex())
 			fmt.Printf("Tx: %s\n", h.Hex())
@@ -81,7 +81,7 @@ func newTxTokenIDsCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&txHash, "tx", "", "transaction hash (0x...)")
-	cmd.Flags().BoolVar(&onlyIncoming, "only-incoming", true, "只输出转入当前钱包的 token")
+	cmd.Flags().BoolVar(&onlyIncoming, "only-incoming", true, i18n.T("tx.flag.only_incoming"))
 	_ = cmd.MarkFlagRequired("tx")
 	return cmd
 }
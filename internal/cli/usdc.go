@@ -2,35 +2,38 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 
-	"limitorderbot/internal/chain"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
 )
 
 func newUSDCCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "usdc",
-		Short: "USDC / USDC.e 排障工具（等价 check_all_usdc.py）",
+		Short: i18n.T("usdc.short"),
 	}
 	cmd.AddCommand(newUSDCCheckCmd())
+	cmd.AddCommand(newUSDCSwapCmd())
 	return cmd
 }
 
 func newUSDCCheckCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "check",
-		Short: "对比 USDC.e 与 Polygon 原生 USDC 余额",
+		Short: i18n.T("usdc.compare.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
@@ -38,8 +41,8 @@ func newUSDCCheckCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 			defer cancel()
 
-			usdcE := common.HexToAddress(chain.USDCeAddress)
-			usdc := common.HexToAddress(chain.USDCAddress)
+			usdcE := ch.Addresses().Collateral
+			usdc := ch.Addresses().USDC
 
 			bE, err := ch.ERC20BalanceFloat6(ctx, usdcE)
 			if err != nil {
@@ -51,8 +54,8 @@ func newUSDCCheckCmd() *cobra.Command {
 			}
 
 			fmt.Printf("Wallet: %s\n", ch.Address().Hex())
-			fmt.Printf("USDC.e (%s): %.6f\n", chain.USDCeAddress, bE)
-			fmt.Printf("USDC   (%s): %.6f\n", chain.USDCAddress, b)
+			fmt.Printf("USDC.e (%s): %.6f\n", usdcE.Hex(), bE)
+			fmt.Printf("USDC   (%s): %.6f\n", usdc.Hex(), b)
 			fmt.Printf("Total: %.6f\n", bE+b)
 			return nil
 		},
@@ -60,3 +63,73 @@ func newUSDCCheckCmd() *cobra.Command {
 	return cmd
 }
 
+// newUSDCSwapCmd swaps between USDC.e and native USDC through
+// USDC_SWAP_ROUTER in either direction; the bot itself only ever swaps
+// native USDC into USDC.e.
+func newUSDCSwapCmd() *cobra.Command {
+	var to string
+	var amount float64
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "swap",
+		Short: i18n.T("usdc.swap.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if to != "native" && to != "usdce" {
+				return errors.New("--to must be native or usdce")
+			}
+			if amount <= 0 {
+				return errors.New("--amount must be > 0")
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			if cfg.USDCSwapRouter == "" {
+				return errors.New("USDC_SWAP_ROUTER is not set")
+			}
+			ch, err := newChainClient(cfg)
+			if err != nil {
+				return err
+			}
+			defer ch.Close()
+			ch.SetDryRun(dryRun)
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+			defer cancel()
+
+			toNative := to == "native"
+			usdcE, err1 := ch.ERC20BalanceFloat6(ctx, ch.Addresses().Collateral)
+			native, err2 := ch.NativeUSDCBalance(ctx)
+			if err1 == nil && err2 == nil {
+				have := native
+				if toNative {
+					have = usdcE
+				}
+				if amount > have {
+					return fmt.Errorf("only %.6f available to swap", have)
+				}
+				delta := amount
+				if toNative {
+					delta = -delta
+				}
+				printChanges([]balanceChange{{"Wallet USDC.e", usdcE, delta}, {"Wallet USDC", native, -delta}})
+			}
+
+			amountIn := big.NewInt(int64(amount * 1_000_000))
+			minOut := new(big.Int).Mul(amountIn, big.NewInt(int64(10000-cfg.USDCSwapMaxSlippageBps)))
+			minOut.Quo(minOut, big.NewInt(10000))
+			hash, err := ch.SwapUSDC(ctx, common.HexToAddress(cfg.USDCSwapRouter), uint32(cfg.USDCSwapFee), toNative, amountIn, minOut)
+			if isDryRun(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✓ swap sent (tx=%s)\n", hash.Hex())
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&to, "to", "native", i18n.T("usdc.swap.flag.to"))
+	cmd.Flags().Float64Var(&amount, "amount", 0, i18n.T("usdc.swap.flag.amount"))
+	addDryRunFlag(cmd, &dryRun)
+	return cmd
+}
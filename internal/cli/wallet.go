@@ -5,32 +5,33 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 
-	"limitorderbot/internal/chain"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
 )
 
 func newWalletCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "wallet",
-		Short: "钱包快速排障（等价 check_balance.py + 一部分 check_all_usdc.py）",
+		Short: i18n.T("wallet.short"),
 	}
 	cmd.AddCommand(newWalletSummaryCmd())
+	cmd.AddCommand(newWalletRebalanceCmd())
+	cmd.AddCommand(newWalletYieldCmd())
 	return cmd
 }
 
 func newWalletSummaryCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "summary",
-		Short: "输出地址、MATIC、USDC、USDC.e 余额",
+		Short: i18n.T("wallet.balances.short"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				return err
 			}
-			ch, err := chain.New(cfg.RPCURL, cfg.PrivateKey, cfg.ChainID)
+			ch, err := newChainClient(cfg)
 			if err != nil {
 				return err
 			}
@@ -42,11 +43,11 @@ func newWalletSummaryCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			usdcE, err := ch.ERC20BalanceFloat6(ctx, common.HexToAddress(chain.USDCeAddress))
+			usdcE, err := ch.ERC20BalanceFloat6(ctx, ch.Addresses().Collateral)
 			if err != nil {
 				return err
 			}
-			usdc, err := ch.ERC20BalanceFloat6(ctx, common.HexToAddress(chain.USDCAddress))
+			usdc, err := ch.ERC20BalanceFloat6(ctx, ch.Addresses().USDC)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"limitorderbot/internal/chain"
+	"limitorderbot/internal/config"
+	"limitorderbot/internal/i18n"
+)
+
+func newWalletYieldCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "yield",
+		Short: i18n.T("wallet.yield.short"),
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: i18n.T("wallet.yield.status.short"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withYieldPool(func(ctx context.Context, cfg config.Config, ch *chain.Client, pool common.Address) error {
+				return printYieldBalances(ctx, cfg, ch)
+			})
+		},
+	})
+	cmd.AddCommand(newYieldMoveCmd("deposit", i18n.T("wallet.yield.deposit"), true,
+		func(ctx context.Context, ch *chain.Client, pool common.Address, amount6 *big.Int) (common.Hash, error) {
+			return ch.YieldDeposit(ctx, pool, amount6)
+		}))
+	cmd.AddCommand(newYieldMoveCmd("withdraw", i18n.T("wallet.yield.withdraw"), false,
+		func(ctx context.Context, ch *chain.Client, pool common.Address, amount6 *big.Int) (common.Hash, error) {
+			return ch.YieldWithdraw(ctx, pool, amount6)
+		}))
+	return cmd
+}
+
+// newYieldMoveCmd builds deposit/withdraw; without --amount, a withdrawal
+// takes everything (amount6 nil) while a deposit is refused.
+func newYieldMoveCmd(use, short string, needAmount bool, move func(ctx context.Context, ch *chain.Client, pool common.Address, amount6 *big.Int) (common.Hash, error)) *cobra.Command {
+	var amount float64
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if amount < 0 || (needAmount && amount == 0) {
+				return errors.New("--amount must be > 0")
+			}
+			return withYieldPool(func(ctx context.Context, cfg config.Config, ch *chain.Client, pool common.Address) error {
+				ch.SetDryRun(dryRun)
+				var amount6 *big.Int
+				if amount > 0 {
+					amount6 = big.NewInt(int64(amount * 1_000_000))
+				}
+				wallet, err1 := ch.USDCBalance(ctx)
+				parked, err2 := ch.YieldBalance(ctx, common.HexToAddress(cfg.YieldReceiptToken))
+				if err1 == nil && err2 == nil {
+					delta := amount
+					if !needAmount && amount6 == nil {
+						delta = parked
+					}
+					if needAmount {
+						delta = -delta
+					}
+					printChanges([]balanceChange{{"Wallet USDC.e", wallet, delta}, {"Pool USDC.e", parked, -delta}})
+				}
+				hash, err := move(ctx, ch, pool, amount6)
+				if isDryRun(err) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				fmt.Printf("✓ %s sent (tx=%s)\n", use, hash.Hex())
+				return printYieldBalances(ctx, cfg, ch)
+			})
+		},
+	}
+	cmd.Flags().Float64Var(&amount, "amount", 0, i18n.T("wallet.rebalance.flag.amount"))
+	addDryRunFlag(cmd, &dryRun)
+	return cmd
+}
+
+// withYieldPool runs fn with a chain client and the configured YIELD_POOL.
+func withYieldPool(fn func(ctx context.Context, cfg config.Config, ch *chain.Client, pool common.Address) error) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.YieldPool == "" {
+		return errors.New("YIELD_POOL is not set")
+	}
+	ch, err := newChainClient(cfg)
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.TxConfirmTimeoutSeconds+60)*time.Second)
+	defer cancel()
+	return fn(ctx, cfg, ch, common.HexToAddress(cfg.YieldPool))
+}
+
+func printYieldBalances(ctx context.Context, cfg config.Config, ch *chain.Client) error {
+	wallet, err := ch.USDCBalance(ctx)
+	if err != nil {
+		return err
+	}
+	parked, err := ch.YieldBalance(ctx, common.HexToAddress(cfg.YieldReceiptToken))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Wallet %s  USDC.e %.6f\n", ch.Address().Hex(), wallet)
+	fmt.Printf("Pool   %s  USDC.e %.6f\n", cfg.YieldPool, parked)
+	return nil
+}
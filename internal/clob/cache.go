@@ -0,0 +1,150 @@
+package clob
+
+import (
+	"container/list"
+	"time"
+)
+
+// Default bounds of the per-token market parameter caches; SetCacheLimits
+// overrides them.
+const (
+	DefaultCacheSize = 5000
+	DefaultCacheTTL  = 6 * time.Hour
+)
+
+// CacheStats describes one cache for /api/metrics.
+type CacheStats struct {
+	Size      int     `json:"size"`
+	Capacity  int     `json:"capacity"`
+	Hits      int64   `json:"hits"`
+	Misses    int64   `json:"misses"`
+	Evictions int64   `json:"evictions"`
+	HitRate   float64 `json:"hit_rate"`
+}
+
+type lruEntry[V any] struct {
+	key string
+	val V
+	at  time.Time
+}
+
+// lru is a size- and age-bounded cache. It is not safe for concurrent use;
+// the client guards its caches with cacheMu.
+type lru[V any] struct {
+	capacity int
+	ttl      time.Duration // 0: entries never expire
+	order    *list.List    // most recently used first
+	items    map[string]*list.Element
+
+	hits, misses, evictions int64
+}
+
+func newLRU[V any](capacity int, ttl time.Duration) *lru[V] {
+	return &lru[V]{capacity: capacity, ttl: ttl, order: list.New(), items: map[string]*list.Element{}}
+}
+
+func (c *lru[V]) expired(e *lruEntry[V], now time.Time) bool {
+	return c.ttl > 0 && now.Sub(e.at) > c.ttl
+}
+
+// get returns a live entry and marks it recently used.
+func (c *lru[V]) get(key string) (V, bool) {
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*lruEntry[V])
+		if !c.expired(e, time.Now()) {
+			c.order.MoveToFront(el)
+			c.hits++
+			return e.val, true
+		}
+		c.remove(el)
+	}
+	c.misses++
+	var zero V
+	return zero, false
+}
+
+// has reports whether key holds a live entry without counting a lookup.
+func (c *lru[V]) has(key string) bool {
+	el, ok := c.items[key]
+	return ok && !c.expired(el.Value.(*lruEntry[V]), time.Now())
+}
+
+func (c *lru[V]) set(key string, val V) {
+	if el, ok := c.items[key]; ok {
+		el.Value = &lruEntry[V]{key: key, val: val, at: time.Now()}
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[V]{key: key, val: val, at: time.Now()})
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		c.evictions++
+	}
+}
+
+func (c *lru[V]) del(key string) {
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+}
+
+func (c *lru[V]) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*lruEntry[V]).key)
+}
+
+// compact drops expired entries and returns how many went.
+func (c *lru[V]) compact() int {
+	if c.ttl <= 0 {
+		return 0
+	}
+	now := time.Now()
+	n := 0
+	for el := c.order.Back(); el != nil; {
+		prev := el.Prev()
+		if c.expired(el.Value.(*lruEntry[V]), now) {
+			c.remove(el)
+			n++
+		}
+		el = prev
+	}
+	return n
+}
+
+func (c *lru[V]) stats() CacheStats {
+	s := CacheStats{Size: c.order.Len(), Capacity: c.capacity, Hits: c.hits, Misses: c.misses, Evictions: c.evictions}
+	if total := c.hits + c.misses; total > 0 {
+		s.HitRate = float64(c.hits) / float64(total)
+	}
+	return s
+}
+
+// SetCacheLimits bounds each market parameter cache to size tokens (0:
+// unbounded) and ttl age (0: no expiry), dropping what is cached so far.
+func (c *Client) SetCacheLimits(size int, ttl time.Duration) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	c.tickSizes = newLRU[TickSize](size, ttl)
+	c.negRisk = newLRU[bool](size, ttl)
+	c.feeRates = newLRU[int](size, ttl)
+	c.minSizes = newLRU[float64](size, ttl)
+}
+
+// CompactCaches drops expired market parameters and returns how many went.
+func (c *Client) CompactCaches() int {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	return c.tickSizes.compact() + c.negRisk.compact() + c.feeRates.compact() + c.minSizes.compact()
+}
+
+// CacheStats reports size and hit rate of each market parameter cache.
+func (c *Client) CacheStats() map[string]CacheStats {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	return map[string]CacheStats{
+		"tick_sizes": c.tickSizes.stats(),
+		"neg_risk":   c.negRisk.stats(),
+		"fee_rates":  c.feeRates.stats(),
+		"min_sizes":  c.minSizes.stats(),
+	}
+}
@@ -6,10 +6,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"limitorderbot/internal/keys"
 )
 
 type Client struct {
@@ -19,22 +23,26 @@ type Client struct {
 	creds  *ApiCreds
 	http   httpClient
 
-	// local caches
-	tickSizes map[string]TickSize
-	negRisk   map[string]bool
-	feeRates  map[string]int
+	// local caches; the CLOB client is shared by the trading loop and
+	// background tasks, so cache access goes through cacheMu.
+	cacheMu   sync.Mutex
+	tickSizes *lru[TickSize]
+	negRisk   *lru[bool]
+	feeRates  *lru[int]
+	minSizes  *lru[float64]
 
 	// signature config
 	sigType int
 	funder  common.Address
 }
 
-func NewClient(host string, chainID int64, privateKey string, signatureType string, funder string) (*Client, error) {
+// NewClient builds a CLOB client; key may be nil for read-only use.
+func NewClient(host string, chainID int64, key keys.Provider, signatureType string, funder string) (*Client, error) {
 	h := strings.TrimSuffix(host, "/")
 	var s *Signer
 	var err error
-	if privateKey != "" {
-		s, err = NewSigner(privateKey, chainID)
+	if key != nil {
+		s, err = NewSignerFromProvider(key, chainID)
 		if err != nil {
 			return nil, err
 		}
@@ -45,9 +53,10 @@ func NewClient(host string, chainID int64, privateKey string, signatureType stri
 		chain:     chainID,
 		signer:    s,
 		http:      defaultHTTPClient(),
-		tickSizes: map[string]TickSize{},
-		negRisk:   map[string]bool{},
-		feeRates:  map[string]int{},
+		tickSizes: newLRU[TickSize](DefaultCacheSize, DefaultCacheTTL),
+		negRisk:   newLRU[bool](DefaultCacheSize, DefaultCacheTTL),
+		feeRates:  newLRU[int](DefaultCacheSize, DefaultCacheTTL),
+		minSizes:  newLRU[float64](DefaultCacheSize, DefaultCacheTTL),
 	}
 
 	c.sigType = 0
@@ -74,10 +83,21 @@ func (c *Client) Address() string {
 	return c.signer.Address().Hex()
 }
 
+// SetHTTPClient replaces the default 15s-timeout client, e.g. with one tuned
+// from CLOB_HTTP_* settings.
+func (c *Client) SetHTTPClient(h *http.Client) {
+	c.http = h
+}
+
 func (c *Client) SetCreds(creds ApiCreds) {
 	c.creds = &creds
 }
 
+// HasCreds reports whether L2 (API key) auth is set up.
+func (c *Client) HasCreds() bool {
+	return c.creds != nil
+}
+
 func (c *Client) CreateOrDeriveAPICreds(ctx context.Context, nonce int64) (ApiCreds, error) {
 	// Try create, fallback derive (matching python create_or_derive_api_creds)
 	creds, err := c.CreateAPIKey(ctx, nonce)
@@ -133,6 +153,33 @@ func (c *Client) DeriveAPIKey(ctx context.Context, nonce int64) (ApiCreds, error
 	}, nil
 }
 
+// GetOk is the unauthenticated health check; it returns nil when the CLOB answers "OK".
+func (c *Client) GetOk(ctx context.Context) error {
+	resp, err := doJSON(ctx, c.http, http.MethodGet, c.host+EndpointOk, nil, nil)
+	if err != nil {
+		return err
+	}
+	if s, ok := resp.(string); !ok || !strings.EqualFold(strings.TrimSpace(s), "OK") {
+		return fmt.Errorf("unexpected health response: %v", resp)
+	}
+	return nil
+}
+
+// GetServerTime returns the CLOB server's unix time in seconds.
+func (c *Client) GetServerTime(ctx context.Context) (int64, error) {
+	resp, err := doJSON(ctx, c.http, http.MethodGet, c.host+EndpointTime, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	switch t := resp.(type) {
+	case float64:
+		return int64(t), nil
+	case string:
+		return strconv.ParseInt(strings.TrimSpace(t), 10, 64)
+	}
+	return 0, fmt.Errorf("unexpected time response: %T", resp)
+}
+
 func (c *Client) GetOrderBook(ctx context.Context, tokenID string) (map[string]any, error) {
 	u := c.host + EndpointGetOrderBook + "?token_id=" + url.QueryEscape(tokenID)
 	resp, err := doJSON(ctx, c.http, http.MethodGet, u, nil, nil)
@@ -146,8 +193,26 @@ func (c *Client) GetOrderBook(ctx context.Context, tokenID string) (map[string]a
 	return m, nil
 }
 
+// GetMarket returns the CLOB market for a condition id, including tokens[] with
+// per-token "winner" flags once the market has resolved.
+func (c *Client) GetMarket(ctx context.Context, conditionID string) (map[string]any, error) {
+	u := c.host + EndpointMarketPrefix + url.PathEscape(conditionID)
+	resp, err := doJSON(ctx, c.http, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := resp.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected market response: %T", resp)
+	}
+	return m, nil
+}
+
 func (c *Client) GetTickSize(ctx context.Context, tokenID string) (TickSize, error) {
-	if t, ok := c.tickSizes[tokenID]; ok {
+	c.cacheMu.Lock()
+	t, ok := c.tickSizes.get(tokenID)
+	c.cacheMu.Unlock()
+	if ok {
 		return t, nil
 	}
 	u := c.host + EndpointGetTickSize + "?token_id=" + url.QueryEscape(tokenID)
@@ -157,12 +222,50 @@ func (c *Client) GetTickSize(ctx context.Context, tokenID string) (TickSize, err
 	}
 	m := resp.(map[string]any)
 	ts := TickSize(fmt.Sprintf("%v", m["minimum_tick_size"]))
-	c.tickSizes[tokenID] = ts
+	c.cacheMu.Lock()
+	c.tickSizes.set(tokenID, ts)
+	c.cacheMu.Unlock()
 	return ts, nil
 }
 
+// GetLotSize returns the share increment order sizes must be a multiple of,
+// from the token's tick size.
+func (c *Client) GetLotSize(ctx context.Context, tokenID string) (float64, error) {
+	ts, err := c.GetTickSize(ctx, tokenID)
+	if err != nil {
+		return 0, err
+	}
+	return SizeIncrement(ts), nil
+}
+
+// GetMinOrderSize returns the smallest order (in shares) the CLOB accepts for
+// a token, from the min_order_size on its order book.
+func (c *Client) GetMinOrderSize(ctx context.Context, tokenID string) (float64, error) {
+	c.cacheMu.Lock()
+	v, ok := c.minSizes.get(tokenID)
+	c.cacheMu.Unlock()
+	if ok {
+		return v, nil
+	}
+	book, err := c.GetOrderBook(ctx, tokenID)
+	if err != nil {
+		return 0, err
+	}
+	v, err = strconv.ParseFloat(asString(book["min_order_size"]), 64)
+	if err != nil {
+		return 0, fmt.Errorf("order book has no min_order_size: %w", err)
+	}
+	c.cacheMu.Lock()
+	c.minSizes.set(tokenID, v)
+	c.cacheMu.Unlock()
+	return v, nil
+}
+
 func (c *Client) GetNegRisk(ctx context.Context, tokenID string) (bool, error) {
-	if v, ok := c.negRisk[tokenID]; ok {
+	c.cacheMu.Lock()
+	v, ok := c.negRisk.get(tokenID)
+	c.cacheMu.Unlock()
+	if ok {
 		return v, nil
 	}
 	u := c.host + EndpointGetNegRisk + "?token_id=" + url.QueryEscape(tokenID)
@@ -171,13 +274,18 @@ func (c *Client) GetNegRisk(ctx context.Context, tokenID string) (bool, error) {
 		return false, err
 	}
 	m := resp.(map[string]any)
-	v := asBool(m["neg_risk"])
-	c.negRisk[tokenID] = v
+	v = asBool(m["neg_risk"])
+	c.cacheMu.Lock()
+	c.negRisk.set(tokenID, v)
+	c.cacheMu.Unlock()
 	return v, nil
 }
 
 func (c *Client) GetFeeRateBps(ctx context.Context, tokenID string) (int, error) {
-	if v, ok := c.feeRates[tokenID]; ok {
+	c.cacheMu.Lock()
+	v, ok := c.feeRates.get(tokenID)
+	c.cacheMu.Unlock()
+	if ok {
 		return v, nil
 	}
 	u := c.host + EndpointGetFeeRate + "?token_id=" + url.QueryEscape(tokenID)
@@ -187,7 +295,9 @@ func (c *Client) GetFeeRateBps(ctx context.Context, tokenID string) (int, error)
 	}
 	m := resp.(map[string]any)
 	fee := asInt(m["base_fee"])
-	c.feeRates[tokenID] = fee
+	c.cacheMu.Lock()
+	c.feeRates.set(tokenID, fee)
+	c.cacheMu.Unlock()
 	return fee, nil
 }
 
@@ -375,6 +485,29 @@ func (c *Client) Cancel(ctx context.Context, orderID string) (any, error) {
 	return doJSON(ctx, c.http, http.MethodDelete, c.host+EndpointCancel, headers, b)
 }
 
+// CancelAll cancels every open order of the account, including ones this
+// process never placed.
+func (c *Client) CancelAll(ctx context.Context) (any, error) {
+	if c.signer == nil {
+		return nil, ErrAuthUnavailableL1
+	}
+	if c.creds == nil {
+		return nil, ErrAuthUnavailableL2
+	}
+	headers, err := c.level2Headers(http.MethodDelete, EndpointCancelAll, nil)
+	if err != nil {
+		return nil, err
+	}
+	return doJSON(ctx, c.http, http.MethodDelete, c.host+EndpointCancelAll, headers, nil)
+}
+
+// Asset types of the balance-allowance endpoints: USDC collateral, or the
+// outcome shares of one TokenID.
+const (
+	AssetCollateral  = "COLLATERAL"
+	AssetConditional = "CONDITIONAL"
+)
+
 type BalanceAllowanceParams struct {
 	AssetType      string
 	TokenID        string
@@ -429,6 +562,20 @@ func (c *Client) UpdateBalanceAllowance(ctx context.Context, params *BalanceAllo
 	return m, nil
 }
 
+// ConditionalBalance returns the CLOB's view of our shares of tokenID, which
+// is what it validates a SELL against.
+func (c *Client) ConditionalBalance(ctx context.Context, tokenID string) (float64, error) {
+	cur, err := c.GetBalanceAllowance(ctx, &BalanceAllowanceParams{AssetType: AssetConditional, TokenID: tokenID})
+	if err != nil {
+		return 0, err
+	}
+	raw, err := strconv.ParseFloat(fmt.Sprint(cur["balance"]), 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected balance %v", cur["balance"])
+	}
+	return raw / 1e6, nil
+}
+
 type OpenOrderParams struct {
 	Market  string
 	AssetID string
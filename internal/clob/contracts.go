@@ -40,3 +40,32 @@ func GetContractConfig(chainID int64, negRisk bool) (ContractConfig, error) {
 	}
 	return ContractConfig{}, ErrInvalidChainID
 }
+
+// Spender is a contract that moves the wallet's collateral or outcome
+// tokens when orders match, and so needs approvals.
+type Spender struct {
+	Address string
+	Name    string
+}
+
+var polygonSpenders = []Spender{
+	{"0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E", "CTF Exchange"},
+	{"0xC5d563A36AE78145C45a50134d48A1215220f80a", "Neg Risk CTF Exchange"},
+	{"0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296", "Neg Risk Adapter"},
+}
+
+// Spenders returns the contracts needing approval on chainID. Polygon keeps
+// the full python list; other chains use the contract config.
+func Spenders(chainID int64) []Spender {
+	if chainID == 137 {
+		return polygonSpenders
+	}
+	var out []Spender
+	if cc, err := GetContractConfig(chainID, false); err == nil {
+		out = append(out, Spender{cc.Exchange, "CTF Exchange"})
+	}
+	if cc, err := GetContractConfig(chainID, true); err == nil {
+		out = append(out, Spender{cc.Exchange, "Neg Risk CTF Exchange"})
+	}
+	return out
+}
@@ -1,10 +1,12 @@
 package clob
 
 const (
+	EndpointOk                   = "/"
 	EndpointTime                 = "/time"
 	EndpointCreateAPIKey         = "/auth/api-key"
 	EndpointDeriveAPIKey         = "/auth/derive-api-key"
 	EndpointGetOrderBook         = "/book"
+	EndpointMarketPrefix         = "/markets/"
 	EndpointGetTickSize          = "/tick-size"
 	EndpointGetNegRisk           = "/neg-risk"
 	EndpointGetFeeRate           = "/fee-rate"
@@ -15,4 +17,8 @@ const (
 	EndpointCancelAll            = "/cancel-all"
 	EndpointBalanceAllowance     = "/balance-allowance"
 	EndpointBalanceAllowanceUpdt = "/balance-allowance/update"
+	EndpointRewardsUserTotal     = "/rewards/user/total"
+	EndpointOrderScoring         = "/order-scoring"
+	EndpointOrdersScoring        = "/orders-scoring"
+	EndpointTrades               = "/data/trades"
 )
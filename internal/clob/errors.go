@@ -1,9 +1,13 @@
 package clob
 
-import "errors"
+import (
+	"errors"
+
+	"limitorderbot/internal/apierr"
+)
 
 var (
 	ErrInvalidChainID    = errors.New("invalid chainID")
-	ErrAuthUnavailableL1 = errors.New("a private key is needed to interact with this endpoint")
-	ErrAuthUnavailableL2 = errors.New("API credentials are needed to interact with this endpoint")
+	ErrAuthUnavailableL1 = apierr.New(apierr.Auth, "a private key is needed to interact with this endpoint")
+	ErrAuthUnavailableL2 = apierr.New(apierr.Auth, "API credentials are needed to interact with this endpoint")
 )
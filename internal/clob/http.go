@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"limitorderbot/internal/apierr"
 )
 
 type httpClient interface {
@@ -43,22 +45,24 @@ func doJSON(ctx context.Context, c httpClient, method, url string, headers map[s
 
 	resp, err := c.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, apierr.Wrap(apierr.Network, err)
 	}
 	defer resp.Body.Close()
 
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, apierr.Wrap(apierr.Network, err)
 	}
 	if resp.StatusCode != 200 {
 		// Attempt to parse json error
 		var j any
 		_ = json.Unmarshal(b, &j)
 		if j != nil {
-			return nil, fmt.Errorf("CLOB API status=%d error=%v", resp.StatusCode, j)
+			err = fmt.Errorf("CLOB API status=%d error=%v", resp.StatusCode, j)
+		} else {
+			err = fmt.Errorf("CLOB API status=%d error=%s", resp.StatusCode, string(b))
 		}
-		return nil, fmt.Errorf("CLOB API status=%d error=%s", resp.StatusCode, string(b))
+		return nil, apierr.FromStatus(resp.StatusCode, resp.Header, string(b), err)
 	}
 
 	// Try json
@@ -82,6 +82,16 @@ var roundingConfig = map[TickSize]roundConfig{
 	"0.0001": {price: 4, size: 2, amount: 6},
 }
 
+// SizeIncrement is the share lot size the CLOB's rounding rules allow for a
+// tick size (order sizes carry rc.size decimals); 0.01 for unknown ticks.
+func SizeIncrement(tick TickSize) float64 {
+	rc, ok := roundingConfig[tick]
+	if !ok {
+		return 0.01
+	}
+	return math.Pow10(-rc.size)
+}
+
 // toTokenDecimals replicates py_order_utils.order_builder.helpers.to_token_decimals (1e6 scale).
 func toTokenDecimals(x float64) uint64 {
 	f := 1e6 * x
@@ -0,0 +1,56 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Prewarm fills the tick size, neg-risk, fee rate and minimum size caches for
+// tokens that aren't cached yet, so CreateOrder and the placement checks don't
+// spend round trips on them at the moment an order goes out.
+func (c *Client) Prewarm(ctx context.Context, tokenIDs []string) error {
+	var errs []error
+	for _, id := range tokenIDs {
+		if c.cached(id) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, err := c.GetTickSize(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("tick size %s: %w", id, err))
+			continue
+		}
+		if _, err := c.GetNegRisk(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("neg risk %s: %w", id, err))
+			continue
+		}
+		if _, err := c.GetFeeRateBps(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("fee rate %s: %w", id, err))
+			continue
+		}
+		if _, err := c.GetMinOrderSize(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("min order size %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Forget drops cached market parameters for tokens that are no longer traded.
+func (c *Client) Forget(tokenIDs []string) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	for _, id := range tokenIDs {
+		c.tickSizes.del(id)
+		c.negRisk.del(id)
+		c.feeRates.del(id)
+		c.minSizes.del(id)
+	}
+}
+
+func (c *Client) cached(tokenID string) bool {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	return c.tickSizes.has(tokenID) && c.negRisk.has(tokenID) && c.feeRates.has(tokenID) && c.minSizes.has(tokenID)
+}
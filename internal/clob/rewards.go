@@ -0,0 +1,116 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// GetUserRewardsTotal returns the liquidity rewards credited to this wallet for
+// date (YYYY-MM-DD, UTC), summed across reward assets.
+func (c *Client) GetUserRewardsTotal(ctx context.Context, date string) (float64, error) {
+	if c.signer == nil {
+		return 0, ErrAuthUnavailableL1
+	}
+	if c.creds == nil {
+		return 0, ErrAuthUnavailableL2
+	}
+	headers, err := c.level2Headers(http.MethodGet, EndpointRewardsUserTotal, nil)
+	if err != nil {
+		return 0, err
+	}
+	q := url.Values{}
+	q.Set("date", date)
+	q.Set("signature_type", strconv.Itoa(c.sigType))
+	resp, err := doJSON(ctx, c.http, http.MethodGet, c.host+EndpointRewardsUserTotal+"?"+q.Encode(), headers, nil)
+	if err != nil {
+		return 0, err
+	}
+	var rows []any
+	switch t := resp.(type) {
+	case []any:
+		rows = t
+	case map[string]any:
+		if data, ok := t["data"].([]any); ok {
+			rows = data
+		} else {
+			rows = []any{t}
+		}
+	default:
+		return 0, fmt.Errorf("unexpected rewards response: %T", resp)
+	}
+	var total float64
+	for _, r := range rows {
+		m, _ := r.(map[string]any)
+		if m == nil {
+			continue
+		}
+		switch v := m["earnings"].(type) {
+		case float64:
+			total += v
+		case string:
+			f, _ := strconv.ParseFloat(v, 64)
+			total += f
+		}
+	}
+	return total, nil
+}
+
+// IsOrderScoring reports whether a resting order currently earns liquidity
+// rewards (it must be within the market's max spread and above its min size).
+func (c *Client) IsOrderScoring(ctx context.Context, orderID string) (bool, error) {
+	if c.signer == nil {
+		return false, ErrAuthUnavailableL1
+	}
+	if c.creds == nil {
+		return false, ErrAuthUnavailableL2
+	}
+	headers, err := c.level2Headers(http.MethodGet, EndpointOrderScoring, nil)
+	if err != nil {
+		return false, err
+	}
+	q := url.Values{}
+	q.Set("order_id", orderID)
+	resp, err := doJSON(ctx, c.http, http.MethodGet, c.host+EndpointOrderScoring+"?"+q.Encode(), headers, nil)
+	if err != nil {
+		return false, err
+	}
+	m, ok := resp.(map[string]any)
+	if !ok {
+		return false, fmt.Errorf("unexpected order scoring response: %T", resp)
+	}
+	scoring, _ := m["scoring"].(bool)
+	return scoring, nil
+}
+
+// AreOrdersScoring is IsOrderScoring for several orders in one request; ids
+// missing from the response are reported as not scoring.
+func (c *Client) AreOrdersScoring(ctx context.Context, orderIDs []string) (map[string]bool, error) {
+	if c.signer == nil {
+		return nil, ErrAuthUnavailableL1
+	}
+	if c.creds == nil {
+		return nil, ErrAuthUnavailableL2
+	}
+	body, _ := json.Marshal(orderIDs)
+	headers, err := c.level2Headers(http.MethodPost, EndpointOrdersScoring, body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := doJSON(ctx, c.http, http.MethodPost, c.host+EndpointOrdersScoring, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := resp.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected orders scoring response: %T", resp)
+	}
+	out := make(map[string]bool, len(orderIDs))
+	for _, id := range orderIDs {
+		out[id], _ = m[id].(bool)
+	}
+	return out, nil
+}
@@ -1,38 +1,42 @@
 package clob
 
 import (
-	"crypto/ecdsa"
 	"encoding/hex"
-	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+
+	"limitorderbot/internal/keys"
 )
 
+// Signer produces CLOB signatures (ClobAuth and Order EIP-712 digests). The
+// digest is signed by a keys.Signer, which may be a local key or a remote
+// signing service.
 type Signer struct {
-	privateKey *ecdsa.PrivateKey
-	chainID    int64
-	address    common.Address
+	signer  keys.Signer
+	chainID int64
 }
 
 func NewSigner(hexKey string, chainID int64) (*Signer, error) {
-	key := strings.TrimSpace(hexKey)
-	key = strings.TrimPrefix(key, "0x")
-	pk, err := crypto.HexToECDSA(key)
+	return NewSignerFromProvider(keys.FromHex(hexKey), chainID)
+}
+
+// NewSignerFromProvider builds a Signer from a keys.Provider (keystore, keychain,
+// raw hex, or remote signer).
+func NewSignerFromProvider(p keys.Provider, chainID int64) (*Signer, error) {
+	s, err := keys.SignerFor(p)
 	if err != nil {
 		return nil, err
 	}
-	addr := crypto.PubkeyToAddress(pk.PublicKey)
-	return &Signer{privateKey: pk, chainID: chainID, address: addr}, nil
+	return &Signer{signer: s, chainID: chainID}, nil
 }
 
-func (s *Signer) Address() common.Address { return s.address }
+func (s *Signer) Address() common.Address { return s.signer.Address() }
 func (s *Signer) ChainID() int64          { return s.chainID }
 
 // SignHash signs a 32-byte hash using raw secp256k1 (no prefix), returning 0x-prefixed hex.
 // Note: eth_account.Account._sign_hash returns a signature whose V is 27/28; we match that.
 func (s *Signer) SignHash(hash [32]byte) (string, error) {
-	sig, err := crypto.Sign(hash[:], s.privateKey)
+	sig, err := s.signer.SignDigest(hash)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,135 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fill is this account's side of one CLOB trade: the taker order, or one of
+// its own maker orders the trade matched.
+type Fill struct {
+	TradeID string
+	OrderID string
+	Market  string // condition id
+	AssetID string
+	Outcome string
+	Side    string
+	Size    float64
+	Price   float64
+	Time    time.Time
+	TxHash  string
+}
+
+// GetFills pages through the account's trade history (only trades matched
+// before before, when set) and returns its fills. Failed trades are left out.
+func (c *Client) GetFills(ctx context.Context, before time.Time) ([]Fill, error) {
+	if c.signer == nil {
+		return nil, ErrAuthUnavailableL1
+	}
+	if c.creds == nil {
+		return nil, ErrAuthUnavailableL2
+	}
+	headers, err := c.level2Headers(http.MethodGet, EndpointTrades, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out []Fill
+	for next := defaultCursor; next != endCursor; {
+		q := url.Values{}
+		q.Set("maker_address", c.funder.Hex())
+		if !before.IsZero() {
+			q.Set("before", strconv.FormatInt(before.Unix(), 10))
+		}
+		q.Set("next_cursor", next)
+		resp, err := doJSON(ctx, c.http, http.MethodGet, c.host+EndpointTrades+"?"+q.Encode(), headers, nil)
+		if err != nil {
+			return nil, err
+		}
+		m, ok := resp.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected trades response: %T", resp)
+		}
+		if next = tradeString(m["next_cursor"]); next == "" {
+			next = endCursor
+		}
+		data, _ := m["data"].([]any)
+		for _, v := range data {
+			if t, _ := v.(map[string]any); t != nil {
+				out = append(out, c.ownFills(t)...)
+			}
+		}
+	}
+	return out, nil
+}
+
+// ownFills picks this account's fills out of one trade.
+func (c *Client) ownFills(t map[string]any) []Fill {
+	if strings.EqualFold(tradeString(t["status"]), "FAILED") {
+		return nil
+	}
+	base := Fill{
+		TradeID: tradeString(t["id"]),
+		Market:  tradeString(t["market"]),
+		TxHash:  tradeString(t["transaction_hash"]),
+	}
+	if ts, err := strconv.ParseInt(tradeString(t["match_time"]), 10, 64); err == nil {
+		base.Time = time.Unix(ts, 0).UTC()
+	}
+	if strings.EqualFold(tradeString(t["trader_side"]), "TAKER") {
+		f := base
+		f.OrderID = tradeString(t["taker_order_id"])
+		f.AssetID = tradeString(t["asset_id"])
+		f.Outcome = tradeString(t["outcome"])
+		f.Side = strings.ToUpper(tradeString(t["side"]))
+		f.Size = tradeFloat(t["size"])
+		f.Price = tradeFloat(t["price"])
+		return []Fill{f}
+	}
+	var out []Fill
+	makers, _ := t["maker_orders"].([]any)
+	for _, v := range makers {
+		mo, _ := v.(map[string]any)
+		if mo == nil {
+			continue
+		}
+		own := strings.EqualFold(tradeString(mo["maker_address"]), c.funder.Hex()) ||
+			(c.creds != nil && tradeString(mo["owner"]) == c.creds.APIKey)
+		if !own {
+			continue
+		}
+		f := base
+		f.OrderID = tradeString(mo["order_id"])
+		f.AssetID = tradeString(mo["asset_id"])
+		f.Outcome = tradeString(mo["outcome"])
+		f.Side = strings.ToUpper(tradeString(mo["side"]))
+		f.Size = tradeFloat(mo["matched_amount"])
+		f.Price = tradeFloat(mo["price"])
+		out = append(out, f)
+	}
+	return out
+}
+
+func tradeString(v any) string {
+	if v == nil {
+		return ""
+	}
+	return asString(v)
+}
+
+// tradeFloat reads a decimal the API sends as a string (or, rarely, a number).
+func tradeFloat(v any) float64 {
+	switch t := v.(type) {
+	case float64:
+		return t
+	case string:
+		f, _ := strconv.ParseFloat(t, 64)
+		return f
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,247 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Quote is the top of book for one asset as seen on the market channel.
+// BidSize and AskSize are the shares resting at the best prices, i.e. the
+// queue a new order joining that level waits behind.
+type Quote struct {
+	BestBid   float64
+	BestAsk   float64
+	BidSize   float64
+	AskSize   float64
+	UpdatedAt time.Time
+}
+
+type wsBook struct {
+	bids map[string]float64 // price -> size
+	asks map[string]float64
+	at   time.Time
+}
+
+// MarketFeed keeps top-of-book quotes for a set of assets from the CLOB
+// market websocket channel. Run owns the connection and reconnects on error;
+// SetAssets changes the subscription (by reconnecting).
+type MarketFeed struct {
+	url string
+
+	mu     sync.Mutex
+	assets []string
+	books  map[string]*wsBook
+	conn   *websocket.Conn
+}
+
+func NewMarketFeed(wsURL string) *MarketFeed {
+	return &MarketFeed{url: wsURL, books: map[string]*wsBook{}}
+}
+
+// SetAssets replaces the subscribed asset ids. Books for dropped assets are
+// discarded; an unchanged set is a no-op.
+func (f *MarketFeed) SetAssets(ids []string) {
+	ids = append([]string(nil), ids...)
+	sort.Strings(ids)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if strings.Join(ids, ",") == strings.Join(f.assets, ",") {
+		return
+	}
+	f.assets = ids
+	keep := map[string]bool{}
+	for _, id := range ids {
+		keep[id] = true
+	}
+	for id := range f.books {
+		if !keep[id] {
+			delete(f.books, id)
+		}
+	}
+	if f.conn != nil {
+		_ = f.conn.Close() // Run resubscribes with the new set
+	}
+}
+
+// Quote returns the latest top of book for assetID.
+func (f *MarketFeed) Quote(assetID string) (Quote, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b := f.books[assetID]
+	if b == nil {
+		return Quote{}, false
+	}
+	q := Quote{UpdatedAt: b.at}
+	for p, sz := range b.bids {
+		if v, _ := strconv.ParseFloat(p, 64); sz > 0 && v > q.BestBid {
+			q.BestBid, q.BidSize = v, sz
+		}
+	}
+	for p, sz := range b.asks {
+		if v, _ := strconv.ParseFloat(p, 64); sz > 0 && (q.BestAsk == 0 || v < q.BestAsk) {
+			q.BestAsk, q.AskSize = v, sz
+		}
+	}
+	return q, true
+}
+
+// Run connects and streams until ctx is done, backing off between attempts.
+func (f *MarketFeed) Run(ctx context.Context) {
+	backoff := time.Second
+	for ctx.Err() == nil {
+		f.mu.Lock()
+		assets := append([]string(nil), f.assets...)
+		f.mu.Unlock()
+		if len(assets) == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		start := time.Now()
+		_ = f.stream(ctx, assets)
+		if time.Since(start) > time.Minute {
+			backoff = time.Second
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, 30*time.Second)
+	}
+}
+
+func (f *MarketFeed) stream(ctx context.Context, assets []string) error {
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	conn, _, err := dialer.DialContext(ctx, f.url, nil)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.conn = conn
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		if f.conn == conn {
+			f.conn = nil
+		}
+		f.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	if err := conn.WriteJSON(map[string]any{"assets_ids": assets, "type": "market"}); err != nil {
+		return err
+	}
+	// The server drops idle clients; PING keeps the session open.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		t := time.NewTicker(10 * time.Second)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				_ = conn.Close()
+				return
+			case <-t.C:
+				_ = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second))
+			}
+		}
+	}()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		f.apply(msg)
+	}
+}
+
+type wsLevel struct {
+	Price string `json:"price"`
+	Size  string `json:"size"`
+	Side  string `json:"side"`
+	Asset string `json:"asset_id"`
+}
+
+type wsEvent struct {
+	EventType    string    `json:"event_type"`
+	AssetID      string    `json:"asset_id"`
+	Bids         []wsLevel `json:"bids"`
+	Asks         []wsLevel `json:"asks"`
+	Changes      []wsLevel `json:"changes"`
+	PriceChanges []wsLevel `json:"price_changes"`
+}
+
+// apply folds one message (a single event or an array of them) into the books.
+func (f *MarketFeed) apply(msg []byte) {
+	var events []wsEvent
+	if len(msg) > 0 && msg[0] == '[' {
+		if json.Unmarshal(msg, &events) != nil {
+			return
+		}
+	} else {
+		var ev wsEvent
+		if json.Unmarshal(msg, &ev) != nil {
+			return
+		}
+		events = []wsEvent{ev}
+	}
+	now := time.Now()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, ev := range events {
+		switch ev.EventType {
+		case "book":
+			b := &wsBook{bids: map[string]float64{}, asks: map[string]float64{}, at: now}
+			for _, l := range ev.Bids {
+				b.bids[l.Price], _ = strconv.ParseFloat(l.Size, 64)
+			}
+			for _, l := range ev.Asks {
+				b.asks[l.Price], _ = strconv.ParseFloat(l.Size, 64)
+			}
+			if f.subscribed(ev.AssetID) {
+				f.books[ev.AssetID] = b
+			}
+		case "price_change":
+			for _, l := range append(ev.Changes, ev.PriceChanges...) {
+				asset := l.Asset
+				if asset == "" {
+					asset = ev.AssetID
+				}
+				b := f.books[asset]
+				if b == nil {
+					continue // wait for the snapshot
+				}
+				side := b.bids
+				if strings.EqualFold(l.Side, "SELL") {
+					side = b.asks
+				}
+				if sz, _ := strconv.ParseFloat(l.Size, 64); sz > 0 {
+					side[l.Price] = sz
+				} else {
+					delete(side, l.Price)
+				}
+				b.at = now
+			}
+		}
+	}
+}
+
+func (f *MarketFeed) subscribed(assetID string) bool {
+	i := sort.SearchStrings(f.assets, assetID)
+	return i < len(f.assets) && f.assets[i] == assetID
+}
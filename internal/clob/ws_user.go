@@ -0,0 +1,233 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// UserTrade is a "trade" event from the user channel. The taker order and
+// every maker order involved are listed; callers pick out their own ids.
+type UserTrade struct {
+	ID           string
+	Status       string // MATCHED, MINED, CONFIRMED, RETRYING or FAILED
+	Market       string
+	AssetID      string
+	Side         string
+	TakerOrderID string
+	Price        float64
+	Size         float64
+	MakerOrders  []UserMakerFill
+}
+
+// UserMakerFill is one maker order's part of a trade.
+type UserMakerFill struct {
+	OrderID       string
+	AssetID       string
+	Price         float64
+	MatchedAmount float64
+}
+
+// UserOrder is an "order" event: PLACEMENT, UPDATE (a fill) or CANCELLATION.
+type UserOrder struct {
+	ID           string
+	Type         string
+	Market       string
+	AssetID      string
+	Side         string
+	Price        float64
+	OriginalSize float64
+	SizeMatched  float64
+}
+
+// UserFeed streams the wallet's own trades and order updates from the CLOB
+// user websocket channel. Callbacks run on the feed goroutine; OnConnect
+// fires after every (re)subscription, when events may have been missed.
+type UserFeed struct {
+	url   string
+	creds ApiCreds
+
+	OnTrade   func(UserTrade)
+	OnOrder   func(UserOrder)
+	OnConnect func()
+
+	connected atomic.Bool
+}
+
+func NewUserFeed(wsURL string, creds ApiCreds) *UserFeed {
+	return &UserFeed{url: wsURL, creds: creds}
+}
+
+// Connected reports whether the feed is currently subscribed.
+func (f *UserFeed) Connected() bool { return f.connected.Load() }
+
+// Run connects and streams until ctx is done, backing off between attempts.
+func (f *UserFeed) Run(ctx context.Context) {
+	backoff := time.Second
+	for ctx.Err() == nil {
+		start := time.Now()
+		_ = f.stream(ctx)
+		f.connected.Store(false)
+		if time.Since(start) > time.Minute {
+			backoff = time.Second
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, 30*time.Second)
+	}
+}
+
+func (f *UserFeed) stream(ctx context.Context) error {
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	conn, _, err := dialer.DialContext(ctx, f.url, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// No market filter: every market the API key trades in.
+	sub := map[string]any{
+		"type": "user",
+		"auth": map[string]string{
+			"apiKey":     f.creds.APIKey,
+			"secret":     f.creds.APISecret,
+			"passphrase": f.creds.APIPassphrase,
+		},
+	}
+	if err := conn.WriteJSON(sub); err != nil {
+		return err
+	}
+	f.connected.Store(true)
+	if f.OnConnect != nil {
+		f.OnConnect()
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		t := time.NewTicker(10 * time.Second)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				_ = conn.Close()
+				return
+			case <-t.C:
+				_ = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second))
+			}
+		}
+	}()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		f.dispatch(msg)
+	}
+}
+
+// wsNum accepts the channel's numbers, which arrive as JSON strings.
+type wsNum float64
+
+func (n *wsNum) UnmarshalJSON(b []byte) error {
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		v, _ := strconv.ParseFloat(s, 64)
+		*n = wsNum(v)
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*n = wsNum(v)
+	return nil
+}
+
+type wsUserEvent struct {
+	EventType    string `json:"event_type"`
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	Status       string `json:"status"`
+	Market       string `json:"market"`
+	AssetID      string `json:"asset_id"`
+	Side         string `json:"side"`
+	Price        wsNum  `json:"price"`
+	Size         wsNum  `json:"size"`
+	OriginalSize wsNum  `json:"original_size"`
+	SizeMatched  wsNum  `json:"size_matched"`
+	TakerOrderID string `json:"taker_order_id"`
+	MakerOrders  []struct {
+		OrderID       string `json:"order_id"`
+		AssetID       string `json:"asset_id"`
+		Price         wsNum  `json:"price"`
+		MatchedAmount wsNum  `json:"matched_amount"`
+	} `json:"maker_orders"`
+}
+
+// dispatch hands one message (a single event or an array of them) to the callbacks.
+func (f *UserFeed) dispatch(msg []byte) {
+	var events []wsUserEvent
+	if len(msg) > 0 && msg[0] == '[' {
+		if json.Unmarshal(msg, &events) != nil {
+			return
+		}
+	} else {
+		var ev wsUserEvent
+		if json.Unmarshal(msg, &ev) != nil {
+			return
+		}
+		events = []wsUserEvent{ev}
+	}
+	for _, ev := range events {
+		switch ev.EventType {
+		case "trade":
+			if f.OnTrade == nil {
+				continue
+			}
+			t := UserTrade{
+				ID:           ev.ID,
+				Status:       ev.Status,
+				Market:       ev.Market,
+				AssetID:      ev.AssetID,
+				Side:         ev.Side,
+				TakerOrderID: ev.TakerOrderID,
+				Price:        float64(ev.Price),
+				Size:         float64(ev.Size),
+			}
+			for _, m := range ev.MakerOrders {
+				t.MakerOrders = append(t.MakerOrders, UserMakerFill{
+					OrderID:       m.OrderID,
+					AssetID:       m.AssetID,
+					Price:         float64(m.Price),
+					MatchedAmount: float64(m.MatchedAmount),
+				})
+			}
+			f.OnTrade(t)
+		case "order":
+			if f.OnOrder == nil {
+				continue
+			}
+			f.OnOrder(UserOrder{
+				ID:           ev.ID,
+				Type:         ev.Type,
+				Market:       ev.Market,
+				AssetID:      ev.AssetID,
+				Side:         ev.Side,
+				Price:        float64(ev.Price),
+				OriginalSize: float64(ev.OriginalSize),
+				SizeMatched:  float64(ev.SizeMatched),
+			})
+		}
+	}
+}
@@ -3,51 +3,243 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/joho/godotenv"
+
+	"limitorderbot/internal/httpx"
+	"limitorderbot/internal/keys"
+	"limitorderbot/internal/notify"
 )
 
+// HTTPService is the per-API part of the HTTP tuning: how long one request
+// may take and how many times an idempotent one is retried.
+type HTTPService struct {
+	Name      string
+	TimeoutMS int
+	Retries   int
+}
+
 type StrategyConfig struct {
 	ExitTimeoutSeconds int  `json:"exit_timeout_seconds"`
 	CancelUnfilled     bool `json:"cancel_unfilled"`
 	MarketSellFilled   bool `json:"market_sell_filled"`
 	Enabled            bool `json:"enabled"`
+
+	// HoldToResolution skips pre-end selling of leftover positions whose mid
+	// is above HoldMinMid and leaves them for auto-redeem instead.
+	HoldToResolution bool    `json:"hold_to_resolution"`
+	HoldMinMid       float64 `json:"hold_min_mid"`
+
+	// CapitalBudgetUSD caps the BUY notional this strategy may have deployed at
+	// once (open + filled-but-not-exited). 0 means unlimited.
+	CapitalBudgetUSD float64 `json:"capital_budget_usd"`
+
+	// ExitWhenMerged exits before the timeout once both sides have filled in
+	// full and the matched sets are merged, instead of idling until the clock.
+	ExitWhenMerged bool `json:"exit_when_merged"`
+	// ExtendSeconds pushes the exit timeout out once the filled share of the
+	// orders reaches ExtendFillRatio (0..1), giving a market that is filling
+	// time to finish. 0 disables.
+	ExtendFillRatio float64 `json:"extend_fill_ratio"`
+	ExtendSeconds   int     `json:"extend_seconds"`
 }
 
 type Config struct {
 	// Polymarket
 	PrivateKey    string
+	Keys          keys.Provider
 	ChainID       int64
+	Network       string
 	SignatureType string
 	FunderAddress string
 
 	// Bot
 	OrderSizeUSD               float64
+	MaxNetDeltaUSD             float64
 	SpreadOffset               float64
+	LiquidityPricing           string
+	LiquidityQueueShares       float64
 	CheckIntervalSeconds       int
+	DiscoveryIntervalSeconds   int
+	OrderStatusIntervalSeconds int
+	PriceIntervalSeconds       int
+	MaintenanceIntervalSeconds int
+	// Around market events (placement window opening, start, end) the
+	// discovery, price and order tasks run at most every EventIntervalSeconds;
+	// with nothing open and no event near they slow by QuietIntervalFactor.
+	EventWindowSeconds         int
+	EventIntervalSeconds       int
+	QuietIntervalFactor        float64
+	LoopBudgetSeconds          float64
 	OrderPlacementMinMinutes   int
 	OrderPlacementMaxMinutes   int
+	PlacementWindows           map[time.Duration]PlacementWindow
+	DiscoveryHorizonMinutes    int
+	DiscoveryHorizons          map[time.Duration]int
+	MaxConcurrentMarkets       int
 	RedeemCheckIntervalSeconds int
+	ReconcileIntervalSeconds   int
+	ReconcileAutoFix           bool
+	ClobHeartbeatSeconds       int
+	BalanceSnapshotMinutes     int
+	TransferScanMinutes        int
+	RewardEstimatePerShareHour float64
+	MinRedeemValueUSD          float64
+	RedeemDustAfterHours       int
 	MinSellPrice               float64
 	MarketSellDiscount         float64
+	MaxSellSlippage            float64
+	MinOrderShares             float64
+	MinOrderNotionalUSD        float64
+	MinBookDepthUSD            float64
+	LotSize                    float64
+	PrewarmOrderCaches         bool
+	PresignOrders              bool
+	ClobCacheSize              int
+	ClobCacheTTLMinutes        int
+	OrderHistoryRetentionDays  int
 	StrategyName               string
 	OrderMode                  string
+	StrategyScript             string
+	SpreadCaptureMinEdge       float64
+	SpreadCaptureUnwindSeconds int
+	StaleQuoteTicks            int
+	DownsizeOrders             bool
+	LastMinuteSeconds          int
+	LastMinuteTickMS           int
+	LastMinuteEdge             float64
+	LastMinuteMaxQuoteAgeMS    int
+	ArbScanIntervalSeconds     int
+	ArbFee                     float64
+	ArbTrade                   bool
+	USDCSwapRouter             string
+	USDCSwapFee                int
+	USDCSwapMaxSlippageBps     int
+	USDCSwapMinUSD             float64
+	MinMatic                   float64
+	GasDailyBudgetUSD          float64
+	MaticUSDPrice              float64
+	RebalanceMinProxyUSD       float64
+	RebalanceTargetProxyUSD    float64
+	RebalanceEOAReserveUSD     float64
+	// AllowanceExactUSD, when set, is the collateral allowance `allowances
+	// set-all --exact` grants each exchange instead of 1,000,000 USDC; the bot
+	// tops allowances back up to it once they fall below half.
+	AllowanceExactUSD          float64
+	YieldPool                  string
+	YieldReceiptToken          string
+	YieldWorkingCapitalUSD     float64
+	YieldMinMoveUSD            float64
+	YieldLookaheadMinutes      int
+	LossStreakLimit            int
+	LossCooldownMinutes        int
+	KillSwitchFile             string
+	NotifyTelegramToken        string
+	NotifyTelegramChatID       string
+	NotifySMTPHost             string
+	NotifySMTPPort             int
+	NotifySMTPUser             string
+	NotifySMTPPassword         string
+	NotifyEmailFrom            string
+	NotifyEmailTo              []string
+	DigestSchedule             string
+	NotifyTrades               bool
+	WebhooksFile               string
+	ImportHistoryOnFirstRun    bool
+	PredictorName              string
+	PredictorMaxSkew           float64
+	PredictorLookbackMinutes   int
 	GammaAPIBaseURL            string
+	MarketSource               string
+	MarketFixtureFile          string
+	MarketSourceFallback       string
+	MarketSourceTimeoutSeconds int
 	ClobAPIURL                 string
+	ClobWSURL                  string
+	ClobUserWSURL              string
+	DataAPIURL                 string
+	MarketSiteURL              string
+	ExplorerURL                string
 	RPCURL                     string
+	RPCURLs                    []string
+	RPCWSURL                   string
+	TxConfirmations            int
+	TxConfirmTimeoutSeconds    int
+	TxReturnOnBroadcast        bool
+
+	// HTTP tuning for the CLOB, Gamma and data-api; the transport settings
+	// apply to all three.
+	ClobHTTP                   HTTPService
+	GammaHTTP                  HTTPService
+	DataAPIHTTP                HTTPService
+	HTTPRetryBackoffMS         int
+	HTTPMaxIdleConns           int
+	HTTPMaxIdleConnsPerHost    int
+	HTTPIdleConnTimeoutSeconds int
+	HTTPKeepAliveSeconds       int
+	DebugHTTP                  bool
+	HTTPTraceFile              string
 	PolymarketAPIKey           string
 	PolymarketAPISecret        string
 	PolymarketAPIPassphrase    string
 	DashboardHost              string
 	DashboardPort              int
+	PprofToken                 string
+	ControlToken               string
+	ProfileDir                 string
 	LogLevel                   string
 	LogFile                    string
 	Strategies                 map[string]StrategyConfig
 }
 
+// network is the per-chain profile of endpoint defaults. Env vars still
+// override each URL individually.
+type network struct {
+	name    string
+	gamma   string
+	clob    string
+	dataAPI string
+	rpc     string
+	clobWS  string
+
+	// site and explorer are linked from notifications and the dashboard.
+	site     string
+	explorer string
+}
+
+var networks = map[int64]network{
+	137: {
+		name:    "polygon",
+		gamma:   "https://gamma-api.polymarket.com",
+		clob:    "https://clob.polymarket.com",
+		dataAPI: "https://data-api.polymarket.com",
+		rpc:     "https://polygon-rpc.com",
+		clobWS:  "wss://ws-subscriptions-clob.polymarket.com/ws/market",
+
+		site:     "https://polymarket.com",
+		explorer: "https://polygonscan.com",
+	},
+	80002: {
+		name:    "amoy",
+		gamma:   "https://gamma-api-staging.polymarket.com",
+		clob:    "https://clob-staging.polymarket.com",
+		dataAPI: "https://data-api-staging.polymarket.com",
+		rpc:     "https://rpc-amoy.polygon.technology",
+		clobWS:  "wss://ws-subscriptions-clob-staging.polymarket.com/ws/market",
+
+		site:     "https://polymarket.com",
+		explorer: "https://amoy.polygonscan.com",
+	},
+}
+
 var (
 	loadedCfg Config
 	loadOnce  sync.Once
@@ -62,38 +254,171 @@ func MustLoad() Config {
 	return cfg
 }
 
+// Language returns the locale CLI text is shown in: BOT_LANG if set, else the
+// usual LANG. It only needs .env, not a valid config, because it has to be
+// known before command help is built.
+func Language() string {
+	_ = godotenv.Load()
+	if l := os.Getenv("BOT_LANG"); l != "" {
+		return l
+	}
+	return os.Getenv("LANG")
+}
+
 func Load() (Config, error) {
 	loadOnce.Do(func() {
 		// Best-effort .env loading to match python behavior.
 		_ = godotenv.Load()
 
+		chainID := mustInt64("CHAIN_ID", 137)
+		net := networks[chainID]
+
 		loadedCfg = Config{
 			PrivateKey:    os.Getenv("PRIVATE_KEY"),
-			ChainID:       mustInt64("CHAIN_ID", 137),
-			SignatureType: envOr("SIGNATURE_TYPE", "EOA"),
+			ChainID:       chainID,
+			Network:       net.name,
+			SignatureType: strings.ToUpper(strings.TrimSpace(envOr("SIGNATURE_TYPE", "EOA"))),
 			FunderAddress: os.Getenv("FUNDER_ADDRESS"),
 
 			OrderSizeUSD:               mustFloat("ORDER_SIZE_USD", 10.0),
+			MaxNetDeltaUSD:             mustFloat("MAX_NET_DELTA_USD", 0),
 			SpreadOffset:               mustFloat("SPREAD_OFFSET", 0.01),
+			LiquidityPricing:           strings.ToLower(envOr("LIQUIDITY_PRICING", "offset")),
+			LiquidityQueueShares:       mustFloat("LIQUIDITY_QUEUE_SHARES", 50),
 			CheckIntervalSeconds:       mustInt("CHECK_INTERVAL_SECONDS", 60),
+			DiscoveryIntervalSeconds:   mustInt("DISCOVERY_INTERVAL_SECONDS", mustInt("CHECK_INTERVAL_SECONDS", 60)),
+			OrderStatusIntervalSeconds: mustInt("ORDER_STATUS_INTERVAL_SECONDS", 5),
+			PriceIntervalSeconds:       mustInt("PRICE_INTERVAL_SECONDS", 2),
+			MaintenanceIntervalSeconds: mustInt("MAINTENANCE_INTERVAL_SECONDS", mustInt("CHECK_INTERVAL_SECONDS", 60)),
+			EventWindowSeconds:         mustInt("EVENT_WINDOW_SECONDS", 0),
+			EventIntervalSeconds:       mustInt("EVENT_INTERVAL_SECONDS", 2),
+			QuietIntervalFactor:        mustFloat("QUIET_INTERVAL_FACTOR", 1),
+			LoopBudgetSeconds:          mustFloat("LOOP_BUDGET_SECONDS", 30),
 			OrderPlacementMinMinutes:   mustInt("ORDER_PLACEMENT_MIN_MINUTES", 10),
 			OrderPlacementMaxMinutes:   mustInt("ORDER_PLACEMENT_MAX_MINUTES", 20),
-			RedeemCheckIntervalSeconds: mustInt("REDEEM_CHECK_INTERVAL_SECONDS", 60),
+			DiscoveryHorizonMinutes:    mustInt("DISCOVERY_HORIZON_MINUTES", 720),
+			MaxConcurrentMarkets:       mustInt("MAX_CONCURRENT_MARKETS", 1),
+			RedeemCheckIntervalSeconds: mustInt("REDEEM_CHECK_INTERVAL_SECONDS", 600),
+			ReconcileIntervalSeconds:   mustInt("RECONCILE_INTERVAL_SECONDS", 300),
+			ReconcileAutoFix:           mustBool("RECONCILE_AUTO_FIX", false),
+			ClobHeartbeatSeconds:       mustInt("CLOB_HEARTBEAT_SECONDS", 30),
+			BalanceSnapshotMinutes:     mustInt("BALANCE_SNAPSHOT_MINUTES", 60),
+			TransferScanMinutes:        mustInt("TRANSFER_SCAN_MINUTES", 10),
+			RewardEstimatePerShareHour: mustFloat("REWARD_ESTIMATE_PER_SHARE_HOUR", 0),
+			MinRedeemValueUSD:          mustFloat("MIN_REDEEM_VALUE_USD", 0.05),
+			RedeemDustAfterHours:       mustInt("REDEEM_DUST_AFTER_HOURS", 0),
 			MinSellPrice:               mustFloat("MIN_SELL_PRICE", 0.10),
 			MarketSellDiscount:         mustFloat("MARKET_SELL_DISCOUNT", 0.02),
+			MaxSellSlippage:            mustFloat("MAX_SELL_SLIPPAGE", 0.05),
+			MinOrderShares:             mustFloat("MIN_ORDER_SHARES", 0),
+			MinOrderNotionalUSD:        mustFloat("MIN_ORDER_NOTIONAL_USD", 1),
+			MinBookDepthUSD:            mustFloat("MIN_BOOK_DEPTH_USD", 0),
+			LotSize:                    mustFloat("LOT_SIZE", 0),
+			PrewarmOrderCaches:         mustBool("PREWARM_ORDER_CACHES", true),
+			PresignOrders:              mustBool("PRESIGN_ORDERS", false),
+			ClobCacheSize:              mustInt("CLOB_CACHE_SIZE", 5000),
+			ClobCacheTTLMinutes:        mustInt("CLOB_CACHE_TTL_MINUTES", 360),
+			OrderHistoryRetentionDays:  mustInt("ORDER_HISTORY_RETENTION_DAYS", 0),
+
+			StrategyName:   envOr("STRATEGY_NAME", "quick_exit_7_5min"),
+			OrderMode:      envOr("ORDER_MODE", "test"),
+			StrategyScript: envOr("STRATEGY_SCRIPT", ""),
+
+			SpreadCaptureMinEdge:       mustFloat("SPREAD_CAPTURE_MIN_EDGE", 0.02),
+			SpreadCaptureUnwindSeconds: mustInt("SPREAD_CAPTURE_UNWIND_SECONDS", 120),
+
+			StaleQuoteTicks: mustInt("STALE_QUOTE_TICKS", 0),
+			DownsizeOrders:  mustBool("DOWNSIZE_ORDERS", true),
+
+			LastMinuteSeconds:       mustInt("LAST_MINUTE_SECONDS", 0),
+			LastMinuteTickMS:        mustInt("LAST_MINUTE_TICK_MS", 250),
+			LastMinuteEdge:          mustFloat("LAST_MINUTE_EDGE", 0.03),
+			LastMinuteMaxQuoteAgeMS: mustInt("LAST_MINUTE_MAX_QUOTE_AGE_MS", 2000),
 
-			StrategyName: envOr("STRATEGY_NAME", "quick_exit_7_5min"),
-			OrderMode:    envOr("ORDER_MODE", "test"),
+			ArbScanIntervalSeconds: mustInt("ARB_SCAN_INTERVAL_SECONDS", 0),
+			ArbFee:                 mustFloat("ARB_FEE", 0.01),
+			ArbTrade:               mustBool("ARB_TRADE", false),
 
-			GammaAPIBaseURL:         envOr("GAMMA_API_BASE_URL", "https://gamma-api.polymarket.com"),
-			ClobAPIURL:              envOr("CLOB_API_URL", "https://clob.polymarket.com"),
-			RPCURL:                  envOr("RPC_URL", "https://polygon-rpc.com"),
+			USDCSwapRouter:         os.Getenv("USDC_SWAP_ROUTER"),
+			USDCSwapFee:            mustInt("USDC_SWAP_FEE", 100),
+			USDCSwapMaxSlippageBps: mustInt("USDC_SWAP_MAX_SLIPPAGE_BPS", 30),
+			USDCSwapMinUSD:         mustFloat("USDC_SWAP_MIN_USD", 5),
+			MinMatic:               mustFloat("MIN_MATIC", 0.1),
+			MaticUSDPrice:          mustFloat("MATIC_USD_PRICE", 0.5),
+			GasDailyBudgetUSD:      mustFloat("GAS_DAILY_BUDGET_USD", 0),
+
+			RebalanceMinProxyUSD:    mustFloat("REBALANCE_MIN_PROXY_USD", 0),
+			RebalanceTargetProxyUSD: mustFloat("REBALANCE_TARGET_PROXY_USD", 0),
+			RebalanceEOAReserveUSD:  mustFloat("REBALANCE_EOA_RESERVE_USD", 0),
+
+			AllowanceExactUSD: mustFloat("ALLOWANCE_EXACT_USD", 0),
+
+			YieldPool:              os.Getenv("YIELD_POOL"),
+			YieldReceiptToken:      os.Getenv("YIELD_RECEIPT_TOKEN"),
+			YieldWorkingCapitalUSD: mustFloat("YIELD_WORKING_CAPITAL_USD", 100),
+			YieldMinMoveUSD:        mustFloat("YIELD_MIN_MOVE_USD", 20),
+			YieldLookaheadMinutes:  mustInt("YIELD_LOOKAHEAD_MINUTES", 60),
+
+			LossStreakLimit:     mustInt("LOSS_STREAK_LIMIT", 3),
+			LossCooldownMinutes: mustInt("LOSS_COOLDOWN_MINUTES", 60),
+
+			KillSwitchFile: envOr("KILL_SWITCH_FILE", "KILL_SWITCH"),
+
+			NotifyTelegramToken:  os.Getenv("NOTIFY_TELEGRAM_TOKEN"),
+			NotifyTelegramChatID: os.Getenv("NOTIFY_TELEGRAM_CHAT_ID"),
+			NotifySMTPHost:       os.Getenv("NOTIFY_SMTP_HOST"),
+			NotifySMTPPort:       mustInt("NOTIFY_SMTP_PORT", 587),
+			NotifySMTPUser:       os.Getenv("NOTIFY_SMTP_USER"),
+			NotifySMTPPassword:   os.Getenv("NOTIFY_SMTP_PASSWORD"),
+			NotifyEmailFrom:      os.Getenv("NOTIFY_EMAIL_FROM"),
+			NotifyEmailTo:        splitList(os.Getenv("NOTIFY_EMAIL_TO")),
+			DigestSchedule:       strings.TrimSpace(os.Getenv("DIGEST_SCHEDULE")),
+			NotifyTrades:         mustBool("NOTIFY_TRADES", false),
+			WebhooksFile:         os.Getenv("WEBHOOKS_FILE"),
+
+			ImportHistoryOnFirstRun: mustBool("IMPORT_HISTORY_ON_FIRST_RUN", true),
+
+			PredictorName:            envOr("PREDICTOR", "none"),
+			PredictorMaxSkew:         mustFloat("PREDICTOR_MAX_SKEW", 0.02),
+			PredictorLookbackMinutes: mustInt("PREDICTOR_LOOKBACK_MINUTES", 15),
+
+			GammaAPIBaseURL:         envOr("GAMMA_API_BASE_URL", net.gamma),
+			MarketSource:            strings.ToLower(envOr("MARKET_SOURCE", "gamma")),
+			MarketFixtureFile:       os.Getenv("MARKET_FIXTURE_FILE"),
+			ClobAPIURL:              envOr("CLOB_API_URL", net.clob),
+			ClobWSURL:               envOr("CLOB_WS_URL", net.clobWS),
+			DataAPIURL:              envOr("DATA_API_URL", net.dataAPI),
+			MarketSiteURL:           strings.TrimRight(envOr("MARKET_SITE_URL", net.site), "/"),
+			ExplorerURL:             strings.TrimRight(envOr("EXPLORER_URL", net.explorer), "/"),
+			RPCURL:                  envOr("RPC_URL", net.rpc),
+			RPCURLs:                 splitList(os.Getenv("RPC_URLS")),
+			RPCWSURL:                os.Getenv("RPC_WS_URL"),
+			TxConfirmations:         mustInt("TX_CONFIRMATIONS", 2),
+			TxConfirmTimeoutSeconds: mustInt("TX_CONFIRM_TIMEOUT_SECONDS", 120),
+			TxReturnOnBroadcast:     mustBool("TX_RETURN_ON_BROADCAST", false),
 			PolymarketAPIKey:        os.Getenv("POLYMARKET_API_KEY"),
 			PolymarketAPISecret:     os.Getenv("POLYMARKET_API_SECRET"),
 			PolymarketAPIPassphrase: envOr("POLYMARKET_API_PASSPHRASE", ""),
 
+			MarketSourceFallback:       strings.ToLower(envOr("MARKET_SOURCE_FALLBACK", "clob")),
+			MarketSourceTimeoutSeconds: mustInt("MARKET_SOURCE_TIMEOUT_SECONDS", 30),
+
+			ClobHTTP:                   HTTPService{Name: "clob", TimeoutMS: mustInt("CLOB_HTTP_TIMEOUT_MS", 15000), Retries: mustInt("CLOB_HTTP_RETRIES", 0)},
+			GammaHTTP:                  HTTPService{Name: "gamma", TimeoutMS: mustInt("GAMMA_HTTP_TIMEOUT_MS", 10000), Retries: mustInt("GAMMA_HTTP_RETRIES", 1)},
+			DataAPIHTTP:                HTTPService{Name: "data-api", TimeoutMS: mustInt("DATA_API_HTTP_TIMEOUT_MS", 15000), Retries: mustInt("DATA_API_HTTP_RETRIES", 1)},
+			HTTPRetryBackoffMS:         mustInt("HTTP_RETRY_BACKOFF_MS", 250),
+			HTTPMaxIdleConns:           mustInt("HTTP_MAX_IDLE_CONNS", 100),
+			HTTPMaxIdleConnsPerHost:    mustInt("HTTP_MAX_IDLE_CONNS_PER_HOST", 10),
+			HTTPIdleConnTimeoutSeconds: mustInt("HTTP_IDLE_CONN_TIMEOUT_SECONDS", 90),
+			HTTPKeepAliveSeconds:       mustInt("HTTP_KEEPALIVE_SECONDS", 30),
+			DebugHTTP:                  mustBool("DEBUG_HTTP", false),
+			HTTPTraceFile:              envOr("HTTP_TRACE_FILE", "http_trace.log"),
+
 			DashboardHost: envOr("DASHBOARD_HOST", "0.0.0.0"),
 			DashboardPort: mustInt("DASHBOARD_PORT", 8000),
+			PprofToken:    os.Getenv("PPROF_TOKEN"),
+			ProfileDir:    envOr("PROFILE_DIR", "profiles"),
+			ControlToken:  os.Getenv("CONTROL_TOKEN"),
 
 			LogLevel: envOr("LOG_LEVEL", "INFO"),
 			LogFile:  envOr("LOG_FILE", "bot.log"),
@@ -104,10 +429,34 @@ func Load() (Config, error) {
 					CancelUnfilled:     true,
 					MarketSellFilled:   true,
 					Enabled:            true,
+					HoldToResolution:   mustBool("HOLD_TO_RESOLUTION", false),
+					HoldMinMid:         mustFloat("HOLD_MIN_MID", 0.70),
+					ExitWhenMerged:     mustBool("EXIT_WHEN_MERGED", false),
+					ExtendFillRatio:    mustFloat("EXIT_EXTEND_FILL_RATIO", 0),
+					ExtendSeconds:      mustInt("EXIT_EXTEND_SECONDS", 0),
+				},
+				// Pairs with ORDER_MODE=spread_capture: both legs are merged as soon
+				// as they fill, so the timeout only sweeps up what is left near the end.
+				"spread_capture": {
+					ExitTimeoutSeconds: 780,
+					CancelUnfilled:     true,
+					MarketSellFilled:   true,
+					Enabled:            true,
+					ExitWhenMerged:     true,
 				},
 			},
 		}
 
+		applyStrategyBudgets(loadedCfg.Strategies, os.Getenv("STRATEGY_BUDGETS"))
+		loadedCfg.PlacementWindows = placementWindows(loadedCfg, os.Getenv("PLACEMENT_WINDOWS"))
+		loadedCfg.DiscoveryHorizons = discoveryHorizons(os.Getenv("DISCOVERY_HORIZONS"))
+		loadedCfg.Keys = keyProvider(loadedCfg.PrivateKey)
+
+		loadedCfg.ClobUserWSURL = envOr("CLOB_USER_WS_URL", strings.TrimSuffix(loadedCfg.ClobWSURL, "/market")+"/user")
+		if len(loadedCfg.RPCURLs) == 0 {
+			loadedCfg.RPCURLs = []string{loadedCfg.RPCURL}
+		}
+
 		loadErr = validate(loadedCfg)
 	})
 
@@ -119,19 +468,399 @@ func (c Config) Strategy() (StrategyConfig, bool) {
 	return s, ok
 }
 
+// PlacementWindow is how many minutes before a market's start orders may be
+// placed, for markets of one duration.
+type PlacementWindow struct {
+	MinMinutes int
+	MaxMinutes int
+}
+
+// HTTPClient builds a client for one of ClobHTTP, GammaHTTP or DataAPIHTTP
+// with the shared transport settings, tracing to HTTP_TRACE_FILE under
+// DEBUG_HTTP.
+func (c Config) HTTPClient(svc HTTPService) *http.Client {
+	opts := httpx.Options{
+		Timeout:             time.Duration(svc.TimeoutMS) * time.Millisecond,
+		Retries:             svc.Retries,
+		RetryBackoff:        time.Duration(c.HTTPRetryBackoffMS) * time.Millisecond,
+		MaxIdleConns:        c.HTTPMaxIdleConns,
+		MaxIdleConnsPerHost: c.HTTPMaxIdleConnsPerHost,
+		IdleConnTimeout:     time.Duration(c.HTTPIdleConnTimeoutSeconds) * time.Second,
+		KeepAlive:           time.Duration(c.HTTPKeepAliveSeconds) * time.Second,
+		Name:                svc.Name,
+	}
+	if c.DebugHTTP {
+		// validate() already opened the file, so this can't fail here.
+		opts.Trace, _ = httpx.TraceFile(c.HTTPTraceFile)
+	}
+	return httpx.NewClient(opts)
+}
+
+// MarketURL links a market's page on Polymarket; empty without a slug.
+func (c Config) MarketURL(slug string) string {
+	if slug == "" || c.MarketSiteURL == "" {
+		return ""
+	}
+	return c.MarketSiteURL + "/market/" + url.PathEscape(slug)
+}
+
+// TxURL links a transaction on the chain's block explorer; empty without a
+// hash.
+func (c Config) TxURL(hash string) string {
+	if hash == "" || c.ExplorerURL == "" {
+		return ""
+	}
+	return c.ExplorerURL + "/tx/" + hash
+}
+
+// PlacementWindowFor returns the window for a market lasting d, falling back
+// to ORDER_PLACEMENT_MIN/MAX_MINUTES for durations without their own entry.
+func (c Config) PlacementWindowFor(d time.Duration) PlacementWindow {
+	if w, ok := c.PlacementWindows[d]; ok {
+		return w
+	}
+	return PlacementWindow{MinMinutes: c.OrderPlacementMinMinutes, MaxMinutes: c.OrderPlacementMaxMinutes}
+}
+
+// placementWindows builds the per-duration windows: 15m markets use the
+// global ORDER_PLACEMENT_* window, hourly and daily markets get wider
+// defaults, and PLACEMENT_WINDOWS ("15m:10-20,1h:20-50,1d:60-360") overrides
+// any of them. Malformed entries are ignored like other env parsing here.
+func placementWindows(c Config, raw string) map[time.Duration]PlacementWindow {
+	out := map[time.Duration]PlacementWindow{
+		15 * time.Minute: {MinMinutes: c.OrderPlacementMinMinutes, MaxMinutes: c.OrderPlacementMaxMinutes},
+		time.Hour:        {MinMinutes: 20, MaxMinutes: 50},
+		24 * time.Hour:   {MinMinutes: 60, MaxMinutes: 360},
+	}
+	for _, item := range splitList(raw) {
+		dur, window, ok := strings.Cut(item, ":")
+		if !ok {
+			continue
+		}
+		d, err := parseMarketDuration(strings.TrimSpace(dur))
+		if err != nil || d <= 0 {
+			continue
+		}
+		lo, hi, ok := strings.Cut(window, "-")
+		if !ok {
+			continue
+		}
+		minM, err1 := strconv.Atoi(strings.TrimSpace(lo))
+		maxM, err2 := strconv.Atoi(strings.TrimSpace(hi))
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		out[d] = PlacementWindow{MinMinutes: minM, MaxMinutes: maxM}
+	}
+	return out
+}
+
+// DiscoveryHorizonFor is how far ahead discovery looks for markets lasting d:
+// their DISCOVERY_HORIZONS entry, else DISCOVERY_HORIZON_MINUTES.
+func (c Config) DiscoveryHorizonFor(d time.Duration) time.Duration {
+	if m, ok := c.DiscoveryHorizons[d]; ok {
+		return time.Duration(m) * time.Minute
+	}
+	return time.Duration(c.DiscoveryHorizonMinutes) * time.Minute
+}
+
+// discoveryHorizons parses DISCOVERY_HORIZONS ("15m:720,1d:2880", minutes
+// per market duration), skipping malformed entries.
+func discoveryHorizons(raw string) map[time.Duration]int {
+	out := map[time.Duration]int{}
+	for _, item := range splitList(raw) {
+		dur, minutes, ok := strings.Cut(item, ":")
+		if !ok {
+			continue
+		}
+		d, err := parseMarketDuration(strings.TrimSpace(dur))
+		if err != nil || d <= 0 {
+			continue
+		}
+		m, err := strconv.Atoi(strings.TrimSpace(minutes))
+		if err != nil {
+			continue
+		}
+		out[d] = m
+	}
+	return out
+}
+
+// parseMarketDuration accepts Go durations plus a "d" day suffix ("1d").
+func parseMarketDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
 func validate(c Config) error {
-	if c.PrivateKey == "" {
-		return errors.New("PRIVATE_KEY is required in .env file")
+	if c.Network == "" {
+		return fmt.Errorf("CHAIN_ID %d is not supported (use 137 for Polygon or 80002 for Amoy)", c.ChainID)
+	}
+	if c.Keys == nil {
+		return errors.New("PRIVATE_KEY (or KEYSTORE_FILE / KEYCHAIN_SERVICE / REMOTE_SIGNER_URL) is required in .env file")
+	}
+	if os.Getenv("REMOTE_SIGNER_URL") != "" && !common.IsHexAddress(os.Getenv("REMOTE_SIGNER_ADDRESS")) {
+		return errors.New("REMOTE_SIGNER_ADDRESS must be set to the remote signer's wallet address")
+	}
+	switch c.SignatureType {
+	case "EOA":
+	case "POLY_PROXY", "POLY_GNOSIS_SAFE":
+		if c.FunderAddress == "" {
+			return fmt.Errorf("SIGNATURE_TYPE=%s needs FUNDER_ADDRESS: the deposit address shown on your Polymarket profile", c.SignatureType)
+		}
+	default:
+		return fmt.Errorf("Invalid SIGNATURE_TYPE %q (use EOA, POLY_PROXY or POLY_GNOSIS_SAFE)", c.SignatureType)
+	}
+	if c.FunderAddress != "" && !common.IsHexAddress(c.FunderAddress) {
+		return fmt.Errorf("FUNDER_ADDRESS %q is not an address", c.FunderAddress)
+	}
+	for name, v := range map[string]int{
+		"DISCOVERY_INTERVAL_SECONDS":    c.DiscoveryIntervalSeconds,
+		"ORDER_STATUS_INTERVAL_SECONDS": c.OrderStatusIntervalSeconds,
+		"PRICE_INTERVAL_SECONDS":        c.PriceIntervalSeconds,
+		"MAINTENANCE_INTERVAL_SECONDS":  c.MaintenanceIntervalSeconds,
+		"REDEEM_CHECK_INTERVAL_SECONDS": c.RedeemCheckIntervalSeconds,
+	} {
+		if v <= 0 {
+			return fmt.Errorf("%s must be positive", name)
+		}
+	}
+	if c.EventWindowSeconds < 0 {
+		return errors.New("EVENT_WINDOW_SECONDS must not be negative")
+	}
+	if c.EventWindowSeconds > 0 && c.EventIntervalSeconds <= 0 {
+		return errors.New("EVENT_INTERVAL_SECONDS must be positive")
+	}
+	if c.QuietIntervalFactor < 1 {
+		return errors.New("QUIET_INTERVAL_FACTOR must be >= 1")
+	}
+	if c.MaxNetDeltaUSD < 0 {
+		return errors.New("MAX_NET_DELTA_USD must not be negative")
 	}
 	if c.OrderSizeUSD <= 0 {
 		return errors.New("ORDER_SIZE_USD must be positive")
 	}
+	if c.GasDailyBudgetUSD < 0 {
+		return errors.New("GAS_DAILY_BUDGET_USD must not be negative")
+	}
+	if c.RedeemDustAfterHours < 0 {
+		return errors.New("REDEEM_DUST_AFTER_HOURS must not be negative")
+	}
+	if c.AllowanceExactUSD < 0 {
+		return errors.New("ALLOWANCE_EXACT_USD must not be negative")
+	}
 	if c.SpreadOffset <= 0 {
 		return errors.New("SPREAD_OFFSET must be positive")
 	}
+	if c.LiquidityPricing != "offset" && c.LiquidityPricing != "tick" {
+		return fmt.Errorf("LIQUIDITY_PRICING must be offset or tick, got %q", c.LiquidityPricing)
+	}
+	if c.LiquidityQueueShares < 0 {
+		return errors.New("LIQUIDITY_QUEUE_SHARES must be >= 0")
+	}
+	if c.PredictorMaxSkew < 0 || c.PredictorMaxSkew >= 0.5 {
+		return errors.New("PREDICTOR_MAX_SKEW must be between 0 and 0.5")
+	}
+	if c.LastMinuteSeconds > 0 && c.LastMinuteTickMS < 50 {
+		return errors.New("LAST_MINUTE_TICK_MS must be at least 50")
+	}
+	if c.LastMinuteSeconds > 0 && c.LastMinuteMaxQuoteAgeMS <= 0 {
+		return errors.New("LAST_MINUTE_MAX_QUOTE_AGE_MS must be > 0")
+	}
+	if c.SpreadCaptureMinEdge < 0 || c.SpreadCaptureMinEdge >= 1 {
+		return errors.New("SPREAD_CAPTURE_MIN_EDGE must be between 0 and 1")
+	}
+	if c.StaleQuoteTicks < 0 {
+		return errors.New("STALE_QUOTE_TICKS must be >= 0")
+	}
+	if strings.EqualFold(strings.TrimSpace(c.OrderMode), "script") {
+		if c.StrategyScript == "" {
+			return errors.New("ORDER_MODE=script needs STRATEGY_SCRIPT")
+		}
+		if _, err := os.Stat(c.StrategyScript); err != nil {
+			return fmt.Errorf("STRATEGY_SCRIPT: %w", err)
+		}
+	}
+	if c.MarketSource == "fixture" && c.MarketFixtureFile == "" {
+		return errors.New("MARKET_SOURCE=fixture needs MARKET_FIXTURE_FILE")
+	}
+	if c.MarketSourceTimeoutSeconds <= 0 {
+		return errors.New("MARKET_SOURCE_TIMEOUT_SECONDS must be positive")
+	}
+	if c.ArbScanIntervalSeconds < 0 || c.ArbFee < 0 || c.ArbFee >= 1 {
+		return errors.New("ARB_SCAN_INTERVAL_SECONDS must not be negative and ARB_FEE must be in [0, 1)")
+	}
+	if c.ReconcileIntervalSeconds < 0 {
+		return errors.New("RECONCILE_INTERVAL_SECONDS must not be negative")
+	}
+	if c.TransferScanMinutes < 0 {
+		return errors.New("TRANSFER_SCAN_MINUTES must not be negative")
+	}
+	if c.TxConfirmTimeoutSeconds <= 0 {
+		return errors.New("TX_CONFIRM_TIMEOUT_SECONDS must be positive")
+	}
+	if c.TxConfirmations < 0 {
+		return errors.New("TX_CONFIRMATIONS must not be negative")
+	}
+	if c.MinOrderShares < 0 || c.MinOrderNotionalUSD < 0 {
+		return errors.New("MIN_ORDER_SHARES and MIN_ORDER_NOTIONAL_USD must not be negative")
+	}
+	if c.ClobCacheSize < 0 || c.ClobCacheTTLMinutes < 0 {
+		return errors.New("CLOB_CACHE_SIZE and CLOB_CACHE_TTL_MINUTES must be >= 0")
+	}
+	if c.OrderHistoryRetentionDays < 0 {
+		return errors.New("ORDER_HISTORY_RETENTION_DAYS must be >= 0")
+	}
+	if c.LotSize < 0 {
+		return errors.New("LOT_SIZE must be >= 0")
+	}
+	if c.MinBookDepthUSD < 0 {
+		return errors.New("MIN_BOOK_DEPTH_USD must be >= 0")
+	}
+	for name, svc := range map[string]HTTPService{"CLOB": c.ClobHTTP, "GAMMA": c.GammaHTTP, "DATA_API": c.DataAPIHTTP} {
+		if svc.TimeoutMS <= 0 || svc.Retries < 0 {
+			return fmt.Errorf("%s_HTTP_TIMEOUT_MS must be positive and %s_HTTP_RETRIES not negative", name, name)
+		}
+	}
+	if c.HTTPRetryBackoffMS < 0 || c.HTTPMaxIdleConns < 0 || c.HTTPMaxIdleConnsPerHost < 0 || c.HTTPIdleConnTimeoutSeconds < 0 || c.HTTPKeepAliveSeconds < 0 {
+		return errors.New("HTTP_* transport settings must not be negative")
+	}
+	if c.DebugHTTP {
+		if _, err := httpx.TraceFile(c.HTTPTraceFile); err != nil {
+			return fmt.Errorf("DEBUG_HTTP: open HTTP_TRACE_FILE: %w", err)
+		}
+	}
+	if c.PresignOrders && !c.PrewarmOrderCaches {
+		return errors.New("PRESIGN_ORDERS needs PREWARM_ORDER_CACHES=true")
+	}
+	if c.MaxConcurrentMarkets < 1 {
+		return errors.New("MAX_CONCURRENT_MARKETS must be at least 1")
+	}
+	if c.LossStreakLimit > 0 && c.LossCooldownMinutes <= 0 {
+		return errors.New("LOSS_COOLDOWN_MINUTES must be positive when LOSS_STREAK_LIMIT is set")
+	}
+	if (c.NotifyTelegramToken == "") != (c.NotifyTelegramChatID == "") {
+		return errors.New("NOTIFY_TELEGRAM_TOKEN and NOTIFY_TELEGRAM_CHAT_ID must be set together")
+	}
+	if c.NotifySMTPHost != "" && (c.NotifyEmailFrom == "" || len(c.NotifyEmailTo) == 0) {
+		return errors.New("NOTIFY_SMTP_HOST needs NOTIFY_EMAIL_FROM and NOTIFY_EMAIL_TO")
+	}
+	if c.DigestSchedule != "" {
+		if _, err := notify.ParseSchedule(c.DigestSchedule); err != nil {
+			return fmt.Errorf("DIGEST_SCHEDULE: %w", err)
+		}
+	}
+	if c.DiscoveryHorizonMinutes <= 0 {
+		return errors.New("DISCOVERY_HORIZON_MINUTES must be positive")
+	}
+	for d, m := range c.DiscoveryHorizons {
+		if m <= 0 {
+			return fmt.Errorf("discovery horizon for %s markets must be positive (got %d)", d, m)
+		}
+	}
+	for d, w := range c.PlacementWindows {
+		if w.MinMinutes < 0 || w.MinMinutes > w.MaxMinutes {
+			return fmt.Errorf("placement window for %s markets must satisfy 0 <= min <= max (got %d-%d)", d, w.MinMinutes, w.MaxMinutes)
+		}
+	}
+	if c.MaxSellSlippage < 0 || c.MaxSellSlippage >= 1 {
+		return errors.New("MAX_SELL_SLIPPAGE must be in [0, 1)")
+	}
+	if c.MaticUSDPrice < 0 {
+		return errors.New("MATIC_USD_PRICE must be >= 0")
+	}
+	if c.RebalanceMinProxyUSD < 0 || c.RebalanceTargetProxyUSD < 0 || c.RebalanceEOAReserveUSD < 0 {
+		return errors.New("REBALANCE_* amounts must be >= 0")
+	}
+	if c.RebalanceMinProxyUSD > 0 {
+		if c.SignatureType == "EOA" || !common.IsHexAddress(c.FunderAddress) {
+			return errors.New("REBALANCE_MIN_PROXY_USD needs SIGNATURE_TYPE=POLY_PROXY/POLY_GNOSIS_SAFE and FUNDER_ADDRESS")
+		}
+		if c.RebalanceTargetProxyUSD > 0 && c.RebalanceTargetProxyUSD <= c.RebalanceMinProxyUSD {
+			return errors.New("REBALANCE_TARGET_PROXY_USD must be above REBALANCE_MIN_PROXY_USD")
+		}
+	}
+	if c.YieldPool != "" {
+		if !common.IsHexAddress(c.YieldPool) || !common.IsHexAddress(c.YieldReceiptToken) {
+			return errors.New("YIELD_POOL and YIELD_RECEIPT_TOKEN must both be hex addresses")
+		}
+		if c.YieldWorkingCapitalUSD < 0 || c.YieldMinMoveUSD <= 0 || c.YieldLookaheadMinutes < 0 {
+			return errors.New("YIELD_WORKING_CAPITAL_USD and YIELD_LOOKAHEAD_MINUTES must be >= 0 and YIELD_MIN_MOVE_USD > 0")
+		}
+	}
+	if c.USDCSwapRouter != "" && !common.IsHexAddress(c.USDCSwapRouter) {
+		return errors.New("USDC_SWAP_ROUTER must be a hex address")
+	}
+	if c.USDCSwapMaxSlippageBps < 0 || c.USDCSwapMaxSlippageBps >= 10000 {
+		return errors.New("USDC_SWAP_MAX_SLIPPAGE_BPS must be between 0 and 10000")
+	}
+	if s, ok := c.Strategy(); ok && s.HoldToResolution && (s.HoldMinMid <= 0 || s.HoldMinMid >= 1) {
+		return errors.New("HOLD_MIN_MID must be between 0 and 1")
+	}
+	if c.MinSellPrice < 0 || c.MinSellPrice >= 1 || c.MarketSellDiscount < 0 || c.MarketSellDiscount >= 1 {
+		return errors.New("MIN_SELL_PRICE and MARKET_SELL_DISCOUNT must be in [0, 1)")
+	}
+	for name, s := range c.Strategies {
+		if s.ExitTimeoutSeconds < 0 || s.CapitalBudgetUSD < 0 {
+			return fmt.Errorf("strategy %s: exit timeout and capital budget must be >= 0", name)
+		}
+		if s.ExtendFillRatio < 0 || s.ExtendFillRatio > 1 || s.ExtendSeconds < 0 {
+			return fmt.Errorf("strategy %s: extend fill ratio must be in [0, 1] and extend seconds >= 0", name)
+		}
+	}
 	return nil
 }
 
+// Validate re-runs the load-time checks, for a config changed while the bot
+// runs.
+func (c Config) Validate() error { return validate(c) }
+
+// keyProvider picks the wallet key source: a remote signing service, an
+// encrypted keystore file, the OS keychain, or the raw PRIVATE_KEY, in that
+// order of preference.
+func keyProvider(privateKey string) keys.Provider {
+	if url := os.Getenv("REMOTE_SIGNER_URL"); url != "" {
+		return keys.FromRemoteSigner(url, os.Getenv("REMOTE_SIGNER_ADDRESS"), os.Getenv("REMOTE_SIGNER_TOKEN"))
+	}
+	if path := os.Getenv("KEYSTORE_FILE"); path != "" {
+		return keys.FromKeystore(path, os.Getenv("KEYSTORE_PASSPHRASE"), os.Getenv("KEYSTORE_PASSPHRASE_FILE"))
+	}
+	if svc := os.Getenv("KEYCHAIN_SERVICE"); svc != "" {
+		return keys.FromKeychain(svc, envOr("KEYCHAIN_ACCOUNT", "polymarket-bot"))
+	}
+	if privateKey != "" {
+		return keys.FromHex(privateKey)
+	}
+	return nil
+}
+
+// applyStrategyBudgets parses STRATEGY_BUDGETS ("name:usd,name:usd") into
+// per-strategy capital budgets. Malformed entries are ignored like other env
+// parsing here; unknown names get a budget-only entry so newer strategies can
+// be capped before they have other settings.
+func applyStrategyBudgets(strategies map[string]StrategyConfig, raw string) {
+	for _, item := range splitList(raw) {
+		name, amount, ok := strings.Cut(item, ":")
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+		if err != nil || v < 0 {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		s := strategies[name]
+		s.CapitalBudgetUSD = v
+		strategies[name] = s
+	}
+}
+
 func envOr(key, def string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
@@ -175,6 +904,28 @@ func mustFloat(key string, def float64) float64 {
 	return v
 }
 
+func splitList(raw string) []string {
+	var out []string
+	for _, part := range strings.Split(raw, ",") {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func mustBool(key string, def bool) bool {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
 func (c Config) String() string {
 	return fmt.Sprintf("chain=%d signature=%s orderSize=%.2f spread=%.4f", c.ChainID, c.SignatureType, c.OrderSizeUSD, c.SpreadOffset)
 }
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// secretNames marks fields whose values never leave the process.
+var secretNames = []string{"privatekey", "secret", "passphrase", "password", "token", "apikey"}
+
+// Snapshot returns the effective configuration as a JSON-friendly map with
+// secrets removed: credentials are dropped, the key provider is reduced to
+// its source, and URLs lose userinfo and query strings (RPC URLs keep only
+// their host, since providers embed API keys in the path).
+func (c Config) Snapshot() map[string]any {
+	out := map[string]any{}
+	v := reflect.ValueOf(c)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Name
+		if isSecretName(name) {
+			continue
+		}
+		switch name {
+		case "Keys":
+			if c.Keys != nil {
+				out["KeySource"] = c.Keys.Source()
+			}
+			continue
+		case "PlacementWindows":
+			windows := map[string]PlacementWindow{}
+			for d, w := range c.PlacementWindows {
+				windows[d.String()] = w
+			}
+			out[name] = windows
+			continue
+		case "DiscoveryHorizons":
+			horizons := map[string]int{}
+			for d, m := range c.DiscoveryHorizons {
+				horizons[d.String()] = m
+			}
+			out[name] = horizons
+			continue
+		}
+		val := v.Field(i).Interface()
+		if strings.HasSuffix(name, "URL") || strings.HasSuffix(name, "URLs") {
+			val = sanitizeURLs(val, strings.HasPrefix(name, "RPC"))
+		}
+		out[name] = val
+	}
+	return out
+}
+
+func isSecretName(name string) bool {
+	n := strings.ToLower(name)
+	for _, s := range secretNames {
+		if strings.Contains(n, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func sanitizeURLs(val any, hostOnly bool) any {
+	switch v := val.(type) {
+	case string:
+		return sanitizeURL(v, hostOnly)
+	case []string:
+		out := make([]string, len(v))
+		for i, s := range v {
+			out[i] = sanitizeURL(s, hostOnly)
+		}
+		return out
+	}
+	return val
+}
+
+func sanitizeURL(raw string, hostOnly bool) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		if raw == "" {
+			return ""
+		}
+		return "[redacted]"
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	if hostOnly {
+		u.Path, u.RawPath = "", ""
+	}
+	return u.String()
+}
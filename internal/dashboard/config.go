@@ -0,0 +1,58 @@
+package dashboard
+
+import (
+	"net/http"
+	"time"
+
+	"limitorderbot/internal/runs"
+)
+
+// handleConfig serves /api/config: the effective configuration (secrets
+// removed) of the current run, or of ?run=<id>, or of the run in effect at
+// ?at=<RFC3339>, which is how an order's created_at maps to the parameters
+// it was placed under. "runs" lists every recorded run, newest first.
+func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
+	all, err := runs.Load(runs.DefaultFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	q := r.URL.Query()
+	var (
+		run   runs.Run
+		found bool
+	)
+	switch {
+	case q.Get("run") != "":
+		run, found = runs.Find(all, q.Get("run"))
+	case q.Get("at") != "":
+		at, err := time.Parse(time.RFC3339, q.Get("at"))
+		if err != nil {
+			http.Error(w, "invalid at: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		run, found = runs.At(all, at)
+	default:
+		run, found = runs.Find(all, s.bot.RunID())
+		if !found {
+			// Not started (or the file was lost): show what is loaded now.
+			run, found = runs.Run{ID: s.bot.RunID(), Config: s.cfg.Snapshot()}, true
+		}
+	}
+	if !found {
+		http.Error(w, "run not found", http.StatusNotFound)
+		return
+	}
+
+	list := make([]map[string]any, 0, len(all))
+	for i := len(all) - 1; i >= 0; i-- {
+		list = append(list, map[string]any{"id": all[i].ID, "started_at": all[i].StartedAt})
+	}
+	writeJSON(w, map[string]any{
+		"run_id":     run.ID,
+		"started_at": run.StartedAt,
+		"current":    run.ID == s.bot.RunID(),
+		"config":     run.Config,
+		"runs":       list,
+	})
+}
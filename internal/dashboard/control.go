@@ -0,0 +1,51 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// registerControl serves the panic and resume buttons when CONTROL_TOKEN is
+// set. Either one moves money or lifts a safety pause, so a stranger on the
+// port must not reach them.
+func (s *Server) registerControl(mux *http.ServeMux) {
+	if s.cfg.ControlToken == "" {
+		return
+	}
+	mux.Handle("/api/control/panic", requireToken(s.cfg.ControlToken, http.HandlerFunc(s.handlePanic)))
+	mux.Handle("/api/control/resume", requireToken(s.cfg.ControlToken, http.HandlerFunc(s.handleResume)))
+}
+
+// handlePanic is the emergency button: pause the bot, cancel every open
+// order and market-sell every position. POST only.
+func (s *Server) handlePanic(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	// Not tied to the request: a client that gives up must not abort the
+	// liquidation halfway.
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	rep, err := s.bot.Panic(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusGatewayTimeout)
+		return
+	}
+	writeJSON(w, rep)
+}
+
+// handleResume lifts a pause set by the panic button. POST only. The bot
+// stays paused while the kill switch is engaged.
+func (s *Server) handleResume(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := s.bot.Resume(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]any{"paused": s.bot.GetState().Paused != nil})
+}
@@ -0,0 +1,44 @@
+package dashboard
+
+import (
+	"net/http"
+	"strings"
+
+	"limitorderbot/internal/decisions"
+)
+
+// handleDecisions serves /api/decisions: the placement audit log, newest
+// first. Besides the common list filters it takes ?action=placed|skipped|failed
+// and ?reason=window|busy|balance|budget|cooldown|no_edge|error, so "why
+// didn't the bot trade market X" is /api/decisions?market=X.
+func (s *Server) handleDecisions(w http.ResponseWriter, r *http.Request) {
+	f, err := parseListFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	action := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("action")))
+	reason := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("reason")))
+
+	all, _ := decisions.Load(decisions.DefaultFile)
+	rows := make([]decisions.Decision, 0, len(all))
+	for i := len(all) - 1; i >= 0; i-- {
+		d := all[i]
+		if action != "" && d.Action != action {
+			continue
+		}
+		if reason != "" && d.Reason != reason {
+			continue
+		}
+		if !f.matchCommon(d.Strategy, d.MarketSlug, d.ConditionID, d.Time) {
+			continue
+		}
+		rows = append(rows, d)
+	}
+	writeJSON(w, map[string]any{
+		"decisions": page(rows, f),
+		"total":     len(rows),
+		"limit":     f.limit,
+		"offset":    f.offset,
+	})
+}
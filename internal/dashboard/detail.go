@@ -0,0 +1,182 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/models"
+	"limitorderbot/internal/outcomes"
+)
+
+// handleMarketDetail serves /api/markets/{cid}/detail: our orders and fills in
+// one market, the wallet's live token balances with mark prices, and what the
+// market would net us if each outcome won from here.
+func (s *Server) handleMarketDetail(w http.ResponseWriter, r *http.Request) {
+	cid := strings.TrimSpace(r.PathValue("cid"))
+	state := s.bot.GetState()
+
+	var market *models.Market
+	for i := range state.ActiveMarkets {
+		if strings.EqualFold(state.ActiveMarkets[i].ConditionID, cid) {
+			market = &state.ActiveMarkets[i]
+			break
+		}
+	}
+
+	// History carries every order we've placed; pending state covers anything
+	// placed since it was last saved.
+	hist, _ := loadHistoryFile("order_history.json")
+	byID := map[string]models.OrderRecord{}
+	for _, o := range hist {
+		if strings.EqualFold(o.ConditionID, cid) {
+			byID[o.OrderID] = o
+		}
+	}
+	for _, o := range state.PendingOrders {
+		if strings.EqualFold(o.ConditionID, cid) {
+			byID[o.OrderID] = o
+		}
+	}
+	if market == nil && len(byID) == 0 {
+		http.Error(w, "market not found", http.StatusNotFound)
+		return
+	}
+	orders := make([]models.OrderRecord, 0, len(byID))
+	for _, o := range byID {
+		orders = append(orders, o)
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].CreatedAt.Before(orders[j].CreatedAt) })
+
+	if market == nil {
+		market = marketFromOrders(cid, orders)
+	}
+
+	// Cash flow from fills: buys pay price*matched, sells/merges/redeems return it.
+	var cash, boughtShares, soldShares float64
+	fills := make([]map[string]any, 0)
+	for _, o := range orders {
+		matched := filledSize(o)
+		if matched <= 0 {
+			continue
+		}
+		notional := o.Price * matched
+		if o.Side == models.OrderSideBuy {
+			cash -= notional
+			boughtShares += matched
+		} else {
+			cash += notional
+			soldShares += matched
+		}
+		fills = append(fills, map[string]any{
+			"order_id":         o.OrderID,
+			"outcome":          o.Outcome,
+			"side":             string(o.Side),
+			"transaction_type": o.TransactionType,
+			"price":            o.Price,
+			"size_matched":     round2(matched),
+			"notional_usd":     round2(notional),
+			"filled_at":        timeOrNil(o.FilledAt),
+			"tx_url":           s.cfg.TxURL(o.TxHash),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	positions := s.bot.OutcomePositions(ctx, *market)
+
+	markValue := cash
+	projected := map[string]float64{}
+	for _, p := range positions {
+		if p.Mark != nil {
+			markValue += p.Balance * *p.Mark
+		}
+		projected[p.Outcome] = round2(cash + p.Balance)
+	}
+
+	var winner any
+	resolved, _ := outcomes.Load(outcomes.DefaultFile)
+	if res, ok := resolved[market.ConditionID]; ok {
+		winner = res.WinningOutcome
+	}
+	var start, end any
+	if market.StartTS > 0 {
+		start = market.StartTime().Format(time.RFC3339Nano)
+		end = market.EndTime().Format(time.RFC3339Nano)
+	}
+
+	rows := make([]map[string]any, 0, len(orders))
+	for _, o := range orders {
+		rows = append(rows, map[string]any{
+			"order_id":         o.OrderID,
+			"outcome":          o.Outcome,
+			"side":             string(o.Side),
+			"transaction_type": o.TransactionType,
+			"price":            round3(o.Price),
+			"size":             round2(o.Size),
+			"size_usd":         round2(o.SizeUSD),
+			"status":           string(o.Status),
+			"strategy":         o.Strategy,
+			"created_at":       o.CreatedAt.Format(time.RFC3339Nano),
+			"filled_at":        timeOrNil(o.FilledAt),
+			"error_message":    o.ErrorMessage,
+			"tx_url":           s.cfg.TxURL(o.TxHash),
+		})
+	}
+
+	writeJSON(w, map[string]any{
+		"condition_id":    market.ConditionID,
+		"market_slug":     market.MarketSlug,
+		"market_url":      s.cfg.MarketURL(market.MarketSlug),
+		"question":        market.Question,
+		"start_datetime":  start,
+		"end_datetime":    end,
+		"is_active":       market.IsActive,
+		"winning_outcome": winner,
+		"volume":          round2(market.Volume),
+		"liquidity":       round2(market.Liquidity),
+		"tags":            market.Tags,
+		"orders":          rows,
+		"fills":           fills,
+		"positions":       positions,
+		"cash_flow_usd":   round2(cash),
+		"bought_shares":   round2(boughtShares),
+		"sold_shares":     round2(soldShares),
+		"mark_pnl_usd":    round2(markValue),
+		"projected_pnl":   projected,
+
+		"resolution_source":     market.ResolutionSource,
+		"uma_resolution_status": market.UMAResolutionStatus,
+	})
+}
+
+// filledSize prefers the matched size; fully filled records without one count whole.
+func filledSize(o models.OrderRecord) float64 {
+	if o.SizeMatched != nil && *o.SizeMatched > 0 {
+		return *o.SizeMatched
+	}
+	if o.Status == models.OrderStatusFilled {
+		return o.Size
+	}
+	return 0
+}
+
+// marketFromOrders rebuilds enough of a market that has left the active list
+// to look up its balances: slug, times and outcome tokens from our orders.
+func marketFromOrders(cid string, orders []models.OrderRecord) *models.Market {
+	m := &models.Market{ConditionID: cid}
+	seen := map[string]bool{}
+	for _, o := range orders {
+		if m.MarketSlug == "" {
+			m.MarketSlug = o.MarketSlug
+		}
+		if o.TokenID == "" || seen[o.TokenID] {
+			continue
+		}
+		seen[o.TokenID] = true
+		m.Outcomes = append(m.Outcomes, models.Outcome{TokenID: o.TokenID, Outcome: o.Outcome})
+	}
+	return m
+}
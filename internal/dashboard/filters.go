@@ -0,0 +1,112 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/models"
+)
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+// listFilter holds the common query parameters of the list endpoints:
+// ?status=&strategy=&market=&from=&to=&limit=&offset=
+// from/to accept RFC3339 or YYYY-MM-DD (to is inclusive of that whole day).
+type listFilter struct {
+	status   string
+	strategy string
+	market   string
+	from     time.Time
+	to       time.Time
+	limit    int
+	offset   int
+}
+
+func parseListFilter(r *http.Request) (listFilter, error) {
+	q := r.URL.Query()
+	f := listFilter{
+		status:   strings.ToUpper(strings.TrimSpace(q.Get("status"))),
+		strategy: strings.TrimSpace(q.Get("strategy")),
+		market:   strings.ToLower(strings.TrimSpace(q.Get("market"))),
+		limit:    defaultPageLimit,
+	}
+	var err error
+	if f.from, err = parseDateParam(q.Get("from"), false); err != nil {
+		return f, fmt.Errorf("invalid from: %w", err)
+	}
+	if f.to, err = parseDateParam(q.Get("to"), true); err != nil {
+		return f, fmt.Errorf("invalid to: %w", err)
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return f, fmt.Errorf("invalid limit %q", v)
+		}
+		f.limit = min(n, maxPageLimit)
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return f, fmt.Errorf("invalid offset %q", v)
+		}
+		f.offset = n
+	}
+	return f, nil
+}
+
+func parseDateParam(v string, endOfDay bool) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+// matchCommon applies the strategy/market/date parts of the filter.
+func (f listFilter) matchCommon(strategy, marketSlug, conditionID string, at time.Time) bool {
+	if f.strategy != "" && !strings.EqualFold(f.strategy, strategy) {
+		return false
+	}
+	if f.market != "" && !strings.Contains(strings.ToLower(marketSlug), f.market) && !strings.EqualFold(conditionID, f.market) {
+		return false
+	}
+	if !f.from.IsZero() && at.Before(f.from) {
+		return false
+	}
+	if !f.to.IsZero() && at.After(f.to) {
+		return false
+	}
+	return true
+}
+
+func (f listFilter) matchOrder(o models.OrderRecord) bool {
+	if f.status != "" && string(o.Status) != f.status {
+		return false
+	}
+	return f.matchCommon(deref(o.Strategy, "None"), o.MarketSlug, o.ConditionID, o.CreatedAt)
+}
+
+// page returns the [offset, offset+limit) window of items.
+func page[T any](items []T, f listFilter) []T {
+	if f.offset >= len(items) {
+		return []T{}
+	}
+	end := min(f.offset+f.limit, len(items))
+	return items[f.offset:end]
+}
@@ -0,0 +1,17 @@
+package dashboard
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec describes the dashboard and control API; internal/apiclient is
+// generated from it, so keep it in step with the handlers.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openAPISpec)
+}
@@ -0,0 +1,227 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/cashflows"
+	"limitorderbot/internal/rewards"
+	"limitorderbot/internal/snapshots"
+)
+
+type pnlPoint struct {
+	Time          time.Time `json:"time"`
+	PNL           float64   `json:"pnl"`
+	CumulativePNL float64   `json:"cumulative_pnl"`
+	USDCBalance   *float64  `json:"usdc_balance"`
+	MaticBalance  *float64  `json:"matic_balance"`
+	PositionValue *float64  `json:"position_value_usd"`
+	Equity        *float64  `json:"equity_usd"`
+	Orders        int       `json:"orders"`
+}
+
+// bucketStart truncates t to the start of its local hour or day.
+func bucketStart(t time.Time, interval string) time.Time {
+	t = t.Local()
+	if interval == "day" {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+}
+
+// handlePNLSeries returns cumulative PnL (the same per-order pnl_usd summed for
+// total_pnl) and the sampled wallet balance, MATIC, position value and equity
+// per hour or day. Cumulative PnL always counts from the first order, so
+// from/to only narrow the window shown.
+func (s *Server) handlePNLSeries(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	interval := strings.ToLower(strings.TrimSpace(q.Get("interval")))
+	if interval == "" {
+		interval = "hour"
+	}
+	if interval != "hour" && interval != "day" {
+		http.Error(w, fmt.Sprintf("invalid interval %q (hour|day)", interval), http.StatusBadRequest)
+		return
+	}
+	from, err := parseDateParam(q.Get("from"), false)
+	if err != nil {
+		http.Error(w, "invalid from: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := parseDateParam(q.Get("to"), true)
+	if err != nil {
+		http.Error(w, "invalid to: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	orders, _ := loadHistoryFile("order_history.json")
+	snaps, _ := snapshots.Load(snapshots.DefaultFile)
+
+	points := map[time.Time]*pnlPoint{}
+	get := func(t time.Time) *pnlPoint {
+		k := bucketStart(t, interval)
+		p := points[k]
+		if p == nil {
+			p = &pnlPoint{Time: k}
+			points[k] = p
+		}
+		return p
+	}
+	for _, o := range orders {
+		if o.PNLUSD == nil || o.CreatedAt.IsZero() {
+			continue
+		}
+		p := get(o.CreatedAt)
+		p.PNL += *o.PNLUSD
+		p.Orders++
+	}
+	// Snapshots are appended in time order, so the last one in a bucket wins.
+	for _, sn := range snaps {
+		p := get(sn.Time)
+		bal := sn.USDCBalance
+		p.USDCBalance = &bal
+		p.MaticBalance, p.PositionValue, p.Equity = nil, nil, nil
+		if sn.Complete() {
+			matic, eq := sn.MaticBalance, round2(sn.Equity(s.cfg.MaticUSDPrice))
+			p.MaticBalance, p.PositionValue, p.Equity = &matic, sn.PositionValueUSD, &eq
+		}
+	}
+
+	keys := make([]time.Time, 0, len(points))
+	for k := range points {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Before(keys[j]) })
+
+	out := make([]pnlPoint, 0, len(keys))
+	var cum float64
+	var last pnlPoint
+	for _, k := range keys {
+		p := points[k]
+		cum += p.PNL
+		p.PNL = round2(p.PNL)
+		p.CumulativePNL = round2(cum)
+		if p.USDCBalance == nil {
+			p.USDCBalance, p.MaticBalance, p.PositionValue, p.Equity = last.USDCBalance, last.MaticBalance, last.PositionValue, last.Equity
+		}
+		last = *p
+		if (!from.IsZero() && k.Before(bucketStart(from, interval))) || (!to.IsZero() && k.After(to)) {
+			continue
+		}
+		out = append(out, *p)
+	}
+
+	writeJSON(w, map[string]any{
+		"interval": interval,
+		"points":   out,
+	})
+}
+
+// handleRewards lists liquidity rewards per UTC day, newest first: resting
+// exposure by price level, the local estimate and reported earnings.
+func (s *Server) handleRewards(w http.ResponseWriter, r *http.Request) {
+	days, _ := rewards.Load(rewards.DefaultFile)
+	rows := make([]map[string]any, 0, len(days))
+	for _, d := range days {
+		levels := make([]rewards.Level, 0, len(d.Levels))
+		for _, l := range d.Levels {
+			levels = append(levels, *l)
+		}
+		sort.Slice(levels, func(i, j int) bool { return levels[i].Price < levels[j].Price })
+		rows = append(rows, map[string]any{
+			"date":          d.Date,
+			"size_hours":    round2(d.SizeSeconds / 3600),
+			"estimated_usd": round2(d.EstimatedUSD),
+			"actual_usd":    d.ActualUSD,
+			"reward_usd":    round2(d.RewardUSD()),
+			"levels":        levels,
+			"updated_at":    d.UpdatedAt,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i]["date"].(string) > rows[j]["date"].(string) })
+	writeJSON(w, map[string]any{
+		"days":      rows,
+		"total_usd": round2(rewards.Total(days)),
+	})
+}
+
+// capitalFlows splits the equity change between the first and last complete
+// balance samples into trading PnL, liquidity rewards and the rest, which is
+// money the operator moved in (positive) or out. Deposits and Withdrawals
+// are the transfers actually recorded over the same period; Unexplained is
+// what neither accounts for (price moves of held positions, unseen flows).
+type capitalFlows struct {
+	Since        time.Time `json:"since"`
+	StartEquity  float64   `json:"start_equity_usd"`
+	Equity       float64   `json:"equity_usd"`
+	EquityChange float64   `json:"equity_change_usd"`
+	TradingPNL   float64   `json:"trading_pnl_usd"`
+	Rewards      float64   `json:"rewards_usd"`
+	NetTransfers float64   `json:"net_transfers_usd"`
+	Deposits     float64   `json:"deposits_usd"`
+	Withdrawals  float64   `json:"withdrawals_usd"`
+	Unexplained  float64   `json:"unexplained_usd"`
+}
+
+// capitalSummary is nil until two complete samples exist. Rewards count
+// from the first sample's UTC day.
+func capitalSummary(snaps []snapshots.BalanceSnapshot, days map[string]*rewards.Day, ledger cashflows.Ledger, maticUSD float64) *capitalFlows {
+	var first, last *snapshots.BalanceSnapshot
+	for i := range snaps {
+		if !snaps[i].Complete() {
+			continue
+		}
+		if first == nil {
+			first = &snaps[i]
+		}
+		last = &snaps[i]
+	}
+	if first == nil || first == last {
+		return nil
+	}
+	since := first.Time.UTC().Format("2006-01-02")
+	var earned float64
+	for _, d := range days {
+		if d.Date >= since {
+			earned += d.RewardUSD()
+		}
+	}
+	start, end := first.Equity(maticUSD), last.Equity(maticUSD)
+	pnl := *last.TradingPNL - *first.TradingPNL
+	in, out := ledger.Totals(first.Time)
+	net := end - start - pnl - earned
+	return &capitalFlows{
+		Since:        first.Time,
+		StartEquity:  round2(start),
+		Equity:       round2(end),
+		EquityChange: round2(end - start),
+		TradingPNL:   round2(pnl),
+		Rewards:      round2(earned),
+		NetTransfers: round2(net),
+		Deposits:     round2(in),
+		Withdrawals:  round2(out),
+		Unexplained:  round2(net - (in - out)),
+	}
+}
+
+// handleCashFlows lists the deposits and withdrawals found on the wallet,
+// newest first, with their totals.
+func (s *Server) handleCashFlows(w http.ResponseWriter, r *http.Request) {
+	ledger, err := cashflows.Load(cashflows.DefaultFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	flows := append([]cashflows.Flow{}, ledger.Flows...)
+	sort.Slice(flows, func(i, j int) bool { return flows[i].Time.After(flows[j].Time) })
+	in, out := ledger.Totals(time.Time{})
+	writeJSON(w, map[string]any{
+		"flows":           flows,
+		"deposits_usd":    round2(in),
+		"withdrawals_usd": round2(out),
+		"last_block":      ledger.LastBlock,
+	})
+}
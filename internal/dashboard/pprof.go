@@ -0,0 +1,140 @@
+package dashboard
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	rpprof "runtime/pprof"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxDumpSeconds bounds the CPU profile a dump request records.
+const maxDumpSeconds = 120
+
+// dumpMu allows one profile dump at a time; the CPU profiler is global.
+var dumpMu sync.Mutex
+
+// registerPprof serves net/http/pprof and the profile dump endpoint when
+// PPROF_TOKEN is set. Profiles expose internals (and cost CPU), so every
+// request must carry the token.
+func (s *Server) registerPprof(mux *http.ServeMux) {
+	if s.cfg.PprofToken == "" {
+		return
+	}
+	mux.Handle("/debug/pprof/", requireToken(s.cfg.PprofToken, http.HandlerFunc(pprof.Index)))
+	mux.Handle("/debug/pprof/cmdline", requireToken(s.cfg.PprofToken, http.HandlerFunc(pprof.Cmdline)))
+	mux.Handle("/debug/pprof/profile", requireToken(s.cfg.PprofToken, http.HandlerFunc(pprof.Profile)))
+	mux.Handle("/debug/pprof/symbol", requireToken(s.cfg.PprofToken, http.HandlerFunc(pprof.Symbol)))
+	mux.Handle("/debug/pprof/trace", requireToken(s.cfg.PprofToken, http.HandlerFunc(pprof.Trace)))
+	mux.Handle("/debug/profile/dump", requireToken(s.cfg.PprofToken, http.HandlerFunc(s.handleProfileDump)))
+}
+
+// requireToken accepts "Authorization: Bearer <token>" or ?token=<token>.
+func requireToken(want string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hasToken(want, r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hasToken reports whether r carries want, as requireToken checks it. An
+// empty want matches nothing.
+func hasToken(want string, r *http.Request) bool {
+	if want == "" {
+		return false
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1
+}
+
+// handleProfileDump records a CPU profile for ?seconds= (default 30), then
+// writes it with heap and goroutine profiles to PROFILE_DIR and returns the
+// file names. POST only; seconds=0 skips the CPU profile.
+func (s *Server) handleProfileDump(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	seconds := 30
+	if v := r.URL.Query().Get("seconds"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > maxDumpSeconds {
+			http.Error(w, fmt.Sprintf("seconds must be 0-%d", maxDumpSeconds), http.StatusBadRequest)
+			return
+		}
+		seconds = n
+	}
+	if !dumpMu.TryLock() {
+		http.Error(w, "a profile dump is already running", http.StatusConflict)
+		return
+	}
+	defer dumpMu.Unlock()
+
+	files, err := dumpProfiles(r, s.cfg.ProfileDir, time.Duration(seconds)*time.Second)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]any{"files": files})
+}
+
+// dumpProfiles writes <kind>_<timestamp>.pprof files into dir. The CPU
+// profile stops early if the request is cancelled.
+func dumpProfiles(r *http.Request, dir string, cpu time.Duration) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	path := func(kind string) string { return filepath.Join(dir, kind+"_"+stamp+".pprof") }
+	var files []string
+
+	if cpu > 0 {
+		f, err := os.Create(path("cpu"))
+		if err != nil {
+			return nil, err
+		}
+		if err := rpprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+		select {
+		case <-time.After(cpu):
+		case <-r.Context().Done():
+		}
+		rpprof.StopCPUProfile()
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+		files = append(files, f.Name())
+	}
+
+	runtime.GC() // heap profile reflects live objects as of the last GC
+	for _, kind := range []string{"heap", "goroutine"} {
+		f, err := os.Create(path(kind))
+		if err != nil {
+			return files, err
+		}
+		err = rpprof.Lookup(kind).WriteTo(f, 0)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return files, err
+		}
+		files = append(files, f.Name())
+	}
+	return files, nil
+}
@@ -0,0 +1,102 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/gamma"
+	"limitorderbot/internal/models"
+)
+
+// priceLead starts the chart ahead of the market so the placement window shows.
+const priceLead = 20 * time.Minute
+
+// handleMarketPrices serves /api/market-history/{cid}/prices: each outcome's
+// CLOB price history from before our first order through the market's end,
+// for charting how prices moved around our fills.
+func (s *Server) handleMarketPrices(w http.ResponseWriter, r *http.Request) {
+	cid := strings.TrimSpace(r.PathValue("cid"))
+	state := s.bot.GetState()
+
+	var market *models.Market
+	for i := range state.ActiveMarkets {
+		if strings.EqualFold(state.ActiveMarkets[i].ConditionID, cid) {
+			market = &state.ActiveMarkets[i]
+			break
+		}
+	}
+	hist, _ := loadHistoryFile("order_history.json")
+	var orders []models.OrderRecord
+	for _, o := range hist {
+		if strings.EqualFold(o.ConditionID, cid) {
+			orders = append(orders, o)
+		}
+	}
+	if market == nil && len(orders) == 0 {
+		http.Error(w, "market not found", http.StatusNotFound)
+		return
+	}
+	if market == nil {
+		market = marketFromOrders(cid, orders)
+		market.StartTS, market.EndTS, _ = gamma.WindowFromSlug(market.MarketSlug)
+	}
+
+	now := time.Now()
+	start := market.StartTime().Add(-priceLead)
+	end := market.EndTime()
+	for _, o := range orders {
+		if !o.CreatedAt.IsZero() && o.CreatedAt.Before(start) {
+			start = o.CreatedAt
+		}
+	}
+	if market.EndTS == 0 {
+		// Unknown window: fall back to the span of our own orders.
+		start, end = now, time.Time{}
+		for _, o := range orders {
+			if o.CreatedAt.IsZero() {
+				continue
+			}
+			if o.CreatedAt.Before(start) {
+				start = o.CreatedAt
+			}
+			if o.CreatedAt.After(end) {
+				end = o.CreatedAt
+			}
+		}
+		start, end = start.Add(-priceLead), end.Add(priceLead)
+	}
+	if end.After(now) {
+		end = now
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	series := make([]map[string]any, 0, len(market.Outcomes))
+	for _, o := range market.Outcomes {
+		row := map[string]any{"outcome": o.Outcome, "token_id": o.TokenID}
+		points, err := s.bot.PriceHistory(ctx, o.TokenID, start, end)
+		if err != nil {
+			row["error"] = err.Error()
+			points = []gamma.PricePoint{}
+		}
+		row["prices"] = points
+		series = append(series, row)
+	}
+
+	var startAt, endAt any
+	if market.StartTS > 0 {
+		startAt = market.StartTime().Format(time.RFC3339Nano)
+		endAt = market.EndTime().Format(time.RFC3339Nano)
+	}
+	writeJSON(w, map[string]any{
+		"condition_id":   market.ConditionID,
+		"market_slug":    market.MarketSlug,
+		"start_datetime": startAt,
+		"end_datetime":   endAt,
+		"from":           start.Format(time.RFC3339Nano),
+		"to":             end.Format(time.RFC3339Nano),
+		"outcomes":       series,
+	})
+}
@@ -14,9 +14,15 @@ import (
 	"strings"
 	"time"
 
+	"limitorderbot/internal/apierr"
 	"limitorderbot/internal/bot"
+	"limitorderbot/internal/cashflows"
 	"limitorderbot/internal/config"
+	"limitorderbot/internal/journal"
 	"limitorderbot/internal/models"
+	"limitorderbot/internal/outcomes"
+	"limitorderbot/internal/rewards"
+	"limitorderbot/internal/snapshots"
 )
 
 type Server struct {
@@ -40,11 +46,31 @@ func (s *Server) Run(ctx context.Context) error {
 	mux.HandleFunc("/", s.handleIndex)
 	mux.HandleFunc("/api/status", s.handleStatus)
 	mux.HandleFunc("/api/markets", s.handleMarkets)
+	mux.HandleFunc("/api/markets/{cid}/detail", s.handleMarketDetail)
 	mux.HandleFunc("/api/orders", s.handleOrders)
 	mux.HandleFunc("/api/market-history", s.handleMarketHistory)
+	mux.HandleFunc("/api/market-history/{cid}/prices", s.handleMarketPrices)
 	mux.HandleFunc("/api/statistics", s.handleStatistics)
 	mux.HandleFunc("/api/strategy-statistics", s.handleStrategyStatistics)
+	mux.HandleFunc("/api/statistics/fills", s.handleFillStatistics)
+	mux.HandleFunc("/api/statistics/phases", s.handlePhaseStatistics)
+	mux.HandleFunc("/api/pnl-series", s.handlePNLSeries)
+	mux.HandleFunc("/api/cash-flows", s.handleCashFlows)
+	mux.HandleFunc("/api/rewards", s.handleRewards)
+	mux.HandleFunc("/api/decisions", s.handleDecisions)
 	mux.HandleFunc("/api/logs", s.handleLogs)
+	mux.HandleFunc("/api/annotations", s.handleAnnotations)
+	mux.HandleFunc("/api/rpc-health", s.handleRPCHealth)
+	mux.HandleFunc("/api/transactions", s.handleTransactions)
+	mux.HandleFunc("/api/reconciliation", s.handleReconciliation)
+	mux.HandleFunc("/api/arbitrage", s.handleArbitrage)
+	mux.HandleFunc("/api/metrics", s.handleMetrics)
+	mux.HandleFunc("/api/config", s.handleConfig)
+	mux.HandleFunc("/api/sessions", s.handleSessions)
+	mux.HandleFunc("/api/openapi.json", s.handleOpenAPI)
+	s.registerPprof(mux)
+	s.registerControl(mux)
+	s.registerStrategies(mux)
 
 	srv := &http.Server{
 		Addr:              fmt.Sprintf("%s:%d", s.cfg.DashboardHost, s.cfg.DashboardPort),
@@ -73,33 +99,124 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 	if state.LastCheck != nil {
 		last = *state.LastCheck
 	}
-	next := last.Add(time.Duration(s.cfg.CheckIntervalSeconds) * time.Second)
-	minBalanceNeeded := s.cfg.OrderSizeUSD * 2
+	next := last.Add(time.Duration(s.cfg.DiscoveryIntervalSeconds) * time.Second)
+	minBalanceNeeded := s.bot.StrategyParams().OrderSizeUSD * 2
 	hasSufficient := state.USDCBalance >= minBalanceNeeded
 
 	resp := map[string]any{
 		"is_running":             state.IsRunning,
 		"last_check":             last.Format(time.RFC3339Nano),
 		"next_check":             next.Format(time.RFC3339Nano),
-		"check_interval_seconds": s.cfg.CheckIntervalSeconds,
+		"check_interval_seconds": s.cfg.DiscoveryIntervalSeconds,
 		"usdc_balance":           round2(state.USDCBalance),
 		"total_pnl":              round2(state.TotalPNL),
 		"error_count":            state.ErrorCount,
 		"last_error":             state.LastError,
+		"last_error_kind":        state.LastErrorKind,
+		"errors_by_kind":         state.ErrorsByKind,
+		"backoff_until":          timeOrNil(state.BackoffUntil),
 		"active_markets_count":   len(state.ActiveMarkets),
 		"pending_orders_count":   len(state.PendingOrders),
 		"wallet_address":         s.botAddress(),
+		"run_id":                 s.bot.RunID(),
+		"paused":                 state.Paused,
 		"balance_warning":        !hasSufficient,
-		"balance_error_count":    0,
+		"balance_error_count":    state.ErrorsByKind[string(apierr.InsufficientBalance)],
 		"min_balance_needed":     minBalanceNeeded,
+		"clob":                   s.bot.ClobHealth(),
+		"native_usdc_balance":    round2(state.NativeUSDC),
+		"proxy_usdc_balance":     state.ProxyUSDC,
+		"yield_usdc_balance":     state.YieldUSDC,
+		"funding_alert":          state.FundingAlert,
+		"matic_balance":          round3(state.MaticBalance),
+		"min_matic":              s.cfg.MinMatic,
+		"gas_alert":              state.GasAlert,
+		"gas_today_matic":        round3(state.GasTodayMatic),
+		"gas_today_usd":          round2(state.GasTodayUSD),
+		"gas_daily_budget_usd":   s.cfg.GasDailyBudgetUSD,
+		"reconciliation":         state.Reconciliation,
+		"net_delta":              state.NetDelta,
+		"max_net_delta_usd":      s.cfg.MaxNetDeltaUSD,
+		"strategy_cooldowns":     state.Cooldowns,
+		"non_scoring_orders":     nonScoring(state.OrderScoring),
+		"pending_resolutions":    s.pendingResolutions(state.PendingResolutions),
 	}
 	writeJSON(w, resp)
 }
 
+// pendingResolution is an ended market waiting on its outcome, with a link.
+type pendingResolution struct {
+	models.PendingResolution
+	MarketURL string `json:"market_url,omitempty"`
+}
+
+func (s *Server) pendingResolutions(pending []models.PendingResolution) []pendingResolution {
+	rows := make([]pendingResolution, 0, len(pending))
+	for _, p := range pending {
+		rows = append(rows, pendingResolution{PendingResolution: p, MarketURL: s.cfg.MarketURL(p.MarketSlug)})
+	}
+	return rows
+}
+
+// scoringOf is an order's reward scoring as a JSON value: nil until the bot
+// has checked it.
+func scoringOf(scoring map[string]bool, orderID string) any {
+	if ok, seen := scoring[orderID]; seen {
+		return ok
+	}
+	return nil
+}
+
+func nonScoring(scoring map[string]bool) int {
+	n := 0
+	for _, ok := range scoring {
+		if !ok {
+			n++
+		}
+	}
+	return n
+}
+
 func (s *Server) botAddress() string {
 	return s.bot.WalletAddress()
 }
 
+func (s *Server) handleRPCHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]any{"endpoints": s.bot.RPCHealth()})
+}
+
+func (s *Server) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	type row struct {
+		bot.TxJob
+		TxURL string `json:"tx_url,omitempty"`
+	}
+	jobs := s.bot.TxJobs()
+	rows := make([]row, 0, len(jobs))
+	for _, j := range jobs {
+		rows = append(rows, row{TxJob: j, TxURL: s.cfg.TxURL(j.TxHash)})
+	}
+	writeJSON(w, map[string]any{"transactions": rows})
+}
+
+// handleReconciliation serves the latest wallet reconciliation report, or
+// null before the first pass.
+func (s *Server) handleReconciliation(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]any{"reconciliation": s.bot.GetState().Reconciliation})
+}
+
+// handleArbitrage serves the mispriced complements from the latest scan.
+func (s *Server) handleArbitrage(w http.ResponseWriter, r *http.Request) {
+	signals := s.bot.GetState().ArbSignals
+	if signals == nil {
+		signals = []models.ArbSignal{}
+	}
+	writeJSON(w, map[string]any{"signals": signals, "fee": s.cfg.ArbFee})
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]any{"loop": s.bot.LoopMetrics(), "memory": s.bot.MemoryStats()})
+}
+
 func (s *Server) handleMarkets(w http.ResponseWriter, r *http.Request) {
 	state := s.bot.GetState()
 	now := time.Now()
@@ -111,6 +228,7 @@ func (s *Server) handleMarkets(w http.ResponseWriter, r *http.Request) {
 		sec := m.TimeUntilStart(now).Seconds()
 		markets = append(markets, map[string]any{
 			"market_slug":                m.MarketSlug,
+			"market_url":                 s.cfg.MarketURL(m.MarketSlug),
 			"question":                   m.Question,
 			"start_timestamp":            m.StartTS,
 			"start_datetime":             startIso,
@@ -121,6 +239,11 @@ func (s *Server) handleMarkets(w http.ResponseWriter, r *http.Request) {
 			"is_resolved":                m.IsResolved,
 			"outcomes":                   outcomesForAPI(m.Outcomes),
 			"orders_placed":              s.bot.OrdersPlaced(m.ConditionID),
+			"volume":                     round2(m.Volume),
+			"liquidity":                  round2(m.Liquidity),
+			"tags":                       m.Tags,
+			"resolution_source":          m.ResolutionSource,
+			"uma_resolution_status":      m.UMAResolutionStatus,
 		})
 	}
 	sort.Slice(markets, func(i, j int) bool {
@@ -150,18 +273,46 @@ func outcomesForAPI(outs []models.Outcome) []map[string]any {
 			"price":    p,
 			"best_bid": bb,
 			"best_ask": ba,
+			"price_at": timeOrNil(o.PriceAt),
 		})
 	}
 	return res
 }
 
+// handleOrders lists pending orders (live state) and recent orders (full
+// history file), both filtered and paginated per listFilter.
 func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request) {
+	f, err := parseListFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	state := s.bot.GetState()
-	var pending []map[string]any
+
+	var pendingAll []models.OrderRecord
 	for _, o := range state.PendingOrders {
+		if f.matchOrder(o) {
+			pendingAll = append(pendingAll, o)
+		}
+	}
+	history, err := loadHistoryFile("order_history.json")
+	if err != nil {
+		history = state.RecentOrders
+	}
+	var recentAll []models.OrderRecord
+	for _, o := range history {
+		if f.matchOrder(o) {
+			recentAll = append(recentAll, o)
+		}
+	}
+	sort.SliceStable(recentAll, func(i, j int) bool { return recentAll[i].CreatedAt.After(recentAll[j].CreatedAt) })
+
+	var pending []map[string]any
+	for _, o := range page(pendingAll, f) {
 		pending = append(pending, map[string]any{
 			"order_id":    shorten(o.OrderID),
 			"market_slug": o.MarketSlug,
+			"market_url":  s.cfg.MarketURL(o.MarketSlug),
 			"outcome":     o.Outcome,
 			"side":        string(o.Side),
 			"price":       round3(o.Price),
@@ -169,15 +320,19 @@ func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request) {
 			"size_usd":    round2(o.SizeUSD),
 			"status":      string(o.Status),
 			"strategy":    o.Strategy,
+			"phase":       o.Phase,
 			"created_at":  o.CreatedAt.Format(time.RFC3339Nano),
 			"filled_at":   timeOrNil(o.FilledAt),
+			"scoring":     scoringOf(state.OrderScoring, o.OrderID),
 		})
 	}
 	var recent []map[string]any
-	for _, o := range state.RecentOrders {
+	for _, o := range page(recentAll, f) {
 		recent = append(recent, map[string]any{
 			"order_id":      shorten(o.OrderID),
 			"market_slug":   o.MarketSlug,
+			"market_url":    s.cfg.MarketURL(o.MarketSlug),
+			"tx_url":        s.cfg.TxURL(o.TxHash),
 			"outcome":       o.Outcome,
 			"side":          string(o.Side),
 			"price":         round3(o.Price),
@@ -185,15 +340,20 @@ func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request) {
 			"size_usd":      round2(o.SizeUSD),
 			"status":        string(o.Status),
 			"strategy":      o.Strategy,
+			"phase":         o.Phase,
 			"created_at":    o.CreatedAt.Format(time.RFC3339Nano),
 			"filled_at":     timeOrNil(o.FilledAt),
 			"error_message": o.ErrorMessage,
 		})
-		if len(recent) >= 100 {
-			break
-		}
 	}
-	writeJSON(w, map[string]any{"pending_orders": pending, "recent_orders": recent})
+	writeJSON(w, map[string]any{
+		"pending_orders": pending,
+		"recent_orders":  recent,
+		"pending_total":  len(pendingAll),
+		"recent_total":   len(recentAll),
+		"limit":          f.limit,
+		"offset":         f.offset,
+	})
 }
 
 func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
@@ -211,19 +371,39 @@ func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleMarketHistory(w http.ResponseWriter, r *http.Request) {
+	f, err := parseListFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	orders, _ := loadHistoryFile("order_history.json")
+	notes := marketNotes(orders)
+	resolved, _ := outcomes.Load(outcomes.DefaultFile)
+	byMarket := map[string][]models.OrderRecord{}
+	for _, o := range orders {
+		byMarket[o.ConditionID] = append(byMarket[o.ConditionID], o)
+	}
 	type agg struct {
 		marketSlug string
 		strategy   string
 		createdAt  time.Time
 		totalCost  float64
 		totalRev   float64
+		gas        float64
 		filled     int
 		total      int
 		open       bool
 	}
 	by := map[string]*agg{}
+	// Gas of swaps and top-ups belongs to no market.
+	var overheadGas float64
 	for _, o := range orders {
+		if o.ConditionID == "" {
+			if o.GasUSD != nil {
+				overheadGas += *o.GasUSD
+			}
+			continue
+		}
 		a := by[o.ConditionID]
 		if a == nil {
 			a = &agg{marketSlug: o.MarketSlug, strategy: deref(o.Strategy, "None"), createdAt: o.CreatedAt}
@@ -241,6 +421,9 @@ func (s *Server) handleMarketHistory(w http.ResponseWriter, r *http.Request) {
 		if o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusPartiallyFilled {
 			a.open = true
 		}
+		if o.GasUSD != nil {
+			a.gas += *o.GasUSD
+		}
 		if (o.Status == models.OrderStatusFilled || o.Status == models.OrderStatusPartiallyFilled) && o.Side == models.OrderSideBuy {
 			if o.CostUSD != nil {
 				a.totalCost += *o.CostUSD
@@ -259,59 +442,198 @@ func (s *Server) handleMarketHistory(w http.ResponseWriter, r *http.Request) {
 
 	type row struct {
 		MarketSlug   string  `json:"market_slug"`
+		MarketURL    string  `json:"market_url"`
 		ConditionID  string  `json:"condition_id"`
 		Strategy     string  `json:"strategy"`
 		Status       string  `json:"status"`
 		Result       string  `json:"result"`
 		TotalCost    float64 `json:"total_cost"`
 		TotalRevenue float64 `json:"total_revenue"`
+		GasCost      float64 `json:"gas_cost"`
 		PNL          float64 `json:"pnl"`
 		FilledCount  int     `json:"filled_count"`
 		TotalCount   int     `json:"total_count"`
 		CreatedAt    string  `json:"created_at"`
+
+		WinningOutcome string `json:"winning_outcome,omitempty"`
+		HeldWinner     *bool  `json:"held_winner,omitempty"`
+
+		Notes []models.Annotation `json:"notes"`
 	}
 	var rows []row
 	for cid, a := range by {
+		if !f.matchCommon(a.strategy, a.marketSlug, cid, a.createdAt) {
+			continue
+		}
 		status := fmt.Sprintf("FILLED %d/%d", a.filled, a.total)
 		result := "N/A"
 		if a.open {
 			result = "OPEN"
 		} else if a.totalRev > 0 {
-			if a.totalRev >= a.totalCost {
+			if a.totalRev >= a.totalCost+a.gas {
 				result = "SUCCESS"
 			} else {
 				result = "FAILED"
 			}
 		}
-		pnl := a.totalRev - a.totalCost
+		if f.status != "" && result != f.status {
+			continue
+		}
+		pnl := a.totalRev - a.totalCost - a.gas
 		if a.open {
 			a.totalCost = 0
 			a.totalRev = 0
+			a.gas = 0
 			pnl = 0
 		}
 		rows = append(rows, row{
 			MarketSlug:   a.marketSlug,
+			MarketURL:    s.cfg.MarketURL(a.marketSlug),
 			ConditionID:  cid,
 			Strategy:     a.strategy,
 			Status:       status,
 			Result:       result,
 			TotalCost:    round2(a.totalCost),
 			TotalRevenue: round2(a.totalRev),
+			GasCost:      round2(a.gas),
 			PNL:          round2(pnl),
 			FilledCount:  a.filled,
 			TotalCount:   a.total,
 			CreatedAt:    a.createdAt.Format(time.RFC3339Nano),
+			Notes:        notes[cid],
+
+			WinningOutcome: resolved[cid].WinningOutcome,
+			HeldWinner:     heldWinner(byMarket[cid], resolved[cid]),
 		})
 	}
 	sort.Slice(rows, func(i, j int) bool { return rows[i].CreatedAt > rows[j].CreatedAt })
-	if len(rows) > 100 {
-		rows = rows[:100]
+	writeJSON(w, map[string]any{
+		"markets": page(rows, f),
+		"total":   len(rows),
+		"limit":   f.limit,
+		"offset":  f.offset,
+
+		"unattributed_gas_usd": round2(overheadGas),
+	})
+}
+
+// handleAnnotations lists journal notes (GET, optional ?condition_id= / ?order_id=)
+// or appends one (POST with a models.Annotation JSON body).
+func (s *Server) handleAnnotations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		all, err := journal.Load(journal.DefaultFile)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		cid := r.URL.Query().Get("condition_id")
+		oid := r.URL.Query().Get("order_id")
+		out := make([]models.Annotation, 0, len(all))
+		for _, a := range all {
+			if cid != "" && a.ConditionID != cid {
+				continue
+			}
+			if oid != "" && a.OrderID != oid {
+				continue
+			}
+			out = append(out, a)
+		}
+		writeJSON(w, map[string]any{"annotations": out})
+	case http.MethodPost:
+		// Reads are open like the other views; writes go to the persisted
+		// journal, so they need CONTROL_TOKEN.
+		if !hasToken(s.cfg.ControlToken, r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var a models.Annotation
+		if err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&a); err != nil {
+			http.Error(w, "invalid annotation body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		a.CreatedAt = time.Time{}
+		saved, err := journal.Append(journal.DefaultFile, a)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, saved)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// marketNotes loads the trade journal keyed by condition id (best-effort).
+func marketNotes(orders []models.OrderRecord) map[string][]models.Annotation {
+	all, err := journal.Load(journal.DefaultFile)
+	if err != nil || len(all) == 0 {
+		return map[string][]models.Annotation{}
 	}
-	writeJSON(w, map[string]any{"markets": rows})
+	orderMarkets := make(map[string]string, len(orders))
+	for _, o := range orders {
+		orderMarkets[o.OrderID] = o.ConditionID
+	}
+	return journal.ByMarket(all, orderMarkets)
+}
+
+// heldWinner reports whether the market ended with a net position in the
+// winning outcome (filled buys minus sells and merges). Nil while unresolved.
+func heldWinner(orders []models.OrderRecord, res models.MarketOutcome) *bool {
+	if res.WinningOutcome == "" {
+		return nil
+	}
+	var net float64
+	for _, o := range orders {
+		if o.Status != models.OrderStatusFilled && o.Status != models.OrderStatusPartiallyFilled {
+			continue
+		}
+		size := o.Size
+		if o.SizeMatched != nil && *o.SizeMatched > 0 {
+			size = *o.SizeMatched
+		}
+		switch {
+		case o.TransactionType == "MERGE":
+			net -= size
+		case !strings.EqualFold(o.Outcome, res.WinningOutcome):
+		case o.Side == models.OrderSideBuy:
+			net += size
+		case o.Side == models.OrderSideSell:
+			net -= size
+		}
+	}
+	held := net > 0.01
+	return &held
+}
+
+// classifyMarket: a market succeeds if both sides filled (merge arbitrage) or
+// it ended holding the winner; once resolved, holding only the loser fails.
+func classifyMarket(ords []models.OrderRecord, res models.MarketOutcome) bool {
+	var yes, no float64
+	for _, o := range ords {
+		if o.Status != models.OrderStatusFilled && o.Status != models.OrderStatusPartiallyFilled {
+			continue
+		}
+		u := strings.ToUpper(strings.TrimSpace(o.Outcome))
+		if u == "YES" || u == "UP" {
+			yes += o.Size
+		}
+		if u == "NO" || u == "DOWN" {
+			no += o.Size
+		}
+	}
+	if yes > 0 && no > 0 {
+		return true
+	}
+	if held := heldWinner(ords, res); held != nil {
+		return *held
+	}
+	return false
 }
 
 func (s *Server) handleStatistics(w http.ResponseWriter, r *http.Request) {
 	orders, _ := loadHistoryFile("order_history.json")
+	resolved, _ := outcomes.Load(outcomes.DefaultFile)
 	by := map[string][]models.OrderRecord{}
 	var pnl float64
 	for _, o := range orders {
@@ -323,36 +645,41 @@ func (s *Server) handleStatistics(w http.ResponseWriter, r *http.Request) {
 	totalMarkets := len(by)
 	success := 0
 	fail := 0
-	for _, ords := range by {
-		var yes, no float64
-		for _, o := range ords {
-			if o.Status != models.OrderStatusFilled && o.Status != models.OrderStatusPartiallyFilled {
-				continue
-			}
-			u := strings.ToUpper(strings.TrimSpace(o.Outcome))
-			if u == "YES" || u == "UP" {
-				yes += o.Size
-			}
-			if u == "NO" || u == "DOWN" {
-				no += o.Size
-			}
-		}
-		if yes > 0 && no > 0 {
+	resolvedCount := 0
+	heldWinners := 0
+	for cid, ords := range by {
+		if classifyMarket(ords, resolved[cid]) {
 			success++
 		} else {
 			fail++
 		}
+		if held := heldWinner(ords, resolved[cid]); held != nil {
+			resolvedCount++
+			if *held {
+				heldWinners++
+			}
+		}
 	}
+	days, _ := rewards.Load(rewards.DefaultFile)
+	earned := rewards.Total(days)
+	snaps, _ := snapshots.Load(snapshots.DefaultFile)
+	ledger, _ := cashflows.Load(cashflows.DefaultFile)
 	writeJSON(w, map[string]any{
 		"total_markets":       totalMarkets,
 		"successful_trades":   success,
 		"unsuccessful_trades": fail,
+		"resolved_markets":    resolvedCount,
+		"held_winner_markets": heldWinners,
 		"total_pnl":           round2(pnl),
+		"rewards_usd":         round2(earned),
+		"pnl_with_rewards":    round2(pnl + earned),
+		"capital":             capitalSummary(snaps, days, ledger, s.cfg.MaticUSDPrice),
 	})
 }
 
 func (s *Server) handleStrategyStatistics(w http.ResponseWriter, r *http.Request) {
 	orders, _ := loadHistoryFile("order_history.json")
+	resolved, _ := outcomes.Load(outcomes.DefaultFile)
 	byStrat := map[string][]models.OrderRecord{}
 	for _, o := range orders {
 		byStrat[deref(o.Strategy, "None")] = append(byStrat[deref(o.Strategy, "None")], o)
@@ -376,21 +703,8 @@ func (s *Server) handleStrategyStatistics(w http.ResponseWriter, r *http.Request
 		}
 		success := 0
 		fail := 0
-		for _, mos := range byMarket {
-			var yes, no float64
-			for _, o := range mos {
-				if o.Status != models.OrderStatusFilled && o.Status != models.OrderStatusPartiallyFilled {
-					continue
-				}
-				u := strings.ToUpper(strings.TrimSpace(o.Outcome))
-				if u == "YES" || u == "UP" {
-					yes += o.Size
-				}
-				if u == "NO" || u == "DOWN" {
-					no += o.Size
-				}
-			}
-			if yes > 0 && no > 0 {
+		for cid, mos := range byMarket {
+			if classifyMarket(mos, resolved[cid]) {
 				success++
 			} else {
 				fail++
@@ -408,6 +722,189 @@ func (s *Server) handleStrategyStatistics(w http.ResponseWriter, r *http.Request
 	writeJSON(w, map[string]any{"strategies": rows})
 }
 
+// handlePhaseStatistics breaks fills down by the market phase each order was
+// placed in, so it's visible which phases make money and which bleed it.
+// Records from before phase tagging are grouped under "untagged"; merges and
+// redemptions carry no phase and are left out.
+func (s *Server) handlePhaseStatistics(w http.ResponseWriter, r *http.Request) {
+	orders, _ := loadHistoryFile("order_history.json")
+	type row struct {
+		Phase      string  `json:"phase"`
+		Orders     int     `json:"orders"`
+		Filled     int     `json:"filled"`
+		CostUSD    float64 `json:"cost_usd"`
+		RevenueUSD float64 `json:"revenue_usd"`
+		NetUSD     float64 `json:"net_usd"`
+	}
+	byPhase := map[string]*row{}
+	for _, o := range orders {
+		if o.TransactionType == "MERGE" || o.TransactionType == "REDEEM" || o.TransactionType == "GAS" {
+			continue
+		}
+		phase := o.Phase
+		if phase == "" {
+			phase = "untagged"
+		}
+		rw := byPhase[phase]
+		if rw == nil {
+			rw = &row{Phase: phase}
+			byPhase[phase] = rw
+		}
+		rw.Orders++
+		matched := filledSize(o)
+		if matched <= 0 {
+			continue
+		}
+		rw.Filled++
+		if o.Side == models.OrderSideBuy {
+			rw.CostUSD += o.Price * matched
+		} else {
+			rw.RevenueUSD += o.Price * matched
+		}
+	}
+	rows := make([]row, 0, len(byPhase))
+	for _, rw := range byPhase {
+		rw.NetUSD = round2(rw.RevenueUSD - rw.CostUSD)
+		rw.CostUSD = round2(rw.CostUSD)
+		rw.RevenueUSD = round2(rw.RevenueUSD)
+		rows = append(rows, *rw)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Phase < rows[j].Phase })
+	writeJSON(w, map[string]any{"phases": rows})
+}
+
+// fillDistanceBuckets are upper bounds (in price units) of |price - mid| at placement.
+var fillDistanceBuckets = []struct {
+	label string
+	max   float64
+}{
+	{"0-1c", 0.01},
+	{"1-2c", 0.02},
+	{"2-3c", 0.03},
+	{"3-5c", 0.05},
+	{"5-10c", 0.10},
+	{"10c+", math.Inf(1)},
+}
+
+// handleFillStatistics reports time-to-first-fill and fill ratio per strategy,
+// bucketed by how far from mid each limit order was placed.
+func (s *Server) handleFillStatistics(w http.ResponseWriter, r *http.Request) {
+	orders, _ := loadHistoryFile("order_history.json")
+
+	type bucket struct {
+		Distance       string  `json:"distance"`
+		Orders         int     `json:"orders"`
+		Filled         int     `json:"filled"`
+		FillRatio      float64 `json:"fill_ratio"`
+		AvgFillSeconds float64 `json:"avg_fill_seconds"`
+		P50FillSeconds float64 `json:"p50_fill_seconds"`
+		P90FillSeconds float64 `json:"p90_fill_seconds"`
+		latencies      []float64
+	}
+	type row struct {
+		StrategyName   string    `json:"strategy_name"`
+		Orders         int       `json:"orders"`
+		Filled         int       `json:"filled"`
+		FillRatio      float64   `json:"fill_ratio"`
+		AvgFillSeconds float64   `json:"avg_fill_seconds"`
+		P50FillSeconds float64   `json:"p50_fill_seconds"`
+		P90FillSeconds float64   `json:"p90_fill_seconds"`
+		ByDistance     []*bucket `json:"by_distance"`
+		latencies      []float64
+	}
+	finish := func(orders, filled int, lat []float64) (ratio, avg, p50, p90 float64) {
+		if orders > 0 {
+			ratio = round3(float64(filled) / float64(orders))
+		}
+		if len(lat) == 0 {
+			return ratio, 0, 0, 0
+		}
+		sort.Float64s(lat)
+		var sum float64
+		for _, v := range lat {
+			sum += v
+		}
+		return ratio, round2(sum / float64(len(lat))), round2(percentile(lat, 0.5)), round2(percentile(lat, 0.9))
+	}
+
+	byStrat := map[string]*row{}
+	for _, o := range orders {
+		if o.TransactionType != "BUY" && o.TransactionType != "SELL" {
+			continue
+		}
+		if o.OrderID == "FAILED" || o.Status == models.OrderStatusFailed {
+			continue
+		}
+		name := deref(o.Strategy, "None")
+		rw := byStrat[name]
+		if rw == nil {
+			rw = &row{StrategyName: name}
+			for _, b := range fillDistanceBuckets {
+				rw.ByDistance = append(rw.ByDistance, &bucket{Distance: b.label})
+			}
+			rw.ByDistance = append(rw.ByDistance, &bucket{Distance: "unknown"})
+			byStrat[name] = rw
+		}
+		bk := rw.ByDistance[len(rw.ByDistance)-1]
+		if o.MidAtPlacement != nil {
+			d := math.Abs(o.Price - *o.MidAtPlacement)
+			for i, b := range fillDistanceBuckets {
+				if d < b.max+1e-9 {
+					bk = rw.ByDistance[i]
+					break
+				}
+			}
+		}
+
+		rw.Orders++
+		bk.Orders++
+		filled := o.Status == models.OrderStatusFilled || o.Status == models.OrderStatusPartiallyFilled || (o.SizeMatched != nil && *o.SizeMatched > 0)
+		if !filled {
+			continue
+		}
+		rw.Filled++
+		bk.Filled++
+		first := o.FirstFillAt
+		if first == nil {
+			first = o.FilledAt
+		}
+		if first != nil && !o.CreatedAt.IsZero() && first.After(o.CreatedAt) {
+			secs := first.Sub(o.CreatedAt).Seconds()
+			rw.latencies = append(rw.latencies, secs)
+			bk.latencies = append(bk.latencies, secs)
+		}
+	}
+
+	rows := make([]*row, 0, len(byStrat))
+	for _, rw := range byStrat {
+		rw.FillRatio, rw.AvgFillSeconds, rw.P50FillSeconds, rw.P90FillSeconds = finish(rw.Orders, rw.Filled, rw.latencies)
+		kept := rw.ByDistance[:0]
+		for _, bk := range rw.ByDistance {
+			if bk.Orders == 0 {
+				continue
+			}
+			bk.FillRatio, bk.AvgFillSeconds, bk.P50FillSeconds, bk.P90FillSeconds = finish(bk.Orders, bk.Filled, bk.latencies)
+			kept = append(kept, bk)
+		}
+		rw.ByDistance = kept
+		rows = append(rows, rw)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].StrategyName < rows[j].StrategyName })
+	writeJSON(w, map[string]any{"strategies": rows})
+}
+
+// percentile expects sorted input.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
 func loadHistoryFile(path string) ([]models.OrderRecord, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -418,8 +915,8 @@ func loadHistoryFile(path string) ([]models.OrderRecord, error) {
 	if err != nil {
 		return nil, err
 	}
-	var arr []map[string]any
-	if err := json.Unmarshal(b, &arr); err != nil {
+	arr, err := bot.DecodeOrderHistory(b)
+	if err != nil {
 		return nil, err
 	}
 	var out []models.OrderRecord
@@ -451,12 +948,23 @@ func parseHistoryOrder(m map[string]any) (models.OrderRecord, error) {
 		Size:            asF(m["size"]),
 		SizeUSD:         asF(m["size_usd"]),
 		Status:          models.OrderStatus(asStr(m["status"])),
+		TokenID:         asStr(m["token_id"]),
+		ErrorMessage:    strPtrOrNil(m["error_message"]),
 		CreatedAt:       created,
 		TransactionType: asStr(m["transaction_type"]),
 		Strategy:        strPtrOrNil(m["strategy"]),
 		PNLUSD:          floatPtrOrNil(m["pnl_usd"]),
 		CostUSD:         floatPtrOrNil(m["cost_usd"]),
 		RevenueUSD:      floatPtrOrNil(m["revenue_usd"]),
+		SizeMatched:     floatPtrOrNil(m["size_matched"]),
+		FilledAt:        timePtrOrNil(m["filled_at"]),
+		FirstFillAt:     timePtrOrNil(m["first_fill_at"]),
+		MidAtPlacement:  floatPtrOrNil(m["mid_at_placement"]),
+		Phase:           asStr(m["phase"]),
+		FillPrice:       floatPtrOrNil(m["fill_price"]),
+		GasUSD:          floatPtrOrNil(m["gas_usd"]),
+		RunID:           asStr(m["run_id"]),
+		TxHash:          asStr(m["tx_hash"]),
 	}, nil
 }
 
@@ -502,6 +1010,18 @@ func strPtrOrNil(v any) *string {
 	return &s
 }
 
+func timePtrOrNil(v any) *time.Time {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
 func timeOrNil(t *time.Time) any {
 	if t == nil {
 		return nil
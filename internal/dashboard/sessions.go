@@ -0,0 +1,71 @@
+package dashboard
+
+import (
+	"net/http"
+	"time"
+
+	"limitorderbot/internal/runs"
+)
+
+// handleSessions lists recorded runs newest first with their duration, error
+// count and the PnL of the orders they placed, for before/after comparisons
+// across deployments. Orders from before run tagging belong to no session.
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	all, err := runs.Load(runs.DefaultFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	orders, _ := loadHistoryFile("order_history.json")
+	type totals struct {
+		orders, filled int
+		pnl            float64
+	}
+	byRun := map[string]*totals{}
+	for _, o := range orders {
+		if o.RunID == "" {
+			continue
+		}
+		t := byRun[o.RunID]
+		if t == nil {
+			t = &totals{}
+			byRun[o.RunID] = t
+		}
+		t.orders++
+		if o.SizeMatched != nil && *o.SizeMatched > 0 {
+			t.filled++
+		}
+		if o.PNLUSD != nil {
+			t.pnl += *o.PNLUSD
+		}
+	}
+
+	current := s.bot.RunID()
+	now := time.Now()
+	sessions := make([]map[string]any, 0, len(all))
+	for i := len(all) - 1; i >= 0; i-- {
+		run := all[i]
+		end := run.End()
+		errs := run.ErrorCount
+		if run.ID == current {
+			end = now
+			errs = s.bot.GetState().ErrorCount
+		}
+		t := byRun[run.ID]
+		if t == nil {
+			t = &totals{}
+		}
+		sessions = append(sessions, map[string]any{
+			"run_id":           run.ID,
+			"started_at":       run.StartedAt,
+			"ended_at":         run.EndedAt,
+			"current":          run.ID == current,
+			"duration_seconds": int(end.Sub(run.StartedAt).Seconds()),
+			"error_count":      errs,
+			"orders":           t.orders,
+			"filled_orders":    t.filled,
+			"total_pnl":        round2(t.pnl),
+		})
+	}
+	writeJSON(w, map[string]any{"sessions": sessions})
+}
@@ -0,0 +1,64 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"limitorderbot/internal/bot"
+)
+
+// maxParamsBody bounds a PUT /api/strategies body.
+const maxParamsBody = 64 << 10
+
+// registerStrategies serves the live parameter editor when CONTROL_TOKEN is
+// set; without it nobody could be told apart from a stranger on the port.
+// Every other state-changing route (registerControl, journal writes in
+// handleAnnotations) checks the same token.
+func (s *Server) registerStrategies(mux *http.ServeMux) {
+	if s.cfg.ControlToken == "" {
+		return
+	}
+	mux.Handle("/api/strategies", requireToken(s.cfg.ControlToken, http.HandlerFunc(s.handleStrategies)))
+}
+
+// handleStrategies returns the live strategy parameters (GET) or changes
+// them (PUT with a partial body in the same shape). A PUT answers with the
+// parameters now in effect and what changed.
+func (s *Server) handleStrategies(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, s.bot.StrategyParams())
+	case http.MethodPut:
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxParamsBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		p, err := s.bot.StrategyParams().Merge(body)
+		if err != nil {
+			http.Error(w, "invalid body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Waits for the loop to pick the change up between passes.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		changes, err := s.bot.UpdateStrategyParams(ctx, p, "dashboard "+r.RemoteAddr)
+		switch {
+		case errors.Is(err, bot.ErrInvalidParams):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusGatewayTimeout)
+			return
+		}
+		if changes == nil {
+			changes = []bot.ParamChange{}
+		}
+		writeJSON(w, map[string]any{"params": s.bot.StrategyParams(), "changes": changes})
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,91 @@
+package decisions
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultFile is the audit log of placement decisions served by /api/decisions.
+const DefaultFile = "placement_decisions.json"
+
+// maxEntries bounds the log; decisions are only recorded when they change, so
+// this covers weeks of 15-minute markets.
+const maxEntries = 5000
+
+// Actions a decision can take.
+const (
+	Placed  = "placed"
+	Skipped = "skipped"
+	Failed  = "failed"
+)
+
+// Reasons for skipped or failed placements.
+const (
+	ReasonWindow   = "window"    // outside the placement window
+	ReasonBusy     = "busy"      // live orders or unmerged positions elsewhere
+	ReasonBalance  = "balance"   // not enough USDC.e
+	ReasonBudget   = "budget"    // strategy capital budget exhausted
+	ReasonCooldown = "cooldown"  // strategy paused after a losing streak
+	ReasonPaused   = "paused"    // bot paused by the operator (panic)
+	ReasonNoEdge   = "no_edge"   // book doesn't offer the required edge
+	ReasonMinimum  = "minimum"   // order below the CLOB minimums or off its lot size
+	ReasonThinBook = "thin_book" // book depth near the mid below MIN_BOOK_DEPTH_USD
+	ReasonError    = "error"     // anything else the placement returned
+)
+
+type Decision struct {
+	Time        time.Time `json:"time"`
+	ConditionID string    `json:"condition_id"`
+	MarketSlug  string    `json:"market_slug"`
+	Strategy    string    `json:"strategy,omitempty"`
+	Action      string    `json:"action"`
+	Reason      string    `json:"reason,omitempty"`
+	Detail      string    `json:"detail,omitempty"`
+	Orders      int       `json:"orders,omitempty"`
+}
+
+var mu sync.Mutex
+
+// Load returns all decisions in time order. A missing file is empty.
+func Load(path string) ([]Decision, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load(path)
+}
+
+// Append adds a decision, dropping the oldest beyond maxEntries.
+func Append(path string, d Decision) error {
+	mu.Lock()
+	defer mu.Unlock()
+	all, err := load(path)
+	if err != nil {
+		return err
+	}
+	all = append(all, d)
+	if len(all) > maxEntries {
+		all = all[len(all)-maxEntries:]
+	}
+	bts, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bts, 0o644)
+}
+
+func load(path string) ([]Decision, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var all []Decision
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,120 @@
+// Package fillprob estimates how likely a resting quote is to fill, from the
+// bot's own order history: each BUY/SELL records its distance from the book
+// mid at placement (MidAtPlacement) and whether it ever filled. Orders are
+// bucketed by that distance and by time to market start when placed.
+package fillprob
+
+import (
+	"math"
+	"time"
+
+	"limitorderbot/internal/models"
+)
+
+// DistanceEdges are upper bounds (price units) of |price - mid|; the last
+// bucket is open-ended. They match the dashboard's fill statistics.
+var DistanceEdges = []float64{0.01, 0.02, 0.03, 0.05, 0.10}
+
+// StartEdges are upper bounds of the time from placement to market start;
+// orders placed after the start fall in the first bucket.
+var StartEdges = []time.Duration{2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 30 * time.Minute}
+
+// priorWeight pulls sparse buckets toward the coarser estimate, as if that
+// many orders had filled at the coarser rate.
+const priorWeight = 5
+
+type cell struct{ orders, filled int }
+
+// Estimator is a fitted fill-rate table; the zero value estimates 0.5 with
+// no samples.
+type Estimator struct {
+	all        cell
+	byDistance []cell
+	cells      [][]cell // [distance][start]
+}
+
+// StartFunc reports when an order's market starts.
+type StartFunc func(o models.OrderRecord) (time.Time, bool)
+
+// Fit builds an estimator from order history. Failed orders, orders without
+// a recorded mid and non-trade records (merges, redemptions) are ignored.
+func Fit(history []models.OrderRecord, startOf StartFunc) *Estimator {
+	e := &Estimator{
+		byDistance: make([]cell, len(DistanceEdges)+1),
+		cells:      make([][]cell, len(DistanceEdges)+1),
+	}
+	for i := range e.cells {
+		e.cells[i] = make([]cell, len(StartEdges)+1)
+	}
+	for _, o := range history {
+		if o.TransactionType != "BUY" && o.TransactionType != "SELL" {
+			continue
+		}
+		if o.OrderID == "FAILED" || o.Status == models.OrderStatusFailed || o.MidAtPlacement == nil {
+			continue
+		}
+		// Still resting: the outcome isn't known yet.
+		if o.Status == models.OrderStatusPlaced && (o.SizeMatched == nil || *o.SizeMatched == 0) {
+			continue
+		}
+		d := distanceBucket(math.Abs(o.Price - *o.MidAtPlacement))
+		s := 0
+		if start, ok := startOf(o); ok && !o.CreatedAt.IsZero() {
+			s = startBucket(start.Sub(o.CreatedAt))
+		}
+		filled := 0
+		if o.Status == models.OrderStatusFilled || o.Status == models.OrderStatusPartiallyFilled || (o.SizeMatched != nil && *o.SizeMatched > 0) {
+			filled = 1
+		}
+		for _, c := range []*cell{&e.all, &e.byDistance[d], &e.cells[d][s]} {
+			c.orders++
+			c.filled += filled
+		}
+	}
+	return e
+}
+
+// Estimate returns the probability that a quote distance away from the mid,
+// placed timeToStart before the market starts, fills at least partly, and
+// how many historical orders that bucket holds. Sparse buckets lean on the
+// distance-only rate, which leans on the overall rate.
+func (e *Estimator) Estimate(distance float64, timeToStart time.Duration) (p float64, samples int) {
+	if e == nil || e.all.orders == 0 {
+		return 0.5, 0
+	}
+	overall := smooth(e.all, 0.5)
+	d := distanceBucket(math.Abs(distance))
+	byDist := smooth(e.byDistance[d], overall)
+	c := e.cells[d][startBucket(timeToStart)]
+	return smooth(c, byDist), c.orders
+}
+
+// Samples is the number of orders the estimator was fitted on.
+func (e *Estimator) Samples() int {
+	if e == nil {
+		return 0
+	}
+	return e.all.orders
+}
+
+func smooth(c cell, prior float64) float64 {
+	return (float64(c.filled) + priorWeight*prior) / (float64(c.orders) + priorWeight)
+}
+
+func distanceBucket(d float64) int {
+	for i, edge := range DistanceEdges {
+		if d < edge+1e-9 {
+			return i
+		}
+	}
+	return len(DistanceEdges)
+}
+
+func startBucket(tts time.Duration) int {
+	for i, edge := range StartEdges {
+		if tts < edge {
+			return i
+		}
+	}
+	return len(StartEdges)
+}
@@ -6,43 +6,145 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"limitorderbot/internal/apierr"
+	"limitorderbot/internal/clock"
+	"limitorderbot/internal/marketdata"
 	"limitorderbot/internal/models"
 )
 
+func init() {
+	marketdata.Register("gamma", func(opts marketdata.Options) (marketdata.Source, error) {
+		d := New(opts.GammaURL)
+		if opts.GammaHTTP != nil {
+			d.HTTP = opts.GammaHTTP
+		}
+		if opts.Clock != nil {
+			d.Clock = opts.Clock
+		}
+		if opts.Horizon != nil {
+			d.Horizon = opts.Horizon(slotLength)
+		}
+		return d, nil
+	})
+}
+
 type Discovery struct {
 	BaseURL string
 	HTTP    *http.Client
+
+	// ClobURL serves price history; optional for discovery itself.
+	ClobURL string
+	// Clock picks which 15-minute slots discovery looks at.
+	Clock clock.Clock
+	// Horizon is how far ahead of now those slots reach.
+	Horizon time.Duration
 }
 
+// slotLength is the period of the markets this source lists.
+const slotLength = 15 * time.Minute
+
 func New(baseURL string) *Discovery {
 	return &Discovery{
 		BaseURL: strings.TrimSuffix(baseURL, "/"),
 		HTTP:    &http.Client{Timeout: 10 * time.Second},
+		Clock:   clock.System,
+		Horizon: 48 * slotLength,
 	}
 }
 
+// Name, Discover and Resolution make Discovery a marketdata.Source.
+func (d *Discovery) Name() string { return "gamma" }
+
+func (d *Discovery) Discover(ctx context.Context) ([]models.Market, error) {
+	return d.DiscoverBTC15mMarkets(ctx)
+}
+
+func (d *Discovery) Resolution(ctx context.Context, m models.Market) (marketdata.Resolution, error) {
+	return d.FetchResolution(ctx, m.MarketSlug)
+}
+
 func (d *Discovery) DiscoverBTC15mMarkets(ctx context.Context) ([]models.Market, error) {
 	var out []models.Market
-	tsList := generate15MinTimestamps(time.Now(), 48)
+	var lastErr error
+	fetched := 0
+	tsList := generate15MinTimestamps(d.Clock.Now(), max(1, int(d.Horizon/slotLength)))
 	for _, ts := range tsList {
 		slug := fmt.Sprintf("btc-updown-15m-%d", ts)
 		ev, err := d.fetchEventBySlug(ctx, slug)
 		if err != nil {
+			lastErr = err
 			continue
 		}
+		fetched++
 		m, ok := parseMarket(ev)
 		if ok {
 			out = append(out, m)
 		}
 	}
+	// Every lookup failing means Gamma itself is unreachable, not that no
+	// markets are listed; report it so a fallback source can take over.
+	if fetched == 0 && lastErr != nil {
+		return nil, fmt.Errorf("gamma discovery: %w", lastErr)
+	}
 	// sort by start
 	sortMarketsByStart(out)
 	return out, nil
 }
 
+// FetchResolution looks up a market by event slug and reports its winner once
+// it is closed and one outcome price has settled to 1, and the UMA status
+// while it waits.
+func (d *Discovery) FetchResolution(ctx context.Context, slug string) (marketdata.Resolution, error) {
+	ev, err := d.fetchEventBySlug(ctx, slug)
+	if err != nil {
+		return marketdata.Resolution{}, err
+	}
+	actual := ev
+	if arr, ok := ev["markets"].([]any); ok && len(arr) > 0 {
+		if first, ok := arr[0].(map[string]any); ok {
+			actual = first
+		}
+	}
+	status := strField(actual, "umaResolutionStatus")
+	if status == "" {
+		status = strField(ev, "umaResolutionStatus")
+	}
+	if !asBool(actual["closed"]) && !asBool(ev["closed"]) {
+		return marketdata.Resolution{Status: status}, nil
+	}
+	prices := jsonList(actual["outcomePrices"])
+	outcomes := parseOutcomes(actual, ev)
+	for i, p := range prices {
+		var f float64
+		if _, err := fmt.Sscanf(asString(p), "%g", &f); err != nil || f < 0.99 {
+			continue
+		}
+		if i >= len(outcomes) {
+			break
+		}
+		return marketdata.Resolution{Resolved: true, WinningOutcome: outcomes[i].Outcome, WinningTokenID: outcomes[i].TokenID, Status: status}, nil
+	}
+	return marketdata.Resolution{Status: status}, nil
+}
+
+// jsonList accepts Gamma's list fields, which arrive either as JSON arrays or
+// as JSON-encoded strings.
+func jsonList(raw any) []any {
+	switch t := raw.(type) {
+	case []any:
+		return t
+	case string:
+		var out []any
+		_ = json.Unmarshal([]byte(t), &out)
+		return out
+	}
+	return nil
+}
+
 func generate15MinTimestamps(now time.Time, count int) []int64 {
 	// Round down to nearest 15-min mark, then start from next interval.
 	t := now.Truncate(time.Minute).Add(-time.Duration(now.Minute()%15) * time.Minute)
@@ -63,11 +165,11 @@ func (d *Discovery) fetchEventBySlug(ctx context.Context, slug string) (map[stri
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := d.HTTP.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, apierr.Wrap(apierr.Network, err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("gamma status=%d", resp.StatusCode)
+		return nil, apierr.FromStatus(resp.StatusCode, resp.Header, "", fmt.Errorf("gamma status=%d", resp.StatusCode))
 	}
 	var arr []any
 	if err := json.NewDecoder(resp.Body).Decode(&arr); err != nil {
@@ -131,7 +233,7 @@ func parseMarket(eventOrMarket map[string]any) (models.Market, bool) {
 	isActive := asBool(eventOrMarket["active"])
 	isResolved := asBool(eventOrMarket["closed"]) || asBool(eventOrMarket["resolved"])
 
-	return models.Market{
+	m := models.Market{
 		ConditionID: conditionID,
 		MarketSlug:  marketSlug,
 		Question:    question,
@@ -140,7 +242,73 @@ func parseMarket(eventOrMarket map[string]any) (models.Market, bool) {
 		Outcomes:    outcomes,
 		IsActive:    isActive,
 		IsResolved:  isResolved,
-	}, true
+	}
+	parseMetadata(&m, actual, eventOrMarket)
+	return m, true
+}
+
+// parseMetadata fills the listing fields, preferring the market object over
+// its event. Gamma sends numbers both as JSON numbers (volumeNum) and as
+// strings (volume).
+func parseMetadata(m *models.Market, actual, event map[string]any) {
+	for _, src := range []map[string]any{actual, event} {
+		if m.Volume == 0 {
+			m.Volume = firstFloat(src, "volumeNum", "volume")
+		}
+		if m.Liquidity == 0 {
+			m.Liquidity = firstFloat(src, "liquidityNum", "liquidity")
+		}
+		if m.ResolutionSource == "" {
+			m.ResolutionSource = strField(src, "resolutionSource")
+		}
+		if m.UMAResolutionStatus == "" {
+			m.UMAResolutionStatus = strField(src, "umaResolutionStatus")
+		}
+		if len(m.Tags) == 0 {
+			m.Tags = parseTags(src["tags"])
+		}
+	}
+}
+
+// parseTags takes event tags ({"label": "Crypto", "slug": "crypto"}) or plain
+// strings.
+func parseTags(raw any) []string {
+	var out []string
+	for _, t := range jsonList(raw) {
+		switch v := t.(type) {
+		case string:
+			out = append(out, v)
+		case map[string]any:
+			if l := strField(v, "label"); l != "" {
+				out = append(out, l)
+			} else if s := strField(v, "slug"); s != "" {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+func firstFloat(m map[string]any, keys ...string) float64 {
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case float64:
+			if v != 0 {
+				return v
+			}
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil && f != 0 {
+				return f
+			}
+		}
+	}
+	return 0
+}
+
+// strField is asString without the "<nil>" for a missing key.
+func strField(m map[string]any, k string) string {
+	s, _ := m[k].(string)
+	return s
 }
 
 func extractStartEnd(slug string, actual map[string]any, event map[string]any) (int64, int64) {
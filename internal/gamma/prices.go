@@ -0,0 +1,85 @@
+package gamma
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"limitorderbot/internal/apierr"
+	"limitorderbot/internal/models"
+)
+
+// pricesHistoryPath is the CLOB's public price history endpoint.
+const pricesHistoryPath = "/prices-history"
+
+// PricePoint is one sample of an outcome token's price.
+type PricePoint struct {
+	Time  time.Time `json:"t"`
+	Price float64   `json:"p"`
+}
+
+// GetPriceHistory returns a token's price over the trailing interval
+// ("1m", "1h", "6h", "1d", "1w" or "max") at one-minute fidelity, fine
+// enough to follow a 15-minute market.
+func (d *Discovery) GetPriceHistory(ctx context.Context, tokenID, interval string) ([]PricePoint, error) {
+	q := url.Values{}
+	q.Set("market", tokenID)
+	q.Set("interval", interval)
+	q.Set("fidelity", "1")
+	return d.fetchPriceHistory(ctx, q)
+}
+
+// GetPriceHistoryRange returns a token's price between start and end at
+// one-minute fidelity, for markets that have already ended.
+func (d *Discovery) GetPriceHistoryRange(ctx context.Context, tokenID string, start, end time.Time) ([]PricePoint, error) {
+	q := url.Values{}
+	q.Set("market", tokenID)
+	q.Set("startTs", strconv.FormatInt(start.Unix(), 10))
+	q.Set("endTs", strconv.FormatInt(end.Unix(), 10))
+	q.Set("fidelity", "1")
+	return d.fetchPriceHistory(ctx, q)
+}
+
+func (d *Discovery) fetchPriceHistory(ctx context.Context, q url.Values) ([]PricePoint, error) {
+	if d.ClobURL == "" {
+		return nil, errors.New("price history needs the CLOB API URL")
+	}
+	u := d.ClobURL + pricesHistoryPath + "?" + q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.HTTP.Do(req)
+	if err != nil {
+		return nil, apierr.Wrap(apierr.Network, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, apierr.FromStatus(resp.StatusCode, resp.Header, "", fmt.Errorf("clob prices-history status=%d", resp.StatusCode))
+	}
+	var body struct {
+		History []struct {
+			T int64   `json:"t"`
+			P float64 `json:"p"`
+		} `json:"history"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	out := make([]PricePoint, 0, len(body.History))
+	for _, h := range body.History {
+		out = append(out, PricePoint{Time: time.Unix(h.T, 0).UTC(), Price: h.P})
+	}
+	return out, nil
+}
+
+// WindowFromSlug recovers a market's start and end from its slug
+// (btc-updown-15m-{start}), for markets no longer tracked.
+func WindowFromSlug(slug string) (int64, int64, bool) {
+	return models.SlugWindow(slug)
+}
@@ -0,0 +1,83 @@
+package gasspend
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultFile holds the gas the bot's transactions paid, per UTC day.
+const DefaultFile = "gas_spend.json"
+
+// keepDays bounds how much history the file retains.
+const keepDays = 90
+
+// Day is one UTC day of gas spend. ByKind splits USD by tx job kind
+// (merge, redeem, swap, ...).
+type Day struct {
+	Date      string             `json:"date"`
+	Matic     float64            `json:"matic"`
+	USD       float64            `json:"usd"`
+	Txs       int                `json:"txs"`
+	ByKind    map[string]float64 `json:"by_kind_usd,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// Add books one mined transaction's gas.
+func (d *Day) Add(kind string, matic, usd float64, at time.Time) {
+	if d.ByKind == nil {
+		d.ByKind = map[string]float64{}
+	}
+	d.Matic += matic
+	d.USD += usd
+	d.Txs++
+	d.ByKind[kind] += usd
+	d.UpdatedAt = at
+}
+
+// DateKey is the UTC day a timestamp is attributed to.
+func DateKey(t time.Time) string { return t.UTC().Format("2006-01-02") }
+
+var mu sync.Mutex
+
+// Load reads all days keyed by date. A missing file is empty.
+func Load(path string) (map[string]*Day, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	out := map[string]*Day{}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return out, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Save writes days, dropping all but the most recent keepDays.
+func Save(path string, days map[string]*Day) error {
+	if len(days) > keepDays {
+		keys := make([]string, 0, len(days))
+		for k := range days {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys[:len(keys)-keepDays] {
+			delete(days, k)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	bts, err := json.MarshalIndent(days, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bts, 0o644)
+}
@@ -0,0 +1,87 @@
+// Package httpx builds the HTTP clients the bot uses for the CLOB, Gamma
+// and data-api, with configurable timeouts, connection pooling and retries.
+package httpx
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Options tunes one client. Zero values fall back to net/http defaults,
+// except Timeout, where zero means no overall request timeout.
+type Options struct {
+	Timeout time.Duration
+	// Retries is how many times an idempotent request (GET/HEAD) is retried
+	// after a network error, 429 or 5xx. Other methods are never retried:
+	// re-posting an order whose response was lost could place it twice.
+	Retries      int
+	RetryBackoff time.Duration
+
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	KeepAlive           time.Duration
+
+	// Trace, when set, receives a sanitized record of every round trip,
+	// labelled with Name.
+	Trace io.Writer
+	Name  string
+}
+
+func NewClient(o Options) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: o.KeepAlive}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DialContext = dialer.DialContext
+	if o.MaxIdleConns > 0 {
+		t.MaxIdleConns = o.MaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	}
+	if o.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = o.IdleConnTimeout
+	}
+	var rt http.RoundTripper = t
+	if o.Trace != nil {
+		rt = &tracing{next: rt, name: o.Name, out: o.Trace}
+	}
+	if o.Retries > 0 {
+		rt = &retrying{next: rt, retries: o.Retries, backoff: o.RetryBackoff}
+	}
+	return &http.Client{Timeout: o.Timeout, Transport: rt}
+}
+
+type retrying struct {
+	next    http.RoundTripper
+	retries int
+	backoff time.Duration
+}
+
+func (r *retrying) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return r.next.RoundTrip(req)
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := r.next.RoundTrip(req)
+		if attempt >= r.retries || !retryable(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(r.backoff * time.Duration(1<<attempt)):
+		}
+	}
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
@@ -0,0 +1,130 @@
+package httpx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxTraceBody caps how much of each request/response body is traced.
+const maxTraceBody = 2048
+
+// redactedHeaders carry credentials; their values never reach the trace.
+var redactedHeaders = map[string]bool{
+	"AUTHORIZATION":   true,
+	"COOKIE":          true,
+	"SET-COOKIE":      true,
+	"POLY_API_KEY":    true,
+	"POLY_PASSPHRASE": true,
+	"POLY_SIGNATURE":  true,
+}
+
+// secretFields matches JSON string fields holding API credentials, as
+// returned by the CLOB's create/derive api-key endpoints.
+var secretFields = regexp.MustCompile(`"(apiKey|api_key|secret|passphrase|privateKey|private_key)"\s*:\s*"[^"]*"`)
+
+var (
+	traceMu    sync.Mutex
+	traceFiles = map[string]*traceFile{}
+)
+
+type traceFile struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func (t *traceFile) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.f.Write(p)
+}
+
+// TraceFile opens (once per path) an append-only trace log that several
+// clients can share.
+func TraceFile(path string) (io.Writer, error) {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	if t, ok := traceFiles[path]; ok {
+		return t, nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	t := &traceFile{f: f}
+	traceFiles[path] = t
+	return t, nil
+}
+
+// tracing logs each round trip as one sanitized block: method, URL, status,
+// latency, headers with credentials redacted and truncated bodies.
+type tracing struct {
+	next http.RoundTripper
+	name string
+	out  io.Writer
+}
+
+func (t *tracing) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		reqBody, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s [%s] %s %s", start.Format(time.RFC3339Nano), t.name, req.Method, req.URL.Redacted())
+	if err != nil {
+		fmt.Fprintf(&sb, " error=%v latency=%s\n", err, latency)
+	} else {
+		fmt.Fprintf(&sb, " status=%d latency=%s\n", resp.StatusCode, latency)
+	}
+	writeHeaders(&sb, "> ", req.Header)
+	writeBody(&sb, "> ", reqBody)
+	if resp != nil {
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		writeHeaders(&sb, "< ", resp.Header)
+		writeBody(&sb, "< ", respBody)
+	}
+	sb.WriteString("\n")
+	_, _ = io.WriteString(t.out, sb.String())
+	return resp, err
+}
+
+func writeHeaders(sb *strings.Builder, prefix string, h http.Header) {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := strings.Join(h[k], ", ")
+		if redactedHeaders[strings.ToUpper(k)] {
+			v = "[redacted]"
+		}
+		fmt.Fprintf(sb, "%s%s: %s\n", prefix, k, v)
+	}
+}
+
+func writeBody(sb *strings.Builder, prefix string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	s := secretFields.ReplaceAllString(string(body), `"$1":"[redacted]"`)
+	if len(s) > maxTraceBody {
+		s = fmt.Sprintf("%s... (%d bytes)", s[:maxTraceBody], len(body))
+	}
+	fmt.Fprintf(sb, "%s%s\n", prefix, s)
+}
@@ -0,0 +1,54 @@
+// Package i18n holds the user-facing CLI strings (command help, flag usage
+// and the few messages that were written in one language only) so operators
+// can pick English or Chinese. Output meant for machines — dashboard JSON
+// field names, log lines, config keys — stays in English regardless.
+package i18n
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	English = "en"
+	Chinese = "zh"
+)
+
+var (
+	mu   sync.RWMutex
+	lang = English
+)
+
+// FromLocale maps a locale such as "zh_CN.UTF-8" or "en" onto a supported
+// language; anything that isn't Chinese falls back to English.
+func FromLocale(locale string) string {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(locale)), Chinese) {
+		return Chinese
+	}
+	return English
+}
+
+// SetLang selects the language T translates into.
+func SetLang(locale string) {
+	mu.Lock()
+	lang = FromLocale(locale)
+	mu.Unlock()
+}
+
+func Lang() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return lang
+}
+
+// T returns key's text in the current language, falling back to English and
+// then to the key itself so a missing translation is visible but harmless.
+func T(key string) string {
+	if s, ok := catalog[Lang()][key]; ok {
+		return s
+	}
+	if s, ok := catalog[English][key]; ok {
+		return s
+	}
+	return key
+}
@@ -0,0 +1,163 @@
+package i18n
+
+// catalog maps language -> key -> text. Keys are namespaced by command
+// ("clob.place_test.short", "redeem_all.flag.limit"); every key needs an
+// English entry.
+var catalog = map[string]map[string]string{
+	English: {
+		"tx.short":                       "Transaction/receipt parsing tools (same as get_token_ids_from_tx.py)",
+		"tx.token_ids.short":             "Parse CTF TransferSingle tokenId/amount from a transaction receipt",
+		"tx.flag.only_incoming":          "only print tokens transferred into this wallet",
+		"journal.short":                  "Trade journal: add notes and tags to markets/orders",
+		"journal.add.short":              "Add a note (at least one of --condition-id or --order-id)",
+		"journal.list.short":             "List notes (filter by condition_id, export with --json)",
+		"flag.json":                      "print JSON",
+		"flag.dry_run":                   "simulate the transactions with eth_call and show the expected balance changes; send nothing",
+		"usdc.short":                     "USDC / USDC.e troubleshooting (same as check_all_usdc.py)",
+		"usdc.compare.short":             "Compare USDC.e and native Polygon USDC balances",
+		"usdc.swap.short":                "Swap between USDC.e and native USDC through USDC_SWAP_ROUTER",
+		"usdc.swap.flag.to":              "token to receive: native|usdce",
+		"usdc.swap.flag.amount":          "amount to swap in USD (required)",
+		"run.short":                      "Run the bot / dashboard / both",
+		"run.flag.mode":                  "run mode: bot|dashboard|both",
+		"check_config.short":             "Validate the .env configuration and exit",
+		"merge.short":                    "Merge YES/NO back into USDC via CTF.mergePositions for a condition_id",
+		"ctf.short":                      "CTF (ERC1155) tools: scan / check balances",
+		"ctf.scan.short":                 "Scan CTF tokenIds received in the last N blocks (single and batch transfers) and print their condition IDs and current balances",
+		"ctf.balance.short":              "Query CTF balanceOf for a tokenId",
+		"ctf.position_id.short":          "Compute a condition's outcome token ids (position ids) from the CTF math",
+		"ctf.identify.short":             "Map token ids back to their condition and outcome",
+		"clob.short":                     "Polymarket CLOB tools: list orders / update L2 allowance / place test orders",
+		"clob.orders.short":              "List this wallet's open orders (same as check_open_orders.py)",
+		"clob.update_balance.short":      "Call /balance-allowance/update and print /balance-allowance (same as update_l2_balance.py)",
+		"clob.scoring.short":             "Check whether orders are scoring for liquidity rewards",
+		"clob.place_test.short":          "Place 2 test orders on the first available BTC 15m market (same as place_test_order.py/test_small_order.py)",
+		"clob.flag.side":                 "BUY | SELL (SELL only sells shares already held, to test exits)",
+		"clob.flag.yes":                  "confirm placing the orders",
+		"redeem.short":                   "Redeem by condition_id (neg-risk markets go through the NegRiskAdapter)",
+		"redeem.flag.neg_risk":           "force neg-risk redemption (detected from the positions API by default)",
+		"redeem_all.short":               "List and batch-redeem all redeemable markets (same as redeem_positions.py/auto_redeem.py)",
+		"redeem_all.flag.yes":            "skip confirmation and execute",
+		"redeem_all.flag.limit":          "process at most the first N (by currentValue, descending)",
+		"redeem_all.flag.min_value":      "skip markets worth less than this many USD (default MIN_REDEEM_VALUE_USD)",
+		"redeem_all.flag.max_gas_price":  "above this gas price (gwei), defer markets whose gas exceeds 5% of their value (0 = no limit)",
+		"redeem_all.flag.batch":          "redeem the proxy wallet's positions N markets per tx through the proxy factory (POLY_PROXY only; 0 = one tx each)",
+		"claim_winnings.short":           "Alias of redeem-all (same as claim_winnings.py)",
+		"wallet.short":                   "Quick wallet troubleshooting (same as check_balance.py + part of check_all_usdc.py)",
+		"wallet.balances.short":          "Print address, MATIC, USDC and USDC.e balances",
+		"wallet.rebalance.short":         "Move USDC.e between the EOA and the proxy (funder) wallet",
+		"wallet.rebalance.status.short":  "Show USDC.e on the EOA and the proxy wallet",
+		"wallet.rebalance.to_proxy":      "Send USDC.e from the EOA to the proxy wallet",
+		"wallet.rebalance.to_eoa":        "Withdraw USDC.e from the proxy wallet to the EOA (POLY_PROXY only)",
+		"wallet.rebalance.flag.amount":   "USDC.e amount to move",
+		"wallet.yield.short":             "Park USDC.e in the YIELD_POOL venue or take it back",
+		"wallet.yield.status.short":      "Show USDC.e on the wallet and in the yield pool",
+		"wallet.yield.deposit":           "Supply USDC.e from the wallet to the yield pool",
+		"wallet.yield.withdraw":          "Withdraw USDC.e from the yield pool (all of it without --amount)",
+		"test_connection.short":          "Test Gamma/CLOB/RPC connectivity",
+		"positions.short":                "Polymarket Data API positions tools (same as get_positions_api.py)",
+		"positions.list.short":           "List positions (optionally only redeemable ones)",
+		"positions.flag.redeemable_only": "only show redeemable=true",
+		"positions.raw.short":            "Print the raw Data API JSON",
+		"allowances.short":               "Check/set the allowances Polymarket trading needs",
+		"allowances.check.short":         "Check USDC allowance + CTF approval",
+		"allowances.set_all.short":       "Set USDC approve + CTF setApprovalForAll for all three spenders",
+		"allowances.revoke.short":        "Revoke USDC allowances and CTF approvals from the exchange contracts",
+		"monitor.short":                  "Run the bot with a terminal UI instead of the web dashboard",
+		"monitor.flag.refresh":           "screen refresh interval",
+		"allowances.set.short":           "Set the USDC allowance for one spender (same as set_allowance.py)",
+		"merge.err.amount":               "--amount must be > 0 (unit: sets / USDC)",
+		"panic.short":                    "Emergency: cancel all orders, market-sell every position and pause the bot",
+		"panic.flag.yes":                 "confirm; without it nothing is done",
+		"panic.resume.short":             "Lift the pause set by panic",
+		"strategy.short":                 "Custom strategy tools (ORDER_MODE=<name>)",
+		"strategy.list.short":            "List built-in order modes and registered strategies",
+		"strategy.scaffold.short":        "Generate a new strategy package to start from",
+		"strategy.scaffold.flag.dir":     "parent directory for the package",
+		"strategy.scaffold.flag.force":   "overwrite an existing file",
+		"state.short":                    "Back up or migrate the bot's persisted state",
+		"state.export.short":             "Write all state files (markets, orders, history, journals) to a .tar.gz archive",
+		"state.import.short":             "Restore state files from an archive made by state export",
+		"state.flag.secrets":             "also include .env and KEYSTORE_FILE (private key, API creds)",
+		"state.flag.force":               "overwrite existing state files",
+		"state.import_history.short":     "Import the wallet's past trades, redemptions and merges from Polymarket into the order history",
+	},
+	Chinese: {
+		"tx.short":                       "交易/回执解析工具（等价 get_token_ids_from_tx.py）",
+		"tx.token_ids.short":             "从交易回执里解析 CTF TransferSingle 的 tokenId/amount",
+		"tx.flag.only_incoming":          "只输出转入当前钱包的 token",
+		"journal.short":                  "交易日志：为市场/订单添加备注与标签",
+		"journal.add.short":              "添加一条备注（--condition-id 或 --order-id 至少一个）",
+		"journal.list.short":             "列出备注（可按 condition_id 过滤，--json 导出）",
+		"flag.json":                      "输出 JSON",
+		"flag.dry_run":                   "用 eth_call 模拟交易并显示预期余额变化，不实际发送",
+		"usdc.short":                     "USDC / USDC.e 排障工具（等价 check_all_usdc.py）",
+		"usdc.compare.short":             "对比 USDC.e 与 Polygon 原生 USDC 余额",
+		"usdc.swap.short":                "通过 USDC_SWAP_ROUTER 在 USDC.e 与原生 USDC 之间兑换",
+		"usdc.swap.flag.to":              "兑换得到的代币：native|usdce",
+		"usdc.swap.flag.amount":          "兑换金额（USD，必填）",
+		"run.short":                      "运行 bot / dashboard / both",
+		"run.flag.mode":                  "运行模式: bot|dashboard|both",
+		"check_config.short":             "检查 .env 配置并退出",
+		"merge.short":                    "按 condition_id 调用 CTF.mergePositions 合并 YES/NO 回 USDC",
+		"ctf.short":                      "CTF (ERC1155) 工具：扫描/查余额",
+		"ctf.scan.short":                 "扫描最近 N 个区块内转入的 CTF tokenId（含单笔与批量转账），并输出所属 condition ID 与当前余额",
+		"ctf.balance.short":              "查询指定 tokenId 的 CTF balanceOf",
+		"ctf.position_id.short":          "按 CTF 规则由 condition 计算各结果的 token id（position id）",
+		"ctf.identify.short":             "把 token id 反查到所属 condition 与结果",
+		"clob.short":                     "Polymarket CLOB 工具：查单/更新 L2 allowance/测试下单",
+		"clob.orders.short":              "查询当前钱包的 open orders（等价 check_open_orders.py）",
+		"clob.update_balance.short":      "调用 /balance-allowance/update 并输出 /balance-allowance（等价 update_l2_balance.py）",
+		"clob.scoring.short":             "查询订单是否计入流动性奖励（scoring）",
+		"clob.place_test.short":          "在第一个可用 BTC 15m 市场下 2 笔测试单（等价 place_test_order.py/test_small_order.py）",
+		"clob.flag.side":                 "BUY | SELL（SELL 只卖出已持有的份额，用于测试退出）",
+		"clob.flag.yes":                  "确认下单",
+		"redeem.short":                   "按 condition_id 赎回（neg-risk 市场走 NegRiskAdapter）",
+		"redeem.flag.neg_risk":           "强制按 neg-risk 市场赎回（默认根据 positions API 自动判断）",
+		"redeem_all.short":               "列出并批量赎回所有 redeemable markets（等价 redeem_positions.py/auto_redeem.py）",
+		"redeem_all.flag.yes":            "跳过确认直接执行",
+		"redeem_all.flag.limit":          "最多处理前 N 个（按 currentValue 降序）",
+		"redeem_all.flag.min_value":      "跳过价值低于该 USD 的市场（默认 MIN_REDEEM_VALUE_USD）",
+		"redeem_all.flag.max_gas_price":  "gas 价格（gwei）高于该值时，推迟 gas 超过其价值 5% 的市场（0 = 不限制）",
+		"redeem_all.flag.batch":          "通过代理工厂每笔交易赎回 N 个市场的代理钱包持仓（仅 POLY_PROXY；0 = 每个市场一笔）",
+		"claim_winnings.short":           "别名：redeem-all（等价 claim_winnings.py）",
+		"wallet.short":                   "钱包快速排障（等价 check_balance.py + 一部分 check_all_usdc.py）",
+		"wallet.balances.short":          "输出地址、MATIC、USDC、USDC.e 余额",
+		"wallet.rebalance.short":         "在 EOA 与代理（funder）钱包之间划转 USDC.e",
+		"wallet.rebalance.status.short":  "显示 EOA 与代理钱包的 USDC.e 余额",
+		"wallet.rebalance.to_proxy":      "从 EOA 向代理钱包转入 USDC.e",
+		"wallet.rebalance.to_eoa":        "从代理钱包提取 USDC.e 到 EOA（仅 POLY_PROXY）",
+		"wallet.rebalance.flag.amount":   "划转的 USDC.e 数量",
+		"wallet.yield.short":             "将 USDC.e 存入 YIELD_POOL 收益池或取回",
+		"wallet.yield.status.short":      "显示钱包与收益池中的 USDC.e 余额",
+		"wallet.yield.deposit":           "从钱包向收益池存入 USDC.e",
+		"wallet.yield.withdraw":          "从收益池取回 USDC.e（不带 --amount 则全部取回）",
+		"test_connection.short":          "测试 Gamma/CLOB/RPC 连接",
+		"positions.short":                "Polymarket Data API positions 工具（等价 get_positions_api.py）",
+		"positions.list.short":           "列出 positions（可选仅 redeemable）",
+		"positions.flag.redeemable_only": "仅显示 redeemable=true",
+		"positions.raw.short":            "输出 Data API 原始 JSON",
+		"allowances.short":               "检查/设置 Polymarket 交易所需 allowances",
+		"allowances.check.short":         "检查 USDC allowance + CTF approval",
+		"allowances.set_all.short":       "为三个 spender 设置 USDC approve + CTF setApprovalForAll",
+		"allowances.revoke.short":        "撤销交易所合约的 USDC 授权与 CTF 授权",
+		"monitor.short":                  "运行 bot 并以终端界面代替 web dashboard 展示状态",
+		"monitor.flag.refresh":           "界面刷新间隔",
+		"allowances.set.short":           "为单个 spender 设置 USDC allowance（等价 set_allowance.py）",
+		"merge.err.amount":               "--amount 必须 > 0（单位: sets / USDC）",
+		"panic.short":                    "紧急操作：撤销全部订单、市价卖出所有持仓并暂停 bot",
+		"panic.flag.yes":                 "确认执行；不加此参数不做任何操作",
+		"panic.resume.short":             "解除 panic 设置的暂停",
+		"strategy.short":                 "自定义策略工具（ORDER_MODE=<名称>）",
+		"strategy.list.short":            "列出内置下单模式和已注册的策略",
+		"strategy.scaffold.short":        "生成一个新的策略包作为起点",
+		"strategy.scaffold.flag.dir":     "策略包所在的父目录",
+		"strategy.scaffold.flag.force":   "覆盖已存在的文件",
+		"state.short":                    "备份或迁移机器人的持久化状态",
+		"state.export.short":             "将所有状态文件（市场、订单、历史、日志）写入 .tar.gz 归档",
+		"state.import.short":             "从 state export 生成的归档恢复状态文件",
+		"state.flag.secrets":             "同时包含 .env 和 KEYSTORE_FILE（私钥、API 凭证）",
+		"state.flag.force":               "覆盖已存在的状态文件",
+		"state.import_history.short":     "从 Polymarket 导入钱包过往的成交、赎回和合并记录到订单历史",
+	},
+}
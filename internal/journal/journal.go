@@ -0,0 +1,94 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"limitorderbot/internal/models"
+)
+
+// DefaultFile sits next to order_history.json so notes travel with the history.
+const DefaultFile = "trade_journal.json"
+
+var mu sync.Mutex
+
+// Load reads all annotations from path. A missing file is an empty journal.
+func Load(path string) ([]models.Annotation, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load(path)
+}
+
+// Append validates and persists a new annotation, returning the stored entry.
+func Append(path string, a models.Annotation) (models.Annotation, error) {
+	a.ConditionID = strings.TrimSpace(a.ConditionID)
+	a.OrderID = strings.TrimSpace(a.OrderID)
+	a.Note = strings.TrimSpace(a.Note)
+	if a.ConditionID == "" && a.OrderID == "" {
+		return a, errors.New("annotation needs a condition_id or order_id")
+	}
+	if a.Note == "" && len(a.Tags) == 0 {
+		return a, errors.New("annotation needs a note or at least one tag")
+	}
+	var tags []string
+	for _, t := range a.Tags {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	a.Tags = tags
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = time.Now()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	all, err := load(path)
+	if err != nil {
+		return a, err
+	}
+	all = append(all, a)
+	bts, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return a, err
+	}
+	return a, os.WriteFile(path, bts, 0o644)
+}
+
+// ByMarket groups annotations by condition id. Order-level notes are folded into
+// their market when the order's condition id is known via orderMarkets.
+func ByMarket(all []models.Annotation, orderMarkets map[string]string) map[string][]models.Annotation {
+	out := map[string][]models.Annotation{}
+	for _, a := range all {
+		cid := a.ConditionID
+		if cid == "" {
+			cid = orderMarkets[a.OrderID]
+		}
+		if cid == "" {
+			continue
+		}
+		out[cid] = append(out[cid], a)
+	}
+	return out
+}
+
+func load(path string) ([]models.Annotation, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var all []models.Annotation
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].CreatedAt.Before(all[j].CreatedAt) })
+	return all, nil
+}
@@ -0,0 +1,123 @@
+package keys
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/term"
+)
+
+// Provider supplies the wallet private key to clob.Signer and chain.Client.
+// Implementations may decrypt or fetch lazily; results are cached per provider.
+type Provider interface {
+	PrivateKey() (*ecdsa.PrivateKey, error)
+	// Source is a human-readable description for logs (never the key itself).
+	Source() string
+}
+
+// FromHex wraps a raw hex private key (the PRIVATE_KEY env var).
+func FromHex(hexKey string) Provider {
+	return &cached{source: "PRIVATE_KEY", load: func() (*ecdsa.PrivateKey, error) {
+		key := strings.TrimPrefix(strings.TrimSpace(hexKey), "0x")
+		return crypto.HexToECDSA(key)
+	}}
+}
+
+// FromKeystore decrypts a geth keystore JSON file. The passphrase comes from
+// passphrase, else passphraseFile, else an interactive prompt on stdin.
+func FromKeystore(path, passphrase, passphraseFile string) Provider {
+	return &cached{source: "keystore " + path, load: func() (*ecdsa.PrivateKey, error) {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		pass := passphrase
+		if pass == "" && passphraseFile != "" {
+			b, err := os.ReadFile(passphraseFile)
+			if err != nil {
+				return nil, err
+			}
+			pass = strings.TrimRight(string(b), "\r\n")
+		}
+		if pass == "" {
+			pass, err = prompt(fmt.Sprintf("Passphrase for %s: ", path))
+			if err != nil {
+				return nil, err
+			}
+		}
+		k, err := keystore.DecryptKey(raw, pass)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt keystore %s: %w", path, err)
+		}
+		return k.PrivateKey, nil
+	}}
+}
+
+// FromKeychain reads a hex private key from the OS keychain: macOS Keychain via
+// `security`, or the Secret Service (GNOME Keyring/KWallet) via `secret-tool`.
+func FromKeychain(service, account string) Provider {
+	return &cached{source: fmt.Sprintf("keychain %s/%s", service, account), load: func() (*ecdsa.PrivateKey, error) {
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w")
+		case "linux":
+			cmd = exec.Command("secret-tool", "lookup", "service", service, "account", account)
+		default:
+			return nil, fmt.Errorf("OS keychain not supported on %s", runtime.GOOS)
+		}
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("keychain lookup failed: %w", err)
+		}
+		key := strings.TrimPrefix(strings.TrimSpace(string(out)), "0x")
+		if key == "" {
+			return nil, errors.New("keychain entry is empty")
+		}
+		return crypto.HexToECDSA(key)
+	}}
+}
+
+type cached struct {
+	source string
+	load   func() (*ecdsa.PrivateKey, error)
+
+	once sync.Once
+	key  *ecdsa.PrivateKey
+	err  error
+}
+
+func (c *cached) PrivateKey() (*ecdsa.PrivateKey, error) {
+	c.once.Do(func() { c.key, c.err = c.load() })
+	return c.key, c.err
+}
+
+func (c *cached) Source() string { return c.source }
+
+// prompt reads a passphrase from stdin, without echo when stdin is a
+// terminal. Piped input is read as a line, as before.
+func prompt(label string) (string, error) {
+	_, _ = fmt.Fprint(os.Stderr, label)
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		pass, err := term.ReadPassword(fd)
+		_, _ = fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("read passphrase: %w", err)
+		}
+		return string(pass), nil
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read passphrase: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,141 @@
+package keys
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs 32-byte digests (EIP-712 CLOB auth/order hashes and tx hashes).
+// Signatures are 65 bytes [R || S || V] with V in {0, 1}.
+type Signer interface {
+	Address() common.Address
+	SignDigest(digest [32]byte) ([]byte, error)
+}
+
+// SignerFor returns p itself when it already signs remotely, otherwise a local
+// signer over p's private key.
+func SignerFor(p Provider) (Signer, error) {
+	if s, ok := p.(Signer); ok {
+		return s, nil
+	}
+	pk, err := p.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return &localSigner{key: pk, addr: crypto.PubkeyToAddress(pk.PublicKey)}, nil
+}
+
+type localSigner struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+func (s *localSigner) Address() common.Address { return s.addr }
+
+func (s *localSigner) SignDigest(digest [32]byte) ([]byte, error) {
+	return crypto.Sign(digest[:], s.key)
+}
+
+// RemoteSigner delegates digest signing to an HTTP JSON-RPC service (e.g. an
+// HSM front-end). It calls method "signer_signDigest" with params
+// [address, digestHex] and expects a 0x-prefixed 65-byte signature back.
+type RemoteSigner struct {
+	URL   string
+	Token string // optional bearer token
+	addr  common.Address
+	http  *http.Client
+	id    atomic.Int64
+}
+
+// FromRemoteSigner configures a remote signer for the given wallet address.
+func FromRemoteSigner(url, address, token string) *RemoteSigner {
+	return &RemoteSigner{
+		URL:   strings.TrimSpace(url),
+		Token: token,
+		addr:  common.HexToAddress(address),
+		http:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (r *RemoteSigner) Address() common.Address { return r.addr }
+
+func (r *RemoteSigner) Source() string { return "remote signer " + r.URL }
+
+// PrivateKey always fails: the key never leaves the remote signer.
+func (r *RemoteSigner) PrivateKey() (*ecdsa.PrivateKey, error) {
+	return nil, errors.New("remote signer does not expose a private key")
+}
+
+func (r *RemoteSigner) SignDigest(digest [32]byte) ([]byte, error) {
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      r.id.Add(1),
+		"method":  "signer_signDigest",
+		"params":  []string{r.addr.Hex(), hexutil.Encode(digest[:])},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.Token)
+	}
+	resp, err := r.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("remote signer status=%d", resp.StatusCode)
+	}
+	var out struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	if out.Error != nil {
+		return nil, fmt.Errorf("remote signer error %d: %s", out.Error.Code, out.Error.Message)
+	}
+	sig, err := hexutil.Decode(out.Result)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned invalid signature: %w", err)
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("remote signer returned %d-byte signature", len(sig))
+	}
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	// Guard against a misconfigured signer answering for a different key.
+	pub, err := crypto.SigToPub(digest[:], sig)
+	if err != nil {
+		return nil, err
+	}
+	if got := crypto.PubkeyToAddress(*pub); got != r.addr {
+		return nil, fmt.Errorf("remote signer signed with %s, expected %s", got.Hex(), r.addr.Hex())
+	}
+	return sig, nil
+}
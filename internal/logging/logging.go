@@ -11,6 +11,11 @@ import (
 var (
 	once   sync.Once
 	logger *log.Logger
+
+	// console is where log lines go besides LOG_FILE; the TUI monitor swaps
+	// it for its log pane so lines don't tear the screen.
+	console io.Writer = os.Stdout
+	logFile io.Writer
 )
 
 func Logger() *log.Logger {
@@ -34,8 +39,27 @@ func Configure(level, filePath string) (func(), error) {
 		return func() {}, err
 	}
 
-	mw := io.MultiWriter(os.Stdout, f)
-	Logger().SetOutput(mw)
+	logFile = f
+	Logger().SetOutput(io.MultiWriter(console, f))
 
 	return func() { _ = f.Close() }, nil
 }
+
+// SetConsole replaces stdout as the console log destination; LOG_FILE, if
+// configured, keeps receiving every line.
+func SetConsole(w io.Writer) {
+	console = w
+	if logFile != nil {
+		Logger().SetOutput(io.MultiWriter(console, logFile))
+		return
+	}
+	Logger().SetOutput(console)
+}
+
+// SetRunID tags every following log line with the run id, after the
+// timestamp, so a shared LOG_FILE can be split by run.
+func SetRunID(id string) {
+	l := Logger()
+	l.SetFlags(l.Flags() | log.Lmsgprefix)
+	l.SetPrefix("[" + id + "] ")
+}
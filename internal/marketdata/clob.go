@@ -0,0 +1,196 @@
+package marketdata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"limitorderbot/internal/apierr"
+	"limitorderbot/internal/clock"
+	"limitorderbot/internal/models"
+)
+
+func init() {
+	Register("clob", func(opts Options) (Source, error) {
+		if opts.ClobURL == "" {
+			return nil, errors.New("clob market source needs CLOB_API_URL")
+		}
+		c := NewCLOB(opts.ClobURL)
+		if opts.ClobHTTP != nil {
+			c.HTTP = opts.ClobHTTP
+		}
+		if opts.Clock != nil {
+			c.Clock = opts.Clock
+		}
+		return c, nil
+	})
+}
+
+// clobEndCursor is the next_cursor the CLOB returns on the last page.
+const clobEndCursor = "LTE="
+
+// CLOB discovers markets from the CLOB's /sampling-markets and /markets
+// listings instead of Gamma. The listings are paginated over every market
+// the CLOB knows, so at most MaxPages pages of each are read per discovery.
+type CLOB struct {
+	BaseURL string
+	HTTP    *http.Client
+	// SlugPrefix limits discovery to the markets Gamma discovery covers.
+	SlugPrefix string
+	MaxPages   int
+	// Clock decides which listed markets have already ended.
+	Clock clock.Clock
+}
+
+func NewCLOB(baseURL string) *CLOB {
+	return &CLOB{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTP:       &http.Client{Timeout: 10 * time.Second},
+		SlugPrefix: "btc-updown-15m-",
+		MaxPages:   10,
+		Clock:      clock.System,
+	}
+}
+
+func (c *CLOB) Name() string { return "clob" }
+
+func (c *CLOB) Discover(ctx context.Context) ([]models.Market, error) {
+	now := c.Clock.Now().Unix()
+	byCID := map[string]models.Market{}
+	var errs []error
+	for _, path := range []string{"/sampling-markets", "/markets"} {
+		cursor := ""
+		for page := 0; page < c.MaxPages && cursor != clobEndCursor; page++ {
+			data, next, err := c.fetchPage(ctx, path, cursor)
+			if err != nil {
+				errs = append(errs, err)
+				break
+			}
+			for _, raw := range data {
+				m, ok := parseCLOBMarket(raw)
+				if !ok || m.IsResolved || m.EndTS <= now || !strings.HasPrefix(strings.ToLower(m.MarketSlug), c.SlugPrefix) {
+					continue
+				}
+				byCID[m.ConditionID] = m
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+	}
+	if len(byCID) == 0 && len(errs) == 2 {
+		return nil, errors.Join(errs...)
+	}
+	out := make([]models.Market, 0, len(byCID))
+	for _, m := range byCID {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartTS < out[j].StartTS })
+	return out, nil
+}
+
+// Resolution reads the winner flag the CLOB sets on a resolved market's token.
+func (c *CLOB) Resolution(ctx context.Context, m models.Market) (Resolution, error) {
+	var raw map[string]any
+	if err := c.get(ctx, "/markets/"+url.PathEscape(m.ConditionID), &raw); err != nil {
+		return Resolution{}, err
+	}
+	tokens, _ := raw["tokens"].([]any)
+	for _, t := range tokens {
+		tm, _ := t.(map[string]any)
+		if w, _ := tm["winner"].(bool); w {
+			out, _ := tm["outcome"].(string)
+			id, _ := tm["token_id"].(string)
+			return Resolution{Resolved: out != "", WinningOutcome: out, WinningTokenID: id}, nil
+		}
+	}
+	return Resolution{}, nil
+}
+
+func (c *CLOB) fetchPage(ctx context.Context, path, cursor string) ([]map[string]any, string, error) {
+	if cursor != "" {
+		path += "?next_cursor=" + url.QueryEscape(cursor)
+	}
+	var page struct {
+		Data       []map[string]any `json:"data"`
+		NextCursor string           `json:"next_cursor"`
+	}
+	if err := c.get(ctx, path, &page); err != nil {
+		return nil, "", err
+	}
+	return page.Data, page.NextCursor, nil
+}
+
+func (c *CLOB) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return apierr.Wrap(apierr.Network, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return apierr.FromStatus(resp.StatusCode, resp.Header, "", fmt.Errorf("clob %s status=%d", path, resp.StatusCode))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// parseCLOBMarket maps a CLOB market object onto models.Market. The window
+// comes from the slug when it encodes one, else from the CLOB's dates.
+func parseCLOBMarket(raw map[string]any) (models.Market, bool) {
+	str := func(k string) string { s, _ := raw[k].(string); return s }
+	flag := func(k string) bool { b, _ := raw[k].(bool); return b }
+	m := models.Market{
+		ConditionID: str("condition_id"),
+		MarketSlug:  str("market_slug"),
+		Question:    str("question"),
+		IsActive:    flag("active"),
+		IsResolved:  flag("closed"),
+	}
+	if m.ConditionID == "" || m.MarketSlug == "" {
+		return models.Market{}, false
+	}
+	tags, _ := raw["tags"].([]any)
+	for _, t := range tags {
+		if s, _ := t.(string); s != "" {
+			m.Tags = append(m.Tags, s)
+		}
+	}
+	var ok bool
+	if m.StartTS, m.EndTS, ok = models.SlugWindow(m.MarketSlug); !ok {
+		m.StartTS = isoUnix(str("game_start_time"))
+		m.EndTS = isoUnix(str("end_date_iso"))
+	}
+	if m.StartTS == 0 || m.EndTS == 0 {
+		return models.Market{}, false
+	}
+	tokens, _ := raw["tokens"].([]any)
+	for _, t := range tokens {
+		tm, _ := t.(map[string]any)
+		id, _ := tm["token_id"].(string)
+		name, _ := tm["outcome"].(string)
+		if id != "" {
+			m.Outcomes = append(m.Outcomes, models.Outcome{TokenID: id, Outcome: name})
+		}
+	}
+	return m, len(m.Outcomes) == 2
+}
+
+func isoUnix(s string) int64 {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05Z07:00", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Unix()
+		}
+	}
+	return 0
+}
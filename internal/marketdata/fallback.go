@@ -0,0 +1,59 @@
+package marketdata
+
+import (
+	"context"
+	"time"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+)
+
+// WithFallback returns a source that asks primary first and switches to
+// secondary for that call when primary errors, finds nothing or takes longer
+// than timeout, so an outage of one API doesn't halt trading.
+func WithFallback(primary, secondary Source, timeout time.Duration) Source {
+	return &fallback{primary: primary, secondary: secondary, timeout: timeout}
+}
+
+type fallback struct {
+	primary, secondary Source
+	timeout            time.Duration
+}
+
+func (f *fallback) Name() string { return f.primary.Name() + "+" + f.secondary.Name() }
+
+func (f *fallback) Discover(ctx context.Context) ([]models.Market, error) {
+	pctx, cancel := context.WithTimeout(ctx, f.timeout)
+	markets, err := f.primary.Discover(pctx)
+	cancel()
+	if err == nil && len(markets) > 0 {
+		return markets, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	reason := "no markets"
+	if err != nil {
+		reason = err.Error()
+	}
+	logging.Logger().Printf("Market discovery via %s failed (%s), falling back to %s\n", f.primary.Name(), reason, f.secondary.Name())
+	return f.secondary.Discover(ctx)
+}
+
+func (f *fallback) Resolution(ctx context.Context, m models.Market) (Resolution, error) {
+	pctx, cancel := context.WithTimeout(ctx, f.timeout)
+	res, err := f.primary.Resolution(pctx, m)
+	cancel()
+	if err == nil && res.Resolved {
+		return res, nil
+	}
+	if ctx.Err() != nil {
+		return Resolution{}, ctx.Err()
+	}
+	sec, serr := f.secondary.Resolution(ctx, m)
+	// Keep the primary's oracle status if the secondary has none.
+	if err == nil && sec.Status == "" {
+		sec.Status = res.Status
+	}
+	return sec, serr
+}
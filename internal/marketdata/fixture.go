@@ -0,0 +1,62 @@
+package marketdata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+
+	"limitorderbot/internal/models"
+)
+
+func init() {
+	Register("fixture", func(opts Options) (Source, error) {
+		if opts.FixtureFile == "" {
+			return nil, errors.New("fixture market source needs MARKET_FIXTURE_FILE")
+		}
+		return &Fixture{Path: opts.FixtureFile}, nil
+	})
+}
+
+// Fixture serves markets from a JSON file holding a list of models.Market.
+// The file is re-read on every call so it can be edited while the bot runs;
+// a market resolves once its winning_outcome is filled in.
+type Fixture struct {
+	Path string
+}
+
+func (f *Fixture) Name() string { return "fixture" }
+
+func (f *Fixture) Discover(ctx context.Context) ([]models.Market, error) {
+	raw, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, err
+	}
+	var out []models.Market
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartTS < out[j].StartTS })
+	return out, nil
+}
+
+func (f *Fixture) Resolution(ctx context.Context, m models.Market) (Resolution, error) {
+	all, err := f.Discover(ctx)
+	if err != nil {
+		return Resolution{}, err
+	}
+	for _, fm := range all {
+		if fm.ConditionID != m.ConditionID || fm.WinningOutcome == "" {
+			continue
+		}
+		res := Resolution{Resolved: true, WinningOutcome: fm.WinningOutcome}
+		for _, o := range fm.Outcomes {
+			if o.Outcome == fm.WinningOutcome {
+				res.WinningTokenID = o.TokenID
+			}
+		}
+		return res, nil
+	}
+	return Resolution{}, nil
+}
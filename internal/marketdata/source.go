@@ -0,0 +1,91 @@
+// Package marketdata abstracts where the bot learns about markets, so the
+// Gamma API can be swapped for another venue or a fixture file without
+// touching bot logic.
+package marketdata
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"limitorderbot/internal/clock"
+	"limitorderbot/internal/models"
+)
+
+// Source discovers tradable markets and reports how they resolved.
+type Source interface {
+	Name() string
+	// Discover returns upcoming and live markets, sorted by start time.
+	Discover(ctx context.Context) ([]models.Market, error)
+	// Resolution reports m's winner; Resolved=false until it has settled.
+	Resolution(ctx context.Context, m models.Market) (Resolution, error)
+}
+
+// Resolution is the settled result of a market.
+type Resolution struct {
+	Resolved       bool
+	WinningOutcome string
+	WinningTokenID string
+	// Status is the oracle's progress as the source reports it (UMA's
+	// "proposed", "disputed", "resolved"); empty when it doesn't say.
+	Status string
+}
+
+// Factory builds a source; opts carries MARKET_SOURCE settings from config.
+type Factory func(opts Options) (Source, error)
+
+type Options struct {
+	GammaURL    string
+	ClobURL     string
+	FixtureFile string
+
+	// GammaHTTP and ClobHTTP replace the sources' default clients when set.
+	GammaHTTP *http.Client
+	ClobHTTP  *http.Client
+	// Clock replaces the wall clock that picks the discovery window.
+	Clock clock.Clock
+	// Horizon is how far ahead to look for markets of a family (markets
+	// lasting d); nil leaves each source's default.
+	Horizon func(d time.Duration) time.Duration
+}
+
+var (
+	mu        sync.Mutex
+	factories = map[string]Factory{}
+)
+
+// Register makes a source selectable via MARKET_SOURCE=<name>. Sources call
+// this from an init() in their own package.
+func Register(name string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[strings.ToLower(name)] = f
+}
+
+// New returns the named source.
+func New(name string, opts Options) (Source, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	mu.Lock()
+	f, ok := factories[name]
+	mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown market source %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return f(opts)
+}
+
+// Names lists registered sources.
+func Names() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	out := make([]string, 0, len(factories))
+	for n := range factories {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -22,12 +24,32 @@ const (
 	OrderStatusFailed          OrderStatus = "FAILED"
 )
 
+// Order phases: where in a market's lifecycle an order was placed.
+const (
+	PhasePreWindow       = "pre_window"       // entry before the market's placement window opened
+	PhasePlacementWindow = "placement_window" // entry inside the placement window
+	PhaseFallback        = "fallback"         // idle-fallback entry
+	PhaseLastMinute      = "last_minute"      // stale-ask take just before start
+	PhaseExit            = "exit"             // strategy timeout / unwind sell
+	PhaseLeftoverSell    = "leftover_sell"    // sell of leftovers near market end
+	PhaseArbitrage       = "arbitrage"        // both-sides take on a mispriced pair
+	PhasePanic           = "panic"            // emergency liquidation
+)
+
+// PauseInfo records why and since when the bot is paused.
+type PauseInfo struct {
+	Reason string    `json:"reason"`
+	Since  time.Time `json:"since"`
+}
+
 type Outcome struct {
 	TokenID string   `json:"token_id"`
 	Outcome string   `json:"outcome"`
 	Price   *float64 `json:"price,omitempty"`
 	BestBid *float64 `json:"best_bid,omitempty"`
 	BestAsk *float64 `json:"best_ask,omitempty"`
+	// PriceAt is when the prices above were read from the book.
+	PriceAt *time.Time `json:"price_at,omitempty"`
 }
 
 type Market struct {
@@ -39,11 +61,58 @@ type Market struct {
 	Outcomes    []Outcome `json:"outcomes"`
 	IsActive    bool      `json:"is_active"`
 	IsResolved  bool      `json:"is_resolved"`
+
+	// WinningOutcome is filled in once the market has resolved (e.g. "Up").
+	WinningOutcome string `json:"winning_outcome,omitempty"`
+
+	// Listing metadata from Gamma (tags also from the CLOB); zero when the
+	// market source doesn't report it. Volume and liquidity are in USD.
+	Volume              float64  `json:"volume,omitempty"`
+	Liquidity           float64  `json:"liquidity,omitempty"`
+	Tags                []string `json:"tags,omitempty"`
+	ResolutionSource    string   `json:"resolution_source,omitempty"`
+	UMAResolutionStatus string   `json:"uma_resolution_status,omitempty"`
 }
 
 func (m Market) StartTime() time.Time { return time.Unix(m.StartTS, 0) }
 func (m Market) EndTime() time.Time   { return time.Unix(m.EndTS, 0) }
 
+// Duration is the market's trading period (15m, 1h, 1d, ...).
+func (m Market) Duration() time.Duration {
+	return time.Duration(m.EndTS-m.StartTS) * time.Second
+}
+
+// SlugWindow recovers start and end from a btc-updown-15m-{start} slug.
+func SlugWindow(slug string) (start, end int64, ok bool) {
+	const prefix = "btc-updown-15m-"
+	i := strings.Index(strings.ToLower(slug), prefix)
+	if i < 0 {
+		return 0, 0, false
+	}
+	ts, err := strconv.ParseInt(strings.Split(slug[i+len(prefix):], "-")[0], 10, 64)
+	if err != nil || ts <= 0 {
+		return 0, 0, false
+	}
+	return ts, ts + 15*60, true
+}
+
+// underlyingAliases folds long-form slug prefixes onto ticker symbols.
+var underlyingAliases = map[string]string{
+	"bitcoin":  "btc",
+	"ethereum": "eth",
+	"solana":   "sol",
+}
+
+// Underlying is the asset the market tracks, from its slug prefix
+// ("btc-updown-15m-..." and "bitcoin-up-or-down-..." are both "btc").
+func (m Market) Underlying() string {
+	head, _, _ := strings.Cut(strings.ToLower(m.MarketSlug), "-")
+	if alias, ok := underlyingAliases[head]; ok {
+		return alias
+	}
+	return head
+}
+
 func (m Market) TimeUntilStart(now time.Time) time.Duration {
 	return time.Unix(m.StartTS, 0).Sub(now)
 }
@@ -70,6 +139,47 @@ type OrderRecord struct {
 	RevenueUSD      *float64 `json:"revenue_usd,omitempty"`
 	CostUSD         *float64 `json:"cost_usd,omitempty"`
 	PNLUSD          *float64 `json:"pnl_usd,omitempty"`
+
+	// FirstFillAt is when a non-zero size_matched was first observed (poll
+	// granularity); MidAtPlacement is the outcome's book mid when placed.
+	FirstFillAt    *time.Time `json:"first_fill_at,omitempty"`
+	MidAtPlacement *float64   `json:"mid_at_placement,omitempty"`
+
+	// Phase is one of the Phase* constants; empty on records from before tagging.
+	Phase string `json:"phase,omitempty"`
+	// FillPrice is the volume-weighted price of the fills reported on the
+	// user channel (takers can fill better than their limit).
+	FillPrice *float64 `json:"fill_price,omitempty"`
+	// RunID is the bot run that placed the order (see runs.json); empty on
+	// records from before run tagging.
+	RunID string `json:"run_id,omitempty"`
+	// GasUSD is the on-chain gas a MERGE, REDEEM or GAS record paid, already
+	// included in its cost_usd and pnl_usd.
+	GasUSD *float64 `json:"gas_usd,omitempty"`
+	// TxHash is the transaction behind a MERGE, REDEEM or GAS record.
+	TxHash string `json:"tx_hash,omitempty"`
+}
+
+// Annotation is a free-form operator note attached to a market (condition id)
+// or a single order, e.g. to record why a manual intervention happened.
+type Annotation struct {
+	ConditionID string    `json:"condition_id,omitempty"`
+	OrderID     string    `json:"order_id,omitempty"`
+	Note        string    `json:"note"`
+	Tags        []string  `json:"tags,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// MarketOutcome is the resolution of a market the bot traded, kept after the
+// market itself is dropped from tracking.
+type MarketOutcome struct {
+	ConditionID    string    `json:"condition_id"`
+	MarketSlug     string    `json:"market_slug"`
+	EndTS          int64     `json:"end_timestamp"`
+	WinningOutcome string    `json:"winning_outcome"`
+	WinningTokenID string    `json:"winning_token_id,omitempty"`
+	Source         string    `json:"source"`
+	ResolvedAt     time.Time `json:"resolved_at"`
 }
 
 type BotState struct {
@@ -82,4 +192,117 @@ type BotState struct {
 	TotalPNL      float64       `json:"total_pnl"`
 	ErrorCount    int           `json:"error_count"`
 	LastError     *string       `json:"last_error,omitempty"`
+
+	// ErrorsByKind counts errors by apierr kind ("other" when unclassified);
+	// BackoffUntil is set while a rate limit holds the loop back.
+	ErrorsByKind  map[string]int `json:"errors_by_kind,omitempty"`
+	LastErrorKind string         `json:"last_error_kind,omitempty"`
+	BackoffUntil  *time.Time     `json:"backoff_until,omitempty"`
+
+	// NativeUSDC is plain USDC in the wallet, which Polymarket can't use as
+	// collateral; FundingAlert is set while it is what keeps orders from placing.
+	NativeUSDC   float64 `json:"native_usdc_balance"`
+	FundingAlert *string `json:"funding_alert,omitempty"`
+
+	// ProxyUSDC is the funder (proxy wallet) collateral, tracked when
+	// REBALANCE_MIN_PROXY_USD keeps it topped up from the EOA.
+	ProxyUSDC *float64 `json:"proxy_usdc_balance,omitempty"`
+
+	// YieldUSDC is the collateral parked in the YIELD_POOL venue.
+	YieldUSDC *float64 `json:"yield_usdc_balance,omitempty"`
+
+	// MaticBalance pays gas; GasAlert is set while it is below MIN_MATIC and
+	// on-chain operations (merge, redeem, swap) are paused.
+	MaticBalance float64 `json:"matic_balance"`
+	GasAlert     *string `json:"gas_alert,omitempty"`
+
+	// GasTodayMatic/GasTodayUSD is what the bot's transactions paid for gas
+	// so far this UTC day, which GAS_DAILY_BUDGET_USD caps.
+	GasTodayMatic float64 `json:"gas_today_matic"`
+	GasTodayUSD   float64 `json:"gas_today_usd"`
+
+	// Cooldowns maps strategies paused after a losing streak to when they resume.
+	Cooldowns map[string]time.Time `json:"strategy_cooldowns,omitempty"`
+
+	// Paused is set while the bot is paused (e.g. by the panic button) and
+	// places no new orders.
+	Paused *PauseInfo `json:"paused,omitempty"`
+
+	// Reconciliation is the latest wallet-vs-bot comparison, if one has run.
+	Reconciliation *ReconcileReport `json:"reconciliation,omitempty"`
+
+	// NetDelta is the net UP-minus-DOWN shares held or bid for, per underlying.
+	NetDelta map[string]float64 `json:"net_delta,omitempty"`
+
+	// ArbSignals are the mispriced complements found by the latest scan.
+	ArbSignals []ArbSignal `json:"arb_signals,omitempty"`
+
+	// PendingResolutions are ended markets we traded that have not settled,
+	// with the oracle status that keeps them from redeeming.
+	PendingResolutions []PendingResolution `json:"pending_resolutions,omitempty"`
+
+	// OrderScoring maps resting order ids to whether they currently earn
+	// liquidity rewards, as of the latest scoring check.
+	OrderScoring map[string]bool `json:"order_scoring,omitempty"`
+}
+
+// UMA oracle statuses as Gamma reports them; a disputed answer goes to a
+// DVM vote, which takes days.
+const (
+	UMAProposed = "proposed"
+	UMADisputed = "disputed"
+)
+
+// PendingResolution is an ended market still waiting for its outcome.
+type PendingResolution struct {
+	ConditionID string     `json:"condition_id"`
+	MarketSlug  string     `json:"market_slug"`
+	EndedAt     time.Time  `json:"ended_at"`
+	UMAStatus   string     `json:"uma_status,omitempty"`
+	CheckedAt   *time.Time `json:"checked_at,omitempty"`
+}
+
+// Arbitrage signal kinds: both asks sum below 1 - fees (buy both, merge) or
+// both bids sum above 1 + fees (split, sell both).
+const (
+	ArbBuyBoth  = "buy_both"
+	ArbSellBoth = "sell_both"
+)
+
+// ArbSignal is a market whose two outcomes are priced inconsistently.
+type ArbSignal struct {
+	ConditionID string    `json:"condition_id"`
+	MarketSlug  string    `json:"market_slug"`
+	Kind        string    `json:"kind"`
+	UpPrice     float64   `json:"up_price"`
+	DownPrice   float64   `json:"down_price"`
+	Sum         float64   `json:"sum"`
+	Edge        float64   `json:"edge"` // per share pair, after fees
+	DetectedAt  time.Time `json:"detected_at"`
+}
+
+// Reconciliation discrepancy kinds.
+const (
+	ReconcileGhostOrder        = "ghost_order"        // tracked as live, not open on the CLOB
+	ReconcileUntrackedOrder    = "untracked_order"    // open on the CLOB, not tracked
+	ReconcileUntrackedPosition = "untracked_position" // held in the wallet, no orders for it
+	ReconcileStaleSold         = "stale_positions_sold"
+)
+
+// ReconcileIssue is one difference between the wallet/CLOB and the bot's state.
+type ReconcileIssue struct {
+	Kind        string  `json:"kind"`
+	ConditionID string  `json:"condition_id"`
+	MarketSlug  string  `json:"market_slug,omitempty"`
+	OrderID     string  `json:"order_id,omitempty"`
+	Size        float64 `json:"size,omitempty"`
+	Detail      string  `json:"detail"`
+	Fixed       bool    `json:"fixed"`
+}
+
+// ReconcileReport is the outcome of one reconciliation pass.
+type ReconcileReport struct {
+	At     time.Time        `json:"at"`
+	Issues []ReconcileIssue `json:"issues"`
+	Errors []string         `json:"errors,omitempty"`
 }
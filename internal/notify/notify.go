@@ -0,0 +1,122 @@
+// Package notify delivers operator messages (digests, alerts) over Telegram
+// and/or email.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Notifier sends one message. Implementations must be safe for concurrent use.
+type Notifier interface {
+	Send(ctx context.Context, subject, body string) error
+}
+
+// Telegram posts messages through the Bot API to a single chat.
+type Telegram struct {
+	Token  string
+	ChatID string
+	client *http.Client
+}
+
+func NewTelegram(token, chatID string) *Telegram {
+	return &Telegram{Token: token, ChatID: chatID, client: &http.Client{Timeout: 15 * time.Second}}
+}
+
+func (t *Telegram) Send(ctx context.Context, subject, body string) error {
+	text := body
+	if subject != "" {
+		text = subject + "\n\n" + body
+	}
+	form := url.Values{"chat_id": {t.ChatID}, "text": {text}, "disable_web_page_preview": {"true"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.telegram.org/bot"+t.Token+"/sendMessage", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		// the request URL embeds the token; don't let it reach the logs
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return fmt.Errorf("telegram: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("telegram: status=%d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Email sends plain-text mail through an SMTP server (STARTTLS when offered).
+type Email struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	From     string
+	To       []string
+}
+
+func (e *Email) Send(ctx context.Context, subject, body string) error {
+	var auth smtp.Auth
+	if e.User != "" {
+		auth = smtp.PlainAuth("", e.User, e.Password, e.Host)
+	}
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", e.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	// net/smtp has no context support; run it aside so ctx still bounds the caller.
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(fmt.Sprintf("%s:%d", e.Host, e.Port), auth, e.From, e.To, []byte(msg.String()))
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("email: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type multi []Notifier
+
+// Multi fans a message out to every non-nil notifier, returning nil when there
+// are none so callers can treat "no channel configured" as a nil Notifier.
+func Multi(ns ...Notifier) Notifier {
+	var out multi
+	for _, n := range ns {
+		if n != nil {
+			out = append(out, n)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func (m multi) Send(ctx context.Context, subject, body string) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Send(ctx, subject, body); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
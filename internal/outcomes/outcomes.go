@@ -0,0 +1,56 @@
+package outcomes
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	"limitorderbot/internal/models"
+)
+
+// DefaultFile keeps resolutions after markets are dropped from markets_state.json.
+const DefaultFile = "market_outcomes.json"
+
+var mu sync.Mutex
+
+// Load reads all recorded outcomes keyed by condition id. A missing file is empty.
+func Load(path string) (map[string]models.MarketOutcome, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load(path)
+}
+
+// Record stores (or replaces) the outcome for one market.
+func Record(path string, o models.MarketOutcome) error {
+	if o.ConditionID == "" || o.WinningOutcome == "" {
+		return errors.New("outcome needs a condition_id and winning_outcome")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	all, err := load(path)
+	if err != nil {
+		return err
+	}
+	all[o.ConditionID] = o
+	bts, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bts, 0o644)
+}
+
+func load(path string) (map[string]models.MarketOutcome, error) {
+	out := map[string]models.MarketOutcome{}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return out, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,98 @@
+package predict
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"limitorderbot/internal/models"
+)
+
+func init() {
+	Register("momentum", func(opts Options) (Predictor, error) {
+		lb := opts.LookbackMinutes
+		if lb <= 0 {
+			lb = 15
+		}
+		return &Momentum{
+			Lookback: lb,
+			Scale:    0.002,
+			URL:      "https://api.binance.com/api/v3/klines",
+			http:     &http.Client{Timeout: 5 * time.Second},
+		}, nil
+	})
+}
+
+// Momentum is the naive default: recent BTC return mapped through a logistic,
+// so a Scale-sized move (0.2%) over the lookback gives p≈0.73 for Up.
+type Momentum struct {
+	Lookback int
+	Scale    float64
+	URL      string
+
+	http *http.Client
+
+	mu      sync.Mutex
+	cachedP float64
+	cachedT time.Time
+}
+
+func (m *Momentum) Name() string { return "momentum" }
+
+func (m *Momentum) Predict(ctx context.Context, _ models.Market) (float64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if time.Since(m.cachedT) < 30*time.Second {
+		return m.cachedP, true
+	}
+	ret, err := m.recentReturn(ctx)
+	if err != nil {
+		return 0.5, false
+	}
+	m.cachedP = 1 / (1 + math.Exp(-ret/m.Scale))
+	m.cachedT = time.Now()
+	return m.cachedP, true
+}
+
+func (m *Momentum) recentReturn(ctx context.Context) (float64, error) {
+	u := fmt.Sprintf("%s?symbol=BTCUSDT&interval=1m&limit=%d", m.URL, m.Lookback+1)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := m.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return 0, fmt.Errorf("klines status=%d", resp.StatusCode)
+	}
+	// Each kline is [openTime, open, high, low, close, ...] with prices as strings.
+	var klines [][]any
+	if err := json.NewDecoder(resp.Body).Decode(&klines); err != nil {
+		return 0, err
+	}
+	if len(klines) < 2 {
+		return 0, fmt.Errorf("not enough klines")
+	}
+	first, err1 := closePrice(klines[0])
+	last, err2 := closePrice(klines[len(klines)-1])
+	if err1 != nil || err2 != nil || first <= 0 {
+		return 0, fmt.Errorf("bad kline data")
+	}
+	return last/first - 1, nil
+}
+
+func closePrice(k []any) (float64, error) {
+	if len(k) < 5 {
+		return 0, fmt.Errorf("short kline")
+	}
+	s, _ := k[4].(string)
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,65 @@
+package predict
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"limitorderbot/internal/models"
+)
+
+// Predictor estimates the probability that a market's Yes/Up outcome wins.
+// ok=false means "no view", and callers should quote as if p were 0.5.
+type Predictor interface {
+	Name() string
+	Predict(ctx context.Context, market models.Market) (p float64, ok bool)
+}
+
+// Factory builds a predictor; opts carries PREDICTOR_* settings from config.
+type Factory func(opts Options) (Predictor, error)
+
+type Options struct {
+	LookbackMinutes int
+}
+
+var (
+	mu        sync.Mutex
+	factories = map[string]Factory{}
+)
+
+// Register makes a predictor selectable via PREDICTOR=<name>. User models call
+// this from an init() in their own package.
+func Register(name string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[strings.ToLower(name)] = f
+}
+
+// New returns the named predictor, or nil for "" / "none".
+func New(name string, opts Options) (Predictor, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == "none" {
+		return nil, nil
+	}
+	mu.Lock()
+	f, ok := factories[name]
+	mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown predictor %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return f(opts)
+}
+
+// Names lists registered predictors.
+func Names() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	out := make([]string, 0, len(factories))
+	for n := range factories {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
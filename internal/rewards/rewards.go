@@ -0,0 +1,115 @@
+package rewards
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultFile holds per-day resting-order exposure and liquidity rewards.
+const DefaultFile = "liquidity_rewards.json"
+
+// keepDays bounds how much history the file retains.
+const keepDays = 90
+
+// Level aggregates resting exposure at one limit price.
+type Level struct {
+	Price float64 `json:"price"`
+	// OrderSeconds is time in book summed over orders; SizeSeconds weights it
+	// by each order's unfilled size (shares).
+	OrderSeconds float64 `json:"order_seconds"`
+	SizeSeconds  float64 `json:"size_seconds"`
+}
+
+// Day is one UTC day of resting liquidity. ActualUSD is set once the CLOB
+// rewards endpoint reports earnings for the day.
+type Day struct {
+	Date         string            `json:"date"`
+	Levels       map[string]*Level `json:"levels"`
+	SizeSeconds  float64           `json:"size_seconds"`
+	EstimatedUSD float64           `json:"estimated_usd"`
+	ActualUSD    *float64          `json:"actual_usd,omitempty"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// RewardUSD prefers the reported earnings over the local estimate.
+func (d *Day) RewardUSD() float64 {
+	if d.ActualUSD != nil {
+		return *d.ActualUSD
+	}
+	return d.EstimatedUSD
+}
+
+// AddResting records size shares resting at price for dt.
+func (d *Day) AddResting(price, size float64, dt time.Duration) {
+	if d.Levels == nil {
+		d.Levels = map[string]*Level{}
+	}
+	key := strconv.FormatFloat(price, 'f', 4, 64)
+	l := d.Levels[key]
+	if l == nil {
+		l = &Level{Price: price}
+		d.Levels[key] = l
+	}
+	sec := dt.Seconds()
+	l.OrderSeconds += sec
+	l.SizeSeconds += size * sec
+	d.SizeSeconds += size * sec
+}
+
+// DateKey is the UTC day a timestamp is attributed to.
+func DateKey(t time.Time) string { return t.UTC().Format("2006-01-02") }
+
+var mu sync.Mutex
+
+// Load reads all days keyed by date. A missing file is empty.
+func Load(path string) (map[string]*Day, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	out := map[string]*Day{}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return out, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Save writes days, dropping all but the most recent keepDays.
+func Save(path string, days map[string]*Day) error {
+	if len(days) > keepDays {
+		keys := make([]string, 0, len(days))
+		for k := range days {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys[:len(keys)-keepDays] {
+			delete(days, k)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	bts, err := json.MarshalIndent(days, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bts, 0o644)
+}
+
+// Total sums RewardUSD across days.
+func Total(days map[string]*Day) float64 {
+	var t float64
+	for _, d := range days {
+		t += d.RewardUSD()
+	}
+	return t
+}
@@ -0,0 +1,134 @@
+// Package runs records each bot start with the configuration it ran under,
+// so historical PnL can be read against the parameters active at the time.
+package runs
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultFile holds one entry per bot start.
+const DefaultFile = "runs.json"
+
+// maxEntries keeps the newest runs; older ones are dropped.
+const maxEntries = 1000
+
+type Run struct {
+	ID        string         `json:"id"`
+	StartedAt time.Time      `json:"started_at"`
+	Config    map[string]any `json:"config"`
+
+	// LastSeenAt is refreshed while the run is alive; EndedAt is set on a
+	// clean stop. A crashed run ends at its LastSeenAt.
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	EndedAt    *time.Time `json:"ended_at,omitempty"`
+	ErrorCount int        `json:"error_count"`
+}
+
+// End is when the run stopped, or was last seen alive.
+func (r Run) End() time.Time {
+	switch {
+	case r.EndedAt != nil:
+		return *r.EndedAt
+	case r.LastSeenAt != nil:
+		return *r.LastSeenAt
+	}
+	return r.StartedAt
+}
+
+var mu sync.Mutex
+
+// NewID derives a run id from its start time.
+func NewID(t time.Time) string {
+	return "run-" + t.UTC().Format("20060102-150405")
+}
+
+// Load returns all runs, oldest first. A missing file is empty.
+func Load(path string) ([]Run, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load(path)
+}
+
+// Record appends r, dropping the oldest beyond maxEntries.
+func Record(path string, r Run) error {
+	mu.Lock()
+	defer mu.Unlock()
+	all, err := load(path)
+	if err != nil {
+		return err
+	}
+	all = append(all, r)
+	if len(all) > maxEntries {
+		all = all[len(all)-maxEntries:]
+	}
+	return save(path, all)
+}
+
+// Update applies fn to the stored run with the given id. Unknown ids (e.g.
+// a run whose Record failed) are ignored.
+func Update(path, id string, fn func(*Run)) error {
+	mu.Lock()
+	defer mu.Unlock()
+	all, err := load(path)
+	if err != nil {
+		return err
+	}
+	for i := range all {
+		if all[i].ID == id {
+			fn(&all[i])
+			return save(path, all)
+		}
+	}
+	return nil
+}
+
+// Find returns the run with the given id.
+func Find(all []Run, id string) (Run, bool) {
+	for _, r := range all {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Run{}, false
+}
+
+// At returns the run in effect at t: the latest one started at or before it.
+func At(all []Run, t time.Time) (Run, bool) {
+	var best Run
+	found := false
+	for _, r := range all {
+		if !r.StartedAt.After(t) && (!found || r.StartedAt.After(best.StartedAt)) {
+			best, found = r, true
+		}
+	}
+	return best, found
+}
+
+func load(path string) ([]Run, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var all []Run
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].StartedAt.Before(all[j].StartedAt) })
+	return all, nil
+}
+
+func save(path string, all []Run) error {
+	bts, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bts, 0o644)
+}
@@ -0,0 +1,87 @@
+// Package schema versions the bot's persisted JSON state. Files are written
+// as {"schema_version": N, "data": ...}; files from before versioning hold
+// the bare data and count as version 0. Loading runs the file kind's
+// migration steps from the file's version up to Current, so a model change
+// ships with a step instead of old files silently losing fields.
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrNewer is returned for files written by a newer build. Callers must not
+// save over such a file.
+var ErrNewer = errors.New("written by a newer version")
+
+// Current is the version every file is written at. Bump it together with a
+// new step for each file kind whose layout changed.
+const Current = 1
+
+// Step upgrades decoded data (as produced by encoding/json into any) from
+// version i to i+1, where i is the step's index in its list.
+type Step func(data any) (any, error)
+
+type envelope struct {
+	Version int             `json:"schema_version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Marshal encodes data at the Current version.
+func Marshal(data any) ([]byte, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(envelope{Version: Current, Data: raw}, "", "  ")
+}
+
+// Unmarshal decodes a versioned or pre-versioning file and migrates it with
+// steps (one per version, len(steps) == Current). A file from a newer
+// version is refused rather than loaded with its new fields dropped.
+func Unmarshal(raw []byte, steps []Step) (any, error) {
+	version, payload, err := split(raw)
+	if err != nil {
+		return nil, err
+	}
+	if version > Current {
+		return nil, fmt.Errorf("schema version %d %w (this build reads up to %d)", version, ErrNewer, Current)
+	}
+	var data any
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, err
+	}
+	for v := version; v < Current && v < len(steps); v++ {
+		if steps[v] == nil {
+			continue
+		}
+		if data, err = steps[v](data); err != nil {
+			return nil, fmt.Errorf("migrate schema %d to %d: %w", v, v+1, err)
+		}
+	}
+	return data, nil
+}
+
+// Version reports the schema version raw was written at.
+func Version(raw []byte) (int, error) {
+	v, _, err := split(raw)
+	return v, err
+}
+
+func split(raw []byte) (int, []byte, error) {
+	var probe map[string]json.RawMessage
+	if json.Unmarshal(raw, &probe) == nil {
+		if v, ok := probe["schema_version"]; ok {
+			var env envelope
+			if err := json.Unmarshal(raw, &env); err != nil {
+				return 0, nil, err
+			}
+			if len(env.Data) == 0 {
+				return 0, nil, fmt.Errorf("schema version %s file has no data", v)
+			}
+			return env.Version, env.Data, nil
+		}
+	}
+	return 0, raw, nil
+}
@@ -0,0 +1,90 @@
+package snapshots
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultFile holds periodic wallet balance samples for the PnL chart.
+const DefaultFile = "balance_history.json"
+
+// maxEntries keeps over a year at the default hourly cadence.
+const maxEntries = 10000
+
+// BalanceSnapshot is one sample of what the account holds. Samples from
+// before MATIC, positions and PnL were recorded carry only USDCBalance.
+type BalanceSnapshot struct {
+	Time        time.Time `json:"time"`
+	USDCBalance float64   `json:"usdc_balance"`
+	// ProxyUSDC and YieldUSDC are collateral held by the funder wallet and
+	// parked in the yield pool, when those are in use.
+	ProxyUSDC    float64 `json:"proxy_usdc_balance,omitempty"`
+	YieldUSDC    float64 `json:"yield_usdc_balance,omitempty"`
+	MaticBalance float64 `json:"matic_balance,omitempty"`
+	// PositionValueUSD marks the open positions at their current price; nil
+	// when the positions couldn't be read.
+	PositionValueUSD *float64 `json:"position_value_usd,omitempty"`
+	// TradingPNL is the cumulative order-history PnL (total_pnl) at the time.
+	TradingPNL *float64 `json:"trading_pnl,omitempty"`
+}
+
+// Complete reports whether s has everything Equity and flow estimates need.
+func (s BalanceSnapshot) Complete() bool {
+	return s.PositionValueUSD != nil && s.TradingPNL != nil
+}
+
+// Equity is the account's marked value in USD: collateral wherever it sits,
+// positions at their mark and MATIC at maticUSD.
+func (s BalanceSnapshot) Equity(maticUSD float64) float64 {
+	eq := s.USDCBalance + s.ProxyUSDC + s.YieldUSDC + s.MaticBalance*maticUSD
+	if s.PositionValueUSD != nil {
+		eq += *s.PositionValueUSD
+	}
+	return eq
+}
+
+var mu sync.Mutex
+
+// Load returns all snapshots in time order. A missing file is empty.
+func Load(path string) ([]BalanceSnapshot, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load(path)
+}
+
+// Append adds a snapshot, dropping the oldest beyond maxEntries.
+func Append(path string, s BalanceSnapshot) error {
+	mu.Lock()
+	defer mu.Unlock()
+	all, err := load(path)
+	if err != nil {
+		return err
+	}
+	all = append(all, s)
+	if len(all) > maxEntries {
+		all = all[len(all)-maxEntries:]
+	}
+	bts, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bts, 0o644)
+}
+
+func load(path string) ([]BalanceSnapshot, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var all []BalanceSnapshot
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,80 @@
+// Package examples holds small reference strategies for the strategy SDK.
+// They are registered by cmd/polymarket-bot and selected like any other
+// order mode, e.g. ORDER_MODE=join_bid.
+package examples
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"limitorderbot/internal/models"
+	"limitorderbot/internal/strategy"
+)
+
+func init() {
+	strategy.Register("join_bid", func() (strategy.Strategy, error) {
+		return &JoinBid{Size: 5, MaxPairCost: 0.97, MaxMarketsPerDay: 24}, nil
+	})
+}
+
+// JoinBid joins the best bid on both outcomes when buying the pair there
+// would cost at most MaxPairCost, so a double fill merges at a profit. It
+// persists a per-day market count to cap how many markets it enters.
+type JoinBid struct {
+	Size             float64
+	MaxPairCost      float64
+	MaxMarketsPerDay int
+}
+
+type joinBidState struct {
+	Day     string `json:"day"`
+	Markets int    `json:"markets"`
+}
+
+func (s *JoinBid) Name() string { return "join_bid" }
+
+func (s *JoinBid) Place(ctx context.Context, sc strategy.Context, market models.Market) error {
+	var st joinBidState
+	if _, err := sc.LoadState(&st); err != nil {
+		return err
+	}
+	if day := sc.Now().UTC().Format("2006-01-02"); st.Day != day {
+		st = joinBidState{Day: day}
+	}
+	if st.Markets >= s.MaxMarketsPerDay {
+		return fmt.Errorf("daily limit of %d markets reached", s.MaxMarketsPerDay)
+	}
+
+	yes, no := strategy.YesNo(market)
+	if yes == nil || no == nil {
+		return fmt.Errorf("market %s has no Yes/No pair", market.MarketSlug)
+	}
+	yesBid, _, err := sc.Book(ctx, yes.TokenID)
+	if err != nil {
+		return err
+	}
+	noBid, _, err := sc.Book(ctx, no.TokenID)
+	if err != nil {
+		return err
+	}
+	if yesBid <= 0 || noBid <= 0 {
+		return fmt.Errorf("empty bid side (yes %.2f, no %.2f)", yesBid, noBid)
+	}
+	if cost := yesBid + noBid; cost > s.MaxPairCost {
+		return fmt.Errorf("pair costs %.2f at the bids, above %.2f", cost, s.MaxPairCost)
+	}
+
+	for _, o := range []struct {
+		outcome models.Outcome
+		price   float64
+	}{{*yes, yesBid}, {*no, noBid}} {
+		price := math.Round(o.price*100) / 100
+		if _, err := sc.Place(ctx, strategy.Order{Market: market, Outcome: o.outcome, Side: models.OrderSideBuy, Price: price, Size: s.Size}); err != nil {
+			return err
+		}
+		sc.Logf("joined %s bid at %.2f x %.2f in %s", o.outcome.Outcome, price, s.Size, market.MarketSlug)
+	}
+	st.Markets++
+	return sc.SaveState(st)
+}
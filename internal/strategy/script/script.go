@@ -0,0 +1,430 @@
+// Package script runs strategies written in Starlark (a small Python
+// dialect) so strategy logic can change without rebuilding the bot. A script
+// defines place(market) and optionally exit(market, orders); it only sees the
+// builtins below, has no file or network access, and is re-read whenever the
+// file changes.
+//
+//	now()                        unix seconds
+//	book(token_id)               (best_bid, best_ask); 0 for an empty side
+//	buy(outcome, price, size)    risk-checked GTC BUY; returns the order id
+//	sell(outcome, price, size)   same, for shares already held
+//	fill_prob(distance, secs)    (p, samples): fill probability of a quote
+//	                             distance from the mid, secs before the start
+//	positions()                  wallet positions as dicts
+//	load_state() / save_state(d) a JSON-able dict kept across calls and restarts
+//	log(*args)                   write to the bot log
+//
+// market and orders are dicts with the same keys as the bot's JSON files
+// (market_slug, start_timestamp, outcomes[].token_id, order_id, status, ...).
+// outcome is an entry of market["outcomes"] or an outcome name ("Up", "No").
+package script
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.starlark.net/starlark"
+
+	"limitorderbot/internal/logging"
+	"limitorderbot/internal/models"
+	"limitorderbot/internal/strategy"
+)
+
+// MaxSteps bounds one call into a script, so a runaway loop can't stall the
+// bot's loop.
+const MaxSteps = 5_000_000
+
+// Script is a strategy.Strategy (and strategy.Exiter) backed by a .star file.
+type Script struct {
+	path string
+	name string
+
+	mu      sync.Mutex
+	modTime time.Time
+	globals starlark.StringDict
+}
+
+var (
+	_ strategy.Strategy = (*Script)(nil)
+	_ strategy.Exiter   = (*Script)(nil)
+)
+
+// Load compiles the script at path; it is named after the file
+// (strategies/fade.star is "fade").
+func Load(path string) (*Script, error) {
+	s := &Script{
+		path: path,
+		name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+	}
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Script) Name() string { return s.name }
+
+func (s *Script) Place(ctx context.Context, sc strategy.Context, market models.Market) error {
+	_, err := s.call(ctx, sc, market, "place", marketValue(market))
+	return err
+}
+
+// ShouldExit calls exit(market, orders) when the script defines it.
+func (s *Script) ShouldExit(ctx context.Context, sc strategy.Context, market models.Market, orders []models.OrderRecord) (bool, error) {
+	if s.current()["exit"] == nil {
+		return false, nil
+	}
+	v, err := s.call(ctx, sc, market, "exit", marketValue(market), toValue(jsonValue(orders)))
+	if err != nil {
+		return false, err
+	}
+	return bool(v.Truth()), nil
+}
+
+// current returns the compiled globals, recompiling first if the file
+// changed. A script that no longer compiles keeps running its last good
+// version.
+func (s *Script) current() starlark.StringDict {
+	if fi, err := os.Stat(s.path); err == nil {
+		s.mu.Lock()
+		changed := !fi.ModTime().Equal(s.modTime)
+		s.mu.Unlock()
+		if changed {
+			if err := s.reload(); err != nil {
+				logging.Logger().Printf("Strategy script %s: %v (keeping the previous version)\n", s.path, err)
+			}
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.globals
+}
+
+func (s *Script) reload() error {
+	fi, err := os.Stat(s.path)
+	if err != nil {
+		return err
+	}
+	thread := &starlark.Thread{Name: s.name}
+	thread.SetMaxExecutionSteps(MaxSteps)
+	globals, err := starlark.ExecFile(thread, s.path, nil, builtins)
+	if err != nil {
+		return err
+	}
+	if _, ok := globals["place"].(starlark.Callable); !ok {
+		return fmt.Errorf("%s: no place(market) function", s.path)
+	}
+	globals.Freeze()
+	s.mu.Lock()
+	s.globals, s.modTime = globals, fi.ModTime()
+	s.mu.Unlock()
+	return nil
+}
+
+// callEnv is what the builtins act on during one call.
+type callEnv struct {
+	ctx    context.Context
+	sc     strategy.Context
+	market models.Market
+}
+
+const envKey = "env"
+
+func (s *Script) call(ctx context.Context, sc strategy.Context, market models.Market, fn string, args ...starlark.Value) (starlark.Value, error) {
+	f, ok := s.current()[fn].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%s: no %s function", s.path, fn)
+	}
+	thread := &starlark.Thread{
+		Name:  s.name,
+		Print: func(_ *starlark.Thread, msg string) { sc.Logf("%s", msg) },
+	}
+	thread.SetMaxExecutionSteps(MaxSteps)
+	thread.SetLocal(envKey, &callEnv{ctx: ctx, sc: sc, market: market})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			thread.Cancel(ctx.Err().Error())
+		case <-stop:
+		}
+	}()
+
+	v, err := starlark.Call(thread, f, args, nil)
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return nil, fmt.Errorf("%s", evalErr.Backtrace())
+	}
+	return v, err
+}
+
+var builtins = starlark.StringDict{
+	"now":        starlark.NewBuiltin("now", builtinNow),
+	"book":       starlark.NewBuiltin("book", builtinBook),
+	"buy":        starlark.NewBuiltin("buy", builtinOrder(models.OrderSideBuy)),
+	"sell":       starlark.NewBuiltin("sell", builtinOrder(models.OrderSideSell)),
+	"fill_prob":  starlark.NewBuiltin("fill_prob", builtinFillProb),
+	"positions":  starlark.NewBuiltin("positions", builtinPositions),
+	"load_state": starlark.NewBuiltin("load_state", builtinLoadState),
+	"save_state": starlark.NewBuiltin("save_state", builtinSaveState),
+	"log":        starlark.NewBuiltin("log", builtinLog),
+}
+
+func env(thread *starlark.Thread) *callEnv {
+	return thread.Local(envKey).(*callEnv)
+}
+
+func builtinNow(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 0); err != nil {
+		return nil, err
+	}
+	return starlark.Float(float64(env(thread).sc.Now().UnixMilli()) / 1000), nil
+}
+
+func builtinBook(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var tokenID string
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &tokenID); err != nil {
+		return nil, err
+	}
+	e := env(thread)
+	bid, ask, err := e.sc.Book(e.ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.Tuple{starlark.Float(bid), starlark.Float(ask)}, nil
+}
+
+func builtinFillProb(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var distance, secs starlark.Value
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 2, &distance, &secs); err != nil {
+		return nil, err
+	}
+	d, ok1 := starlark.AsFloat(distance)
+	t, ok2 := starlark.AsFloat(secs)
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("%s: distance and seconds must be numbers", b.Name())
+	}
+	p, n := env(thread).sc.FillProbability(d, time.Duration(t*float64(time.Second)))
+	return starlark.Tuple{starlark.Float(p), starlark.MakeInt(n)}, nil
+}
+
+func builtinOrder(side models.OrderSide) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var which, price, size starlark.Value
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "outcome", &which, "price", &price, "size", &size); err != nil {
+			return nil, err
+		}
+		p, ok1 := starlark.AsFloat(price)
+		n, ok2 := starlark.AsFloat(size)
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("%s: price and size must be numbers", b.Name())
+		}
+		e := env(thread)
+		outcome, err := findOutcome(e.market, which)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", b.Name(), err)
+		}
+		rec, err := e.sc.Place(e.ctx, strategy.Order{Market: e.market, Outcome: outcome, Side: side, Price: p, Size: n})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", b.Name(), err)
+		}
+		return starlark.String(rec.OrderID), nil
+	}
+}
+
+// findOutcome resolves a market["outcomes"] entry, a token id or an outcome
+// name against the market being processed.
+func findOutcome(m models.Market, v starlark.Value) (models.Outcome, error) {
+	var key string
+	switch x := v.(type) {
+	case starlark.String:
+		key = string(x)
+	case *starlark.Dict:
+		tok, found, _ := x.Get(starlark.String("token_id"))
+		if s, ok := tok.(starlark.String); found && ok {
+			key = string(s)
+		}
+	}
+	for _, o := range m.Outcomes {
+		if key != "" && (o.TokenID == key || strings.EqualFold(o.Outcome, key)) {
+			return o, nil
+		}
+	}
+	return models.Outcome{}, fmt.Errorf("no outcome %s in %s", v, m.MarketSlug)
+}
+
+func builtinPositions(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 0); err != nil {
+		return nil, err
+	}
+	e := env(thread)
+	pos, err := e.sc.Positions(e.ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]starlark.Value, 0, len(pos))
+	for _, p := range pos {
+		out = append(out, toValue(map[string]any{
+			"condition_id":  p.ConditionID,
+			"slug":          p.Slug,
+			"outcome":       p.Outcome,
+			"token_id":      p.TokenID,
+			"size":          p.Size,
+			"cur_price":     p.CurPrice,
+			"current_value": p.CurrentValue,
+			"redeemable":    p.Redeemable,
+		}))
+	}
+	return starlark.NewList(out), nil
+}
+
+func builtinLoadState(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 0); err != nil {
+		return nil, err
+	}
+	st := map[string]any{}
+	if _, err := env(thread).sc.LoadState(&st); err != nil {
+		return nil, err
+	}
+	return toValue(st), nil
+}
+
+func builtinSaveState(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var d *starlark.Dict
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &d); err != nil {
+		return nil, err
+	}
+	v, err := fromValue(d)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	return starlark.None, env(thread).sc.SaveState(v)
+}
+
+func builtinLog(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	parts := make([]string, 0, len(args))
+	for _, a := range args {
+		if s, ok := a.(starlark.String); ok {
+			parts = append(parts, string(s))
+		} else {
+			parts = append(parts, a.String())
+		}
+	}
+	env(thread).sc.Logf("%s", strings.Join(parts, " "))
+	return starlark.None, nil
+}
+
+func marketValue(m models.Market) starlark.Value {
+	return toValue(jsonValue(m))
+}
+
+// jsonValue round-trips v through JSON so scripts see the same keys as the
+// bot's state files; integral numbers stay ints.
+func jsonValue(v any) any {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.UseNumber()
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return nil
+	}
+	return out
+}
+
+func toValue(v any) starlark.Value {
+	switch x := v.(type) {
+	case nil:
+		return starlark.None
+	case bool:
+		return starlark.Bool(x)
+	case string:
+		return starlark.String(x)
+	case int:
+		return starlark.MakeInt(x)
+	case int64:
+		return starlark.MakeInt64(x)
+	case float64:
+		return starlark.Float(x)
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return starlark.MakeInt64(i)
+		}
+		f, _ := x.Float64()
+		return starlark.Float(f)
+	case []any:
+		out := make([]starlark.Value, len(x))
+		for i := range x {
+			out[i] = toValue(x[i])
+		}
+		return starlark.NewList(out)
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		d := starlark.NewDict(len(x))
+		for _, k := range keys {
+			_ = d.SetKey(starlark.String(k), toValue(x[k]))
+		}
+		return d
+	}
+	return toValue(jsonValue(v))
+}
+
+func fromValue(v starlark.Value) (any, error) {
+	switch x := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(x), nil
+	case starlark.String:
+		return string(x), nil
+	case starlark.Int:
+		i, ok := x.Int64()
+		if !ok {
+			return nil, fmt.Errorf("int %s out of range", x)
+		}
+		return i, nil
+	case starlark.Float:
+		return float64(x), nil
+	case starlark.Indexable: // list, tuple
+		out := make([]any, x.Len())
+		for i := range out {
+			e, err := fromValue(x.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = e
+		}
+		return out, nil
+	case *starlark.Dict:
+		out := make(map[string]any, x.Len())
+		for _, item := range x.Items() {
+			k, ok := item[0].(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("state keys must be strings, got %s", item[0].Type())
+			}
+			e, err := fromValue(item[1])
+			if err != nil {
+				return nil, err
+			}
+			out[string(k)] = e
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("can't save a %s", v.Type())
+}
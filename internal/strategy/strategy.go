@@ -0,0 +1,164 @@
+// Package strategy is the surface custom order strategies are written
+// against. A strategy registers itself under a name from an init() and is
+// selected with ORDER_MODE=<name>; the bot calls Place once per market when
+// its placement window opens, passing a Context that exposes prices,
+// positions, risk-checked order placement and a small persisted state.
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"limitorderbot/internal/models"
+)
+
+// Strategy decides what to quote in one market. Orders placed through
+// sc.Place are tracked by the bot (fills, timeout exit, merge, redemption)
+// exactly like the built-in modes' orders. Returning an error records the
+// market as skipped; orders already placed stay tracked.
+type Strategy interface {
+	Name() string
+	Place(ctx context.Context, sc Context, market models.Market) error
+}
+
+// Exiter is optionally implemented by a Strategy to end a market early: when
+// ShouldExit returns true the bot runs the STRATEGY_NAME exit (cancel unfilled,
+// merge, sell leftovers) without waiting for its timeout. sc.Place is refused
+// during the call.
+type Exiter interface {
+	ShouldExit(ctx context.Context, sc Context, market models.Market, orders []models.OrderRecord) (bool, error)
+}
+
+// Context is the bot as seen by a strategy. It is only valid during the
+// Place call it was passed to.
+type Context interface {
+	// Now is the bot's clock (a manual one in replays and tests).
+	Now() time.Time
+	// Book fetches the current best bid and ask for a token; 0 means the
+	// side is empty.
+	Book(ctx context.Context, tokenID string) (bid, ask float64, err error)
+	// FillProbability estimates, from this bot's order history, how likely a
+	// quote distance (price units) from the mid, placed timeToStart before
+	// the market starts, is to fill; samples is the history behind it. With
+	// the edge a fill would earn, p*edge ranks price levels by expected value.
+	FillProbability(distance float64, timeToStart time.Duration) (p float64, samples int)
+	// Positions lists the wallet's open positions from the data-api.
+	Positions(ctx context.Context) ([]Position, error)
+	// Place submits a GTC limit order after the bot's risk checks (pause,
+	// loss cooldown, capital budget, balance, exchange minimums, duplicate
+	// orders). A refused order returns an error and is not posted.
+	// Placement is only allowed from Strategy.Place.
+	Place(ctx context.Context, o Order) (models.OrderRecord, error)
+	// LoadState decodes the strategy's saved state into v; ok=false means
+	// nothing has been saved yet.
+	LoadState(v any) (ok bool, err error)
+	// SaveState persists v (JSON) for the next Place call or restart.
+	SaveState(v any) error
+	// Logf writes to the bot log, prefixed with the strategy name.
+	Logf(format string, args ...any)
+}
+
+// Order is a limit order request. Size is in shares.
+type Order struct {
+	Market  models.Market
+	Outcome models.Outcome
+	Side    models.OrderSide
+	Price   float64
+	Size    float64
+}
+
+// Position is one wallet position as reported by the data-api.
+type Position struct {
+	ConditionID  string
+	Slug         string
+	Outcome      string
+	TokenID      string
+	Size         float64
+	CurPrice     float64
+	CurrentValue float64
+	Redeemable   bool
+}
+
+// Factory builds a strategy.
+type Factory func() (Strategy, error)
+
+var (
+	mu        sync.Mutex
+	factories = map[string]Factory{}
+)
+
+// Builtin are the ORDER_MODE values the bot implements itself; they can't be
+// registered over.
+var Builtin = []string{"test", "liquidity", "spread_capture", "script"}
+
+// IsBuiltin reports whether name is one of the bot's own order modes.
+func IsBuiltin(name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, n := range Builtin {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Register makes a strategy selectable via ORDER_MODE=<name>. Strategies call
+// this from an init() in their own package, which cmd/polymarket-bot then
+// imports for its side effects.
+func Register(name string, f Factory) {
+	if IsBuiltin(name) {
+		panic(fmt.Sprintf("strategy: %q is a built-in order mode", name))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	factories[strings.ToLower(name)] = f
+}
+
+// New returns the named strategy, or nil for "" and the built-in modes.
+func New(name string) (Strategy, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || IsBuiltin(name) {
+		return nil, nil
+	}
+	mu.Lock()
+	f, ok := factories[name]
+	mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown order mode %q (built-in: %s; registered: %s)",
+			name, strings.Join(Builtin, ", "), strings.Join(Names(), ", "))
+	}
+	return f()
+}
+
+// Names lists registered strategies.
+func Names() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	out := make([]string, 0, len(factories))
+	for n := range factories {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// YesNo returns the market's Yes/Up and No/Down outcomes, if both exist.
+func YesNo(m models.Market) (yes, no *models.Outcome) {
+	for i := range m.Outcomes {
+		switch strings.ToLower(strings.TrimSpace(m.Outcomes[i].Outcome)) {
+		case "yes", "up":
+			if yes == nil {
+				yes = &m.Outcomes[i]
+			}
+		case "no", "down":
+			if no == nil {
+				no = &m.Outcomes[i]
+			}
+		}
+	}
+	return yes, no
+}
@@ -0,0 +1,241 @@
+// Package webhook posts bot events (orders placed and filled, merges,
+// redemptions, errors) to user-configured HTTP endpoints, optionally
+// templated and HMAC-signed.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Event types a hook can subscribe to.
+const (
+	OrderPlaced = "order_placed"
+	OrderFilled = "order_filled"
+	Merge       = "merge"
+	Redemption  = "redemption"
+	// Tx covers the other on-chain jobs: swaps, rebalances, yield moves.
+	Tx    = "tx"
+	Error = "error"
+)
+
+// Events lists every event type, for validation and docs.
+var Events = []string{OrderPlaced, OrderFilled, Merge, Redemption, Tx, Error}
+
+// Headers set on every delivery. The signature is HMAC-SHA256 over
+// "<timestamp>.<body>" with the hook's secret, hex-encoded after "sha256=".
+const (
+	EventHeader     = "X-Nicebot-Event"
+	TimestampHeader = "X-Nicebot-Timestamp"
+	SignatureHeader = "X-Nicebot-Signature"
+)
+
+// Event is what a hook receives. Data is the event's record (an order, a
+// tx job, an error) as a JSON object, so templates address it by its JSON
+// field names: {{.Data.market_slug}}.
+type Event struct {
+	Event string         `json:"event"`
+	Time  time.Time      `json:"time"`
+	RunID string         `json:"run_id,omitempty"`
+	Data  map[string]any `json:"data"`
+}
+
+// Hook is one entry of WEBHOOKS_FILE.
+type Hook struct {
+	URL string `json:"url"`
+	// Events to deliver; empty or "*" means all of them.
+	Events []string `json:"events"`
+	// Template renders the request body from the Event (text/template, with
+	// a json function for quoting values). The default body is the Event
+	// itself.
+	Template string `json:"template"`
+	// SecretEnv names the environment variable holding the signing secret,
+	// so the secret stays out of the file. Unset: requests are unsigned.
+	SecretEnv string            `json:"secret_env"`
+	Headers   map[string]string `json:"headers"`
+
+	tmpl   *template.Template
+	secret []byte
+}
+
+func (h *Hook) wants(event string) bool {
+	return len(h.Events) == 0 || slices.Contains(h.Events, "*") || slices.Contains(h.Events, event)
+}
+
+// Dispatcher delivers events to the configured hooks. It is safe for
+// concurrent use.
+type Dispatcher struct {
+	hooks  []*Hook
+	client *http.Client
+}
+
+var funcs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// Load reads the hooks file. An empty path means no hooks (a nil
+// Dispatcher, which drops every event).
+func Load(path string) (*Dispatcher, error) {
+	if path == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var hooks []*Hook
+	if err := json.Unmarshal(raw, &hooks); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i, h := range hooks {
+		if !strings.HasPrefix(h.URL, "http://") && !strings.HasPrefix(h.URL, "https://") {
+			return nil, fmt.Errorf("%s: hook %d: url must be http(s)", path, i)
+		}
+		for _, e := range h.Events {
+			if e != "*" && !slices.Contains(Events, e) {
+				return nil, fmt.Errorf("%s: hook %d: unknown event %q (use %s)", path, i, e, strings.Join(Events, ", "))
+			}
+		}
+		if h.Template != "" {
+			if h.tmpl, err = template.New(h.URL).Funcs(funcs).Option("missingkey=zero").Parse(h.Template); err != nil {
+				return nil, fmt.Errorf("%s: hook %d: template: %w", path, i, err)
+			}
+		}
+		if h.SecretEnv != "" {
+			if h.secret = []byte(os.Getenv(h.SecretEnv)); len(h.secret) == 0 {
+				return nil, fmt.Errorf("%s: hook %d: %s is not set", path, i, h.SecretEnv)
+			}
+		}
+	}
+	return &Dispatcher{hooks: hooks, client: &http.Client{Timeout: 15 * time.Second}}, nil
+}
+
+// Wants reports whether any hook subscribes to event, so callers can skip
+// building its data.
+func (d *Dispatcher) Wants(event string) bool {
+	if d == nil {
+		return false
+	}
+	return slices.ContainsFunc(d.hooks, func(h *Hook) bool { return h.wants(event) })
+}
+
+// NewEvent builds an Event, turning data (any JSON-marshalable value) into
+// the object templates see.
+func NewEvent(event string, at time.Time, runID string, data any) (Event, error) {
+	e := Event{Event: event, Time: at.UTC(), RunID: runID, Data: map[string]any{}}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return e, err
+	}
+	return e, json.Unmarshal(raw, &e.Data)
+}
+
+// Send delivers e to every hook subscribed to it, retrying network errors
+// and 5xx responses twice.
+func (d *Dispatcher) Send(ctx context.Context, e Event) error {
+	if d == nil {
+		return nil
+	}
+	var errs []error
+	for _, h := range d.hooks {
+		if !h.wants(e.Event) {
+			continue
+		}
+		body, err := h.render(e)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", h.URL, err))
+			continue
+		}
+		if err := d.deliver(ctx, h, e.Event, body); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", h.URL, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// render builds the body; a template must produce valid JSON.
+func (h *Hook) render(e Event) ([]byte, error) {
+	if h.tmpl == nil {
+		return json.Marshal(e)
+	}
+	var buf bytes.Buffer
+	if err := h.tmpl.Execute(&buf, e); err != nil {
+		return nil, err
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.New("template did not produce valid JSON")
+	}
+	return buf.Bytes(), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, h *Hook, event string, body []byte) error {
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(attempt) * time.Second):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		var retry bool
+		if retry, err = d.post(ctx, h, event, body); err == nil || !retry {
+			return err
+		}
+	}
+	return err
+}
+
+func (d *Dispatcher) post(ctx context.Context, h *Hook, event string, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range h.Headers {
+		req.Header.Set(k, v)
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(TimestampHeader, ts)
+	if len(h.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(h.secret, ts, body))
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode/100 != 2 {
+		return resp.StatusCode >= 500, fmt.Errorf("status=%d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+// Sign is the SignatureHeader value for body sent at timestamp ts; a
+// receiver recomputes it with the shared secret and compares.
+func Sign(secret []byte, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}